@@ -14,31 +14,61 @@ import (
 
 	"github.com/osmait/gestorDePresupuesto/internal/config"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/fxrates"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/observability"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/server"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/storage/blob"
 	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
 	analyticsRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/analytics"
+	attachmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/attachment"
+	auditRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/audit"
+	balanceRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/balance"
 	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
 	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	fxRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/fx"
+	idempotencyRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/idempotency"
+	importerRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/importer"
 	investmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/investment"
+	netWorthRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/networth"
 	notificationRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/notification"
+	payeeRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/payee"
+	reconciliationRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/reconciliation"
 	recurringRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/recurring_transaction"
+	ruleRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/rule"
+	statementRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/statement"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
 	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
 	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	viewRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/view"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/worker"
 	"github.com/osmait/gestorDePresupuesto/internal/services/account"
 	"github.com/osmait/gestorDePresupuesto/internal/services/analytics"
+	"github.com/osmait/gestorDePresupuesto/internal/services/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/services/auth"
+	"github.com/osmait/gestorDePresupuesto/internal/services/balance"
 	"github.com/osmait/gestorDePresupuesto/internal/services/budget"
 	"github.com/osmait/gestorDePresupuesto/internal/services/category"
+	"github.com/osmait/gestorDePresupuesto/internal/services/export"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/services/idempotency"
+	"github.com/osmait/gestorDePresupuesto/internal/services/importer"
 	"github.com/osmait/gestorDePresupuesto/internal/services/investment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/networth"
 	"github.com/osmait/gestorDePresupuesto/internal/services/notification"
+	"github.com/osmait/gestorDePresupuesto/internal/services/payee"
 	"github.com/osmait/gestorDePresupuesto/internal/services/quote"
+	"github.com/osmait/gestorDePresupuesto/internal/services/reconciliation"
 	"github.com/osmait/gestorDePresupuesto/internal/services/recurring_transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/rule"
 	"github.com/osmait/gestorDePresupuesto/internal/services/search"
+	"github.com/osmait/gestorDePresupuesto/internal/services/statement"
+	"github.com/osmait/gestorDePresupuesto/internal/services/tag"
 	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/trash"
 	"github.com/osmait/gestorDePresupuesto/internal/services/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
 )
 
 func Run() error {
@@ -86,8 +116,20 @@ func Run() error {
 	// Initialize repositories
 	repositories := initializeRepositories(db)
 
+	// Initialize attachment storage
+	attachmentStore, err := blob.NewStore(cfg.Attachments)
+	if err != nil {
+		return fmt.Errorf("failed to initialize attachment storage: %w", err)
+	}
+
+	// Initialize exchange rate provider
+	rateProvider, err := fxrates.NewProvider(cfg.FX)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exchange rate provider: %w", err)
+	}
+
 	// Initialize services
-	services := initializeServices(repositories, cfg)
+	services := initializeServices(repositories, cfg, attachmentStore, rateProvider)
 
 	// Initialize and start server
 	scheduler := worker.NewTransactionScheduler(services.recurringService)
@@ -96,6 +138,32 @@ func Run() error {
 	demoCleanupWorker := worker.NewDemoCleanupWorker(services.userService, 24*time.Hour)
 	demoCleanupWorker.Start(ctx)
 
+	if cfg.Attachments.PurgeInterval > 0 {
+		attachmentCleanupWorker := worker.NewAttachmentCleanupWorker(services.attachmentService, cfg.Attachments.PurgeInterval)
+		attachmentCleanupWorker.Start(ctx)
+	}
+
+	fxSyncWorker := worker.NewFxSyncWorker(services.fxService, cfg.FX.SyncInterval)
+	fxSyncWorker.Start(ctx)
+
+	if cfg.Trash.PurgeInterval > 0 {
+		trashPurgeWorker := worker.NewTrashPurgeWorker(services.trashService, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+		trashPurgeWorker.Start(ctx)
+	}
+
+	if cfg.Idempotency.PurgeInterval > 0 {
+		idempotencyPurgeWorker := worker.NewIdempotencyPurgeWorker(services.idempotencyService, cfg.Idempotency.PurgeInterval)
+		idempotencyPurgeWorker.Start(ctx)
+	}
+
+	netWorthWorker := worker.NewNetWorthWorker(services.netWorthService, cfg.NetWorth.SnapshotInterval)
+	netWorthWorker.Start(ctx)
+
+	if cfg.Statement.ReminderInterval > 0 {
+		statementReminderWorker := worker.NewStatementReminderWorker(services.statementService, cfg.Statement.ReminderInterval)
+		statementReminderWorker.Start(ctx)
+	}
+
 	serverCtx, srv := server.New(
 		ctx,
 		cfg.Server.Host,
@@ -115,6 +183,21 @@ func Run() error {
 		cfg,
 		services.quoteService,
 		services.notificationService,
+		services.importService,
+		services.exportService,
+		services.ruleService,
+		services.tagService,
+		services.attachmentService,
+		services.fxService,
+		services.reconciliationService,
+		services.auditService,
+		services.trashService,
+		services.payeeService,
+		services.viewService,
+		services.idempotencyService,
+		services.balanceService,
+		services.netWorthService,
+		services.statementService,
 	)
 
 	logger.Infof("Server starting on %s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -207,70 +290,130 @@ func runMigrations(cfg *config.Config, logger *observability.Logger) error {
 
 // repositories holds all repository interfaces
 type repositories struct {
-	accountRepository      accountRepo.AccountRepositoryInterface
-	transactionRepository  transactionRepo.TransactionRepositoryInterface
-	userRepository         userRepo.UserRepositoryInterface
-	budgetRepository       budgetRepo.BudgetRepoInterface
-	categoryRepository     categoryRepo.CategoryRepoInterface
-	investmentRepository   investmentRepo.InvestmentRepoInterface
-	analyticsRepository    *analyticsRepo.AnalyticsRepository
-	recurringRepository    *recurringRepo.RecurringTransactionRepository
-	notificationRepository *notificationRepo.NotificationRepository
+	accountRepository        accountRepo.AccountRepositoryInterface
+	transactionRepository    transactionRepo.TransactionRepositoryInterface
+	userRepository           userRepo.UserRepositoryInterface
+	budgetRepository         budgetRepo.BudgetRepoInterface
+	categoryRepository       categoryRepo.CategoryRepoInterface
+	investmentRepository     investmentRepo.InvestmentRepoInterface
+	analyticsRepository      *analyticsRepo.AnalyticsRepository
+	recurringRepository      *recurringRepo.RecurringTransactionRepository
+	notificationRepository   *notificationRepo.NotificationRepository
+	importMappingRepository  importerRepo.ImportMappingRepoInterface
+	ruleRepository           ruleRepo.RuleRepoInterface
+	tagRepository            tagRepo.TagRepoInterface
+	attachmentRepository     attachmentRepo.AttachmentRepoInterface
+	fxRepository             fxRepo.FxRepoInterface
+	reconciliationRepository reconciliationRepo.ReconciliationRepoInterface
+	auditRepository          auditRepo.AuditRepoInterface
+	payeeRepository          payeeRepo.PayeeRepoInterface
+	viewRepository           viewRepo.ViewRepoInterface
+	idempotencyRepository    idempotencyRepo.IdempotencyRepoInterface
+	balanceRepository        balanceRepo.BalanceRepositoryInterface
+	netWorthRepository       netWorthRepo.NetWorthRepoInterface
+	statementRepository      statementRepo.StatementRepoInterface
 }
 
 // initializeRepositories creates all repository instances
 func initializeRepositories(db *sql.DB) *repositories {
 	return &repositories{
-		accountRepository:      accountRepo.NewAccountRepository(db),
-		transactionRepository:  transactionRepo.NewTransactionRepository(db),
-		userRepository:         userRepo.NewUserRepository(db),
-		budgetRepository:       budgetRepo.NewBudgetRepository(db),
-		categoryRepository:     categoryRepo.NewCategoryRepository(db),
-		investmentRepository:   investmentRepo.NewInvestmentRepository(db),
-		analyticsRepository:    analyticsRepo.NewAnalyticsRepository(db),
-		recurringRepository:    recurringRepo.NewRecurringTransactionRepository(db),
-		notificationRepository: notificationRepo.NewNotificationRepository(db),
+		accountRepository:        accountRepo.NewAccountRepository(db),
+		transactionRepository:    transactionRepo.NewTransactionRepository(db),
+		userRepository:           userRepo.NewUserRepository(db),
+		budgetRepository:         budgetRepo.NewBudgetRepository(db),
+		categoryRepository:       categoryRepo.NewCategoryRepository(db),
+		investmentRepository:     investmentRepo.NewInvestmentRepository(db),
+		analyticsRepository:      analyticsRepo.NewAnalyticsRepository(db),
+		recurringRepository:      recurringRepo.NewRecurringTransactionRepository(db),
+		notificationRepository:   notificationRepo.NewNotificationRepository(db),
+		importMappingRepository:  importerRepo.NewImportMappingRepository(db),
+		ruleRepository:           ruleRepo.NewRuleRepository(db),
+		tagRepository:            tagRepo.NewTagRepository(db),
+		attachmentRepository:     attachmentRepo.NewAttachmentRepository(db),
+		fxRepository:             fxRepo.NewFxRepository(db),
+		reconciliationRepository: reconciliationRepo.NewReconciliationRepository(db),
+		auditRepository:          auditRepo.NewAuditRepository(db),
+		payeeRepository:          payeeRepo.NewPayeeRepository(db),
+		viewRepository:           viewRepo.NewViewRepository(db),
+		idempotencyRepository:    idempotencyRepo.NewIdempotencyRepository(db),
+		balanceRepository:        balanceRepo.NewBalanceRepository(db),
+		netWorthRepository:       netWorthRepo.NewNetWorthRepository(db),
+		statementRepository:      statementRepo.NewStatementRepository(db),
 	}
 }
 
 // services holds all service instances
 type services struct {
-	accountService      *account.AccountService
-	transactionService  *transaction.TransactionService
-	userService         *user.UserService
-	authService         *auth.AuthService
-	budgetService       *budget.BudgetServices
-	categoryService     *category.CategoryServices
-	investmentService   *investment.InvestmentService
-	analyticsService    *analytics.AnalyticsService
-	recurringService    *recurring_transaction.RecurringTransactionService
-	searchService       *search.SearchService
-	quoteService        *quote.QuoteService
-	notificationService *notification.NotificationService
+	accountService        *account.AccountService
+	transactionService    *transaction.TransactionService
+	userService           *user.UserService
+	authService           *auth.AuthService
+	budgetService         *budget.BudgetServices
+	categoryService       *category.CategoryServices
+	investmentService     *investment.InvestmentService
+	analyticsService      *analytics.AnalyticsService
+	recurringService      *recurring_transaction.RecurringTransactionService
+	searchService         *search.SearchService
+	quoteService          *quote.QuoteService
+	notificationService   *notification.NotificationService
+	importService         *importer.ImportService
+	exportService         *export.ExportService
+	ruleService           *rule.RuleService
+	tagService            *tag.TagService
+	attachmentService     *attachment.AttachmentService
+	fxService             *fx.FxService
+	reconciliationService *reconciliation.ReconciliationService
+	auditService          *audit.AuditService
+	trashService          *trash.TrashService
+	payeeService          *payee.PayeeService
+	viewService           *view.ViewService
+	idempotencyService    *idempotency.IdempotencyService
+	balanceService        *balance.BalanceService
+	netWorthService       *networth.NetWorthService
+	statementService      *statement.StatementService
 }
 
 // initializeServices creates all service instances
-func initializeServices(repos *repositories, cfg *config.Config) *services {
+func initializeServices(repos *repositories, cfg *config.Config, attachmentStore blob.Store, rateProvider fxrates.Provider) *services {
 	// Services
 	quoteService := quote.NewQuoteService()
 
 	notificationService := notification.NewNotificationService(repos.notificationRepository)
 
+	fxService := fx.NewFxService(repos.fxRepository, repos.userRepository, rateProvider)
+
+	auditService := audit.NewAuditService(repos.auditRepository)
+
 	transactionCache := cache.NewInMemoryCache(5*time.Minute, 10*time.Minute)
-	transactionService := transaction.NewTransactionService(repos.transactionRepository, repos.budgetRepository, notificationService, transactionCache)
+	transactionService := transaction.NewTransactionService(repos.transactionRepository, repos.accountRepository, repos.budgetRepository, repos.ruleRepository, repos.tagRepository, repos.payeeRepository, notificationService, transactionCache, fxService, auditService)
 
 	return &services{
-		accountService:      account.NewAccountService(repos.accountRepository),
-		transactionService:  transactionService,
-		userService:         user.NewUserService(repos.userRepository),
-		authService:         auth.NewAuthService(repos.userRepository, repos.accountRepository, repos.categoryRepository, repos.budgetRepository, repos.transactionRepository, cfg),
-		budgetService:       budget.NewBudgetServices(repos.budgetRepository, repos.transactionRepository),
-		categoryService:     category.NewCategoryServices(repos.categoryRepository),
-		investmentService:   investment.NewInvestmentService(repos.investmentRepository, quoteService),
-		analyticsService:    analytics.NewAnalyticsService(repos.analyticsRepository),
-		recurringService:    recurring_transaction.NewRecurringTransactionService(repos.recurringRepository, transactionService, notificationService),
-		searchService:       search.NewSearchService(repos.transactionRepository, repos.categoryRepository, repos.accountRepository, repos.budgetRepository),
-		quoteService:        quoteService,
-		notificationService: notificationService,
+		accountService:        account.NewAccountService(repos.accountRepository, fxService, transactionCache, auditService),
+		transactionService:    transactionService,
+		userService:           user.NewUserService(repos.userRepository),
+		authService:           auth.NewAuthService(repos.userRepository, repos.accountRepository, repos.categoryRepository, repos.budgetRepository, repos.transactionRepository, cfg),
+		budgetService:         budget.NewBudgetServices(repos.budgetRepository, repos.transactionRepository, auditService),
+		categoryService:       category.NewCategoryServices(repos.categoryRepository, auditService),
+		investmentService:     investment.NewInvestmentService(repos.investmentRepository, repos.accountRepository, quoteService, auditService),
+		analyticsService:      analytics.NewAnalyticsService(repos.analyticsRepository, fxService),
+		recurringService:      recurring_transaction.NewRecurringTransactionService(repos.recurringRepository, transactionService, notificationService),
+		searchService:         search.NewSearchService(repos.transactionRepository, repos.categoryRepository, repos.accountRepository, repos.budgetRepository),
+		quoteService:          quoteService,
+		notificationService:   notificationService,
+		importService:         importer.NewImportService(repos.importMappingRepository, transactionService),
+		exportService:         export.NewExportService(repos.transactionRepository, repos.categoryRepository, repos.accountRepository),
+		ruleService:           rule.NewRuleService(repos.ruleRepository, repos.categoryRepository, repos.budgetRepository),
+		tagService:            tag.NewTagService(repos.tagRepository),
+		attachmentService:     attachment.NewAttachmentService(repos.attachmentRepository, repos.transactionRepository, attachmentStore, cfg.Attachments.MaxFileSize, cfg.Attachments.UserQuota),
+		fxService:             fxService,
+		reconciliationService: reconciliation.NewReconciliationService(repos.reconciliationRepository, repos.accountRepository, transactionCache),
+		auditService:          auditService,
+		trashService:          trash.NewTrashService(repos.transactionRepository, repos.accountRepository, repos.categoryRepository, transactionCache, auditService),
+		payeeService:          payee.NewPayeeService(repos.payeeRepository, transactionCache),
+		viewService:           view.NewViewService(repos.viewRepository),
+		idempotencyService:    idempotency.NewIdempotencyService(repos.idempotencyRepository, cfg.Idempotency.TTL),
+		balanceService:        balance.NewBalanceService(repos.balanceRepository, repos.accountRepository),
+		netWorthService:       networth.NewNetWorthService(repos.netWorthRepository, repos.accountRepository, repos.investmentRepository, repos.userRepository, fxService),
+		statementService:      statement.NewStatementService(repos.statementRepository, repos.accountRepository, repos.userRepository, transactionService, notificationService, cfg.Statement.ReminderDays),
 	}
 }
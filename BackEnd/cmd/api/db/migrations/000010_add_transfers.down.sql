@@ -0,0 +1,6 @@
+-- Enum values cannot be dropped in PostgreSQL; 'transfer' stays on TypeTransaction.
+DELETE FROM transactions WHERE transfer_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+ALTER TABLE transactions DROP COLUMN transfer_id;
+ALTER TABLE transactions ALTER COLUMN category_id SET NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TYPE TypeTransaction ADD VALUE IF NOT EXISTS 'transfer';
+
+ALTER TABLE transactions ADD COLUMN transfer_id VARCHAR;
+ALTER TABLE transactions ALTER COLUMN category_id DROP NOT NULL;
+
+CREATE INDEX idx_transactions_transfer_id ON transactions(transfer_id);
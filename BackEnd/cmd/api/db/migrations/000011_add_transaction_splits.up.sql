@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id VARCHAR PRIMARY KEY,
+    transaction_id VARCHAR NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id VARCHAR NOT NULL REFERENCES categorys(id),
+    budget_id VARCHAR REFERENCES budgets(id) ON DELETE SET NULL,
+    amount float NOT NULL,
+    description TEXT,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_transaction_splits_transaction_id ON transaction_splits(transaction_id);
+CREATE INDEX idx_transaction_splits_category_id ON transaction_splits(category_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS import_mappings (
+    id VARCHAR PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    has_header BOOLEAN NOT NULL DEFAULT true,
+    date_column INTEGER NOT NULL,
+    amount_column INTEGER NOT NULL,
+    description_column INTEGER NOT NULL,
+    date_format VARCHAR(20) NOT NULL,
+    sign_convention VARCHAR(20) NOT NULL DEFAULT 'negative_is_bill',
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    category_id VARCHAR REFERENCES categorys(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_import_mappings_user_id ON import_mappings(user_id);
@@ -0,0 +1,3 @@
+-- Bank reference (OFX FITID or a hash for QIF) used to skip lines that were already imported
+ALTER TABLE transactions ADD COLUMN external_id VARCHAR;
+CREATE UNIQUE INDEX idx_transactions_account_external_id ON transactions(account_id, external_id);
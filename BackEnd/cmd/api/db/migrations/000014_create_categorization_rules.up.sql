@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id VARCHAR PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    name_contains VARCHAR(255),
+    amount_min float,
+    amount_max float,
+    account_id VARCHAR REFERENCES account(id) ON DELETE CASCADE,
+    category_id VARCHAR REFERENCES categorys(id) ON DELETE CASCADE,
+    budget_id VARCHAR REFERENCES budgets(id) ON DELETE SET NULL,
+    rename_to VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_categorization_rules_user_priority ON categorization_rules(user_id, priority);
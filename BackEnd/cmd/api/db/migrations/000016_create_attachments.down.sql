@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attachment_deletions;
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id VARCHAR NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key VARCHAR NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_transaction ON attachments(transaction_id);
+CREATE INDEX idx_attachments_user ON attachments(user_id);
+
+-- Blobs whose attachment row is gone; a worker removes them from the blob store.
+CREATE TABLE IF NOT EXISTS attachment_deletions (
+    storage_key VARCHAR PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
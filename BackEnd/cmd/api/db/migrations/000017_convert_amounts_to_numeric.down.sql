@@ -0,0 +1,6 @@
+ALTER TABLE categorization_rules ALTER COLUMN amount_max TYPE float USING amount_max::float;
+ALTER TABLE categorization_rules ALTER COLUMN amount_min TYPE float USING amount_min::float;
+ALTER TABLE budgets ALTER COLUMN amount TYPE float USING amount::float;
+ALTER TABLE transaction_splits ALTER COLUMN amount TYPE float USING amount::float;
+ALTER TABLE transactions ALTER COLUMN amount TYPE float USING amount::float;
+ALTER TABLE account ALTER COLUMN balance TYPE float USING balance::float;
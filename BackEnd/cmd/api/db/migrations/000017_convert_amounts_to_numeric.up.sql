@@ -0,0 +1,8 @@
+-- Amounts were stored as double precision, which made totals drift by cents.
+-- Existing values are rounded to the cent while converting.
+ALTER TABLE account ALTER COLUMN balance TYPE NUMERIC(15, 2) USING ROUND(balance::numeric, 2);
+ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(15, 2) USING ROUND(amount::numeric, 2);
+ALTER TABLE transaction_splits ALTER COLUMN amount TYPE NUMERIC(15, 2) USING ROUND(amount::numeric, 2);
+ALTER TABLE budgets ALTER COLUMN amount TYPE NUMERIC(15, 2) USING ROUND(amount::numeric, 2);
+ALTER TABLE categorization_rules ALTER COLUMN amount_min TYPE NUMERIC(15, 2) USING ROUND(amount_min::numeric, 2);
+ALTER TABLE categorization_rules ALTER COLUMN amount_max TYPE NUMERIC(15, 2) USING ROUND(amount_max::numeric, 2);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS fx_rates;
+ALTER TABLE users DROP COLUMN IF EXISTS base_currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE account DROP COLUMN IF EXISTS currency;
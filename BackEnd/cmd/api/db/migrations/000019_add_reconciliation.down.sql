@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_account_status;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reconciliation_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS status;
+DROP TABLE IF EXISTS reconciliations;
@@ -0,0 +1,20 @@
+-- Statement reconciliations: the statement balance of an account at a date, checked against its cleared transactions.
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id VARCHAR PRIMARY KEY,
+    account_id VARCHAR NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    statement_date DATE NOT NULL,
+    statement_balance NUMERIC(15, 2) NOT NULL,
+    cleared_balance NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_reconciliations_account ON reconciliations(account_id, statement_date);
+
+-- pending: entered by the user, cleared: confirmed by the bank, reconciled: matched to a completed statement.
+ALTER TABLE transactions ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cleared', 'reconciled'));
+ALTER TABLE transactions ADD COLUMN reconciliation_id VARCHAR REFERENCES reconciliations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_account_status ON transactions(account_id, status);
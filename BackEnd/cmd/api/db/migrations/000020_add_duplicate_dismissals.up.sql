@@ -0,0 +1,10 @@
+-- Pairs of transactions the user marked as not being duplicates. transaction_id is always the smaller id of the pair.
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    transaction_id VARCHAR NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    duplicate_id VARCHAR NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (transaction_id, duplicate_id)
+);
+
+CREATE INDEX idx_duplicate_dismissals_user ON duplicate_dismissals(user_id);
@@ -0,0 +1,26 @@
+-- Append-only history of the changes made to financial records. Rows are never updated or deleted, and they are
+-- kept when the record or the user is removed, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id VARCHAR PRIMARY KEY,
+    entity_type VARCHAR(30) NOT NULL CHECK (entity_type IN ('transaction', 'account', 'budget', 'category', 'investment')),
+    entity_id VARCHAR NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    user_id VARCHAR NOT NULL,
+    request_id VARCHAR,
+    before_snapshot JSONB,
+    after_snapshot JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id, created_at);
+CREATE INDEX idx_audit_events_user ON audit_events(user_id, created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_categorys_trash;
+DROP INDEX IF EXISTS idx_account_trash;
+DROP INDEX IF EXISTS idx_transactions_trash;
+ALTER TABLE categorys DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE account DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS deleted_at;
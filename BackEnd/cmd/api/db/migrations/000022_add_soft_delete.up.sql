@@ -0,0 +1,8 @@
+-- Deleted transactions, accounts and categories go to the trash: they stay hidden until restored or purged.
+ALTER TABLE transactions ADD COLUMN deleted_at timestamptz;
+ALTER TABLE account ADD COLUMN deleted_at timestamptz;
+ALTER TABLE categorys ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX idx_transactions_trash ON transactions(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_account_trash ON account(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_categorys_trash ON categorys(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Payees group the raw names of transactions under one merchant through alias patterns.
+CREATE TABLE IF NOT EXISTS payees (
+    id VARCHAR PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS payee_aliases (
+    payee_id VARCHAR NOT NULL REFERENCES payees(id) ON DELETE CASCADE,
+    pattern VARCHAR(100) NOT NULL,
+    PRIMARY KEY (payee_id, pattern)
+);
+
+ALTER TABLE transactions ADD COLUMN payee_id VARCHAR REFERENCES payees(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_payee ON transactions(payee_id) WHERE payee_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- A refund is an income transaction linked to the bill it gives money back for.
+ALTER TABLE transactions ADD COLUMN refund_of VARCHAR REFERENCES transactions(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_refund_of ON transactions(refund_of) WHERE refund_of IS NOT NULL;
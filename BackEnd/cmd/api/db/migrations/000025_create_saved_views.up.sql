@@ -0,0 +1,9 @@
+-- Saved views keep a named transaction filter per user as the query string of the listing.
+CREATE TABLE IF NOT EXISTS saved_views (
+    id VARCHAR PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
@@ -0,0 +1,15 @@
+-- Idempotency keys let clients retry create requests safely: the first response is kept until the key expires
+-- and replayed to any retry with the same body. A status code of 0 marks a request still being processed.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
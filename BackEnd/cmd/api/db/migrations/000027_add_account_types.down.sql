@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_investments_account;
+ALTER TABLE investments DROP COLUMN IF EXISTS account_id;
+
+ALTER TABLE account DROP COLUMN IF EXISTS interest_rate;
+ALTER TABLE account DROP COLUMN IF EXISTS due_day;
+ALTER TABLE account DROP COLUMN IF EXISTS statement_day;
+ALTER TABLE account DROP COLUMN IF EXISTS credit_limit;
+ALTER TABLE account DROP COLUMN IF EXISTS account_type;
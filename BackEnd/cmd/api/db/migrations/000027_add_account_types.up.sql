@@ -0,0 +1,12 @@
+-- Account types with their own details: credit limit, statement day and due day for credit cards, and the annual
+-- interest rate of savings accounts and loans. Credit cards and loans are liabilities and keep negative balances.
+ALTER TABLE account ADD COLUMN account_type VARCHAR(20) NOT NULL DEFAULT 'checking'
+    CHECK (account_type IN ('checking', 'savings', 'credit_card', 'cash', 'loan', 'brokerage'));
+ALTER TABLE account ADD COLUMN credit_limit NUMERIC(15, 2);
+ALTER TABLE account ADD COLUMN statement_day SMALLINT CHECK (statement_day BETWEEN 1 AND 31);
+ALTER TABLE account ADD COLUMN due_day SMALLINT CHECK (due_day BETWEEN 1 AND 31);
+ALTER TABLE account ADD COLUMN interest_rate NUMERIC(6, 3);
+
+-- Investments can be held in a brokerage account
+ALTER TABLE investments ADD COLUMN account_id VARCHAR REFERENCES account(id) ON DELETE SET NULL;
+CREATE INDEX idx_investments_account ON investments(account_id) WHERE account_id IS NOT NULL;
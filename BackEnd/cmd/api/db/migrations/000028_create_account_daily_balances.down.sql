@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS account_daily_balances_invalidate ON transactions;
+DROP FUNCTION IF EXISTS account_daily_balances_invalidate();
+DROP INDEX IF EXISTS idx_transactions_account_created_at;
+DROP TABLE IF EXISTS account_daily_balances;
@@ -0,0 +1,26 @@
+-- Closing balance of every account on every day, without the initial balance. Rows are built on demand from the
+-- last one stored, and a trigger drops them from the day of any transaction that is added, changed or removed.
+CREATE TABLE IF NOT EXISTS account_daily_balances (
+    account_id VARCHAR NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    balance NUMERIC(15, 2) NOT NULL,
+    PRIMARY KEY (account_id, day)
+);
+
+CREATE INDEX idx_transactions_account_created_at ON transactions(account_id, created_at);
+
+CREATE OR REPLACE FUNCTION account_daily_balances_invalidate() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM account_daily_balances WHERE account_id = OLD.account_id AND day >= (OLD.created_at AT TIME ZONE 'UTC')::date;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        DELETE FROM account_daily_balances WHERE account_id = NEW.account_id AND day >= (NEW.created_at AT TIME ZONE 'UTC')::date;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER account_daily_balances_invalidate
+    AFTER INSERT OR DELETE OR UPDATE OF amount, account_id, created_at, deleted_at ON transactions
+    FOR EACH ROW EXECUTE FUNCTION account_daily_balances_invalidate();
@@ -0,0 +1,13 @@
+-- Net worth of every user per month in their base currency, recorded by a worker. The row of the current month is
+-- overwritten on every run, so past months keep the last value recorded in them.
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    user_id VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    assets NUMERIC(15, 2) NOT NULL,
+    liabilities NUMERIC(15, 2) NOT NULL,
+    investments NUMERIC(15, 2) NOT NULL,
+    net_worth NUMERIC(15, 2) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, month)
+);
@@ -0,0 +1,3 @@
+-- Archived accounts are closed: hidden from listings and search and closed to new transactions, but their history
+-- stays in analytics and exports
+ALTER TABLE account ADD COLUMN archived_at timestamptz;
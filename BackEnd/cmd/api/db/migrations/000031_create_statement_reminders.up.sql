@@ -0,0 +1,7 @@
+-- Credit card statements a due date reminder was sent for, so each statement is only reminded once
+CREATE TABLE IF NOT EXISTS statement_reminders (
+    account_id VARCHAR NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+    closing_date DATE NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, closing_date)
+);
@@ -175,6 +175,47 @@ type MiddlewareConfig struct {
 	EnableValidation     bool `json:"enable_validation"`
 }
 
+// AttachmentsConfig holds the storage and limits of transaction attachments
+type AttachmentsConfig struct {
+	Driver        string        `json:"driver"`
+	LocalPath     string        `json:"local_path"`
+	MaxFileSize   int64         `json:"max_file_size"` // bytes
+	UserQuota     int64         `json:"user_quota"`    // bytes
+	PurgeInterval time.Duration `json:"purge_interval"`
+}
+
+// FXConfig holds the exchange rate provider used to convert reports into the base currency of each user
+type FXConfig struct {
+	Provider     string        `json:"provider"`
+	ManualRates  string        `json:"manual_rates"` // e.g. "EUR/USD=1.08,USD/MXN=17.05"
+	RatesFile    string        `json:"rates_file"`
+	SyncInterval time.Duration `json:"sync_interval"`
+}
+
+// TrashConfig holds how long deleted transactions, accounts and categories stay restorable before they are purged
+type TrashConfig struct {
+	Retention     time.Duration `json:"retention"`
+	PurgeInterval time.Duration `json:"purge_interval"`
+}
+
+// IdempotencyConfig holds how long the responses of requests sent with an Idempotency-Key are replayed
+type IdempotencyConfig struct {
+	TTL           time.Duration `json:"ttl"`
+	PurgeInterval time.Duration `json:"purge_interval"`
+}
+
+// NetWorthConfig holds how often the net worth of every user is recorded for the current month
+type NetWorthConfig struct {
+	SnapshotInterval time.Duration `json:"snapshot_interval"`
+}
+
+// StatementConfig holds how many days before a credit card statement falls due its owner is reminded, and how
+// often the reminders are checked
+type StatementConfig struct {
+	ReminderDays     int           `json:"reminder_days"`
+	ReminderInterval time.Duration `json:"reminder_interval"`
+}
+
 // Config holds all application configuration settings
 type Config struct {
 	Server        ServerConfig        `json:"server"`
@@ -188,6 +229,12 @@ type Config struct {
 	OpenTelemetry OpenTelemetryConfig `json:"opentelemetry"`
 	Prometheus    PrometheusConfig    `json:"prometheus"`
 	Middleware    MiddlewareConfig    `json:"middleware"`
+	Attachments   AttachmentsConfig   `json:"attachments"`
+	FX            FXConfig            `json:"fx"`
+	Trash         TrashConfig         `json:"trash"`
+	Idempotency   IdempotencyConfig   `json:"idempotency"`
+	NetWorth      NetWorthConfig      `json:"net_worth"`
+	Statement     StatementConfig     `json:"statement"`
 }
 
 // LoadConfig loads configuration from environment variables with comprehensive validation
@@ -343,6 +390,35 @@ func LoadConfig() (*Config, error) {
 			EnableAuthentication: getEnvBool("MIDDLEWARE_ENABLE_AUTHENTICATION", true),
 			EnableValidation:     getEnvBool("MIDDLEWARE_ENABLE_VALIDATION", true),
 		},
+
+		Attachments: AttachmentsConfig{
+			Driver:        getEnvString("ATTACHMENTS_DRIVER", "local"),
+			LocalPath:     getEnvString("ATTACHMENTS_LOCAL_PATH", "./data/attachments"),
+			MaxFileSize:   int64(getEnvInt("ATTACHMENTS_MAX_FILE_SIZE", 10<<20)), // 10MB
+			UserQuota:     int64(getEnvInt("ATTACHMENTS_USER_QUOTA", 200<<20)),   // 200MB
+			PurgeInterval: getDuration(getEnvString("ATTACHMENTS_PURGE_INTERVAL", "10m")),
+		},
+		FX: FXConfig{
+			Provider:     getEnvString("FX_PROVIDER", "manual"),
+			ManualRates:  getEnvString("FX_MANUAL_RATES", ""),
+			RatesFile:    getEnvString("FX_RATES_FILE", "./data/fx_rates.csv"),
+			SyncInterval: getDuration(getEnvString("FX_SYNC_INTERVAL", "12h")),
+		},
+		Trash: TrashConfig{
+			Retention:     getDuration(getEnvString("TRASH_RETENTION", "720h")), // 30 days
+			PurgeInterval: getDuration(getEnvString("TRASH_PURGE_INTERVAL", "1h")),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           getDuration(getEnvString("IDEMPOTENCY_TTL", "24h")),
+			PurgeInterval: getDuration(getEnvString("IDEMPOTENCY_PURGE_INTERVAL", "1h")),
+		},
+		NetWorth: NetWorthConfig{
+			SnapshotInterval: getDuration(getEnvString("NET_WORTH_SNAPSHOT_INTERVAL", "6h")),
+		},
+		Statement: StatementConfig{
+			ReminderDays:     getEnvInt("STATEMENT_REMINDER_DAYS", 3),
+			ReminderInterval: getDuration(getEnvString("STATEMENT_REMINDER_INTERVAL", "1h")),
+		},
 	}
 
 	// Validate configuration
@@ -365,6 +441,12 @@ func (c *Config) Validate() error {
 		c.validateLogging,
 		c.validateOpenTelemetry,
 		c.validatePrometheus,
+		c.validateAttachments,
+		c.validateFX,
+		c.validateTrash,
+		c.validateIdempotency,
+		c.validateNetWorth,
+		c.validateStatement,
 		c.validateEnvironmentSpecific,
 	}
 
@@ -611,6 +693,83 @@ func (c *Config) validatePrometheus() error {
 	return nil
 }
 
+// validateAttachments validates attachment storage configuration
+func (c *Config) validateAttachments() error {
+	if c.Attachments.MaxFileSize < 0 || c.Attachments.UserQuota < 0 {
+		return fmt.Errorf("attachment size limits cannot be negative")
+	}
+
+	if c.Attachments.MaxFileSize > 0 && c.Attachments.UserQuota > 0 && c.Attachments.MaxFileSize > c.Attachments.UserQuota {
+		return fmt.Errorf("attachment max file size cannot exceed the user quota")
+	}
+
+	if c.Attachments.PurgeInterval < 0 {
+		return fmt.Errorf("attachment purge interval cannot be negative")
+	}
+
+	return nil
+}
+
+// validateFX validates the exchange rate provider configuration
+func (c *Config) validateFX() error {
+	switch c.FX.Provider {
+	case "", "manual":
+	case "file":
+		if c.FX.RatesFile == "" {
+			return fmt.Errorf("the file exchange rate provider needs FX_RATES_FILE")
+		}
+	default:
+		return fmt.Errorf("invalid FX provider: %s (must be one of: manual, file)", c.FX.Provider)
+	}
+
+	if c.FX.SyncInterval < 0 {
+		return fmt.Errorf("FX sync interval cannot be negative")
+	}
+
+	return nil
+}
+
+// validateTrash validates the trash retention configuration
+func (c *Config) validateTrash() error {
+	if c.Trash.Retention < 0 {
+		return fmt.Errorf("trash retention cannot be negative")
+	}
+	if c.Trash.PurgeInterval < 0 {
+		return fmt.Errorf("trash purge interval cannot be negative")
+	}
+	return nil
+}
+
+// validateIdempotency validates the idempotency key configuration
+func (c *Config) validateIdempotency() error {
+	if c.Idempotency.TTL < 0 {
+		return fmt.Errorf("idempotency TTL cannot be negative")
+	}
+	if c.Idempotency.PurgeInterval < 0 {
+		return fmt.Errorf("idempotency purge interval cannot be negative")
+	}
+	return nil
+}
+
+// validateNetWorth validates the net worth snapshot configuration
+func (c *Config) validateNetWorth() error {
+	if c.NetWorth.SnapshotInterval < 0 {
+		return fmt.Errorf("net worth snapshot interval cannot be negative")
+	}
+	return nil
+}
+
+// validateStatement validates the credit card statement reminder configuration
+func (c *Config) validateStatement() error {
+	if c.Statement.ReminderDays < 0 || c.Statement.ReminderDays > 31 {
+		return fmt.Errorf("statement reminder days must be between 0 and 31")
+	}
+	if c.Statement.ReminderInterval < 0 {
+		return fmt.Errorf("statement reminder interval cannot be negative")
+	}
+	return nil
+}
+
 // validateEnvironmentSpecific validates environment-specific requirements
 func (c *Config) validateEnvironmentSpecific() error {
 	if c.Server.Environment == EnvironmentProduction {
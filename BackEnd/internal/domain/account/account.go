@@ -1,21 +1,129 @@
 package account
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// Account types. Credit cards and loans are liabilities: their balance is what is owed and, like any outflow,
+// it is negative, so the balances of all accounts add up to the net worth.
+const (
+	TypeChecking   = "checking"
+	TypeSavings    = "savings"
+	TypeCreditCard = "credit_card"
+	TypeCash       = "cash"
+	TypeLoan       = "loan"
+	TypeBrokerage  = "brokerage"
+)
+
+// MaxInterestRate is the highest annual interest rate accepted, in percent.
+const MaxInterestRate = 100
 
 type Account struct {
-	Id             string    `json:"id" example:"acc_123456789"`
-	Name           string    `json:"name" example:"My Savings Account"`
-	Bank           string    `json:"bank" example:"Bank of America"`
-	UserId         string    `json:"user_id" example:"user_987654321"`
-	InitialBalance float64   `json:"initial_balance" example:"1000.50"`
-	CreatedAt      time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	Id             string      `json:"id" example:"acc_123456789"`
+	Name           string      `json:"name" example:"My Savings Account"`
+	Bank           string      `json:"bank" example:"Bank of America"`
+	UserId         string      `json:"user_id" example:"user_987654321"`
+	InitialBalance money.Money `json:"initial_balance" example:"1000.50"`
+	Currency       string      `json:"currency" example:"EUR"`
+	Type           string      `json:"type" example:"checking" enums:"checking,savings,credit_card,cash,loan,brokerage"`
+	Details
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	// ArchivedAt is set while the account is archived: hidden from listings and closed to new transactions
+	ArchivedAt *time.Time `json:"archived_at,omitempty" example:"2024-06-30T18:00:00Z"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty" example:"2024-02-01T08:00:00Z"`
 }
 
-func NewAccount(balance float64, id string, name string, bank string) *Account {
+// Details holds the fields that only apply to some account types: the credit limit, statement day and due day of
+// credit cards, and the annual interest rate of savings accounts and loans, in percent.
+type Details struct {
+	CreditLimit  *money.Money `json:"credit_limit,omitempty" example:"3000.00"`
+	StatementDay *int         `json:"statement_day,omitempty" example:"25"`
+	DueDay       *int         `json:"due_day,omitempty" example:"10"`
+	InterestRate *float64     `json:"interest_rate,omitempty" example:"3.5"`
+}
+
+func NewAccount(balance money.Money, id string, name string, bank string) *Account {
 	return &Account{
 		Id:             id,
 		Name:           name,
 		Bank:           bank,
 		InitialBalance: balance,
+		Currency:       fx.DefaultCurrency,
+		Type:           TypeChecking,
+	}
+}
+
+// IsValidType reports whether accountType is one of the account types.
+func IsValidType(accountType string) bool {
+	switch accountType {
+	case TypeChecking, TypeSavings, TypeCreditCard, TypeCash, TypeLoan, TypeBrokerage:
+		return true
+	}
+	return false
+}
+
+func (a *Account) IsArchived() bool {
+	return a.ArchivedAt != nil
+}
+
+// IsLiability reports whether the account holds money owed rather than owned.
+func (a *Account) IsLiability() bool {
+	return a.Type == TypeCreditCard || a.Type == TypeLoan
+}
+
+// Validate checks the type of the account and that its details belong to that type.
+func (a *Account) Validate() error {
+	if !IsValidType(a.Type) {
+		return fmt.Errorf("account type must be one of: %s, %s, %s, %s, %s, %s", TypeChecking, TypeSavings, TypeCreditCard, TypeCash, TypeLoan, TypeBrokerage)
+	}
+	if a.InitialBalance < 0 && !a.IsLiability() {
+		return errors.New("initial balance cannot be negative")
+	}
+
+	if a.Type != TypeCreditCard && (a.CreditLimit != nil || a.StatementDay != nil || a.DueDay != nil) {
+		return errors.New("credit limit, statement day and due day only apply to credit cards")
+	}
+	if a.CreditLimit != nil && *a.CreditLimit < 0 {
+		return errors.New("credit limit cannot be negative")
+	}
+	for _, day := range []*int{a.StatementDay, a.DueDay} {
+		if day != nil && (*day < 1 || *day > 31) {
+			return errors.New("statement and due days must be between 1 and 31")
+		}
+	}
+
+	if a.InterestRate != nil {
+		if a.Type != TypeSavings && a.Type != TypeLoan {
+			return errors.New("interest rate only applies to savings accounts and loans")
+		}
+		if *a.InterestRate < 0 || *a.InterestRate > MaxInterestRate {
+			return fmt.Errorf("interest rate must be between 0 and %d", MaxInterestRate)
+		}
+	}
+	return nil
+}
+
+// AvailableCredit is what can still be spent on a credit card with the given balance. It is only known for cards
+// with a credit limit, and never goes below zero.
+func (a *Account) AvailableCredit(balance money.Money) (money.Money, bool) {
+	if a.Type != TypeCreditCard || a.CreditLimit == nil {
+		return 0, false
+	}
+	return max(*a.CreditLimit+balance, 0), true
+}
+
+// Utilization is the share of the credit limit of a card in use, in percent rounded to two decimals. It goes
+// over 100 when the card is over its limit.
+func (a *Account) Utilization(balance money.Money) (float64, bool) {
+	if a.Type != TypeCreditCard || a.CreditLimit == nil || *a.CreditLimit <= 0 {
+		return 0, false
 	}
+	owed := max(-balance, 0)
+	return math.Round(float64(owed)*10000/float64(*a.CreditLimit)) / 100, true
 }
@@ -0,0 +1,74 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	limit := money.FromUnits(1000)
+	day, badDay := 25, 32
+	rate, badRate := 3.5, 120.0
+
+	card := NewAccount(money.FromUnits(-200), "acc_card", "Visa", "Bank")
+	card.Type = TypeCreditCard
+	card.Details = Details{CreditLimit: &limit, StatementDay: &day, DueDay: &day}
+	assert.NoError(t, card.Validate())
+
+	card.DueDay = &badDay
+	assert.Error(t, card.Validate())
+
+	checking := NewAccount(money.FromUnits(-200), "acc_checking", "Checking", "Bank")
+	assert.Error(t, checking.Validate(), "only liabilities start below zero")
+
+	checking.InitialBalance = 0
+	checking.CreditLimit = &limit
+	assert.Error(t, checking.Validate(), "a credit limit only applies to cards")
+
+	savings := NewAccount(0, "acc_savings", "Savings", "Bank")
+	savings.Type = TypeSavings
+	savings.InterestRate = &rate
+	assert.NoError(t, savings.Validate())
+
+	savings.InterestRate = &badRate
+	assert.Error(t, savings.Validate())
+
+	savings.Type = TypeCash
+	savings.InterestRate = &rate
+	assert.Error(t, savings.Validate(), "interest only applies to savings and loans")
+
+	savings.Type = "mattress"
+	savings.InterestRate = nil
+	assert.Error(t, savings.Validate())
+}
+
+func TestAvailableCreditAndUtilization(t *testing.T) {
+	limit := money.FromUnits(1000)
+	card := NewAccount(0, "acc_card", "Visa", "Bank")
+	card.Type = TypeCreditCard
+	card.CreditLimit = &limit
+
+	available, ok := card.AvailableCredit(money.FromUnits(-250))
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(750), available)
+
+	utilization, ok := card.Utilization(money.FromUnits(-250))
+	assert.True(t, ok)
+	assert.Equal(t, 25.0, utilization)
+
+	available, _ = card.AvailableCredit(money.FromUnits(-1200))
+	assert.Equal(t, money.Money(0), available)
+	utilization, _ = card.Utilization(money.FromUnits(-1200))
+	assert.Equal(t, 120.0, utilization)
+
+	utilization, _ = card.Utilization(money.FromUnits(50))
+	assert.Equal(t, 0.0, utilization)
+
+	checking := NewAccount(0, "acc_checking", "Checking", "Bank")
+	_, ok = checking.AvailableCredit(0)
+	assert.False(t, ok)
+	_, ok = checking.Utilization(0)
+	assert.False(t, ok)
+}
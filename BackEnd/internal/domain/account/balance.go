@@ -0,0 +1,66 @@
+package account
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// Intervals of a balance history.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// DateLayout is the format of the days of a balance history.
+const DateLayout = "2006-01-02"
+
+// DailyBalance is the closing balance of an account on a day, in UTC. It is the sum of the transactions up to
+// the end of the day and does not include the initial balance of the account.
+type DailyBalance struct {
+	AccountId string
+	Day       time.Time
+	Balance   money.Money
+}
+
+// BalancePoint is the balance of an account at the end of Date, initial balance included.
+type BalancePoint struct {
+	Date    string      `json:"date" example:"2024-03-01"`
+	Balance money.Money `json:"balance" example:"1250.75"`
+}
+
+func IsValidInterval(interval string) bool {
+	return interval == IntervalDay || interval == IntervalWeek || interval == IntervalMonth
+}
+
+// Day truncates t to the start of its day in UTC.
+func Day(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Sample turns consecutive daily balances into one point per interval, taken on the last day of each day, week
+// (weeks start on Monday) or month. The last point is always the last day, even when its period goes on.
+func Sample(days []DailyBalance, interval string, initialBalance money.Money) []BalancePoint {
+	points := make([]BalancePoint, 0, len(days))
+	for i, day := range days {
+		if i+1 < len(days) && samePeriod(day.Day, days[i+1].Day, interval) {
+			continue
+		}
+		points = append(points, BalancePoint{Date: day.Day.Format(DateLayout), Balance: day.Balance + initialBalance})
+	}
+	return points
+}
+
+func samePeriod(a time.Time, b time.Time, interval string) bool {
+	switch interval {
+	case IntervalWeek:
+		aYear, aWeek := a.ISOWeek()
+		bYear, bWeek := b.ISOWeek()
+		return aYear == bYear && aWeek == bWeek
+	case IntervalMonth:
+		return a.Year() == b.Year() && a.Month() == b.Month()
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package account
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// MinimumPaymentPercent is the share of the statement balance asked as minimum payment, in percent.
+const MinimumPaymentPercent = 2
+
+// MinimumPaymentFloor is the least minimum payment of a statement, unless its whole balance is lower.
+var MinimumPaymentFloor = money.FromUnits(25)
+
+// StatementPeriod is a billing cycle of a credit card: the transactions from Start to Close, both days included,
+// are billed on the statement that closes on Close, which is due on Due. All three are days in UTC.
+type StatementPeriod struct {
+	Start time.Time
+	Close time.Time
+	Due   time.Time
+}
+
+// StatementTotals are the sums of stored transactions a statement is built from: the balance before the cycle,
+// without the initial balance of the account, the charges (negative) and credits of the cycle, and the credits
+// posted after the close up to the due date.
+type StatementTotals struct {
+	Before  money.Money
+	Charges money.Money
+	Credits money.Money
+	Paid    money.Money
+}
+
+// Statement is a billing cycle of a credit card with its totals. Amounts owed are positive: the statement balance
+// is what the card owed at the close, negative when the card was in credit. The minimum payment, paid and
+// remaining amounts are only set once the cycle has closed.
+type Statement struct {
+	AccountId        string      `json:"account_id" example:"acc_123456789"`
+	PeriodStart      string      `json:"period_start" example:"2024-02-26"`
+	ClosingDate      string      `json:"closing_date" example:"2024-03-25"`
+	DueDate          string      `json:"due_date" example:"2024-04-10"`
+	Closed           bool        `json:"closed" example:"true"`
+	PreviousBalance  money.Money `json:"previous_balance" example:"320.00"`
+	Charges          money.Money `json:"charges" example:"845.30"`
+	Credits          money.Money `json:"credits" example:"320.00"`
+	StatementBalance money.Money `json:"statement_balance" example:"845.30"`
+	MinimumPayment   money.Money `json:"minimum_payment" example:"25.00"`
+	Paid             money.Money `json:"paid" example:"100.00"`
+	Remaining        money.Money `json:"remaining" example:"745.30"`
+}
+
+// HasStatementCycle reports whether the account is a credit card with both a statement day and a due day.
+func (a *Account) HasStatementCycle() bool {
+	return a.Type == TypeCreditCard && a.StatementDay != nil && a.DueDay != nil
+}
+
+// StatementPeriodOn returns the billing cycle that day falls in. Months shorter than the statement or due day
+// close, or fall due, on their last day. It must only be called on accounts with a statement cycle.
+func (a *Account) StatementPeriodOn(day time.Time) StatementPeriod {
+	day = Day(day)
+	closing := dayOfMonth(day.Year(), day.Month(), *a.StatementDay)
+	if day.After(closing) {
+		closing = dayOfMonth(day.Year(), day.Month()+1, *a.StatementDay)
+	}
+	return a.periodClosingOn(closing)
+}
+
+// PreviousStatementPeriod returns the billing cycle right before period.
+func (a *Account) PreviousStatementPeriod(period StatementPeriod) StatementPeriod {
+	return a.periodClosingOn(period.Start.AddDate(0, 0, -1))
+}
+
+func (a *Account) periodClosingOn(closing time.Time) StatementPeriod {
+	previous := dayOfMonth(closing.Year(), closing.Month()-1, *a.StatementDay)
+	due := dayOfMonth(closing.Year(), closing.Month(), *a.DueDay)
+	if !due.After(closing) {
+		due = dayOfMonth(closing.Year(), closing.Month()+1, *a.DueDay)
+	}
+	return StatementPeriod{Start: previous.AddDate(0, 0, 1), Close: closing, Due: due}
+}
+
+// dayOfMonth returns the given day of a month in UTC, or the last day of the month when it is shorter. The month
+// may overflow into the next or previous year.
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// NewStatement builds the statement of a billing cycle of the account from its totals. The cycle counts as closed
+// once today is past its closing day.
+func NewStatement(a *Account, period StatementPeriod, totals StatementTotals, today time.Time) *Statement {
+	statement := &Statement{
+		AccountId:       a.Id,
+		PeriodStart:     period.Start.Format(DateLayout),
+		ClosingDate:     period.Close.Format(DateLayout),
+		DueDate:         period.Due.Format(DateLayout),
+		Closed:          Day(today).After(period.Close),
+		PreviousBalance: -(a.InitialBalance + totals.Before),
+		Charges:         -totals.Charges,
+		Credits:         totals.Credits,
+	}
+	statement.StatementBalance = statement.PreviousBalance + statement.Charges - statement.Credits
+	if statement.Closed {
+		statement.MinimumPayment = MinimumPayment(statement.StatementBalance)
+		statement.Paid = totals.Paid
+		statement.Remaining = max(statement.StatementBalance-totals.Paid, 0)
+	}
+	return statement
+}
+
+// MinimumPayment is the least to pay on a statement balance: MinimumPaymentPercent of it, but no less than
+// MinimumPaymentFloor, and never more than the balance itself.
+func MinimumPayment(balance money.Money) money.Money {
+	if balance <= 0 {
+		return 0
+	}
+	minimum := max((balance * MinimumPaymentPercent).Div(100), MinimumPaymentFloor)
+	return min(minimum, balance)
+}
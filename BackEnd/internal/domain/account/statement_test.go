@@ -0,0 +1,79 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newCard(statementDay int, dueDay int) *Account {
+	card := NewAccount(0, "acc_card", "Visa", "Bank")
+	card.Type = TypeCreditCard
+	card.StatementDay = &statementDay
+	card.DueDay = &dueDay
+	return card
+}
+
+func TestStatementPeriodOn(t *testing.T) {
+	card := newCard(25, 10)
+
+	period := card.StatementPeriodOn(time.Date(2024, 3, 25, 23, 30, 0, 0, time.UTC))
+	assert.Equal(t, StatementPeriod{Start: date(2024, 2, 26), Close: date(2024, 3, 25), Due: date(2024, 4, 10)}, period)
+
+	period = card.StatementPeriodOn(date(2024, 12, 26))
+	assert.Equal(t, StatementPeriod{Start: date(2024, 12, 26), Close: date(2025, 1, 25), Due: date(2025, 2, 10)}, period)
+
+	previous := card.PreviousStatementPeriod(period)
+	assert.Equal(t, StatementPeriod{Start: date(2024, 11, 26), Close: date(2024, 12, 25), Due: date(2025, 1, 10)}, previous)
+
+	// Short months close and fall due on their last day
+	endOfMonth := newCard(31, 30)
+	period = endOfMonth.StatementPeriodOn(date(2023, 2, 10))
+	assert.Equal(t, StatementPeriod{Start: date(2023, 2, 1), Close: date(2023, 2, 28), Due: date(2023, 3, 30)}, period)
+	period = endOfMonth.StatementPeriodOn(date(2023, 3, 1))
+	assert.Equal(t, StatementPeriod{Start: date(2023, 3, 1), Close: date(2023, 3, 31), Due: date(2023, 4, 30)}, period)
+
+	assert.True(t, card.HasStatementCycle())
+	assert.False(t, NewAccount(0, "acc_checking", "Checking", "Bank").HasStatementCycle())
+}
+
+func TestNewStatement(t *testing.T) {
+	card := newCard(25, 10)
+	card.InitialBalance = money.FromUnits(-100)
+	period := card.StatementPeriodOn(date(2024, 3, 1))
+	totals := StatementTotals{
+		Before:  money.FromUnits(-220),
+		Charges: money.FromUnits(-900),
+		Credits: money.FromUnits(320),
+		Paid:    money.FromUnits(200),
+	}
+
+	open := NewStatement(card, period, totals, date(2024, 3, 25))
+	assert.False(t, open.Closed)
+	assert.Equal(t, money.FromUnits(320), open.PreviousBalance)
+	assert.Equal(t, money.FromUnits(900), open.StatementBalance)
+	assert.Equal(t, money.Money(0), open.MinimumPayment)
+	assert.Equal(t, money.Money(0), open.Remaining)
+
+	closed := NewStatement(card, period, totals, date(2024, 3, 26))
+	assert.True(t, closed.Closed)
+	assert.Equal(t, "2024-02-26", closed.PeriodStart)
+	assert.Equal(t, "2024-04-10", closed.DueDate)
+	assert.Equal(t, money.FromUnits(900), closed.Charges)
+	assert.Equal(t, money.FromUnits(25), closed.MinimumPayment)
+	assert.Equal(t, money.FromUnits(200), closed.Paid)
+	assert.Equal(t, money.FromUnits(700), closed.Remaining)
+}
+
+func TestMinimumPayment(t *testing.T) {
+	assert.Equal(t, money.Money(0), MinimumPayment(money.FromUnits(-50)))
+	assert.Equal(t, money.FromUnits(10), MinimumPayment(money.FromUnits(10)))
+	assert.Equal(t, money.FromUnits(25), MinimumPayment(money.FromUnits(600)))
+	assert.Equal(t, money.FromFloat(40.02), MinimumPayment(money.FromUnits(2001)))
+}
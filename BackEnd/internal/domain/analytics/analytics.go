@@ -1,18 +1,52 @@
 package analytics
 
-import "time"
+import (
+	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// CategoryExpense is the spending of one category. Value is in Currency, the base currency of the user;
+// ByCurrency keeps the native amounts it was converted from.
 type CategoryExpense struct {
-	ID    string  `json:"id"`
-	Label string  `json:"label"`
-	Value float64 `json:"value"`
-	Color string  `json:"color"`
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Color      string              `json:"color"`
+	Currency   string              `json:"currency,omitempty"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
+}
+
+// TagExpense is the spending of one tag. A transaction with several tags counts towards each of them.
+type TagExpense struct {
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Count      int                 `json:"count"`
+	Currency   string              `json:"currency,omitempty"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
+}
+
+// PayeeExpense is the spending of one payee, used to rank where the money goes.
+type PayeeExpense struct {
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Count      int                 `json:"count"`
+	Currency   string              `json:"currency,omitempty"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
 }
 
+// MonthlySummary holds the income and expenses of one month in the base currency of the user, next to
+// the native amounts per currency.
 type MonthlySummary struct {
-	Month    string  `json:"month"`
-	Income   float64 `json:"income"`
-	Expenses float64 `json:"expenses"`
+	Month              string              `json:"month"`
+	Income             money.Money         `json:"income"`
+	Expenses           money.Money         `json:"expenses"`
+	Currency           string              `json:"currency,omitempty"`
+	IncomeByCurrency   []fx.CurrencyAmount `json:"income_by_currency,omitempty"`
+	ExpensesByCurrency []fx.CurrencyAmount `json:"expenses_by_currency,omitempty"`
 }
 
 type AnalyticsRepository interface {
@@ -26,27 +60,44 @@ type AnalyticsService interface {
 }
 
 type GetCategoryExpensesResponse struct {
-	ID    string  `json:"id"`
-	Label string  `json:"label"`
-	Value float64 `json:"value"`
-	Color string  `json:"color"`
+	ID    string      `json:"id"`
+	Label string      `json:"label"`
+	Value money.Money `json:"value"`
+	Color string      `json:"color"`
 }
 
 type GetMonthlySummaryResponse struct {
-	Month    string  `json:"month"`
-	Income   float64 `json:"income"`
-	Expenses float64 `json:"expenses"`
+	Month    string      `json:"month"`
+	Income   money.Money `json:"income"`
+	Expenses money.Money `json:"expenses"`
 }
 
 type CategoryExpenseRepository struct {
 	CategoryName  string
-	TotalAmount   float64
+	Currency      string
+	TotalAmount   money.Money
 	CategoryColor string
 }
 
+type TagExpenseRepository struct {
+	TagName          string
+	Currency         string
+	TotalAmount      money.Money
+	TransactionCount int
+}
+
+type PayeeExpenseRepository struct {
+	PayeeId          string
+	PayeeName        string
+	Currency         string
+	TotalAmount      money.Money
+	TransactionCount int
+}
+
 type MonthlySummaryRepository struct {
 	Year        int
 	Month       time.Month
-	TotalIncome float64
-	TotalBill   float64
+	Currency    string
+	TotalIncome money.Money
+	TotalBill   money.Money
 }
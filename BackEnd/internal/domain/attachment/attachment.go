@@ -0,0 +1,46 @@
+package attachment
+
+import (
+	"fmt"
+	"time"
+)
+
+// AllowedContentTypes lists the file types accepted as attachments: invoice PDFs and receipt photos.
+var AllowedContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"image/gif":       true,
+}
+
+// Attachment is a document, such as an invoice or a receipt photo, kept alongside a transaction.
+// The file itself lives in the blob store under StorageKey.
+type Attachment struct {
+	Id            string    `json:"id"`
+	UserId        string    `json:"user_id"`
+	TransactionId string    `json:"transaction_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	StorageKey    string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewAttachment(id, userId, transactionId, fileName, contentType string, size int64) *Attachment {
+	return &Attachment{
+		Id:            id,
+		UserId:        userId,
+		TransactionId: transactionId,
+		FileName:      fileName,
+		ContentType:   contentType,
+		Size:          size,
+		StorageKey:    StorageKey(userId, transactionId, id),
+		CreatedAt:     time.Now().UTC(),
+	}
+}
+
+// StorageKey groups the blobs of a user by transaction.
+func StorageKey(userId, transactionId, id string) string {
+	return fmt.Sprintf("%s/%s/%s", userId, transactionId, id)
+}
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+// Entity types recorded in the audit log.
+const (
+	EntityTransaction = "transaction"
+	EntityAccount     = "account"
+	EntityBudget      = "budget"
+	EntityCategory    = "category"
+	EntityInvestment  = "investment"
+)
+
+// Event is one append-only entry of the audit log: who changed which record, in which request, and what the record
+// looked like before and after. Before is empty for creations and After for deletions.
+type Event struct {
+	Id         string          `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	UserId     string          `json:"user_id"`
+	RequestId  string          `json:"request_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// NewEvent builds an event from snapshots of the record. A nil snapshot is left empty.
+func NewEvent(id, entityType, entityId, action, userId, requestId string, before, after any) (*Event, error) {
+	beforeSnapshot, err := snapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	afterSnapshot, err := snapshot(after)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Id:         id,
+		EntityType: entityType,
+		EntityId:   entityId,
+		Action:     action,
+		UserId:     userId,
+		RequestId:  requestId,
+		Before:     beforeSnapshot,
+		After:      afterSnapshot,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// IsEntityType reports whether entityType is one of the audited record types.
+func IsEntityType(entityType string) bool {
+	switch entityType {
+	case EntityTransaction, EntityAccount, EntityBudget, EntityCategory, EntityInvestment:
+		return true
+	}
+	return false
+}
+
+// IsAction reports whether action is one of the recorded actions.
+func IsAction(action string) bool {
+	switch action {
+	case ActionCreate, ActionUpdate, ActionDelete, ActionRestore:
+		return true
+	}
+	return false
+}
+
+func snapshot(record any) (json.RawMessage, error) {
+	if record == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
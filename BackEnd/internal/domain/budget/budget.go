@@ -1,17 +1,21 @@
 package budget
 
-import "time"
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
 type Budget struct {
 	CreatedAt    time.Time
 	Id           string
 	CategoryId   string
 	UserId       string
-	Amount       float64
+	Amount       money.Money
 	CategoryName string `json:"category_name,omitempty"`
 }
 
-func NewBudget(id, categoryId, userId string, amount float64) *Budget {
+func NewBudget(id, categoryId, userId string, amount money.Money) *Budget {
 	return &Budget{
 		Id:         id,
 		CategoryId: categoryId,
@@ -3,12 +3,13 @@ package category
 import "time"
 
 type Category struct {
-	CreatedAt time.Time `json:"created_at"`
-	Id        string    `json:"id"`
-	Name      string    `json:"name"`
-	Icon      string    `json:"icon"`
-	Color     string    `json:"color"`
-	UserId    string    `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+	Icon      string     `json:"icon"`
+	Color     string     `json:"color"`
+	UserId    string     `json:"user_id"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func NewCategory(id, name, icon, color string) *Category {
@@ -0,0 +1,178 @@
+// Package fx holds currencies, exchange rates and the conversion of amounts into the base currency of a user.
+package fx
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// DefaultCurrency is used for accounts and users that never picked a currency.
+const DefaultCurrency = "USD"
+
+// Rate says that one unit of Base buys Rate units of Quote on Date.
+type Rate struct {
+	Base   string    `json:"base" example:"EUR"`
+	Quote  string    `json:"quote" example:"USD"`
+	Rate   float64   `json:"rate" example:"1.0845"`
+	Date   time.Time `json:"date" example:"2024-01-15T00:00:00Z"`
+	Source string    `json:"source" example:"manual"`
+}
+
+func NewRate(base, quote string, rate float64, date time.Time, source string) *Rate {
+	return &Rate{
+		Base:   base,
+		Quote:  quote,
+		Rate:   rate,
+		Date:   date,
+		Source: source,
+	}
+}
+
+// NormalizeCurrency upper-cases an ISO 4217 code and reports whether it has the expected three letters.
+func NormalizeCurrency(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return code, false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return code, false
+		}
+	}
+	return code, true
+}
+
+// CurrencyAmount is an amount in its own currency next to its value in the base currency of the user.
+// Converted is nil when there is no exchange rate between the two currencies.
+type CurrencyAmount struct {
+	Currency  string       `json:"currency" example:"MXN"`
+	Amount    money.Money  `json:"amount" example:"1500.00"`
+	Converted *money.Money `json:"converted" example:"81.25"`
+}
+
+// Totals accumulates amounts per currency.
+type Totals map[string]money.Money
+
+func (t Totals) Add(currency string, amount money.Money) {
+	t[currency] += amount
+}
+
+type pair struct {
+	base  string
+	quote string
+}
+
+// Converter converts amounts into a single base currency with a snapshot of exchange rates.
+// A nil Converter leaves every amount untouched, as if all of them were in the same currency.
+type Converter struct {
+	base  string
+	rates map[pair]float64
+}
+
+// NewConverter keeps the most recent rate of every pair.
+func NewConverter(base string, rates []*Rate) *Converter {
+	latest := make(map[pair]*Rate)
+	for _, rate := range rates {
+		if rate.Rate <= 0 {
+			continue
+		}
+		key := pair{base: rate.Base, quote: rate.Quote}
+		if current, ok := latest[key]; !ok || rate.Date.After(current.Date) {
+			latest[key] = rate
+		}
+	}
+
+	converter := &Converter{base: base, rates: make(map[pair]float64, len(latest))}
+	for key, rate := range latest {
+		converter.rates[key] = rate.Rate
+	}
+	return converter
+}
+
+// Base returns the currency amounts are converted into, or an empty string for a nil Converter.
+func (c *Converter) Base() string {
+	if c == nil {
+		return ""
+	}
+	return c.base
+}
+
+// Rate returns how many units of the base currency one unit of from is worth.
+// Besides the direct pair it uses the inverse one and, failing both, a cross rate through a third currency.
+func (c *Converter) Rate(from string) (float64, bool) {
+	if c == nil || from == c.base || from == "" {
+		return 1, true
+	}
+	if rate, ok := c.direct(from, c.base); ok {
+		return rate, true
+	}
+
+	var pivots []string
+	for key := range c.rates {
+		for _, currency := range []string{key.base, key.quote} {
+			if currency != from && currency != c.base {
+				pivots = append(pivots, currency)
+			}
+		}
+	}
+	// Walk the pivots in a fixed order so the same rates always give the same result.
+	sort.Strings(pivots)
+	for _, pivot := range pivots {
+		first, ok := c.direct(from, pivot)
+		if !ok {
+			continue
+		}
+		if second, ok := c.direct(pivot, c.base); ok {
+			return first * second, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Converter) direct(from, to string) (float64, bool) {
+	if rate, ok := c.rates[pair{base: from, quote: to}]; ok {
+		return rate, true
+	}
+	if rate, ok := c.rates[pair{base: to, quote: from}]; ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+// Convert returns the amount in the base currency, rounded to the cent.
+func (c *Converter) Convert(amount money.Money, from string) (money.Money, bool) {
+	rate, ok := c.Rate(from)
+	if !ok {
+		return 0, false
+	}
+	if rate == 1 {
+		return amount, true
+	}
+	return money.Money(math.Round(float64(amount) * rate)), true
+}
+
+// Breakdown lists the totals sorted by currency with their converted value, and returns the sum of every
+// amount that could be converted.
+func (c *Converter) Breakdown(totals Totals) ([]CurrencyAmount, money.Money) {
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	var sum money.Money
+	breakdown := make([]CurrencyAmount, 0, len(currencies))
+	for _, currency := range currencies {
+		amount := CurrencyAmount{Currency: currency, Amount: totals[currency]}
+		if converted, ok := c.Convert(amount.Amount, currency); ok {
+			amount.Converted = &converted
+			sum += converted
+		}
+		breakdown = append(breakdown, amount)
+	}
+	return breakdown, sum
+}
@@ -0,0 +1,70 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCurrency(t *testing.T) {
+	code, ok := NormalizeCurrency(" eur ")
+	assert.True(t, ok)
+	assert.Equal(t, "EUR", code)
+
+	for _, invalid := range []string{"", "EU", "EURO", "E1R"} {
+		_, ok := NormalizeCurrency(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestConverter(t *testing.T) {
+	yesterday := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	today := yesterday.AddDate(0, 0, 1)
+	converter := NewConverter("EUR", []*Rate{
+		NewRate("EUR", "USD", 1.25, yesterday, "manual"),
+		NewRate("EUR", "USD", 1.0, today, "manual"),
+		NewRate("USD", "MXN", 20, today, "manual"),
+	})
+
+	converted, ok := converter.Convert(money.FromUnits(100), "EUR")
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(100), converted, "the base currency is not converted")
+
+	converted, ok = converter.Convert(money.FromUnits(100), "USD")
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(100), converted, "the latest rate wins and the inverse pair is used")
+
+	converted, ok = converter.Convert(money.FromUnits(-300), "MXN")
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(-15), converted, "MXN goes through USD")
+
+	_, ok = converter.Convert(money.FromUnits(1), "JPY")
+	assert.False(t, ok)
+}
+
+func TestConverterBreakdown(t *testing.T) {
+	converter := NewConverter("USD", []*Rate{NewRate("EUR", "USD", 1.1, time.Now(), "manual")})
+
+	totals := Totals{}
+	totals.Add("USD", money.FromUnits(10))
+	totals.Add("EUR", money.Money(1005))
+	totals.Add("JPY", money.FromUnits(500))
+	totals.Add("USD", money.FromUnits(5))
+
+	breakdown, sum := converter.Breakdown(totals)
+	assert.Equal(t, []string{"EUR", "JPY", "USD"}, []string{breakdown[0].Currency, breakdown[1].Currency, breakdown[2].Currency})
+	assert.Equal(t, money.Money(1106), *breakdown[0].Converted, "10.05 EUR is 11.055 USD, rounded to the cent")
+	assert.Nil(t, breakdown[1].Converted, "there is no JPY rate")
+	assert.Equal(t, money.FromUnits(15), breakdown[2].Amount)
+	assert.Equal(t, money.Money(2606), sum)
+}
+
+func TestNilConverter(t *testing.T) {
+	var converter *Converter
+	converted, ok := converter.Convert(money.FromUnits(7), "MXN")
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(7), converted)
+	assert.Empty(t, converter.Base())
+}
@@ -0,0 +1,71 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Header is the request header carrying the key chosen by the client for a create request.
+const Header = "Idempotency-Key"
+
+// MaxKeyLength is the longest key accepted, in bytes.
+const MaxKeyLength = 255
+
+// Record is the first request made with an idempotency key and, once it finished, the response to replay to any
+// retry. A record without a status code belongs to a request still being processed.
+type Record struct {
+	UserId      string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Response    []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func NewRecord(userId, key, requestHash string, ttl time.Duration) *Record {
+	now := time.Now().UTC()
+	return &Record{
+		UserId:      userId,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// Completed reports whether the response of the request was stored.
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// ValidateKey checks a key sent by a client.
+func ValidateKey(key string) error {
+	if key == "" {
+		return errors.New("idempotency key cannot be empty")
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("idempotency keys cannot be longer than %d characters", MaxKeyLength)
+	}
+	return nil
+}
+
+// HashRequest fingerprints a request by method, path and body. JSON bodies are compacted first, so a retry
+// that only differs in whitespace still matches.
+func HashRequest(method string, path string, body []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		body = compact.Bytes()
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
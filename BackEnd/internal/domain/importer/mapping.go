@@ -0,0 +1,63 @@
+package importer
+
+import "time"
+
+const (
+	// SignNegativeIsBill treats negative amounts as expenses, the usual layout of checking account statements.
+	SignNegativeIsBill = "negative_is_bill"
+	// SignPositiveIsBill treats positive amounts as expenses, as most credit card statements do.
+	SignPositiveIsBill = "positive_is_bill"
+)
+
+// DateFormats maps the date formats accepted in a mapping to their Go layouts.
+var DateFormats = map[string]string{
+	"YYYY/MM/DD": "2006/01/02",
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"MM/DD/YYYY": "01/02/2006",
+}
+
+// ImportMapping describes how the columns of a bank CSV statement map to transactions.
+// Column indexes are zero based.
+type ImportMapping struct {
+	Id                string    `json:"id"`
+	UserId            string    `json:"user_id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"has_header"`
+	DateColumn        int       `json:"date_column"`
+	AmountColumn      int       `json:"amount_column"`
+	DescriptionColumn int       `json:"description_column"`
+	DateFormat        string    `json:"date_format"`
+	SignConvention    string    `json:"sign_convention"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	CategoryId        string    `json:"category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func NewImportMapping(id, userId, name string, dateColumn, amountColumn, descriptionColumn int, dateFormat string) *ImportMapping {
+	now := time.Now().UTC()
+	return &ImportMapping{
+		Id:                id,
+		UserId:            userId,
+		Name:              name,
+		Delimiter:         ",",
+		HasHeader:         true,
+		DateColumn:        dateColumn,
+		AmountColumn:      amountColumn,
+		DescriptionColumn: descriptionColumn,
+		DateFormat:        dateFormat,
+		SignConvention:    SignNegativeIsBill,
+		DecimalSeparator:  ".",
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+// DateLayout returns the Go layout for the mapping date format.
+func (m *ImportMapping) DateLayout() (string, bool) {
+	layout, ok := DateFormats[m.DateFormat]
+	return layout, ok
+}
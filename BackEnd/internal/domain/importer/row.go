@@ -0,0 +1,24 @@
+package importer
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// ImportRow is a statement line parsed into transaction fields.
+// Rows that could not be parsed keep the reason in Error and are never imported.
+type ImportRow struct {
+	Line           int         `json:"line"`
+	Date           time.Time   `json:"date"`
+	Amount         money.Money `json:"amount"`
+	TypeTransation string      `json:"type_transation"`
+	Name           string      `json:"name"`
+	ExternalId     string      `json:"external_id,omitempty"`
+	Error          string      `json:"error,omitempty"`
+}
+
+// IsValid reports whether the row can be turned into a transaction.
+func (r *ImportRow) IsValid() bool {
+	return r.Error == ""
+}
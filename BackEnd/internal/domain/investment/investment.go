@@ -22,8 +22,10 @@ type Investment struct {
 	Quantity      float64        `json:"quantity"`
 	PurchasePrice float64        `json:"purchase_price"`
 	CurrentPrice  float64        `json:"current_price"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	// AccountId is the brokerage account holding the investment, if any
+	AccountId string    `json:"account_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 func NewInvestment(id, userId string, investmentType InvestmentType, name, symbol string, quantity, purchasePrice, currentPrice float64) *Investment {
@@ -0,0 +1,196 @@
+// Package money provides the exact amount type used for transactions, balances and budgets.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (cents). Sums and comparisons are exact; it is exchanged with
+// JSON and the database as a decimal with two places, so 12.34 is stored as 1234.
+type Money int64
+
+// Zero is the zero amount.
+const Zero Money = 0
+
+const (
+	scale    = 2
+	minorPer = 100
+)
+
+// ErrInvalid is returned when a string is not a decimal amount.
+var ErrInvalid = errors.New("invalid amount")
+
+// FromFloat rounds a float to the nearest cent, halves away from zero. It is meant for values that
+// are already floats, such as spreadsheet cells; prefer Parse for text.
+func FromFloat(f float64) Money {
+	return Money(math.Round(f * minorPer))
+}
+
+// FromUnits builds an amount from whole units, so FromUnits(12) is 12.00.
+func FromUnits(units int64) Money {
+	return Money(units * minorPer)
+}
+
+// Parse reads a decimal such as "-1234.5" exactly. Digits past the cents are rounded half away from zero.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		return FromFloat(f), nil
+	}
+
+	negative := false
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if (whole == "" && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+
+	roundUp := len(fraction) > scale && fraction[scale] >= '5'
+	if len(fraction) > scale {
+		fraction = fraction[:scale]
+	}
+	fraction += strings.Repeat("0", scale-len(fraction))
+
+	cents, err := strconv.ParseInt(strings.TrimLeft(whole, "0")+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	if roundUp {
+		cents++
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Abs returns the amount without its sign.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Div splits the amount in n equal parts, rounded to the nearest cent with halves away from zero.
+func (m Money) Div(n int64) Money {
+	if n == 0 {
+		return 0
+	}
+	negative := (m < 0) != (n < 0)
+	quotient, remainder := int64(m)/n, int64(m)%n
+	if remainder < 0 {
+		remainder = -remainder
+	}
+	if n < 0 {
+		n = -n
+	}
+	if remainder*2 >= n {
+		if negative {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Money(quotient)
+}
+
+// Percent returns how many whole percent of total the amount is, rounded down, so thresholds such as
+// 70% or 100% compare exactly. A non-positive total yields 0.
+func (m Money) Percent(total Money) int64 {
+	if total <= 0 {
+		return 0
+	}
+	return int64(m) * 100 / int64(total)
+}
+
+// Float64 returns the amount in units. Use it only for display and charts.
+func (m Money) Float64() float64 {
+	return float64(m) / minorPer
+}
+
+// String formats the amount with two decimals, such as "-1234.50".
+func (m Money) String() string {
+	sign := ""
+	abs := int64(m)
+	if abs < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, abs/minorPer, abs%minorPer)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" || text == "" {
+		*m = 0
+		return nil
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string, which NUMERIC columns keep exactly.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads NUMERIC, integer and floating point columns.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.scanText(string(v))
+	case string:
+		return m.scanText(v)
+	case int64:
+		*m = FromUnits(v)
+	case float64:
+		*m = FromFloat(v)
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	return nil
+}
+
+func (m *Money) scanText(text string) error {
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,96 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Money
+	}{
+		{"12.34", 1234},
+		{"-12.3", -1230},
+		{"0.1", 10},
+		{".5", 50},
+		{"100", 10000},
+		{"+7.00", 700},
+		{"1.005", 101},
+		{"-1.004", -100},
+		{"1e3", 100000},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			amount, err := Parse(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, amount)
+		})
+	}
+
+	for _, input := range []string{"", "-", ".", "12,34", "1.2.3", "abc", "99999999999999999999"} {
+		_, err := Parse(input)
+		assert.ErrorIs(t, err, ErrInvalid, input)
+	}
+}
+
+func TestMoney_SumIsExact(t *testing.T) {
+	var total Money
+	for i := 0; i < 10; i++ {
+		amount, err := Parse("0.10")
+		assert.NoError(t, err)
+		total += amount
+	}
+	assert.Equal(t, FromUnits(1), total)
+	assert.Equal(t, "1.00", total.String())
+}
+
+func TestMoney_Div(t *testing.T) {
+	assert.Equal(t, Money(333), Money(1000).Div(3))
+	assert.Equal(t, Money(667), Money(2000).Div(3))
+	assert.Equal(t, Money(-667), Money(-2000).Div(3))
+	assert.Equal(t, Money(-3), Money(5).Div(-2))
+	assert.Equal(t, Money(0), Money(5).Div(0))
+}
+
+func TestMoney_Percent(t *testing.T) {
+	assert.Equal(t, int64(69), Money(6999).Percent(10000))
+	assert.Equal(t, int64(70), Money(7000).Percent(10000))
+	assert.Equal(t, int64(100), Money(10000).Percent(10000))
+	assert.Equal(t, int64(0), Money(10000).Percent(0))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Amount: -123405})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": -1234.05}`, string(data))
+
+	var request struct {
+		Amount Money `json:"amount"`
+		Quoted Money `json:"quoted"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 19.99, "quoted": "5.5"}`), &request))
+	assert.Equal(t, Money(1999), request.Amount)
+	assert.Equal(t, Money(550), request.Quoted)
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": true}`), &request))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var amount Money
+	assert.NoError(t, amount.Scan([]byte("-45.10")))
+	assert.Equal(t, Money(-4510), amount)
+	assert.NoError(t, amount.Scan(0.3))
+	assert.Equal(t, Money(30), amount)
+	assert.NoError(t, amount.Scan(int64(2)))
+	assert.Equal(t, Money(200), amount)
+	assert.NoError(t, amount.Scan(nil))
+	assert.Equal(t, Zero, amount)
+
+	value, err := Money(1050).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.50", value)
+}
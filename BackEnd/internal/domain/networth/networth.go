@@ -0,0 +1,105 @@
+package networth
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// MonthLayout is the format of the months of the net worth history.
+const MonthLayout = "2006-01"
+
+// AccountValue is the balance of one account, initial balance included. Converted is its value in the base
+// currency of the user, or nil when there is no exchange rate for the currency of the account.
+type AccountValue struct {
+	AccountId   string       `json:"account_id" example:"acc_123456789"`
+	Name        string       `json:"name" example:"Visa"`
+	Type        string       `json:"type" example:"credit_card"`
+	IsLiability bool         `json:"is_liability" example:"true"`
+	Currency    string       `json:"currency" example:"EUR"`
+	Balance     money.Money  `json:"balance" example:"-450.00"`
+	Converted   *money.Money `json:"converted" example:"-486.90"`
+}
+
+// InvestmentValue is the market value of one investment, its quantity times its current price.
+type InvestmentValue struct {
+	InvestmentId string      `json:"investment_id" example:"inv_123456789"`
+	Name         string      `json:"name" example:"Apple"`
+	Symbol       string      `json:"symbol" example:"AAPL"`
+	Value        money.Money `json:"value" example:"1750.20"`
+}
+
+// Totals split the net worth of a user in the base currency. Liabilities is what is owed, as a positive amount,
+// so NetWorth is Assets plus Investments minus Liabilities.
+type Totals struct {
+	Currency    string      `json:"currency" example:"EUR"`
+	Assets      money.Money `json:"assets" example:"5200.00"`
+	Liabilities money.Money `json:"liabilities" example:"486.90"`
+	Investments money.Money `json:"investments" example:"1750.20"`
+	NetWorth    money.Money `json:"net_worth" example:"6463.30"`
+}
+
+// Snapshot is the current net worth of a user with the value of every account and investment in it.
+type Snapshot struct {
+	Totals
+	Accounts []AccountValue    `json:"accounts"`
+	Holdings []InvestmentValue `json:"holdings"`
+	// Unconverted lists the currencies of the accounts left out of the totals for lack of an exchange rate
+	Unconverted []string  `json:"unconverted,omitempty"`
+	AsOf        time.Time `json:"as_of"`
+}
+
+// MonthlyNetWorth is the net worth of a user recorded for a month. It is overwritten on every run of the worker
+// during the month, so past months keep the last value recorded in them.
+type MonthlyNetWorth struct {
+	UserId string    `json:"-"`
+	Month  time.Time `json:"-"`
+	Totals
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewSnapshot starts an empty snapshot in the given currency.
+func NewSnapshot(currency string, asOf time.Time) *Snapshot {
+	return &Snapshot{
+		Totals:   Totals{Currency: currency},
+		Accounts: []AccountValue{},
+		Holdings: []InvestmentValue{},
+		AsOf:     asOf,
+	}
+}
+
+// AddAccount adds the account to the snapshot, and to the totals when its value in the base currency is known.
+func (s *Snapshot) AddAccount(value AccountValue) {
+	s.Accounts = append(s.Accounts, value)
+	switch {
+	case value.Converted == nil:
+		s.addUnconverted(value.Currency)
+	case value.IsLiability:
+		s.Liabilities -= *value.Converted
+	default:
+		s.Assets += *value.Converted
+	}
+	s.NetWorth = s.Assets + s.Investments - s.Liabilities
+}
+
+// AddInvestment adds the investment to the snapshot and its value to the totals.
+func (s *Snapshot) AddInvestment(value InvestmentValue) {
+	s.Holdings = append(s.Holdings, value)
+	s.Investments += value.Value
+	s.NetWorth = s.Assets + s.Investments - s.Liabilities
+}
+
+func (s *Snapshot) addUnconverted(currency string) {
+	for _, existing := range s.Unconverted {
+		if existing == currency {
+			return
+		}
+	}
+	s.Unconverted = append(s.Unconverted, currency)
+}
+
+// MonthStart truncates t to the first day of its month in UTC.
+func MonthStart(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,119 @@
+package payee
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the longest payee name or alias accepted, in characters.
+const MaxNameLength = 100
+
+// MaxAliases bounds the number of alias patterns a single payee can carry.
+const MaxAliases = 20
+
+// Payee is the merchant or person on the other side of a transaction. Raw transaction names are linked to a payee
+// when one of its aliases matches them, so "UBER *TRIP 8812" and "Uber Trip" both end up under "Uber".
+type Payee struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewPayee(id, userId, name string, aliases []string) *Payee {
+	return &Payee{
+		Id:        id,
+		UserId:    userId,
+		Name:      strings.Join(strings.Fields(name), " "),
+		Aliases:   NormalizeAliases(aliases),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Normalize reduces a raw transaction name to the words that identify the merchant: it is lowercased, punctuation
+// becomes a space and words made only of digits, such as trip or store numbers, are dropped. "UBER *TRIP 8812"
+// becomes "uber trip".
+func Normalize(raw string) string {
+	words := strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, word := range words {
+		if strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// NormalizeAliases normalizes a list of alias patterns, dropping empty and repeated ones while keeping their order.
+func NormalizeAliases(aliases []string) []string {
+	seen := make(map[string]bool, len(aliases))
+	normalized := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = Normalize(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		normalized = append(normalized, alias)
+	}
+	return normalized
+}
+
+// Validate checks the name and the already normalized aliases of the payee.
+func (p *Payee) Validate() error {
+	if p.Name == "" {
+		return errors.New("payee name is required")
+	}
+	if utf8.RuneCountInString(p.Name) > MaxNameLength {
+		return fmt.Errorf("payee names cannot be longer than %d characters", MaxNameLength)
+	}
+	if len(p.Aliases) > MaxAliases {
+		return fmt.Errorf("a payee cannot have more than %d aliases", MaxAliases)
+	}
+	for _, alias := range p.Aliases {
+		if utf8.RuneCountInString(alias) > MaxNameLength {
+			return fmt.Errorf("aliases cannot be longer than %d characters", MaxNameLength)
+		}
+	}
+	return nil
+}
+
+// patterns returns the aliases of the payee together with its own normalized name, which always matches.
+func (p *Payee) patterns() []string {
+	patterns := p.Aliases
+	if name := Normalize(p.Name); name != "" {
+		patterns = append([]string{name}, patterns...)
+	}
+	return patterns
+}
+
+// Match returns the payee a raw transaction name belongs to, or nil when none matches. A pattern matches when its
+// words appear one after the other in the normalized name. The longest matching pattern wins, so "uber eats" beats
+// "uber" for "UBER *EATS 1234"; ties go to the payee that comes first.
+func Match(payees []*Payee, raw string) *Payee {
+	name := " " + Normalize(raw) + " "
+	var found *Payee
+	longest := 0
+	for _, p := range payees {
+		for _, pattern := range p.patterns() {
+			if len(pattern) > longest && strings.Contains(name, " "+pattern+" ") {
+				found = p
+				longest = len(pattern)
+			}
+		}
+	}
+	return found
+}
+
+// Candidate is a transaction that has no payee yet, with the raw name used to find one.
+type Candidate struct {
+	TransactionId string
+	Name          string
+}
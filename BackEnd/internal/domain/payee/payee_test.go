@@ -0,0 +1,29 @@
+package payee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "uber trip", Normalize("UBER *TRIP 8812"))
+	assert.Equal(t, "uber trip", Normalize("  Uber Trip "))
+	assert.Equal(t, "eleven", Normalize("7-ELEVEN #0042"))
+	assert.Equal(t, "", Normalize("1234 5678"))
+	assert.Equal(t, []string{"uber", "uber eats"}, NormalizeAliases([]string{"UBER", " uber ", "", "Uber*Eats"}))
+}
+
+func TestMatch(t *testing.T) {
+	uber := NewPayee("payee_uber", "user_1", "Uber", []string{"uber trip"})
+	uberEats := NewPayee("payee_uber_eats", "user_1", "Uber Eats", nil)
+	netflix := NewPayee("payee_netflix", "user_1", "Netflix", []string{"netflix com"})
+	payees := []*Payee{uber, uberEats, netflix}
+
+	assert.Equal(t, uber, Match(payees, "UBER *TRIP 8812"))
+	assert.Equal(t, uber, Match(payees, "Uber"))
+	assert.Equal(t, uberEats, Match(payees, "UBER *EATS 1234"))
+	assert.Equal(t, netflix, Match(payees, "NETFLIX.COM 866-579"))
+	assert.Nil(t, Match(payees, "Uberrima bakery"))
+	assert.Nil(t, Match(nil, "Uber"))
+}
@@ -0,0 +1,55 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// Reconciliation statuses. An open reconciliation can still be edited; completing it locks the transactions it
+// matched.
+const (
+	StatusOpen      = "open"
+	StatusCompleted = "completed"
+)
+
+// Reconciliation checks the balance of a bank statement against the cleared transactions of an account up to the
+// statement date.
+type Reconciliation struct {
+	Id               string      `json:"id"`
+	AccountId        string      `json:"account_id"`
+	UserId           string      `json:"user_id"`
+	StatementDate    time.Time   `json:"statement_date"`
+	StatementBalance money.Money `json:"statement_balance"`
+	ClearedBalance   money.Money `json:"cleared_balance"`
+	Status           string      `json:"status"`
+	CreatedAt        time.Time   `json:"created_at"`
+	CompletedAt      *time.Time  `json:"completed_at,omitempty"`
+}
+
+func NewReconciliation(id, accountId, userId string, statementDate time.Time, statementBalance money.Money) *Reconciliation {
+	return &Reconciliation{
+		Id:               id,
+		AccountId:        accountId,
+		UserId:           userId,
+		StatementDate:    statementDate,
+		StatementBalance: statementBalance,
+		Status:           StatusOpen,
+		CreatedAt:        time.Now().UTC(),
+	}
+}
+
+// Difference is what is missing from the cleared transactions to match the statement. A reconciliation can only be
+// completed when it is zero.
+func (r *Reconciliation) Difference() money.Money {
+	return r.StatementBalance - r.ClearedBalance
+}
+
+// Until is the first instant after the statement date. Transactions created before it belong to the statement.
+func (r *Reconciliation) Until() time.Time {
+	return r.StatementDate.AddDate(0, 0, 1)
+}
+
+func (r *Reconciliation) IsCompleted() bool {
+	return r.Status == StatusCompleted
+}
@@ -0,0 +1,111 @@
+package rule
+
+import (
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+)
+
+// Rule categorizes transactions automatically. Every condition that is set must match; every action that is set
+// is applied. Rules are evaluated in ascending priority.
+type Rule struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+
+	// Conditions
+	NameContains string       `json:"name_contains"`
+	AmountMin    *money.Money `json:"amount_min"`
+	AmountMax    *money.Money `json:"amount_max"`
+	AccountId    string       `json:"account_id"`
+
+	// Actions
+	CategoryId string `json:"category_id"`
+	BudgetId   string `json:"budget_id"`
+	RenameTo   string `json:"rename_to"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewRule(id, userId, name string, priority int) *Rule {
+	now := time.Now().UTC()
+	return &Rule{
+		Id:        id,
+		UserId:    userId,
+		Name:      name,
+		Priority:  priority,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Matches reports whether the transaction meets every condition of the rule. The name match is case-insensitive
+// and amounts are compared by absolute value, like the transaction filters. Transfers never match.
+func (r *Rule) Matches(t *transaction.Transaction) bool {
+	if !r.Enabled || t.IsTransfer() {
+		return false
+	}
+	if r.NameContains != "" && !strings.Contains(strings.ToLower(t.Name), strings.ToLower(r.NameContains)) {
+		return false
+	}
+	amount := t.Amount.Abs()
+	if r.AmountMin != nil && amount < *r.AmountMin {
+		return false
+	}
+	if r.AmountMax != nil && amount > *r.AmountMax {
+		return false
+	}
+	if r.AccountId != "" && t.AccountId != r.AccountId {
+		return false
+	}
+	return true
+}
+
+// Outcome is what a set of rules decided for a transaction. Empty fields were not set by any rule.
+type Outcome struct {
+	CategoryId string
+	BudgetId   string
+	Name       string
+	RuleIds    []string
+}
+
+// Evaluate runs the rules, already sorted by priority, against the transaction. Each action is taken from the
+// first matching rule that sets it, so a high priority rule can rename a payee while a later one categorizes it.
+// Split transactions keep their category lines and can only be renamed.
+func Evaluate(rules []*Rule, t *transaction.Transaction) *Outcome {
+	outcome := &Outcome{}
+	for _, r := range rules {
+		if !r.Matches(t) {
+			continue
+		}
+
+		applied := false
+		if r.CategoryId != "" && outcome.CategoryId == "" && !t.IsSplit() {
+			outcome.CategoryId = r.CategoryId
+			applied = true
+		}
+		if r.BudgetId != "" && outcome.BudgetId == "" && !t.IsSplit() {
+			outcome.BudgetId = r.BudgetId
+			applied = true
+		}
+		if r.RenameTo != "" && outcome.Name == "" {
+			outcome.Name = r.RenameTo
+			applied = true
+		}
+		if applied {
+			outcome.RuleIds = append(outcome.RuleIds, r.Id)
+		}
+	}
+	return outcome
+}
+
+// Matched reports whether any rule changed something.
+func (o *Outcome) Matched() bool {
+	return len(o.RuleIds) > 0
+}
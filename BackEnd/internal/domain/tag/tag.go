@@ -0,0 +1,64 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the longest tag name accepted, in characters.
+const MaxNameLength = 50
+
+// MaxPerTransaction bounds the number of tags a single transaction can carry.
+const MaxPerTransaction = 20
+
+// Tag is a user-owned label that can be attached to any number of transactions, independently of their category.
+type Tag struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTag(id, userId, name string) *Tag {
+	return &Tag{
+		Id:        id,
+		UserId:    userId,
+		Name:      NormalizeName(name),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// NormalizeName trims a tag name, collapses inner whitespace and lowercases it, so "Vacation  2026" and
+// "vacation 2026" are the same tag.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ValidateName checks an already normalized tag name.
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.New("tag name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return fmt.Errorf("tag names cannot be longer than %d characters", MaxNameLength)
+	}
+	return nil
+}
+
+// NormalizeNames normalizes a list of tag names, dropping empty and repeated ones while keeping their order.
+func NormalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
@@ -0,0 +1,123 @@
+package transaction
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DuplicateWindow is how far apart two charges can be and still be taken for the same one. Imports and recurring
+// executions often date a charge a day or two away from the manual entry.
+const DuplicateWindow = 3 * 24 * time.Hour
+
+// minNameSimilarity is the share of characters two normalized names must have in common to match.
+const minNameSimilarity = 0.8
+
+// minContainedName is the shortest normalized name that matches any name containing it.
+const minContainedName = 4
+
+// DuplicatePair identifies two transactions that look like the same charge. TransactionId is always the smaller id,
+// so a pair has a single representation whatever the order it was found in.
+type DuplicatePair struct {
+	TransactionId string
+	DuplicateId   string
+}
+
+func NewDuplicatePair(id, otherId string) DuplicatePair {
+	if otherId < id {
+		id, otherId = otherId, id
+	}
+	return DuplicatePair{TransactionId: id, DuplicateId: otherId}
+}
+
+// IsLikelyDuplicate reports whether two transactions look like the same charge: same account and amount, dates
+// within DuplicateWindow and similar names. Transfer legs are never duplicates of anything.
+func IsLikelyDuplicate(t, other *Transaction) bool {
+	if t.Id == other.Id || t.IsTransfer() || other.IsTransfer() {
+		return false
+	}
+	if t.AccountId != other.AccountId || t.Amount != other.Amount {
+		return false
+	}
+	gap := t.CreatedAt.Sub(other.CreatedAt)
+	if gap < 0 {
+		gap = -gap
+	}
+	return gap <= DuplicateWindow && SimilarNames(t.Name, other.Name)
+}
+
+// FindDuplicatePairs returns the likely duplicate pairs among transactions, skipping the dismissed ones. The
+// newest pairs come first.
+func FindDuplicatePairs(transactions []*Transaction, dismissed map[DuplicatePair]bool) []DuplicatePair {
+	sorted := make([]*Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	var pairs []DuplicatePair
+	for i, t := range sorted {
+		for _, other := range sorted[i+1:] {
+			// Sorted by date, so nothing further down can be within the window
+			if t.CreatedAt.Sub(other.CreatedAt) > DuplicateWindow {
+				break
+			}
+			if !IsLikelyDuplicate(t, other) {
+				continue
+			}
+			pair := NewDuplicatePair(t.Id, other.Id)
+			if !dismissed[pair] {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	return pairs
+}
+
+// SimilarNames compares two transaction names ignoring case, punctuation and spacing. Names match when one contains
+// the other, as in "Netflix" and "NETFLIX.COM 866-579", or when they differ by a few characters.
+func SimilarNames(name, other string) bool {
+	a, b := normalizeName(name), normalizeName(other)
+	if a == "" || b == "" {
+		return a == b
+	}
+	ra, rb := []rune(a), []rune(b)
+	if min(len(ra), len(rb)) >= minContainedName && (strings.Contains(a, b) || strings.Contains(b, a)) {
+		return true
+	}
+	longest := max(len(ra), len(rb))
+	return 1-float64(levenshtein(ra, rb))/float64(longest) >= minNameSimilarity
+}
+
+// normalizeName lowercases a name and keeps only its letters and digits.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// levenshtein counts the single character edits needed to turn a into b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
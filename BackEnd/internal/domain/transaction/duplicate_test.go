@@ -0,0 +1,40 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarNames(t *testing.T) {
+	assert.True(t, SimilarNames("Netflix", "NETFLIX.COM 866-579"))
+	assert.True(t, SimilarNames("Starbucks Coffee", "Starbuks coffee"))
+	assert.True(t, SimilarNames("  Mercadona ", "mercadona"))
+	assert.False(t, SimilarNames("Rent", "Groceries"))
+	assert.False(t, SimilarNames("Bar", "Barber shop"))
+}
+
+func TestFindDuplicatePairs(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	newTransaction := func(id, name, accountId string, amount int64, createdAt time.Time) *Transaction {
+		txn := NewTransaction(id, name, "", "bill", accountId, "", money.FromUnits(amount))
+		txn.CreatedAt = createdAt
+		return txn
+	}
+	manual := newTransaction("txn_b", "Netflix", "acc_1", -15, now)
+	imported := newTransaction("txn_a", "NETFLIX.COM", "acc_1", -15, now.Add(-48*time.Hour))
+	otherAccount := newTransaction("txn_c", "Netflix", "acc_2", -15, now)
+	otherAmount := newTransaction("txn_d", "Netflix", "acc_1", -16, now)
+	lastMonth := newTransaction("txn_e", "Netflix", "acc_1", -15, now.AddDate(0, -1, 0))
+	transferLeg := newTransaction("txn_f", "Netflix", "acc_1", -15, now)
+	transferLeg.TransferId = "trf_1"
+
+	transactions := []*Transaction{lastMonth, manual, otherAccount, imported, otherAmount, transferLeg}
+	pairs := FindDuplicatePairs(transactions, nil)
+	assert.Equal(t, []DuplicatePair{{TransactionId: "txn_a", DuplicateId: "txn_b"}}, pairs)
+
+	dismissed := map[DuplicatePair]bool{NewDuplicatePair("txn_b", "txn_a"): true}
+	assert.Empty(t, FindDuplicatePairs(transactions, dismissed))
+}
@@ -0,0 +1,23 @@
+package transaction
+
+import "github.com/osmait/gestorDePresupuesto/internal/domain/money"
+
+// Split is one category line of a transaction whose total is spread across several categories.
+// Amounts carry the same sign as the parent transaction.
+type Split struct {
+	Id            string      `json:"id"`
+	TransactionId string      `json:"transaction_id"`
+	CategoryId    string      `json:"category_id"`
+	BudgetId      string      `json:"budget_id"`
+	Amount        money.Money `json:"amount"`
+	Description   string      `json:"description"`
+}
+
+func NewSplit(Id, TransactionId, categoryId string, Amount money.Money) *Split {
+	return &Split{
+		Id:            Id,
+		TransactionId: TransactionId,
+		CategoryId:    categoryId,
+		Amount:        Amount,
+	}
+}
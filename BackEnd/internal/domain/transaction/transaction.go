@@ -1,21 +1,43 @@
 package transaction
 
-import "time"
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// Transaction statuses. A pending transaction was entered by the user, a cleared one was confirmed by the bank and
+// a reconciled one was matched to a completed statement reconciliation, which locks it against edits.
+const (
+	StatusPending    = "pending"
+	StatusCleared    = "cleared"
+	StatusReconciled = "reconciled"
+)
 
 type Transaction struct {
-	Id             string    `json:"id"`
-	Name           string    `json:"name" validate:"required"`
-	Description    string    `json:"description"`
-	Amount         float64   `json:"amount" validate:"required"`
-	TypeTransation string    `json:"type_transation" validator:"required"`
-	AccountId      string    `json:"account_id"`
-	CategoryId     string    `json:"category_id"`
-	BudgetId       string    `json:"budget_id"`
-	UserId         string    `json:"user_id"`
-	CreatedAt      time.Time `json:"created_at"`
+	Id             string      `json:"id"`
+	Name           string      `json:"name" validate:"required"`
+	Description    string      `json:"description"`
+	Amount         money.Money `json:"amount" validate:"required"`
+	Currency       string      `json:"currency,omitempty"`
+	TypeTransation string      `json:"type_transation" validator:"required"`
+	AccountId      string      `json:"account_id"`
+	CategoryId     string      `json:"category_id"`
+	BudgetId       string      `json:"budget_id"`
+	UserId         string      `json:"user_id"`
+	TransferId     string      `json:"transfer_id,omitempty"`
+	ExternalId     string      `json:"external_id,omitempty"`
+	PayeeId        string      `json:"payee_id,omitempty"`
+	RefundOf       string      `json:"refund_of,omitempty"`
+	RefundedBy     []string    `json:"refunded_by,omitempty"`
+	Status         string      `json:"status"`
+	Splits         []*Split    `json:"splits,omitempty"`
+	Tags           []string    `json:"tags,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	DeletedAt      *time.Time  `json:"deleted_at,omitempty"`
 }
 
-func NewTransaction(Id, Name, Description, TypeTransation, AccountId, categoryId string, Amount float64) *Transaction {
+func NewTransaction(Id, Name, Description, TypeTransation, AccountId, categoryId string, Amount money.Money) *Transaction {
 	return &Transaction{
 		Id:             Id,
 		Name:           Name,
@@ -24,5 +46,33 @@ func NewTransaction(Id, Name, Description, TypeTransation, AccountId, categoryId
 		TypeTransation: TypeTransation,
 		AccountId:      AccountId,
 		CategoryId:     categoryId,
+		Status:         StatusPending,
 	}
 }
+
+// IsTransfer reports whether the transaction is one leg of an account-to-account transfer.
+func (t *Transaction) IsTransfer() bool {
+	return t.TransferId != ""
+}
+
+// IsSplit reports whether the transaction is spread across several category lines.
+func (t *Transaction) IsSplit() bool {
+	return len(t.Splits) > 0
+}
+
+// IsRefund reports whether the transaction is an income linked as the refund of an earlier bill. Refunds count
+// against the spending of the bill instead of as income.
+func (t *Transaction) IsRefund() bool {
+	return t.RefundOf != ""
+}
+
+// IsReconciled reports whether the transaction belongs to a completed reconciliation and must be unlocked before
+// it can be changed.
+func (t *Transaction) IsReconciled() bool {
+	return t.Status == StatusReconciled
+}
+
+// IsTrashed reports whether the transaction was deleted and is waiting in the trash to be restored or purged.
+func (t *Transaction) IsTrashed() bool {
+	return t.DeletedAt != nil
+}
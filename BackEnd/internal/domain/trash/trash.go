@@ -0,0 +1,17 @@
+package trash
+
+// Entity types that can be deleted to the trash and restored from it.
+const (
+	EntityTransaction = "transaction"
+	EntityAccount     = "account"
+	EntityCategory    = "category"
+)
+
+// IsEntityType reports whether entityType is one of the types kept in the trash.
+func IsEntityType(entityType string) bool {
+	switch entityType {
+	case EntityTransaction, EntityAccount, EntityCategory:
+		return true
+	}
+	return false
+}
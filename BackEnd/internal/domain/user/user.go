@@ -14,6 +14,8 @@ type User struct {
 	IsDemo    bool      `json:"is_demo"`
 	IpAddress string    `json:"ip_address,omitempty"`
 	Role      string    `json:"role"`
+	// BaseCurrency is the currency reports are converted into
+	BaseCurrency string `json:"base_currency"`
 }
 
 func NewUser(id, name, lastName, email, password string) *User {
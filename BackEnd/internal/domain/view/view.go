@@ -0,0 +1,43 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the longest view name accepted, in characters.
+const MaxNameLength = 100
+
+// View is a named transaction filter saved by a user. The filter is kept as the query string of the transaction
+// listing, so relative periods such as this_month are resolved again every time the view is used.
+type View struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewView(id, userId, name, query string) *View {
+	return &View{
+		Id:        id,
+		UserId:    userId,
+		Name:      strings.Join(strings.Fields(name), " "),
+		Query:     query,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// ValidateName checks an already trimmed view name.
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.New("view name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return fmt.Errorf("view names cannot be longer than %d characters", MaxNameLength)
+	}
+	return nil
+}
@@ -229,13 +229,14 @@ func (suite *E2ETestSuite) setupRoutes(
 	// Authentication middleware for protected routes
 	suite.engine.Use(middleware.AuthMiddleware(userService, suite.config))
 
-	// Application routes
+	// Application routes; idempotency keys are not exercised here
+	idempotent := func(c *gin.Context) {}
 	routes.AuhtRoutes(suite.engine, authService)
 	routes.UserRoute(suite.engine, userService)
-	routes.AccountRotes(suite.engine, accountService)
-	routes.TransactionRoutes(suite.engine, transactionService)
+	routes.AccountRotes(suite.engine, accountService, nil, idempotent)
+	routes.TransactionRoutes(suite.engine, transactionService, nil, idempotent)
 	routes.CategoryRoutes(suite.engine, categoryService)
-	routes.BudgetRoutes(suite.engine, budgetService)
+	routes.BudgetRoutes(suite.engine, budgetService, idempotent)
 }
 
 // TearDownSuite runs once after all tests in the suite
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/go-playground/assert/v2"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 )
 
@@ -17,7 +18,7 @@ func TestAccountRequest(t *testing.T) {
 
 func TestAccountResponse(t *testing.T) {
 	account := utils.GetNewRandomAccount()
-	balance := 1000.00
+	balance := money.FromUnits(1000)
 	accountResponse := NewAccountResponse(account, balance)
 	assert.Equal(t, account.Name, accountResponse.AccountInfo.Name)
 	assert.Equal(t, account.Bank, accountResponse.AccountInfo.Bank)
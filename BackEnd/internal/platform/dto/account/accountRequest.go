@@ -1,18 +1,32 @@
 package dto
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// maxInitialBalance is 999,999,999.99 in minor units.
+const maxInitialBalance money.Money = 99999999999
 
 // AccountRequest represents the data required to create a new account.
 // All fields are validated for financial data security and business rules.
 type AccountRequest struct {
-	Name           string  `json:"name" validate:"required,min=2,max=100,printascii" binding:"required" example:"My Savings Account"`
-	Bank           string  `json:"bank" validate:"required,min=2,max=100,printascii" binding:"required" example:"Bank of America"`
-	InitialBalance float64 `json:"initial_balance" validate:"min=0,max=999999999.99" binding:"required" example:"1000.50"`
+	Name           string      `json:"name" validate:"required,min=2,max=100,printascii" binding:"required" example:"My Savings Account"`
+	Bank           string      `json:"bank" validate:"required,min=2,max=100,printascii" binding:"required" example:"Bank of America"`
+	InitialBalance money.Money `json:"initial_balance" binding:"required" example:"1000.50"`
+	// Currency is an ISO 4217 code and cannot change once the account exists; it defaults to USD
+	Currency string `json:"currency,omitempty" example:"EUR"`
+	// Type defaults to checking. Credit cards and loans may start with a negative balance, the amount owed
+	Type string `json:"type,omitempty" example:"credit_card" enums:"checking,savings,credit_card,cash,loan,brokerage"`
+	account.Details
 }
 
 // NewAccountRequest creates a new AccountRequest with the provided information.
 // This constructor ensures proper initialization of the request object.
-func NewAccountRequest(name, bank string, initialBalance float64) *AccountRequest {
+func NewAccountRequest(name, bank string, initialBalance money.Money) *AccountRequest {
 	return &AccountRequest{
 		Name:           name,
 		Bank:           bank,
@@ -23,8 +37,23 @@ func NewAccountRequest(name, bank string, initialBalance float64) *AccountReques
 // Validate performs business logic validation on the AccountRequest.
 // It checks for additional constraints beyond basic field validation.
 func (a *AccountRequest) Validate() error {
-	if a.InitialBalance < 0 {
-		return errors.New("initial balance cannot be negative")
+	if a.InitialBalance.Abs() > maxInitialBalance {
+		return errors.New("initial balance cannot exceed 999999999.99")
+	}
+	if a.Type == "" {
+		a.Type = account.TypeChecking
+	}
+	candidate := account.Account{Type: a.Type, InitialBalance: a.InitialBalance, Details: a.Details}
+	if err := candidate.Validate(); err != nil {
+		return err
+	}
+	if a.Currency == "" {
+		a.Currency = fx.DefaultCurrency
+	}
+	currency, ok := fx.NormalizeCurrency(a.Currency)
+	if !ok {
+		return errors.New("currency must be a three-letter ISO 4217 code")
 	}
+	a.Currency = currency
 	return nil
 }
@@ -1,15 +1,47 @@
 package dto
 
-import "github.com/osmait/gestorDePresupuesto/internal/domain/account"
+import (
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
+// AccountResponse carries the balance in the currency of the account and, when an exchange rate is known,
+// in the base currency of the user. The balance of a liability is negative while money is owed; credit cards
+// with a credit limit also report the credit still available and the share of the limit in use.
 type AccountResponse struct {
-	AccountInfo    *account.Account `json:"account_info"`
-	CurrentBalance float64          `json:"current_balance" example:"1250.75"`
+	AccountInfo      *account.Account `json:"account_info"`
+	CurrentBalance   money.Money      `json:"current_balance" example:"1250.75"`
+	IsLiability      bool             `json:"is_liability" example:"false"`
+	AvailableCredit  *money.Money     `json:"available_credit,omitempty" example:"2450.00"`
+	Utilization      *float64         `json:"utilization,omitempty" example:"18.33"`
+	BaseCurrency     string           `json:"base_currency,omitempty" example:"USD"`
+	ConvertedBalance *money.Money     `json:"converted_balance,omitempty" example:"1356.45"`
 }
 
-func NewAccountResponse(accountInfo *account.Account, currentBalance float64) *AccountResponse {
-	return &AccountResponse{
+func NewAccountResponse(accountInfo *account.Account, currentBalance money.Money) *AccountResponse {
+	response := &AccountResponse{
 		AccountInfo:    accountInfo,
 		CurrentBalance: currentBalance,
+		IsLiability:    accountInfo.IsLiability(),
+	}
+	if available, ok := accountInfo.AvailableCredit(currentBalance); ok {
+		response.AvailableCredit = &available
+	}
+	if utilization, ok := accountInfo.Utilization(currentBalance); ok {
+		response.Utilization = &utilization
+	}
+	return response
+}
+
+// Convert fills in the balance in the base currency of the converter. Without a converter, or without a
+// rate for the currency of the account, only the native balance is reported.
+func (r *AccountResponse) Convert(converter *fx.Converter) {
+	if converter == nil {
+		return
+	}
+	r.BaseCurrency = converter.Base()
+	if converted, ok := converter.Convert(r.CurrentBalance, r.AccountInfo.Currency); ok {
+		r.ConvertedBalance = &converted
 	}
 }
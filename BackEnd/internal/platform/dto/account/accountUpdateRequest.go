@@ -1,10 +1,15 @@
 package dto
 
+import "github.com/osmait/gestorDePresupuesto/internal/domain/account"
+
 // AccountUpdateRequest represents the data required to update an existing account.
-// Only name and bank can be updated - balance and creation date remain unchanged.
+// Name, bank, type and type details can be updated - balance, currency and creation date remain unchanged.
+// Details left out keep their current value, unless the type changes, which drops the details of the old type.
 type AccountUpdateRequest struct {
 	Name string `json:"name" validate:"required,min=2,max=100,printascii" binding:"required" example:"Updated Savings Account"`
 	Bank string `json:"bank" validate:"required,min=2,max=100,printascii" binding:"required" example:"Updated Bank Name"`
+	Type string `json:"type,omitempty" example:"savings" enums:"checking,savings,credit_card,cash,loan,brokerage"`
+	account.Details
 }
 
 // NewAccountUpdateRequest creates a new AccountUpdateRequest with the provided information.
@@ -23,3 +28,27 @@ func (a *AccountUpdateRequest) Validate() error {
 	// e.g., valid bank names, prohibited names, etc.
 	return nil
 }
+
+// Apply returns the account as it is after the update, to be validated before it is stored.
+func (a *AccountUpdateRequest) Apply(current *account.Account) *account.Account {
+	updated := *current
+	updated.Name = a.Name
+	updated.Bank = a.Bank
+	if a.Type != "" && a.Type != current.Type {
+		updated.Type = a.Type
+		updated.Details = account.Details{}
+	}
+	if a.CreditLimit != nil {
+		updated.CreditLimit = a.CreditLimit
+	}
+	if a.StatementDay != nil {
+		updated.StatementDay = a.StatementDay
+	}
+	if a.DueDay != nil {
+		updated.DueDay = a.DueDay
+	}
+	if a.InterestRate != nil {
+		updated.InterestRate = a.InterestRate
+	}
+	return &updated
+}
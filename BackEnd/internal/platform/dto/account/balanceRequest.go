@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+)
+
+// MaxHistoryDays bounds the range of a balance history to about ten years.
+const MaxHistoryDays = 3660
+
+// BalanceHistoryRequest is the range and interval of a balance history. Dates use the YYYY-MM-DD format and
+// both ends are included.
+type BalanceHistoryRequest struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+}
+
+// ParseFromQuery reads the request from the query string. To defaults to today and from to one month, six
+// months or one year before it, depending on the interval.
+func (r *BalanceHistoryRequest) ParseFromQuery(ctx *gin.Context, now time.Time) error {
+	r.Interval = ctx.DefaultQuery("interval", account.IntervalDay)
+	if !account.IsValidInterval(r.Interval) {
+		return errors.New("interval must be 'day', 'week' or 'month'")
+	}
+
+	r.To = account.Day(now)
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.Parse(account.DateLayout, to)
+		if err != nil {
+			return errors.New("to must use the YYYY-MM-DD format")
+		}
+		r.To = date
+	}
+
+	switch r.Interval {
+	case account.IntervalDay:
+		r.From = r.To.AddDate(0, -1, 0)
+	case account.IntervalWeek:
+		r.From = r.To.AddDate(0, -6, 0)
+	default:
+		r.From = r.To.AddDate(-1, 0, 0)
+	}
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.Parse(account.DateLayout, from)
+		if err != nil {
+			return errors.New("from must use the YYYY-MM-DD format")
+		}
+		r.From = date
+	}
+	return r.Validate(now)
+}
+
+func (r *BalanceHistoryRequest) Validate(now time.Time) error {
+	if r.To.After(account.Day(now)) {
+		return errors.New("to cannot be in the future")
+	}
+	if r.From.After(r.To) {
+		return errors.New("from cannot be after to")
+	}
+	if r.To.Sub(r.From) > MaxHistoryDays*24*time.Hour {
+		return fmt.Errorf("the range cannot be longer than %d days", MaxHistoryDays)
+	}
+	return nil
+}
+
+// ParseBalanceDate reads the date of a balance-at-date query, today when it is missing.
+func ParseBalanceDate(ctx *gin.Context, now time.Time) (time.Time, error) {
+	value := ctx.Query("date")
+	if value == "" {
+		return account.Day(now), nil
+	}
+	date, err := time.Parse(account.DateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("date must use the YYYY-MM-DD format")
+	}
+	if date.After(account.Day(now)) {
+		return time.Time{}, errors.New("date cannot be in the future")
+	}
+	return date, nil
+}
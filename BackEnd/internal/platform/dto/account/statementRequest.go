@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// DefaultStatements and MaxStatements bound how many billing cycles a statement listing returns.
+const (
+	DefaultStatements = 6
+	MaxStatements     = 24
+)
+
+// ParseStatementCount reads how many statements to list, the current cycle included.
+func ParseStatementCount(value string) (int, error) {
+	if value == "" {
+		return DefaultStatements, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 || count > MaxStatements {
+		return 0, fmt.Errorf("count must be a number between 1 and %d", MaxStatements)
+	}
+	return count, nil
+}
+
+// PayStatementRequest pays the latest closed statement of a credit card from a checking account. The amount
+// defaults to what is left to pay on the statement.
+type PayStatementRequest struct {
+	FromAccountId string       `json:"from_account_id" binding:"required" example:"acc_123456789"`
+	Amount        *money.Money `json:"amount,omitempty" example:"845.30"`
+}
+
+func (r *PayStatementRequest) Validate() error {
+	if r.FromAccountId == "" {
+		return errors.New("from_account_id is required")
+	}
+	if r.Amount != nil && *r.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	return nil
+}
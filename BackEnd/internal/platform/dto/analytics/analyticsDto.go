@@ -1,40 +1,123 @@
 package analyticsdto
 
-import "github.com/osmait/gestorDePresupuesto/internal/domain/analytics"
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/analytics"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
 type GetCategoryExpensesResponse struct {
-	ID    string  `json:"id"`
-	Label string  `json:"label"`
-	Value float64 `json:"value"`
-	Color string  `json:"color"`
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Color      string              `json:"color"`
+	Currency   string              `json:"currency,omitempty" example:"USD"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
 }
 
 func NewGetCategoryExpensesResponse(categoryExpenses []*analytics.CategoryExpense) []GetCategoryExpensesResponse {
 	var response []GetCategoryExpensesResponse
 	for _, categoryExpense := range categoryExpenses {
 		response = append(response, GetCategoryExpensesResponse{
-			ID:    categoryExpense.ID,
-			Label: categoryExpense.Label,
-			Value: categoryExpense.Value,
-			Color: categoryExpense.Color,
+			ID:         categoryExpense.ID,
+			Label:      categoryExpense.Label,
+			Value:      categoryExpense.Value,
+			Color:      categoryExpense.Color,
+			Currency:   categoryExpense.Currency,
+			ByCurrency: categoryExpense.ByCurrency,
+		})
+	}
+	return response
+}
+
+type GetTagExpensesResponse struct {
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Count      int                 `json:"count"`
+	Currency   string              `json:"currency,omitempty" example:"USD"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
+}
+
+func NewGetTagExpensesResponse(tagExpenses []*analytics.TagExpense) []GetTagExpensesResponse {
+	var response []GetTagExpensesResponse
+	for _, tagExpense := range tagExpenses {
+		response = append(response, GetTagExpensesResponse{
+			ID:         tagExpense.ID,
+			Label:      tagExpense.Label,
+			Value:      tagExpense.Value,
+			Count:      tagExpense.Count,
+			Currency:   tagExpense.Currency,
+			ByCurrency: tagExpense.ByCurrency,
+		})
+	}
+	return response
+}
+
+// DefaultTopPayees and MaxTopPayees bound the number of payees in the top-payees report.
+const (
+	DefaultTopPayees = 10
+	MaxTopPayees     = 100
+)
+
+type GetPayeeExpensesResponse struct {
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Value      money.Money         `json:"value"`
+	Count      int                 `json:"count"`
+	Currency   string              `json:"currency,omitempty" example:"USD"`
+	ByCurrency []fx.CurrencyAmount `json:"by_currency,omitempty"`
+}
+
+func NewGetPayeeExpensesResponse(payeeExpenses []*analytics.PayeeExpense) []GetPayeeExpensesResponse {
+	response := make([]GetPayeeExpensesResponse, 0, len(payeeExpenses))
+	for _, payeeExpense := range payeeExpenses {
+		response = append(response, GetPayeeExpensesResponse{
+			ID:         payeeExpense.ID,
+			Label:      payeeExpense.Label,
+			Value:      payeeExpense.Value,
+			Count:      payeeExpense.Count,
+			Currency:   payeeExpense.Currency,
+			ByCurrency: payeeExpense.ByCurrency,
 		})
 	}
 	return response
 }
 
+// ParseTopPayeesLimit reads the limit query parameter of the top-payees report.
+func ParseTopPayeesLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultTopPayees, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxTopPayees {
+		return 0, fmt.Errorf("limit must be a number between 1 and %d", MaxTopPayees)
+	}
+	return limit, nil
+}
+
 type GetMonthlySummaryResponse struct {
-	Month    string  `json:"month"`
-	Ingresos float64 `json:"Ingresos"`
-	Gastos   float64 `json:"Gastos"`
+	Month              string              `json:"month"`
+	Ingresos           money.Money         `json:"Ingresos"`
+	Gastos             money.Money         `json:"Gastos"`
+	Currency           string              `json:"currency,omitempty" example:"USD"`
+	IncomeByCurrency   []fx.CurrencyAmount `json:"income_by_currency,omitempty"`
+	ExpensesByCurrency []fx.CurrencyAmount `json:"expenses_by_currency,omitempty"`
 }
 
 func NewGetMonthlySummaryResponse(monthlySummaries []*analytics.MonthlySummary) []GetMonthlySummaryResponse {
 	var response []GetMonthlySummaryResponse
 	for _, monthlySummary := range monthlySummaries {
 		response = append(response, GetMonthlySummaryResponse{
-			Month:    monthlySummary.Month,
-			Ingresos: monthlySummary.Income,
-			Gastos:   monthlySummary.Expenses,
+			Month:              monthlySummary.Month,
+			Ingresos:           monthlySummary.Income,
+			Gastos:             monthlySummary.Expenses,
+			Currency:           monthlySummary.Currency,
+			IncomeByCurrency:   monthlySummary.IncomeByCurrency,
+			ExpensesByCurrency: monthlySummary.ExpensesByCurrency,
 		})
 	}
 	return response
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+)
+
+type AttachmentResponse struct {
+	Id            string    `json:"id"`
+	TransactionId string    `json:"transaction_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewAttachmentResponse(attachment *attachment.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		Id:            attachment.Id,
+		TransactionId: attachment.TransactionId,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		CreatedAt:     attachment.CreatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// AuditFilter narrows the audit log. Every field is optional; events come newest first.
+type AuditFilter struct {
+	EntityType string    `json:"entity_type,omitempty" enums:"transaction,account,budget,category,investment"`
+	EntityId   string    `json:"entity_id,omitempty"`
+	UserId     string    `json:"user_id,omitempty"`
+	Action     string    `json:"action,omitempty" enums:"create,update,delete,restore"`
+	From       time.Time `json:"from,omitempty"`
+	To         time.Time `json:"to,omitempty"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+}
+
+func NewAuditFilter() *AuditFilter {
+	return &AuditFilter{Limit: defaultAuditLimit}
+}
+
+// ParseFromQuery reads the filter from the query string. Dates use the YYYY-MM-DD format and to includes the
+// whole day.
+func (f *AuditFilter) ParseFromQuery(ctx *gin.Context) error {
+	f.EntityType = ctx.Query("entity_type")
+	f.EntityId = ctx.Query("entity_id")
+	f.UserId = ctx.Query("user_id")
+	f.Action = ctx.Query("action")
+
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return errors.New("from must use the YYYY-MM-DD format")
+		}
+		f.From = date
+	}
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return errors.New("to must use the YYYY-MM-DD format")
+		}
+		f.To = date.AddDate(0, 0, 1)
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return errors.New("limit must be a number")
+		}
+		f.Limit = value
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			return errors.New("offset must be a number")
+		}
+		f.Offset = value
+	}
+	return f.Validate()
+}
+
+func (f *AuditFilter) Validate() error {
+	if f.EntityType != "" && !audit.IsEntityType(f.EntityType) {
+		return errors.New("entity_type must be 'transaction', 'account', 'budget', 'category' or 'investment'")
+	}
+	if f.Action != "" && !audit.IsAction(f.Action) {
+		return errors.New("action must be 'create', 'update', 'delete' or 'restore'")
+	}
+	if f.Limit < 1 || f.Limit > maxAuditLimit {
+		return errors.New("limit must be between 1 and 200")
+	}
+	if f.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return errors.New("from must be before to")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+)
+
+type AuditEventResponse struct {
+	Id         string          `json:"id"`
+	EntityType string          `json:"entity_type" example:"transaction"`
+	EntityId   string          `json:"entity_id"`
+	Action     string          `json:"action" example:"update"`
+	UserId     string          `json:"user_id"`
+	RequestId  string          `json:"request_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func NewAuditEventResponse(event *audit.Event) *AuditEventResponse {
+	return &AuditEventResponse{
+		Id:         event.Id,
+		EntityType: event.EntityType,
+		EntityId:   event.EntityId,
+		Action:     event.Action,
+		UserId:     event.UserId,
+		RequestId:  event.RequestId,
+		Before:     event.Before,
+		After:      event.After,
+		CreatedAt:  event.CreatedAt,
+	}
+}
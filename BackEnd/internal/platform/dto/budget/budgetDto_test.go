@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/go-playground/assert/v2"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 )
 
@@ -15,7 +16,7 @@ func TestBudgetRequest(t *testing.T) {
 
 func TestBudgetResponse(t *testing.T) {
 	budget := utils.GetNewRandomBudget()
-	currentBalance := 1000.00
+	currentBalance := money.FromUnits(1000)
 	budgetResponse := NewBudgetReponse(budget.Id, budget.CategoryId, budget.UserId, budget.Amount, currentBalance, budget.CreatedAt)
 	assert.Equal(t, budget.Amount, budgetResponse.Amount)
 	assert.Equal(t, budget.Id, budgetResponse.Id)
@@ -1,11 +1,13 @@
 package dto
 
+import "github.com/osmait/gestorDePresupuesto/internal/domain/money"
+
 type BudgetRequest struct {
-	CategoryId string  `json:"category_id"`
-	Amount     float64 `json:"amount"`
+	CategoryId string      `json:"category_id"`
+	Amount     money.Money `json:"amount"`
 }
 
-func NewBudgetRequest(categoryId string, amount float64) *BudgetRequest {
+func NewBudgetRequest(categoryId string, amount money.Money) *BudgetRequest {
 	return &BudgetRequest{
 		CategoryId: categoryId,
 		Amount:     amount,
@@ -1,17 +1,21 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
 type BudgetResponse struct {
-	CreatedAt     time.Time `json:"created_at"`
-	Id            string    `json:"id"`
-	CategoryId    string    `json:"category_id"`
-	UserId        string    `json:"user_id"`
-	Amount        float64   `json:"amount"`
-	CurrentAmount float64   `json:"current_amount"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Id            string      `json:"id"`
+	CategoryId    string      `json:"category_id"`
+	UserId        string      `json:"user_id"`
+	Amount        money.Money `json:"amount"`
+	CurrentAmount money.Money `json:"current_amount"`
 }
 
-func NewBudgetReponse(id, categoryId, userId string, amount, curentAmount float64, createdAt time.Time) *BudgetResponse {
+func NewBudgetReponse(id, categoryId, userId string, amount, curentAmount money.Money, createdAt time.Time) *BudgetResponse {
 	return &BudgetResponse{
 		Id:            id,
 		CategoryId:    categoryId,
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+)
+
+// RateRequest enters an exchange rate by hand: one unit of Base buys Rate units of Quote.
+type RateRequest struct {
+	Base  string    `json:"base" binding:"required" example:"EUR"`
+	Quote string    `json:"quote" binding:"required" example:"USD"`
+	Rate  float64   `json:"rate" binding:"required" example:"1.0845"`
+	Date  time.Time `json:"date" example:"2024-01-15T00:00:00Z"`
+}
+
+// Validate normalizes the currency codes and checks the rate.
+func (r *RateRequest) Validate() error {
+	var ok bool
+	if r.Base, ok = fx.NormalizeCurrency(r.Base); !ok {
+		return errors.New("base must be a three-letter ISO 4217 code")
+	}
+	if r.Quote, ok = fx.NormalizeCurrency(r.Quote); !ok {
+		return errors.New("quote must be a three-letter ISO 4217 code")
+	}
+	if r.Base == r.Quote {
+		return errors.New("base and quote must be different currencies")
+	}
+	if r.Rate <= 0 {
+		return errors.New("rate must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportMappingRequestValidate(t *testing.T) {
+	request := NewImportMappingRequest("Checking", 0, 2, 1, "YYYY-MM-DD")
+	assert.NoError(t, request.Validate())
+	assert.Equal(t, ",", request.Delimiter)
+	assert.Equal(t, ".", request.DecimalSeparator)
+	assert.Equal(t, importer.SignNegativeIsBill, request.SignConvention)
+	assert.True(t, *request.HasHeader)
+
+	request.DateFormat = "YY/MM"
+	assert.Error(t, request.Validate())
+
+	request = NewImportMappingRequest("Checking", 0, 2, 1, "DD/MM/YYYY")
+	request.DecimalSeparator = ","
+	assert.Error(t, request.Validate(), "decimal separator cannot match the delimiter")
+}
+
+func TestNewImportPreviewResponse(t *testing.T) {
+	rows := []*importer.ImportRow{
+		{Line: 2, Amount: 10, TypeTransation: "bill", Name: "Coffee"},
+		{Line: 3, Error: "invalid amount"},
+	}
+
+	preview := NewImportPreviewResponse(rows)
+
+	assert.Equal(t, 2, preview.TotalRows)
+	assert.Equal(t, 1, preview.ValidRows)
+	assert.Equal(t, 1, preview.InvalidRows)
+	assert.Equal(t, "invalid amount", preview.Rows[1].Error)
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+)
+
+type ImportMappingRequest struct {
+	Name              string `json:"name" binding:"required" example:"Main bank checking"`
+	Delimiter         string `json:"delimiter" example:";"`
+	HasHeader         *bool  `json:"has_header" example:"true"`
+	DateColumn        int    `json:"date_column" example:"0"`
+	AmountColumn      int    `json:"amount_column" example:"3"`
+	DescriptionColumn int    `json:"description_column" example:"1"`
+	DateFormat        string `json:"date_format" binding:"required" example:"DD/MM/YYYY" enums:"YYYY/MM/DD,YYYY-MM-DD,DD/MM/YYYY,DD-MM-YYYY,MM/DD/YYYY"`
+	SignConvention    string `json:"sign_convention" example:"negative_is_bill" enums:"negative_is_bill,positive_is_bill"`
+	DecimalSeparator  string `json:"decimal_separator" example:","`
+	CategoryId        string `json:"category_id" example:"cat_987654321"`
+}
+
+func NewImportMappingRequest(name string, dateColumn, amountColumn, descriptionColumn int, dateFormat string) *ImportMappingRequest {
+	return &ImportMappingRequest{
+		Name:              name,
+		DateColumn:        dateColumn,
+		AmountColumn:      amountColumn,
+		DescriptionColumn: descriptionColumn,
+		DateFormat:        dateFormat,
+	}
+}
+
+// Validate checks the mapping and fills in the defaults for the optional fields.
+func (r *ImportMappingRequest) Validate() error {
+	if r.Delimiter == "" {
+		r.Delimiter = ","
+	}
+	if r.SignConvention == "" {
+		r.SignConvention = importer.SignNegativeIsBill
+	}
+	if r.DecimalSeparator == "" {
+		r.DecimalSeparator = "."
+	}
+	if r.HasHeader == nil {
+		hasHeader := true
+		r.HasHeader = &hasHeader
+	}
+
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Delimiter) != 1 {
+		return errors.New("delimiter must be a single character")
+	}
+	if r.DateColumn < 0 || r.AmountColumn < 0 || r.DescriptionColumn < 0 {
+		return errors.New("column indexes must be zero or greater")
+	}
+	if r.DateColumn == r.AmountColumn || r.DateColumn == r.DescriptionColumn || r.AmountColumn == r.DescriptionColumn {
+		return errors.New("date, amount and description must use different columns")
+	}
+	if _, ok := importer.DateFormats[r.DateFormat]; !ok {
+		return errors.New("date_format must be one of YYYY/MM/DD, YYYY-MM-DD, DD/MM/YYYY, DD-MM-YYYY or MM/DD/YYYY")
+	}
+	if r.SignConvention != importer.SignNegativeIsBill && r.SignConvention != importer.SignPositiveIsBill {
+		return errors.New("sign_convention must be 'negative_is_bill' or 'positive_is_bill'")
+	}
+	if r.DecimalSeparator != "." && r.DecimalSeparator != "," {
+		return errors.New("decimal_separator must be '.' or ','")
+	}
+	if r.DecimalSeparator == r.Delimiter {
+		return errors.New("decimal_separator and delimiter must be different")
+	}
+	return nil
+}
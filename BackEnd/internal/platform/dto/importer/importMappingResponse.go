@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+)
+
+type ImportMappingResponse struct {
+	Id                string    `json:"id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"has_header"`
+	DateColumn        int       `json:"date_column"`
+	AmountColumn      int       `json:"amount_column"`
+	DescriptionColumn int       `json:"description_column"`
+	DateFormat        string    `json:"date_format"`
+	SignConvention    string    `json:"sign_convention"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	CategoryId        string    `json:"category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func NewImportMappingResponse(mapping *importer.ImportMapping) *ImportMappingResponse {
+	return &ImportMappingResponse{
+		Id:                mapping.Id,
+		Name:              mapping.Name,
+		Delimiter:         mapping.Delimiter,
+		HasHeader:         mapping.HasHeader,
+		DateColumn:        mapping.DateColumn,
+		AmountColumn:      mapping.AmountColumn,
+		DescriptionColumn: mapping.DescriptionColumn,
+		DateFormat:        mapping.DateFormat,
+		SignConvention:    mapping.SignConvention,
+		DecimalSeparator:  mapping.DecimalSeparator,
+		CategoryId:        mapping.CategoryId,
+		CreatedAt:         mapping.CreatedAt,
+		UpdatedAt:         mapping.UpdatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// ImportRowResponse is a parsed statement line as shown in the import preview
+type ImportRowResponse struct {
+	Line           int         `json:"line" example:"2"`
+	Date           time.Time   `json:"date" example:"2024-01-15T00:00:00Z"`
+	Amount         money.Money `json:"amount" example:"42.50"`
+	TypeTransation string      `json:"type_transation" example:"bill"`
+	Name           string      `json:"name" example:"SUPERMARKET 1234"`
+	ExternalId     string      `json:"external_id,omitempty" example:"20240115001"`
+	Error          string      `json:"error,omitempty" example:"invalid amount"`
+}
+
+// ImportPreviewResponse is the dry-run result of an import; nothing is stored
+type ImportPreviewResponse struct {
+	Rows        []*ImportRowResponse `json:"rows"`
+	TotalRows   int                  `json:"total_rows" example:"30"`
+	ValidRows   int                  `json:"valid_rows" example:"28"`
+	InvalidRows int                  `json:"invalid_rows" example:"2"`
+}
+
+// ImportResultResponse summarizes a committed import. Skipped counts every row that was not imported, broken
+// down into Duplicates and Rejected; Errors lists each of them with the reason
+type ImportResultResponse struct {
+	Imported   int                  `json:"imported" example:"28"`
+	Skipped    int                  `json:"skipped" example:"5"`
+	Duplicates int                  `json:"duplicates" example:"3"`
+	Rejected   int                  `json:"rejected" example:"2"`
+	Errors     []*ImportRowResponse `json:"errors,omitempty"`
+}
+
+func NewImportRowResponse(row *importer.ImportRow) *ImportRowResponse {
+	return &ImportRowResponse{
+		Line:           row.Line,
+		Date:           row.Date,
+		Amount:         row.Amount,
+		TypeTransation: row.TypeTransation,
+		Name:           row.Name,
+		ExternalId:     row.ExternalId,
+		Error:          row.Error,
+	}
+}
+
+func NewImportPreviewResponse(rows []*importer.ImportRow) *ImportPreviewResponse {
+	preview := &ImportPreviewResponse{
+		Rows:      make([]*ImportRowResponse, 0, len(rows)),
+		TotalRows: len(rows),
+	}
+	for _, row := range rows {
+		preview.Rows = append(preview.Rows, NewImportRowResponse(row))
+		if row.IsValid() {
+			preview.ValidRows++
+		} else {
+			preview.InvalidRows++
+		}
+	}
+	return preview
+}
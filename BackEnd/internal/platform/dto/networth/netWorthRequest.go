@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+)
+
+// maxHistoryMonths bounds the range of the net worth history.
+const maxHistoryMonths = 120
+
+// HistoryRequest is the range of months of the net worth history, both ends included.
+type HistoryRequest struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseFromQuery reads the range from the query string. Months use the YYYY-MM format; to defaults to the current
+// month and from to eleven months before it, for a year of history.
+func (r *HistoryRequest) ParseFromQuery(ctx *gin.Context, now time.Time) error {
+	r.To = networth.MonthStart(now)
+	if to := ctx.Query("to"); to != "" {
+		month, err := time.Parse(networth.MonthLayout, to)
+		if err != nil {
+			return errors.New("to must use the YYYY-MM format")
+		}
+		r.To = month
+	}
+	r.From = r.To.AddDate(0, -11, 0)
+	if from := ctx.Query("from"); from != "" {
+		month, err := time.Parse(networth.MonthLayout, from)
+		if err != nil {
+			return errors.New("from must use the YYYY-MM format")
+		}
+		r.From = month
+	}
+	return r.Validate()
+}
+
+func (r *HistoryRequest) Validate() error {
+	if r.From.After(r.To) {
+		return errors.New("from cannot be after to")
+	}
+	if r.From.AddDate(0, maxHistoryMonths, 0).Before(r.To) {
+		return errors.New("the range cannot be longer than 120 months")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+)
+
+// MonthlyNetWorthResponse is the net worth recorded for a month, in the base currency the user had then.
+type MonthlyNetWorthResponse struct {
+	Month string `json:"month" example:"2024-03"`
+	networth.Totals
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-31T23:00:00Z"`
+}
+
+func NewMonthlyNetWorthResponses(records []*networth.MonthlyNetWorth) []*MonthlyNetWorthResponse {
+	responses := make([]*MonthlyNetWorthResponse, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, &MonthlyNetWorthResponse{
+			Month:     record.Month.Format(networth.MonthLayout),
+			Totals:    record.Totals,
+			UpdatedAt: record.UpdatedAt,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+)
+
+type PayeeRequest struct {
+	Name    string   `json:"name" binding:"required" example:"Uber"`
+	Aliases []string `json:"aliases" example:"uber trip,uber eats"`
+}
+
+// Validate trims the name, normalizes the aliases and checks them.
+func (r *PayeeRequest) Validate() error {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	r.Aliases = payee.NormalizeAliases(r.Aliases)
+	return (&payee.Payee{Name: r.Name, Aliases: r.Aliases}).Validate()
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+)
+
+type PayeeResponse struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	// Linked is how many existing transactions were linked to the payee by the request
+	Linked int `json:"linked,omitempty" example:"12"`
+}
+
+func NewPayeeResponse(payee *payee.Payee) *PayeeResponse {
+	aliases := payee.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return &PayeeResponse{
+		Id:        payee.Id,
+		Name:      payee.Name,
+		Aliases:   aliases,
+		CreatedAt: payee.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// StatementDateLayout is the format of statement dates in requests and responses.
+const StatementDateLayout = "2006-01-02"
+
+// ReconciliationRequest holds the closing date and balance of a bank statement.
+type ReconciliationRequest struct {
+	StatementDate    string      `json:"statement_date" binding:"required" example:"2026-01-31"`
+	StatementBalance money.Money `json:"statement_balance" example:"1250.75"`
+	// Date is StatementDate parsed by Validate
+	Date time.Time `json:"-"`
+}
+
+// Validate parses the statement date, which cannot be in the future.
+func (r *ReconciliationRequest) Validate() error {
+	date, err := time.Parse(StatementDateLayout, r.StatementDate)
+	if err != nil {
+		return errors.New("statement_date must use the YYYY-MM-DD format")
+	}
+	if date.After(time.Now().UTC()) {
+		return errors.New("statement_date cannot be in the future")
+	}
+	r.Date = date
+	return nil
+}
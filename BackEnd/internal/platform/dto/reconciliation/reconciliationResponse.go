@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+)
+
+type ReconciliationResponse struct {
+	Id               string      `json:"id"`
+	AccountId        string      `json:"account_id"`
+	StatementDate    string      `json:"statement_date" example:"2026-01-31"`
+	StatementBalance money.Money `json:"statement_balance" example:"1250.75"`
+	ClearedBalance   money.Money `json:"cleared_balance" example:"1200.75"`
+	// Difference is statement_balance minus cleared_balance; the reconciliation can be completed when it is zero
+	Difference  money.Money `json:"difference" example:"50.00"`
+	Status      string      `json:"status" example:"open"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+}
+
+func NewReconciliationResponse(rec *reconciliation.Reconciliation) *ReconciliationResponse {
+	return &ReconciliationResponse{
+		Id:               rec.Id,
+		AccountId:        rec.AccountId,
+		StatementDate:    rec.StatementDate.Format(StatementDateLayout),
+		StatementBalance: rec.StatementBalance,
+		ClearedBalance:   rec.ClearedBalance,
+		Difference:       rec.Difference(),
+		Status:           rec.Status,
+		CreatedAt:        rec.CreatedAt,
+		CompletedAt:      rec.CompletedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+type RuleRequest struct {
+	Name     string `json:"name" binding:"required" example:"Rides"`
+	Priority int    `json:"priority" example:"10"`
+	Enabled  *bool  `json:"enabled" example:"true"`
+
+	NameContains string       `json:"name_contains" example:"uber"`
+	AmountMin    *money.Money `json:"amount_min" example:"5.00"`
+	AmountMax    *money.Money `json:"amount_max" example:"80.00"`
+	AccountId    string       `json:"account_id" example:"acc_123456789"`
+
+	CategoryId string `json:"category_id" example:"cat_987654321"`
+	BudgetId   string `json:"budget_id" example:"budget_555666777"`
+	RenameTo   string `json:"rename_to" example:"Uber"`
+}
+
+func NewRuleRequest(name string, priority int, nameContains string, categoryId string) *RuleRequest {
+	return &RuleRequest{
+		Name:         name,
+		Priority:     priority,
+		NameContains: nameContains,
+		CategoryId:   categoryId,
+	}
+}
+
+// Validate checks the rule and fills in the defaults for the optional fields.
+func (r *RuleRequest) Validate() error {
+	if r.Enabled == nil {
+		enabled := true
+		r.Enabled = &enabled
+	}
+	r.NameContains = strings.TrimSpace(r.NameContains)
+	r.RenameTo = strings.TrimSpace(r.RenameTo)
+
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.NameContains == "" && r.AmountMin == nil && r.AmountMax == nil && r.AccountId == "" {
+		return errors.New("a rule needs at least one condition: name_contains, amount_min, amount_max or account_id")
+	}
+	if (r.AmountMin != nil && *r.AmountMin < 0) || (r.AmountMax != nil && *r.AmountMax < 0) {
+		return errors.New("amounts are compared by absolute value and must be zero or greater")
+	}
+	if r.AmountMin != nil && r.AmountMax != nil && *r.AmountMin > *r.AmountMax {
+		return errors.New("amount_min cannot be greater than amount_max")
+	}
+	if r.CategoryId == "" && r.BudgetId == "" && r.RenameTo == "" {
+		return errors.New("a rule needs at least one action: category_id, budget_id or rename_to")
+	}
+	return nil
+}
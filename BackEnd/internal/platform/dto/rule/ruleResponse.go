@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+)
+
+type RuleResponse struct {
+	Id           string       `json:"id"`
+	Name         string       `json:"name"`
+	Priority     int          `json:"priority"`
+	Enabled      bool         `json:"enabled"`
+	NameContains string       `json:"name_contains,omitempty"`
+	AmountMin    *money.Money `json:"amount_min,omitempty"`
+	AmountMax    *money.Money `json:"amount_max,omitempty"`
+	AccountId    string       `json:"account_id,omitempty"`
+	CategoryId   string       `json:"category_id,omitempty"`
+	BudgetId     string       `json:"budget_id,omitempty"`
+	RenameTo     string       `json:"rename_to,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ApplyRulesResponse summarizes a retroactive run of the rules. On a dry run Changes lists what would be stored
+type ApplyRulesResponse struct {
+	DryRun    bool                  `json:"dry_run" example:"true"`
+	Evaluated int                   `json:"evaluated" example:"120"`
+	Changed   int                   `json:"changed" example:"14"`
+	Skipped   int                   `json:"skipped" example:"2"`
+	Changes   []*RuleChangeResponse `json:"changes"`
+}
+
+// RuleChangeResponse is one transaction the rules changed
+type RuleChangeResponse struct {
+	TransactionId string              `json:"transaction_id"`
+	CreatedAt     time.Time           `json:"created_at"`
+	RuleIds       []string            `json:"rule_ids"`
+	Before        *RuleFieldsResponse `json:"before"`
+	After         *RuleFieldsResponse `json:"after"`
+}
+
+// RuleFieldsResponse holds the transaction fields a rule can change
+type RuleFieldsResponse struct {
+	CategoryId string `json:"category_id"`
+	BudgetId   string `json:"budget_id"`
+	Name       string `json:"name"`
+}
+
+func NewRuleResponse(rule *rule.Rule) *RuleResponse {
+	return &RuleResponse{
+		Id:           rule.Id,
+		Name:         rule.Name,
+		Priority:     rule.Priority,
+		Enabled:      rule.Enabled,
+		NameContains: rule.NameContains,
+		AmountMin:    rule.AmountMin,
+		AmountMax:    rule.AmountMax,
+		AccountId:    rule.AccountId,
+		CategoryId:   rule.CategoryId,
+		BudgetId:     rule.BudgetId,
+		RenameTo:     rule.RenameTo,
+		CreatedAt:    rule.CreatedAt,
+		UpdatedAt:    rule.UpdatedAt,
+	}
+}
+
+func NewRuleFieldsResponse(categoryId, budgetId, name string) *RuleFieldsResponse {
+	return &RuleFieldsResponse{
+		CategoryId: categoryId,
+		BudgetId:   budgetId,
+		Name:       name,
+	}
+}
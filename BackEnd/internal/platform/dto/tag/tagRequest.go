@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+
+type TagRequest struct {
+	Name string `json:"name" binding:"required" example:"vacation 2026"`
+}
+
+// Validate normalizes the tag name and checks it.
+func (r *TagRequest) Validate() error {
+	r.Name = tag.NormalizeName(r.Name)
+	return tag.ValidateName(r.Name)
+}
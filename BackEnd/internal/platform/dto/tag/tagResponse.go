@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+)
+
+type TagResponse struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewTagResponse(tag *tag.Tag) *TagResponse {
+	return &TagResponse{
+		Id:        tag.Id,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}
+}
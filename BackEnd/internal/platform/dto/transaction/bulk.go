@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+)
+
+// Bulk actions.
+const (
+	BulkActionDelete      = "delete"
+	BulkActionSetCategory = "set_category"
+	BulkActionSetAccount  = "set_account"
+	BulkActionAddTag      = "add_tag"
+	BulkActionRemoveTag   = "remove_tag"
+)
+
+// MaxBulkItems is the most transactions a single bulk request can change.
+const MaxBulkItems = 1000
+
+// Results of each transaction of a bulk request.
+const (
+	BulkStatusOk     = "ok"
+	BulkStatusFailed = "failed"
+)
+
+// BulkRequest applies one action to a selection of transactions, given either as a list of ids or as a filter. A
+// filter selects every matching transaction, with the same defaults as the listing, so without dates it covers the
+// last 30 days.
+type BulkRequest struct {
+	Action     string             `json:"action" binding:"required" example:"set_category" enums:"delete,set_category,set_account,add_tag,remove_tag"`
+	Ids        []string           `json:"ids,omitempty" example:"2bX8sYQd7f0Hk3pL9mN4qR6tV1w,2bX8tA1c5e9Gj2oK8lM3pQ5sU0v"`
+	Filter     *TransactionFilter `json:"filter,omitempty"`
+	CategoryId string             `json:"category_id,omitempty" example:"cat_123456789"`
+	AccountId  string             `json:"account_id,omitempty" example:"acc_123456789"`
+	Tag        string             `json:"tag,omitempty" example:"reimbursable"`
+}
+
+// Validate checks the request and normalizes its ids, tag and filter.
+func (r *BulkRequest) Validate() error {
+	switch r.Action {
+	case BulkActionDelete:
+	case BulkActionSetCategory:
+		if r.CategoryId == "" {
+			return errors.New("category_id is required to change the category")
+		}
+	case BulkActionSetAccount:
+		if r.AccountId == "" {
+			return errors.New("account_id is required to change the account")
+		}
+	case BulkActionAddTag, BulkActionRemoveTag:
+		r.Tag = tag.NormalizeName(r.Tag)
+		if err := tag.ValidateName(r.Tag); err != nil {
+			return err
+		}
+	default:
+		return errors.New("action must be 'delete', 'set_category', 'set_account', 'add_tag' or 'remove_tag'")
+	}
+
+	if (len(r.Ids) > 0) == (r.Filter != nil) {
+		return errors.New("select the transactions with either ids or filter")
+	}
+	if r.Filter != nil {
+		return r.Filter.PrepareSelection()
+	}
+
+	seen := make(map[string]bool, len(r.Ids))
+	ids := make([]string, 0, len(r.Ids))
+	for _, id := range r.Ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) > MaxBulkItems {
+		return fmt.Errorf("a bulk request can change at most %d transactions", MaxBulkItems)
+	}
+	r.Ids = ids
+	return nil
+}
+
+// BulkItemResult is the outcome of the action on one transaction.
+type BulkItemResult struct {
+	Id     string `json:"id"`
+	Status string `json:"status" example:"ok" enums:"ok,failed"`
+	Error  string `json:"error,omitempty" example:"transaction is reconciled"`
+}
+
+// BulkResponse reports the outcome of a bulk request, one result per selected transaction.
+type BulkResponse struct {
+	Action    string            `json:"action" example:"set_category"`
+	Succeeded int               `json:"succeeded" example:"198"`
+	Failed    int               `json:"failed" example:"2"`
+	Results   []*BulkItemResult `json:"results"`
+}
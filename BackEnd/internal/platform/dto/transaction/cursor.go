@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor directions
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// Cursor marks a position in a listing sorted by (created_at, id). Clients receive it as an opaque token and
+// send it back to read the rows after it (next) or before it (prev), so pages don't shift when rows are added.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	Id        string    `json:"id"`
+	Direction string    `json:"d"`
+}
+
+func NewCursor(createdAt time.Time, id string, direction string) *Cursor {
+	return &Cursor{
+		CreatedAt: createdAt,
+		Id:        id,
+		Direction: direction,
+	}
+}
+
+// Encode returns the opaque token of the cursor.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a token returned by Encode.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	cursor := &Cursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	if cursor.Id == "" || cursor.CreatedAt.IsZero() || (cursor.Direction != CursorNext && cursor.Direction != CursorPrev) {
+		return nil, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
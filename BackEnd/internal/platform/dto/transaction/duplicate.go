@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Duplicate listings look back DefaultDuplicateDays unless asked otherwise, and never more than MaxDuplicateDays.
+const (
+	DefaultDuplicateDays = 90
+	MaxDuplicateDays     = 365
+)
+
+// DuplicateRequest names a pair of transactions reported as likely duplicates. When merging, the transaction is
+// kept and the duplicate removed.
+type DuplicateRequest struct {
+	TransactionId string `json:"transaction_id" binding:"required" example:"2bX8sYQd7f0Hk3pL9mN4qR6tV1w"`
+	DuplicateId   string `json:"duplicate_id" binding:"required" example:"2bX8tA1c5e9Gj2oK8lM3pQ5sU0v"`
+}
+
+func (r *DuplicateRequest) Validate() error {
+	if r.TransactionId == r.DuplicateId {
+		return errors.New("a transaction cannot be a duplicate of itself")
+	}
+	return nil
+}
+
+// DuplicateResponse is a pair of transactions that look like the same charge, newest first.
+type DuplicateResponse struct {
+	Transaction *TransactionResponse `json:"transaction"`
+	Duplicate   *TransactionResponse `json:"duplicate"`
+}
+
+// ParseDuplicateDays reads the days query parameter of the duplicate listing.
+func ParseDuplicateDays(value string) (int, error) {
+	if value == "" {
+		return DefaultDuplicateDays, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > MaxDuplicateDays {
+		return 0, fmt.Errorf("days must be a number between 1 and %d", MaxDuplicateDays)
+	}
+	return days, nil
+}
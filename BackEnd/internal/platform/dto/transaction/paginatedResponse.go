@@ -1,6 +1,12 @@
 package dto
 
-import "math"
+import (
+	"math"
+	"sort"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
 // PaginatedTransactionResponse represents a paginated response for transactions
 type PaginatedTransactionResponse struct {
@@ -18,6 +24,9 @@ type PaginationMeta struct {
 	HasPrevPage  bool  `json:"has_prev_page" example:"false"`
 	NextPage     *int  `json:"next_page,omitempty" example:"2"`
 	PrevPage     *int  `json:"prev_page,omitempty" example:"1"`
+	// Opaque tokens for the cursor query parameter, only set when sorting by created_at
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpZCI6InR4bl8yMCIsImQiOiJuZXh0In0"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpZCI6InR4bl8xIiwiZCI6InByZXYifQ"`
 }
 
 // NewPaginatedTransactionResponse creates a new paginated response with metadata
@@ -44,42 +53,76 @@ func NewPaginatedTransactionResponse(
 		prevPage = &prev
 	}
 
+	pagination := PaginationMeta{
+		CurrentPage:  filter.Page,
+		PerPage:      filter.Limit,
+		TotalPages:   totalPages,
+		TotalRecords: totalRecords,
+		HasNextPage:  hasNextPage,
+		HasPrevPage:  hasPrevPage,
+		NextPage:     nextPage,
+		PrevPage:     prevPage,
+	}
+	if filter.DecodedCursor == nil {
+		pagination.SetCursors(transactions, filter, hasNextPage, hasPrevPage)
+	}
+
 	return &PaginatedTransactionResponse{
-		Data: transactions,
-		Pagination: PaginationMeta{
-			CurrentPage:  filter.Page,
-			PerPage:      filter.Limit,
-			TotalPages:   totalPages,
-			TotalRecords: totalRecords,
-			HasNextPage:  hasNextPage,
-			HasPrevPage:  hasPrevPage,
-			NextPage:     nextPage,
-			PrevPage:     prevPage,
-		},
+		Data:       transactions,
+		Pagination: pagination,
+	}
+}
+
+// SetCursors points the next and prev cursors at the last and first transaction of the page. On a cursor page
+// the page numbers don't apply, so hasNext and hasPrev come from the rows read around the page instead.
+func (m *PaginationMeta) SetCursors(transactions []*TransactionResponse, filter *TransactionFilter, hasNext bool, hasPrev bool) {
+	if filter.DecodedCursor != nil {
+		m.HasNextPage = hasNext
+		m.HasPrevPage = hasPrev
+		m.NextPage = nil
+		m.PrevPage = nil
+	}
+	if !filter.SortsByCreatedAt() || len(transactions) == 0 {
+		return
+	}
+
+	if hasNext {
+		last := transactions[len(transactions)-1]
+		m.NextCursor = NewCursor(last.CreatedAt, last.Id, CursorNext).Encode()
+	}
+	if hasPrev {
+		first := transactions[0]
+		m.PrevCursor = NewCursor(first.CreatedAt, first.Id, CursorPrev).Encode()
 	}
 }
 
-// TransactionSummary provides aggregated data for transactions
+// TransactionSummary provides aggregated data for transactions.
+// Amounts are in Currency, the base currency of the user; the by-currency lists keep the native totals.
 type TransactionSummary struct {
-	TotalIncome       float64                    `json:"total_income" example:"5000.00"`
-	TotalExpenses     float64                    `json:"total_expenses" example:"3000.00"`
-	NetAmount         float64                    `json:"net_amount" example:"2000.00"`
-	IncomeCount       int                        `json:"income_count" example:"15"`
-	ExpenseCount      int                        `json:"expense_count" example:"35"`
-	AverageIncome     float64                    `json:"average_income" example:"333.33"`
-	AverageExpense    float64                    `json:"average_expense" example:"85.71"`
-	LargestIncome     float64                    `json:"largest_income" example:"2500.00"`
-	LargestExpense    float64                    `json:"largest_expense" example:"800.00"`
-	FilteredRecords   int64                      `json:"filtered_records" example:"50"`
-	CategoryBreakdown map[string]CategorySummary `json:"category_breakdown,omitempty"`
+	TotalIncome        money.Money                `json:"total_income" example:"5000.00"`
+	TotalExpenses      money.Money                `json:"total_expenses" example:"3000.00"`
+	NetAmount          money.Money                `json:"net_amount" example:"2000.00"`
+	IncomeCount        int                        `json:"income_count" example:"15"`
+	ExpenseCount       int                        `json:"expense_count" example:"35"`
+	AverageIncome      money.Money                `json:"average_income" example:"333.33"`
+	AverageExpense     money.Money                `json:"average_expense" example:"85.71"`
+	LargestIncome      money.Money                `json:"largest_income" example:"2500.00"`
+	LargestExpense     money.Money                `json:"largest_expense" example:"800.00"`
+	FilteredRecords    int64                      `json:"filtered_records" example:"50"`
+	CategoryBreakdown  map[string]CategorySummary `json:"category_breakdown,omitempty"`
+	Currency           string                     `json:"currency,omitempty" example:"USD"`
+	IncomeByCurrency   []fx.CurrencyAmount        `json:"income_by_currency,omitempty"`
+	ExpensesByCurrency []fx.CurrencyAmount        `json:"expenses_by_currency,omitempty"`
+	// MissingRates lists the currencies left out of the converted figures for lack of an exchange rate
+	MissingRates []string `json:"missing_rates,omitempty"`
 }
 
 // CategorySummary provides summary for each category
 type CategorySummary struct {
-	CategoryId    string  `json:"category_id" example:"cat_123456789"`
-	TotalAmount   float64 `json:"total_amount" example:"500.00"`
-	Count         int     `json:"count" example:"10"`
-	AverageAmount float64 `json:"average_amount" example:"50.00"`
+	CategoryId    string      `json:"category_id" example:"cat_123456789"`
+	TotalAmount   money.Money `json:"total_amount" example:"500.00"`
+	Count         int         `json:"count" example:"10"`
+	AverageAmount money.Money `json:"average_amount" example:"50.00"`
 }
 
 // PaginatedTransactionResponseWithSummary extends the paginated response with summary data
@@ -105,62 +148,156 @@ func NewPaginatedTransactionResponseWithSummary(
 	}
 }
 
-// CalculateSummary calculates summary statistics from a list of transactions
-func CalculateSummary(transactions []*TransactionResponse, filteredCount int64) TransactionSummary {
+// SummaryTotal aggregates the filtered transactions of one type in one currency
+type SummaryTotal struct {
+	TypeTransation string
+	Currency       string
+	Total          money.Money
+	Count          int
+	Largest        money.Money
+}
+
+// SummaryCategoryTotal aggregates the filtered transactions of one category in one currency. Split transactions
+// count towards the category of each of their lines.
+type SummaryCategoryTotal struct {
+	CategoryId string
+	Currency   string
+	Total      money.Money
+	Count      int
+}
+
+// SummaryTotals are the aggregates of a filtered set of transactions a summary is built from
+type SummaryTotals struct {
+	Types      []SummaryTotal
+	Categories []SummaryCategoryTotal
+}
+
+// CalculateSummary calculates summary statistics from a list of transactions.
+// Amounts are converted with the converter; a nil converter adds them up as they are.
+func CalculateSummary(transactions []*TransactionResponse, filteredCount int64, converter *fx.Converter) TransactionSummary {
+	return Summarize(aggregateTransactions(transactions), filteredCount, converter)
+}
+
+// aggregateTransactions groups a list of transactions the way the summary query of the repository does.
+func aggregateTransactions(transactions []*TransactionResponse) *SummaryTotals {
+	totals := &SummaryTotals{}
+	typeIndex := make(map[[2]string]int)
+	categoryIndex := make(map[[2]string]int)
+	addCategory := func(categoryId string, currency string, amount money.Money) {
+		key := [2]string{categoryId, currency}
+		i, ok := categoryIndex[key]
+		if !ok {
+			i = len(totals.Categories)
+			categoryIndex[key] = i
+			totals.Categories = append(totals.Categories, SummaryCategoryTotal{CategoryId: categoryId, Currency: currency})
+		}
+		totals.Categories[i].Total += amount
+		totals.Categories[i].Count++
+	}
+
+	for _, transaction := range transactions {
+		key := [2]string{transaction.TypeTransation, transaction.Currency}
+		i, ok := typeIndex[key]
+		if !ok {
+			i = len(totals.Types)
+			typeIndex[key] = i
+			totals.Types = append(totals.Types, SummaryTotal{TypeTransation: transaction.TypeTransation, Currency: transaction.Currency, Largest: transaction.Amount})
+		}
+		totals.Types[i].Total += transaction.Amount
+		totals.Types[i].Count++
+		totals.Types[i].Largest = max(totals.Types[i].Largest, transaction.Amount)
+
+		if len(transaction.Splits) > 0 {
+			for _, split := range transaction.Splits {
+				addCategory(split.CategoryId, transaction.Currency, split.Amount)
+			}
+		} else if transaction.CategoryId != "" {
+			addCategory(transaction.CategoryId, transaction.Currency, transaction.Amount)
+		}
+	}
+	return totals
+}
+
+// Summarize builds the summary of a filtered set of transactions from its aggregates.
+// Amounts are converted with the converter; a nil converter adds them up as they are.
+func Summarize(totals *SummaryTotals, filteredCount int64, converter *fx.Converter) TransactionSummary {
 	summary := TransactionSummary{
 		FilteredRecords:   filteredCount,
 		CategoryBreakdown: make(map[string]CategorySummary),
+		Currency:          converter.Base(),
 	}
 
-	if len(transactions) == 0 {
-		return summary
-	}
-
-	categoryTotals := make(map[string]float64)
+	categoryTotals := make(map[string]money.Money)
 	categoryCounts := make(map[string]int)
+	incomeTotals := fx.Totals{}
+	expenseTotals := fx.Totals{}
+	missingRates := make(map[string]bool)
 
-	for _, transaction := range transactions {
-		amount := transaction.Amount
+	for _, total := range totals.Types {
+		if total.Currency != "" {
+			switch total.TypeTransation {
+			case "income":
+				incomeTotals.Add(total.Currency, total.Total)
+			case "expense":
+				expenseTotals.Add(total.Currency, total.Total)
+			}
+		}
+
+		amount, ok := converter.Convert(total.Total, total.Currency)
+		if !ok {
+			missingRates[total.Currency] = true
+			continue
+		}
+		largest, _ := converter.Convert(total.Largest, total.Currency)
 
-		switch transaction.TypeTransation {
+		switch total.TypeTransation {
 		case "income":
 			summary.TotalIncome += amount
-			summary.IncomeCount++
-
-			if amount > summary.LargestIncome {
-				summary.LargestIncome = amount
-			}
+			summary.IncomeCount += total.Count
+			summary.LargestIncome = max(summary.LargestIncome, largest)
 		case "expense":
 			summary.TotalExpenses += amount
-			summary.ExpenseCount++
-
-			if amount > summary.LargestExpense {
-				summary.LargestExpense = amount
-			}
+			summary.ExpenseCount += total.Count
+			summary.LargestExpense = max(summary.LargestExpense, largest)
 		}
+	}
 
-		// Category breakdown
-		if transaction.CategoryId != "" {
-			categoryTotals[transaction.CategoryId] += amount
-			categoryCounts[transaction.CategoryId]++
+	// Category breakdown, split transactions count towards each of their lines' categories
+	for _, total := range totals.Categories {
+		amount, ok := converter.Convert(total.Total, total.Currency)
+		if !ok {
+			missingRates[total.Currency] = true
+			continue
 		}
+		categoryTotals[total.CategoryId] += amount
+		categoryCounts[total.CategoryId] += total.Count
 	}
 
 	// Calculate derived values
 	summary.NetAmount = summary.TotalIncome - summary.TotalExpenses
+	if len(incomeTotals) > 0 {
+		summary.IncomeByCurrency, _ = converter.Breakdown(incomeTotals)
+	}
+	if len(expenseTotals) > 0 {
+		summary.ExpensesByCurrency, _ = converter.Breakdown(expenseTotals)
+	}
+	for currency := range missingRates {
+		summary.MissingRates = append(summary.MissingRates, currency)
+	}
+	sort.Strings(summary.MissingRates)
 
 	if summary.IncomeCount > 0 {
-		summary.AverageIncome = summary.TotalIncome / float64(summary.IncomeCount)
+		summary.AverageIncome = summary.TotalIncome.Div(int64(summary.IncomeCount))
 	}
 
 	if summary.ExpenseCount > 0 {
-		summary.AverageExpense = summary.TotalExpenses / float64(summary.ExpenseCount)
+		summary.AverageExpense = summary.TotalExpenses.Div(int64(summary.ExpenseCount))
 	}
 
 	// Build category breakdown
 	for categoryId, total := range categoryTotals {
 		count := categoryCounts[categoryId]
-		average := total / float64(count)
+		average := total.Div(int64(count))
 
 		summary.CategoryBreakdown[categoryId] = CategorySummary{
 			CategoryId:    categoryId,
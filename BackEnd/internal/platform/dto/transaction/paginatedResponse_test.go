@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 )
 
 func TestNewPaginatedTransactionResponse(t *testing.T) {
@@ -13,7 +16,7 @@ func TestNewPaginatedTransactionResponse(t *testing.T) {
 		{
 			Id:             "1",
 			Name:           "Grocery",
-			Amount:         100.0,
+			Amount:         money.FromUnits(100),
 			TypeTransation: "expense",
 			AccountId:      "acc_1",
 			CategoryId:     "cat_1",
@@ -22,7 +25,7 @@ func TestNewPaginatedTransactionResponse(t *testing.T) {
 		{
 			Id:             "2",
 			Name:           "Salary",
-			Amount:         2000.0,
+			Amount:         money.FromUnits(2000),
 			TypeTransation: "income",
 			AccountId:      "acc_1",
 			CategoryId:     "cat_2",
@@ -114,47 +117,47 @@ func TestCalculateSummary(t *testing.T) {
 		{
 			Id:             "1",
 			Name:           "Grocery",
-			Amount:         100.0,
+			Amount:         money.FromUnits(100),
 			TypeTransation: "expense",
 			CategoryId:     "cat_food",
 		},
 		{
 			Id:             "2",
 			Name:           "Restaurant",
-			Amount:         50.0,
+			Amount:         money.FromUnits(50),
 			TypeTransation: "expense",
 			CategoryId:     "cat_food",
 		},
 		{
 			Id:             "3",
 			Name:           "Salary",
-			Amount:         2000.0,
+			Amount:         money.FromUnits(2000),
 			TypeTransation: "income",
 			CategoryId:     "cat_salary",
 		},
 		{
 			Id:             "4",
 			Name:           "Freelance",
-			Amount:         500.0,
+			Amount:         money.FromUnits(500),
 			TypeTransation: "income",
 			CategoryId:     "cat_freelance",
 		},
 		{
 			Id:             "5",
 			Name:           "Gas",
-			Amount:         75.0,
+			Amount:         money.FromUnits(75),
 			TypeTransation: "expense",
 			CategoryId:     "cat_transport",
 		},
 	}
 
 	filteredCount := int64(10)
-	summary := CalculateSummary(transactions, filteredCount)
+	summary := CalculateSummary(transactions, filteredCount, nil)
 
 	// Test totals
-	assert.Equal(t, 2500.0, summary.TotalIncome)  // 2000 + 500
-	assert.Equal(t, 225.0, summary.TotalExpenses) // 100 + 50 + 75
-	assert.Equal(t, 2275.0, summary.NetAmount)    // 2500 - 225
+	assert.Equal(t, money.FromUnits(2500), summary.TotalIncome)  // 2000 + 500
+	assert.Equal(t, money.FromUnits(225), summary.TotalExpenses) // 100 + 50 + 75
+	assert.Equal(t, money.FromUnits(2275), summary.NetAmount)    // 2500 - 225
 
 	// Test counts
 	assert.Equal(t, 2, summary.IncomeCount)
@@ -162,12 +165,12 @@ func TestCalculateSummary(t *testing.T) {
 	assert.Equal(t, filteredCount, summary.FilteredRecords)
 
 	// Test averages
-	assert.Equal(t, 1250.0, summary.AverageIncome) // 2500 / 2
-	assert.Equal(t, 75.0, summary.AverageExpense)  // 225 / 3
+	assert.Equal(t, money.FromUnits(1250), summary.AverageIncome) // 2500 / 2
+	assert.Equal(t, money.FromUnits(75), summary.AverageExpense)  // 225 / 3
 
 	// Test largest amounts
-	assert.Equal(t, 2000.0, summary.LargestIncome)
-	assert.Equal(t, 100.0, summary.LargestExpense)
+	assert.Equal(t, money.FromUnits(2000), summary.LargestIncome)
+	assert.Equal(t, money.FromUnits(100), summary.LargestExpense)
 
 	// Test category breakdown
 	assert.Len(t, summary.CategoryBreakdown, 4)
@@ -175,33 +178,33 @@ func TestCalculateSummary(t *testing.T) {
 	// Check food category (2 transactions)
 	foodCategory := summary.CategoryBreakdown["cat_food"]
 	assert.Equal(t, "cat_food", foodCategory.CategoryId)
-	assert.Equal(t, 150.0, foodCategory.TotalAmount) // 100 + 50
+	assert.Equal(t, money.FromUnits(150), foodCategory.TotalAmount) // 100 + 50
 	assert.Equal(t, 2, foodCategory.Count)
-	assert.Equal(t, 75.0, foodCategory.AverageAmount) // 150 / 2
+	assert.Equal(t, money.FromUnits(75), foodCategory.AverageAmount) // 150 / 2
 
 	// Check salary category (1 transaction)
 	salaryCategory := summary.CategoryBreakdown["cat_salary"]
 	assert.Equal(t, "cat_salary", salaryCategory.CategoryId)
-	assert.Equal(t, 2000.0, salaryCategory.TotalAmount)
+	assert.Equal(t, money.FromUnits(2000), salaryCategory.TotalAmount)
 	assert.Equal(t, 1, salaryCategory.Count)
-	assert.Equal(t, 2000.0, salaryCategory.AverageAmount)
+	assert.Equal(t, money.FromUnits(2000), salaryCategory.AverageAmount)
 }
 
 func TestCalculateSummary_EmptyTransactions(t *testing.T) {
 	transactions := []*TransactionResponse{}
 	filteredCount := int64(0)
 
-	summary := CalculateSummary(transactions, filteredCount)
+	summary := CalculateSummary(transactions, filteredCount, nil)
 
-	assert.Equal(t, 0.0, summary.TotalIncome)
-	assert.Equal(t, 0.0, summary.TotalExpenses)
-	assert.Equal(t, 0.0, summary.NetAmount)
+	assert.Equal(t, money.Zero, summary.TotalIncome)
+	assert.Equal(t, money.Zero, summary.TotalExpenses)
+	assert.Equal(t, money.Zero, summary.NetAmount)
 	assert.Equal(t, 0, summary.IncomeCount)
 	assert.Equal(t, 0, summary.ExpenseCount)
-	assert.Equal(t, 0.0, summary.AverageIncome)
-	assert.Equal(t, 0.0, summary.AverageExpense)
-	assert.Equal(t, 0.0, summary.LargestIncome)
-	assert.Equal(t, 0.0, summary.LargestExpense)
+	assert.Equal(t, money.Zero, summary.AverageIncome)
+	assert.Equal(t, money.Zero, summary.AverageExpense)
+	assert.Equal(t, money.Zero, summary.LargestIncome)
+	assert.Equal(t, money.Zero, summary.LargestExpense)
 	assert.Equal(t, filteredCount, summary.FilteredRecords)
 	assert.Empty(t, summary.CategoryBreakdown)
 }
@@ -210,7 +213,7 @@ func TestNewPaginatedTransactionResponseWithSummary(t *testing.T) {
 	transactions := []*TransactionResponse{
 		{
 			Id:             "1",
-			Amount:         100.0,
+			Amount:         money.FromUnits(100),
 			TypeTransation: "expense",
 		},
 	}
@@ -222,9 +225,9 @@ func TestNewPaginatedTransactionResponseWithSummary(t *testing.T) {
 
 	totalRecords := int64(1)
 	summary := TransactionSummary{
-		TotalIncome:   0.0,
-		TotalExpenses: 100.0,
-		NetAmount:     -100.0,
+		TotalIncome:   money.Zero,
+		TotalExpenses: money.FromUnits(100),
+		NetAmount:     money.FromUnits(-100),
 		ExpenseCount:  1,
 	}
 
@@ -236,3 +239,125 @@ func TestNewPaginatedTransactionResponseWithSummary(t *testing.T) {
 	assert.Equal(t, 1, response.Pagination.CurrentPage)
 	assert.Equal(t, totalRecords, response.Pagination.TotalRecords)
 }
+
+func TestCalculateSummary_SplitTransactions(t *testing.T) {
+	transactions := []*TransactionResponse{
+		{
+			Id:             "1",
+			Name:           "Supermarket",
+			Amount:         money.FromUnits(100),
+			TypeTransation: "expense",
+			Splits: []*SplitResponse{
+				NewSplitResponse("s1", "cat_food", "", "", money.FromUnits(70)),
+				NewSplitResponse("s2", "cat_pharmacy", "", "", money.FromUnits(30)),
+			},
+		},
+		{
+			Id:             "2",
+			Name:           "Restaurant",
+			Amount:         money.FromUnits(50),
+			TypeTransation: "expense",
+			CategoryId:     "cat_food",
+		},
+	}
+
+	summary := CalculateSummary(transactions, 2, nil)
+
+	assert.Equal(t, money.FromUnits(150), summary.TotalExpenses)
+	assert.Equal(t, 2, summary.ExpenseCount)
+	assert.Len(t, summary.CategoryBreakdown, 2)
+	assert.Equal(t, money.FromUnits(120), summary.CategoryBreakdown["cat_food"].TotalAmount)
+	assert.Equal(t, 2, summary.CategoryBreakdown["cat_food"].Count)
+	assert.Equal(t, money.FromUnits(30), summary.CategoryBreakdown["cat_pharmacy"].TotalAmount)
+}
+
+func TestCalculateSummary_ExactCents(t *testing.T) {
+	var transactions []*TransactionResponse
+	for i := 0; i < 3; i++ {
+		transactions = append(transactions, &TransactionResponse{Amount: money.Money(10), TypeTransation: "income", CategoryId: "cat_interest"})
+	}
+	transactions = append(transactions, &TransactionResponse{Amount: money.Money(20), TypeTransation: "expense", CategoryId: "cat_fees"})
+
+	summary := CalculateSummary(transactions, 4, nil)
+
+	// 0.10 + 0.10 + 0.10 is 0.30 exactly, and 0.30 - 0.20 is 0.10
+	assert.Equal(t, "0.30", summary.TotalIncome.String())
+	assert.Equal(t, "0.10", summary.NetAmount.String())
+	assert.Equal(t, money.Money(10), summary.AverageIncome)
+}
+
+func TestCalculateSummary_MultiCurrency(t *testing.T) {
+	converter := fx.NewConverter("USD", []*fx.Rate{fx.NewRate("EUR", "USD", 1.5, time.Now(), "manual")})
+	transactions := []*TransactionResponse{
+		{Amount: money.FromUnits(100), TypeTransation: "income", Currency: "USD"},
+		{Amount: money.FromUnits(40), TypeTransation: "expense", Currency: "EUR", CategoryId: "cat_food"},
+		{Amount: money.FromUnits(500), TypeTransation: "expense", Currency: "MXN", CategoryId: "cat_food"},
+	}
+
+	summary := CalculateSummary(transactions, 3, converter)
+
+	assert.Equal(t, "USD", summary.Currency)
+	assert.Equal(t, money.FromUnits(100), summary.TotalIncome)
+	assert.Equal(t, money.FromUnits(60), summary.TotalExpenses)
+	assert.Equal(t, 1, summary.ExpenseCount)
+	assert.Equal(t, money.FromUnits(60), summary.CategoryBreakdown["cat_food"].TotalAmount)
+	assert.Equal(t, []string{"MXN"}, summary.MissingRates)
+	assert.Len(t, summary.ExpensesByCurrency, 2)
+	assert.Equal(t, "EUR", summary.ExpensesByCurrency[0].Currency)
+	assert.Equal(t, money.FromUnits(40), summary.ExpensesByCurrency[0].Amount)
+	assert.Equal(t, money.FromUnits(60), *summary.ExpensesByCurrency[0].Converted)
+	assert.Nil(t, summary.ExpensesByCurrency[1].Converted)
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	token := NewCursor(createdAt, "txn_1", CursorPrev).Encode()
+
+	cursor, err := DecodeCursor(token)
+
+	assert.NoError(t, err)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, "txn_1", cursor.Id)
+	assert.Equal(t, CursorPrev, cursor.Direction)
+
+	for _, invalid := range []string{"!!!", "bm90IGpzb24", NewCursor(createdAt, "", CursorNext).Encode(), NewCursor(createdAt, "txn_1", "up").Encode()} {
+		_, err := DecodeCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewPaginatedTransactionResponse_Cursors(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	transactions := []*TransactionResponse{
+		{Id: "txn_3", CreatedAt: createdAt},
+		{Id: "txn_2", CreatedAt: createdAt.Add(-time.Hour)},
+	}
+	filter := NewTransactionFilter()
+	filter.Limit = 2
+
+	// Offset pages hand out cursors so clients can switch to keyset paging
+	response := NewPaginatedTransactionResponse(transactions, filter, 5)
+	assert.Empty(t, response.Pagination.PrevCursor)
+	next, err := DecodeCursor(response.Pagination.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_2", next.Id)
+	assert.Equal(t, CursorNext, next.Direction)
+
+	// Cursor pages take their flags from the rows around the page instead of the page number
+	filter.DecodedCursor = NewCursor(createdAt.Add(time.Hour), "txn_4", CursorNext)
+	response = NewPaginatedTransactionResponse(transactions, filter, 5)
+	response.Pagination.SetCursors(transactions, filter, false, true)
+	assert.False(t, response.Pagination.HasNextPage)
+	assert.True(t, response.Pagination.HasPrevPage)
+	assert.Nil(t, response.Pagination.NextPage)
+	assert.Empty(t, response.Pagination.NextCursor)
+	prev, err := DecodeCursor(response.Pagination.PrevCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_3", prev.Id)
+
+	// Other sort orders have no cursors
+	filter.DecodedCursor = nil
+	filter.SortBy = "amount"
+	response = NewPaginatedTransactionResponse(transactions, filter, 5)
+	assert.Empty(t, response.Pagination.NextCursor)
+}
@@ -0,0 +1,6 @@
+package dto
+
+// RefundRequest names the bill an income transaction gives money back for.
+type RefundRequest struct {
+	RefundOf string `json:"refund_of" binding:"required" example:"2bX8sYQd7f0Hk3pL9mN4qR6tV1w"`
+}
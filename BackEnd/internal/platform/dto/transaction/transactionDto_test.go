@@ -3,6 +3,7 @@ package dto
 import (
 	"testing"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -31,3 +32,37 @@ func TestTransactionResponse(t *testing.T) {
 	assert.Equal(t, transaction.Amount, transactionResponse.Amount)
 	assert.Equal(t, transaction.CreatedAt, transactionResponse.CreatedAt)
 }
+
+func TestTransferRequestValidate(t *testing.T) {
+	assert.NoError(t, NewTransferRequest("acc_1", "acc_2", "Savings", "", money.FromUnits(100)).Validate())
+	assert.Error(t, NewTransferRequest("acc_1", "acc_1", "Savings", "", money.FromUnits(100)).Validate())
+	assert.Error(t, NewTransferRequest("acc_1", "acc_2", "Savings", "", money.FromUnits(0)).Validate())
+	assert.Error(t, NewTransferRequest("", "acc_2", "Savings", "", money.FromUnits(10)).Validate())
+}
+
+func TestTransactionRequestValidateSplits(t *testing.T) {
+	request := NewTransactionRequest("Supermarket", "", "bill", "acc_1", "", "", money.FromUnits(100))
+	assert.Error(t, request.Validate(), "category or splits are required")
+
+	request.Splits = []SplitRequest{
+		{CategoryId: "cat_food", Amount: money.FromUnits(70)},
+		{CategoryId: "cat_pharmacy", Amount: money.FromUnits(30)},
+	}
+	assert.NoError(t, request.Validate())
+
+	request.Splits[1].Amount = money.FromUnits(20)
+	assert.Error(t, request.Validate(), "splits must add up to the amount")
+
+	// Thirds of 100.00 must add up to the cent
+	request.Splits = []SplitRequest{
+		{CategoryId: "cat_food", Amount: money.Money(3333)},
+		{CategoryId: "cat_pharmacy", Amount: money.Money(3333)},
+		{CategoryId: "cat_home", Amount: money.Money(3333)},
+	}
+	assert.Error(t, request.Validate(), "99.99 is not 100.00")
+	request.Splits[2].Amount = money.Money(3334)
+	assert.NoError(t, request.Validate())
+
+	request.Splits = []SplitRequest{{CategoryId: "cat_food", Amount: money.FromUnits(100)}}
+	assert.Error(t, request.Validate(), "a single split is not a split")
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+// TransactionExportRow is a transaction as written by the export, with account and category names resolved
+type TransactionExportRow struct {
+	Id             string            `json:"id"`
+	Date           time.Time         `json:"date"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	Amount         money.Money       `json:"amount"`
+	TypeTransation string            `json:"type_transation"`
+	AccountId      string            `json:"account_id"`
+	AccountName    string            `json:"account_name"`
+	CategoryId     string            `json:"category_id,omitempty"`
+	CategoryName   string            `json:"category_name,omitempty"`
+	TransferId     string            `json:"transfer_id,omitempty"`
+	ExternalId     string            `json:"external_id,omitempty"`
+	Splits         []*SplitExportRow `json:"splits,omitempty"`
+}
+
+// SplitExportRow is one category line of an exported split transaction
+type SplitExportRow struct {
+	CategoryId   string      `json:"category_id"`
+	CategoryName string      `json:"category_name"`
+	Amount       money.Money `json:"amount"`
+	Description  string      `json:"description,omitempty"`
+}
@@ -2,11 +2,21 @@ package dto
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+)
+
+// Tag filter modes
+const (
+	TagsModeAny = "any"
+	TagsModeAll = "all"
 )
 
 // TransactionFilter represents the filtering and pagination parameters for transaction queries
@@ -16,12 +26,16 @@ type TransactionFilter struct {
 	Limit  int `json:"limit" example:"20"`
 	Offset int `json:"offset" example:"0"`
 
+	// Keyset pagination, takes the place of page and offset when set
+	Cursor        string  `json:"cursor,omitempty" example:"eyJ0IjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpZCI6InR4bl8xIiwiZCI6Im5leHQifQ"`
+	DecodedCursor *Cursor `json:"-"`
+
 	// Sorting parameters
 	SortBy    string `json:"sort_by" example:"created_at"`
 	SortOrder string `json:"sort_order" example:"desc" enums:"asc,desc"`
 
 	// Type filter
-	Type string `json:"type" example:"income" enums:"income,bill,all"`
+	Type string `json:"type" example:"income" enums:"income,bill,transfer,all"`
 
 	// Category filters
 	CategoryId string   `json:"category_id" example:"cat_123456789"`
@@ -33,18 +47,28 @@ type TransactionFilter struct {
 	// Budget filter
 	BudgetId string `json:"budget_id" example:"budget_555666777"`
 
+	// Payee filter
+	PayeeId string `json:"payee_id" example:"payee_123456789"`
+
+	// Status filter
+	Status string `json:"status" example:"cleared" enums:"pending,cleared,reconciled"`
+
 	// Date filters
 	DateFrom string `json:"date_from" example:"2024/01/01"`
 	DateTo   string `json:"date_to" example:"2024/12/31"`
 	Period   string `json:"period" example:"this_month" enums:"today,this_week,this_month,this_year,last_week,last_month,last_year"`
 
 	// Amount filters
-	AmountMin *float64 `json:"amount_min" example:"0.00"`
-	AmountMax *float64 `json:"amount_max" example:"1000.00"`
+	AmountMin *money.Money `json:"amount_min" example:"0.00"`
+	AmountMax *money.Money `json:"amount_max" example:"1000.00"`
 
 	// Search filter
 	Search string `json:"search" example:"grocery"`
 
+	// Tag filters, "any" matches transactions with at least one of the tags and "all" those with every tag
+	Tags     []string `json:"tags" example:"vacation 2026,reimbursable"`
+	TagsMode string   `json:"tags_mode" example:"any" enums:"any,all"`
+
 	// Internal fields for calculated values
 	CalculatedDateFrom time.Time `json:"-"`
 	CalculatedDateTo   time.Time `json:"-"`
@@ -59,37 +83,52 @@ func NewTransactionFilter() *TransactionFilter {
 		SortBy:    "created_at",
 		SortOrder: "desc",
 		Type:      "all",
+		TagsMode:  TagsModeAny,
 	}
 }
 
 // ParseFromQuery parses query parameters from gin.Context and populates the TransactionFilter
 func (f *TransactionFilter) ParseFromQuery(ctx *gin.Context) error {
+	return f.ParseFromValues(ctx.Request.URL.Query())
+}
+
+// ParseFromValues populates the TransactionFilter from query parameters, such as the expanded query of a saved view
+func (f *TransactionFilter) ParseFromValues(values url.Values) error {
 	// Parse pagination parameters
-	if pageStr := ctx.Query("page"); pageStr != "" {
+	if pageStr := values.Get("page"); pageStr != "" {
 		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
 			f.Page = page
 		}
 	}
 
-	if limitStr := ctx.Query("limit"); limitStr != "" {
+	if limitStr := values.Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
 			f.Limit = limit
 		}
 	}
 
-	if offsetStr := ctx.Query("offset"); offsetStr != "" {
+	if offsetStr := values.Get("offset"); offsetStr != "" {
 		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
 			f.Offset = offset
 		}
 	}
 
+	if cursor := values.Get("cursor"); cursor != "" {
+		decoded, err := DecodeCursor(cursor)
+		if err != nil {
+			return err
+		}
+		f.Cursor = cursor
+		f.DecodedCursor = decoded
+	}
+
 	// Calculate offset from page if not provided
 	if f.Offset == 0 && f.Page > 1 {
 		f.Offset = (f.Page - 1) * f.Limit
 	}
 
 	// Parse sorting parameters
-	if sortBy := ctx.Query("sort_by"); sortBy != "" {
+	if sortBy := values.Get("sort_by"); sortBy != "" {
 		// Validate allowed sort fields
 		allowedSortFields := []string{"created_at", "amount", "name", "type_transation"}
 		for _, field := range allowedSortFields {
@@ -100,22 +139,22 @@ func (f *TransactionFilter) ParseFromQuery(ctx *gin.Context) error {
 		}
 	}
 
-	if sortOrder := ctx.Query("sort_order"); sortOrder != "" {
+	if sortOrder := values.Get("sort_order"); sortOrder != "" {
 		if sortOrder == "asc" || sortOrder == "desc" {
 			f.SortOrder = sortOrder
 		}
 	}
 
 	// Parse type filter
-	if typeFilter := ctx.Query("type"); typeFilter != "" {
-		if typeFilter == "income" || typeFilter == "bill" || typeFilter == "all" {
+	if typeFilter := values.Get("type"); typeFilter != "" {
+		if typeFilter == "income" || typeFilter == "bill" || typeFilter == "transfer" || typeFilter == "all" {
 			f.Type = typeFilter
 		}
 	}
 
 	// Parse category filters
-	f.CategoryId = ctx.Query("category_id")
-	if categoriesStr := ctx.Query("categories"); categoriesStr != "" {
+	f.CategoryId = values.Get("category_id")
+	if categoriesStr := values.Get("categories"); categoriesStr != "" {
 		f.Categories = strings.Split(categoriesStr, ",")
 		// Clean up categories
 		for i := range f.Categories {
@@ -124,31 +163,45 @@ func (f *TransactionFilter) ParseFromQuery(ctx *gin.Context) error {
 	}
 
 	// Parse account filter
-	f.AccountId = ctx.Query("account_id")
+	f.AccountId = values.Get("account_id")
 
 	// Parse budget filter
-	f.BudgetId = ctx.Query("budget_id")
+	f.BudgetId = values.Get("budget_id")
+
+	// Parse payee filter
+	f.PayeeId = values.Get("payee_id")
+
+	// Parse status filter
+	f.Status = values.Get("status")
 
 	// Parse date filters
-	f.DateFrom = ctx.Query("date_from")
-	f.DateTo = ctx.Query("date_to")
-	f.Period = ctx.Query("period")
+	f.DateFrom = values.Get("date_from")
+	f.DateTo = values.Get("date_to")
+	f.Period = values.Get("period")
 
 	// Parse amount filters
-	if amountMinStr := ctx.Query("amount_min"); amountMinStr != "" {
-		if amountMin, err := strconv.ParseFloat(amountMinStr, 64); err == nil {
+	if amountMinStr := values.Get("amount_min"); amountMinStr != "" {
+		if amountMin, err := money.Parse(amountMinStr); err == nil {
 			f.AmountMin = &amountMin
 		}
 	}
 
-	if amountMaxStr := ctx.Query("amount_max"); amountMaxStr != "" {
-		if amountMax, err := strconv.ParseFloat(amountMaxStr, 64); err == nil {
+	if amountMaxStr := values.Get("amount_max"); amountMaxStr != "" {
+		if amountMax, err := money.Parse(amountMaxStr); err == nil {
 			f.AmountMax = &amountMax
 		}
 	}
 
 	// Parse search filter
-	f.Search = strings.TrimSpace(ctx.Query("search"))
+	f.Search = strings.TrimSpace(values.Get("search"))
+
+	// Parse tag filters
+	if tagsStr := values.Get("tags"); tagsStr != "" {
+		f.Tags = tag.NormalizeNames(strings.Split(tagsStr, ","))
+	}
+	if tagsMode := values.Get("tags_mode"); tagsMode != "" {
+		f.TagsMode = tagsMode
+	}
 
 	// Process date calculations
 	if err := f.calculateDates(); err != nil {
@@ -262,6 +315,44 @@ func (f *TransactionFilter) Validate() error {
 		return fmt.Errorf("page must be positive")
 	}
 
+	if f.DecodedCursor != nil && !f.SortsByCreatedAt() {
+		return fmt.Errorf("cursor pagination requires sort_by=created_at")
+	}
+
+	return f.validateCriteria()
+}
+
+// PrepareSelection completes a filter decoded from a request body so it selects every matching transaction rather
+// than a page of them, the way bulk operations use it.
+func (f *TransactionFilter) PrepareSelection() error {
+	f.Page, f.Limit, f.Offset = 1, 0, 0
+	f.Cursor, f.DecodedCursor = "", nil
+	f.SortBy, f.SortOrder = "created_at", "desc"
+	if f.Type == "" {
+		f.Type = "all"
+	}
+	if f.TagsMode == "" {
+		f.TagsMode = TagsModeAny
+	}
+	f.Tags = tag.NormalizeNames(f.Tags)
+	if err := f.calculateDates(); err != nil {
+		return err
+	}
+	return f.validateCriteria()
+}
+
+// validateCriteria validates the filter parameters that select transactions, leaving out pagination.
+func (f *TransactionFilter) validateCriteria() error {
+	switch f.Status {
+	case "", transaction.StatusPending, transaction.StatusCleared, transaction.StatusReconciled:
+	default:
+		return fmt.Errorf("status must be 'pending', 'cleared' or 'reconciled'")
+	}
+
+	if f.TagsMode != "" && f.TagsMode != TagsModeAny && f.TagsMode != TagsModeAll {
+		return fmt.Errorf("tags_mode must be 'any' or 'all'")
+	}
+
 	if f.AmountMin != nil && f.AmountMax != nil && *f.AmountMin > *f.AmountMax {
 		return fmt.Errorf("amount_min cannot be greater than amount_max")
 	}
@@ -280,7 +371,15 @@ func (f *TransactionFilter) HasFilters() bool {
 		len(f.Categories) > 0 ||
 		f.AccountId != "" ||
 		f.BudgetId != "" ||
+		f.PayeeId != "" ||
+		f.Status != "" ||
 		f.AmountMin != nil ||
 		f.AmountMax != nil ||
-		f.Search != ""
+		f.Search != "" ||
+		len(f.Tags) > 0
+}
+
+// SortsByCreatedAt returns true if the listing is ordered by (created_at, id), the order cursors walk through.
+func (f *TransactionFilter) SortsByCreatedAt() bool {
+	return f.SortBy == "" || f.SortBy == "created_at"
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 )
 
 func TestNewTransactionFilter(t *testing.T) {
@@ -81,8 +83,8 @@ func TestTransactionFilter_ParseFromQuery(t *testing.T) {
 			},
 			expectedFilter: func() *TransactionFilter {
 				f := NewTransactionFilter()
-				min := 100.50
-				max := 999.99
+				min := money.Money(10050)
+				max := money.Money(99999)
 				f.AmountMin = &min
 				f.AmountMax = &max
 				return f
@@ -134,6 +136,26 @@ func TestTransactionFilter_ParseFromQuery(t *testing.T) {
 				return f
 			},
 		},
+		{
+			name: "tag filters",
+			queryParams: map[string]string{
+				"tags":      " Vacation  2026,reimbursable,,vacation 2026",
+				"tags_mode": "all",
+			},
+			expectedFilter: func() *TransactionFilter {
+				f := NewTransactionFilter()
+				f.Tags = []string{"vacation 2026", "reimbursable"}
+				f.TagsMode = TagsModeAll
+				return f
+			},
+		},
+		{
+			name: "invalid cursor",
+			queryParams: map[string]string{
+				"cursor": "not-a-cursor",
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid sort field - should use default",
 			queryParams: map[string]string{
@@ -183,6 +205,8 @@ func TestTransactionFilter_ParseFromQuery(t *testing.T) {
 				assert.Equal(t, expected.BudgetId, filter.BudgetId)
 				assert.Equal(t, expected.Search, filter.Search)
 				assert.Equal(t, expected.Categories, filter.Categories)
+				assert.Equal(t, expected.Tags, filter.Tags)
+				assert.Equal(t, expected.TagsMode, filter.TagsMode)
 
 				// Compare amount filters
 				if expected.AmountMin != nil {
@@ -256,8 +280,8 @@ func TestTransactionFilter_Validate(t *testing.T) {
 			name: "invalid amount range",
 			filter: func() *TransactionFilter {
 				f := NewTransactionFilter()
-				min := 100.0
-				max := 50.0
+				min := money.FromUnits(100)
+				max := money.FromUnits(50)
 				f.AmountMin = &min
 				f.AmountMax = &max
 				return f
@@ -265,6 +289,27 @@ func TestTransactionFilter_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "amount_min cannot be greater than amount_max",
 		},
+		{
+			name: "invalid tags mode",
+			filter: func() *TransactionFilter {
+				f := NewTransactionFilter()
+				f.TagsMode = "some"
+				return f
+			}(),
+			expectError: true,
+			errorMsg:    "tags_mode must be 'any' or 'all'",
+		},
+		{
+			name: "cursor with another sort field",
+			filter: func() *TransactionFilter {
+				f := NewTransactionFilter()
+				f.SortBy = "amount"
+				f.DecodedCursor = NewCursor(time.Now(), "txn_1", CursorNext)
+				return f
+			}(),
+			expectError: true,
+			errorMsg:    "cursor pagination requires sort_by=created_at",
+		},
 	}
 
 	for _, tt := range tests {
@@ -401,11 +446,20 @@ func TestTransactionFilter_HasFilters(t *testing.T) {
 			},
 			expectedResult: true,
 		},
+		{
+			name: "has tag filter",
+			filter: func() *TransactionFilter {
+				f := NewTransactionFilter()
+				f.Tags = []string{"reimbursable"}
+				return f
+			}(),
+			expectedResult: true,
+		},
 		{
 			name: "has amount filter",
 			filter: func() *TransactionFilter {
 				f := NewTransactionFilter()
-				min := 100.0
+				min := money.FromUnits(100)
 				f.AmountMin = &min
 				return f
 			}(),
@@ -2,21 +2,35 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 )
 
 type TransactionRequest struct {
-	Name           string    `json:"name" validate:"required" binding:"required" example:"Grocery Shopping"`
-	Description    string    `json:"description" example:"Weekly grocery shopping at Walmart"`
-	Amount         float64   `json:"amount" validate:"required" binding:"required,gt=0" example:"125.50"`
-	TypeTransation string    `json:"type_transation" validate:"required" binding:"required" example:"expense" enums:"income,expense"`
-	AccountId      string    `json:"account_id" validate:"required" binding:"required" example:"acc_123456789"`
-	CategoryId     string    `json:"category_id" validate:"required" binding:"required" example:"cat_987654321"`
-	BudgetId       string    `json:"budget_id" example:"budget_555666777"`
-	CreatedAt      time.Time `json:"created_at" example:"2023-01-01T15:04:05Z"`
+	Name           string         `json:"name" validate:"required" binding:"required" example:"Grocery Shopping"`
+	Description    string         `json:"description" example:"Weekly grocery shopping at Walmart"`
+	Amount         money.Money    `json:"amount" validate:"required" binding:"required,gt=0" example:"125.50"`
+	TypeTransation string         `json:"type_transation" validate:"required" binding:"required" example:"expense" enums:"income,expense"`
+	AccountId      string         `json:"account_id" validate:"required" binding:"required" example:"acc_123456789"`
+	CategoryId     string         `json:"category_id" example:"cat_987654321"`
+	BudgetId       string         `json:"budget_id" example:"budget_555666777"`
+	Splits         []SplitRequest `json:"splits"`
+	Tags           []string       `json:"tags" example:"vacation 2026,reimbursable"`
+	CreatedAt      time.Time      `json:"created_at" example:"2023-01-01T15:04:05Z"`
+}
+
+// SplitRequest is one category line of a split transaction. Amounts are positive, like the parent amount.
+type SplitRequest struct {
+	CategoryId  string      `json:"category_id" binding:"required" example:"cat_987654321"`
+	Amount      money.Money `json:"amount" binding:"required,gt=0" example:"40.00"`
+	Description string      `json:"description" example:"Pharmacy"`
 }
 
-func NewTransactionRequest(Name, Description, TypeTransation, AccountId, categoryId, budgetId string, Amount float64) *TransactionRequest {
+func NewTransactionRequest(Name, Description, TypeTransation, AccountId, categoryId, budgetId string, Amount money.Money) *TransactionRequest {
 	return &TransactionRequest{
 		Name:           Name,
 		Description:    Description,
@@ -35,5 +49,67 @@ func (t *TransactionRequest) Validate() error {
 	if t.TypeTransation != "income" && t.TypeTransation != "expense" && t.TypeTransation != "bill" {
 		return errors.New("type_transation must be 'income', 'expense' or 'bill'")
 	}
+	if t.CategoryId == "" && len(t.Splits) == 0 {
+		return errors.New("category_id is required unless splits are provided")
+	}
+	if err := t.ValidateTags(); err != nil {
+		return err
+	}
+	return t.ValidateSplits()
+}
+
+// StatusRequest marks a transaction as pending or cleared. Transactions only become reconciled by completing a
+// reconciliation.
+type StatusRequest struct {
+	Status string `json:"status" binding:"required" example:"cleared" enums:"pending,cleared"`
+}
+
+func (r *StatusRequest) Validate() error {
+	if r.Status != transaction.StatusPending && r.Status != transaction.StatusCleared {
+		return errors.New("status must be 'pending' or 'cleared'")
+	}
+	return nil
+}
+
+// ValidateTags normalizes the tag names and checks their length and count. A nil list is kept as is, so an
+// update can tell "leave the tags alone" apart from "remove every tag".
+func (t *TransactionRequest) ValidateTags() error {
+	if t.Tags == nil {
+		return nil
+	}
+	t.Tags = tag.NormalizeNames(t.Tags)
+	if len(t.Tags) > tag.MaxPerTransaction {
+		return fmt.Errorf("a transaction cannot have more than %d tags", tag.MaxPerTransaction)
+	}
+	for _, name := range t.Tags {
+		if err := tag.ValidateName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSplits checks that every split line has a category and that the lines add up to the total amount.
+func (t *TransactionRequest) ValidateSplits() error {
+	if len(t.Splits) == 0 {
+		return nil
+	}
+	if len(t.Splits) < 2 {
+		return errors.New("a split transaction needs at least two splits")
+	}
+
+	var total money.Money
+	for _, split := range t.Splits {
+		if split.CategoryId == "" {
+			return errors.New("every split requires a category_id")
+		}
+		if split.Amount <= 0 {
+			return errors.New("split amounts must be greater than 0")
+		}
+		total += split.Amount
+	}
+	if total != t.Amount {
+		return errors.New("splits must add up to the transaction amount")
+	}
 	return nil
 }
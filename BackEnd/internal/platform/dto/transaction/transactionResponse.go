@@ -1,20 +1,42 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
 
 type TransactionResponse struct {
-	Id             string    `json:"id"`
-	Name           string    `json:"name" validate:"required"`
-	Description    string    `json:"description"`
-	Amount         float64   `json:"amount" validate:"required"`
-	TypeTransation string    `json:"type_transation" validator:"required"`
-	AccountId      string    `json:"account_id"`
-	CategoryId     string    `json:"category_id"`
-	BudgetId       string    `json:"budget_id"`
-	CreatedAt      time.Time `json:"created_at"`
+	Id             string           `json:"id"`
+	Name           string           `json:"name" validate:"required"`
+	Description    string           `json:"description"`
+	Amount         money.Money      `json:"amount" validate:"required"`
+	Currency       string           `json:"currency,omitempty" example:"EUR"`
+	TypeTransation string           `json:"type_transation" validator:"required"`
+	AccountId      string           `json:"account_id"`
+	CategoryId     string           `json:"category_id"`
+	BudgetId       string           `json:"budget_id"`
+	TransferId     string           `json:"transfer_id,omitempty"`
+	ExternalId     string           `json:"external_id,omitempty"`
+	PayeeId        string           `json:"payee_id,omitempty"`
+	RefundOf       string           `json:"refund_of,omitempty"`
+	RefundedBy     []string         `json:"refunded_by,omitempty"`
+	Status         string           `json:"status,omitempty" example:"cleared" enums:"pending,cleared,reconciled"`
+	Splits         []*SplitResponse `json:"splits,omitempty"`
+	Tags           []string         `json:"tags,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// SplitResponse is one category line of a split transaction
+type SplitResponse struct {
+	Id          string      `json:"id"`
+	CategoryId  string      `json:"category_id"`
+	BudgetId    string      `json:"budget_id"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description"`
 }
 
-func NewTransactionResponse(Id, Name, Description, TypeTransation, AccountId, categoryId string, Amount float64, createdAt time.Time) *TransactionResponse {
+func NewTransactionResponse(Id, Name, Description, TypeTransation, AccountId, categoryId string, Amount money.Money, createdAt time.Time) *TransactionResponse {
 	return &TransactionResponse{
 		Id:             Id,
 		Name:           Name,
@@ -26,3 +48,13 @@ func NewTransactionResponse(Id, Name, Description, TypeTransation, AccountId, ca
 		CreatedAt:      createdAt,
 	}
 }
+
+func NewSplitResponse(Id, categoryId, budgetId, description string, Amount money.Money) *SplitResponse {
+	return &SplitResponse{
+		Id:          Id,
+		CategoryId:  categoryId,
+		BudgetId:    budgetId,
+		Amount:      Amount,
+		Description: description,
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+type TransferRequest struct {
+	FromAccountId string      `json:"from_account_id" binding:"required" example:"acc_123456789"`
+	ToAccountId   string      `json:"to_account_id" binding:"required" example:"acc_987654321"`
+	Amount        money.Money `json:"amount" binding:"required,gt=0" example:"250.00"`
+	Name          string      `json:"name" example:"Monthly savings"`
+	Description   string      `json:"description" example:"Move surplus to savings"`
+	CreatedAt     time.Time   `json:"created_at" example:"2023-01-01T15:04:05Z"`
+}
+
+func NewTransferRequest(fromAccountId, toAccountId, name, description string, amount money.Money) *TransferRequest {
+	return &TransferRequest{
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        amount,
+		Name:          name,
+		Description:   description,
+	}
+}
+
+func (t *TransferRequest) Validate() error {
+	if t.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+	if t.FromAccountId == "" || t.ToAccountId == "" {
+		return errors.New("from_account_id and to_account_id are required")
+	}
+	if t.FromAccountId == t.ToAccountId {
+		return errors.New("from_account_id and to_account_id must be different")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package dto
+
+// TransferResponse groups the two legs of an account-to-account transfer
+type TransferResponse struct {
+	TransferId string               `json:"transfer_id" example:"2ZooXQ6iVZgoX7pM9HOh0LYch5J"`
+	Outgoing   *TransactionResponse `json:"outgoing"`
+	Incoming   *TransactionResponse `json:"incoming"`
+}
+
+func NewTransferResponse(transferId string, outgoing, incoming *TransactionResponse) *TransferResponse {
+	return &TransferResponse{
+		TransferId: transferId,
+		Outgoing:   outgoing,
+		Incoming:   incoming,
+	}
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// TrashItemResponse is one deleted record waiting in the trash. Record holds the record as it was when deleted.
+type TrashItemResponse struct {
+	Id         string    `json:"id"`
+	EntityType string    `json:"entity_type" example:"transaction"`
+	Name       string    `json:"name"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	Record     any       `json:"record" swaggertype:"object"`
+}
@@ -27,3 +27,8 @@ func (u *UserRequest) Validate() error {
 	// e.g., blacklisted emails, common passwords, etc.
 	return nil
 }
+
+// BaseCurrencyRequest changes the currency the reports of a user are converted into.
+type BaseCurrencyRequest struct {
+	BaseCurrency string `json:"base_currency" binding:"required" example:"EUR"`
+}
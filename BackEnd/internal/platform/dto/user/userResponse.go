@@ -9,6 +9,8 @@ type UserResponse struct {
 	LastName  string    `json:"last_name"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
+	// BaseCurrency is the currency reports are converted into
+	BaseCurrency string `json:"base_currency,omitempty"`
 }
 
 func NewUserResponse(id, name, lastName, email, role string, createdAt time.Time) *UserResponse {
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+	transactionDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+)
+
+// ViewParam is the query parameter of the transaction listing that expands a saved view.
+const ViewParam = "view_id"
+
+// queryKeys are the listing parameters a view can save. Pagination is left to the request that uses the view.
+var queryKeys = map[string]bool{
+	"type": true, "category_id": true, "categories": true, "account_id": true, "budget_id": true, "payee_id": true,
+	"status": true, "date_from": true, "date_to": true, "period": true, "amount_min": true, "amount_max": true,
+	"search": true, "tags": true, "tags_mode": true, "sort_by": true, "sort_order": true, "limit": true,
+}
+
+type ViewRequest struct {
+	Name  string `json:"name" binding:"required" example:"Food and transport this month"`
+	Query string `json:"query" example:"period=this_month&categories=cat_food,cat_transport&amount_min=50"`
+}
+
+// Validate trims the name and checks the query, which is stored in a canonical form.
+func (r *ViewRequest) Validate() error {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	if err := view.ValidateName(r.Name); err != nil {
+		return err
+	}
+	query, err := NormalizeQuery(r.Query)
+	if err != nil {
+		return err
+	}
+	r.Query = query
+	return nil
+}
+
+// NormalizeQuery checks that a query string only holds filter parameters the transaction listing accepts and
+// returns it with its parameters in a stable order. A leading "?" is ignored.
+func NormalizeQuery(raw string) (string, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(raw), "?"))
+	if err != nil {
+		return "", errors.New("query is not a valid query string")
+	}
+	for key, value := range values {
+		if !queryKeys[key] {
+			return "", fmt.Errorf("%s cannot be saved in a view", key)
+		}
+		if len(value) > 1 {
+			return "", fmt.Errorf("%s is given more than once", key)
+		}
+	}
+
+	filter := transactionDto.NewTransactionFilter()
+	if err := filter.ParseFromValues(values); err != nil {
+		return "", err
+	}
+	if err := filter.Validate(); err != nil {
+		return "", err
+	}
+	return values.Encode(), nil
+}
+
+// ExpandQuery returns the parameters of a saved query with those of the request on top, so a request can still
+// page through a view or narrow it down.
+func ExpandQuery(saved string, request url.Values) (url.Values, error) {
+	values, err := url.ParseQuery(saved)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range request {
+		if key != ViewParam {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
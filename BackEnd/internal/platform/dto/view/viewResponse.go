@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+)
+
+type ViewResponse struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query" example:"amount_min=50&categories=cat_food%2Ccat_transport&period=this_month"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewViewResponse(view *view.View) *ViewResponse {
+	return &ViewResponse{
+		Id:        view.Id,
+		Name:      view.Name,
+		Query:     view.Query,
+		CreatedAt: view.CreatedAt,
+	}
+}
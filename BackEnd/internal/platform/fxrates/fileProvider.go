@@ -0,0 +1,74 @@
+package fxrates
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+)
+
+// FileProvider reads rates from a CSV file with the columns pair,rate and an optional date:
+//
+//	pair,rate,date
+//	EUR/USD,1.0845,2024-01-15
+//	USD/MXN,17.05
+//
+// The file is read on every fetch, so it can be replaced while the server runs.
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+func (p *FileProvider) FetchRates(ctx context.Context) ([]*fx.Rate, error) {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open exchange rate file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	var rates []*fx.Rate
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read exchange rate file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "pair") {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected pair,rate[,date]", line)
+		}
+
+		date := ""
+		if len(record) > 2 {
+			date = record[2]
+		}
+		rate, err := parseRate(record[0], record[1], date, p.Name())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
@@ -0,0 +1,72 @@
+// Package fxrates feeds exchange rates into the rate table. Providers are pluggable; the bundled ones read
+// rates from the configuration or from a local file, so the backend works without network access.
+package fxrates
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/config"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+)
+
+// Provider returns the current exchange rates of the pairs it knows about.
+type Provider interface {
+	// Name is stored as the source of every rate the provider returns
+	Name() string
+	FetchRates(ctx context.Context) ([]*fx.Rate, error)
+}
+
+// NewProvider creates the provider selected by the configuration.
+func NewProvider(cfg config.FXConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", "manual":
+		return NewManualProvider(cfg.ManualRates)
+	case "file":
+		return NewFileProvider(cfg.RatesFile), nil
+	default:
+		return nil, fmt.Errorf("unsupported FX provider: %s", cfg.Provider)
+	}
+}
+
+// parseRate reads one "BASE/QUOTE" pair and its rate. An empty date means today.
+func parseRate(pair, value, date, source string) (*fx.Rate, error) {
+	codes := strings.Split(pair, "/")
+	if len(codes) != 2 {
+		return nil, fmt.Errorf("invalid currency pair %q, expected BASE/QUOTE", pair)
+	}
+	base, ok := fx.NormalizeCurrency(codes[0])
+	if !ok {
+		return nil, fmt.Errorf("invalid currency %q", codes[0])
+	}
+	quote, ok := fx.NormalizeCurrency(codes[1])
+	if !ok {
+		return nil, fmt.Errorf("invalid currency %q", codes[1])
+	}
+	if base == quote {
+		return nil, fmt.Errorf("currency pair %q converts a currency into itself", pair)
+	}
+
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || rate <= 0 {
+		return nil, fmt.Errorf("invalid rate %q for %s/%s", value, base, quote)
+	}
+
+	day := today()
+	if date = strings.TrimSpace(date); date != "" {
+		day, err = time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s/%s, expected YYYY-MM-DD", date, base, quote)
+		}
+	}
+
+	return fx.NewRate(base, quote, rate, day, source), nil
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
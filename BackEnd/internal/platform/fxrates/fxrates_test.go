@@ -0,0 +1,56 @@
+package fxrates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualProvider(t *testing.T) {
+	provider, err := NewProvider(config.FXConfig{Provider: "manual", ManualRates: "eur/usd=1.08, USD/MXN=17.05"})
+	require.NoError(t, err)
+
+	rates, err := provider.FetchRates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "EUR", rates[0].Base)
+	assert.Equal(t, "USD", rates[0].Quote)
+	assert.Equal(t, 1.08, rates[0].Rate)
+	assert.Equal(t, today(), rates[0].Date)
+	assert.Equal(t, "manual", rates[1].Source)
+
+	for _, invalid := range []string{"EUR/USD", "EUR=1.1", "EUR/USD=abc", "EUR/USD=-1", "EUR/EUR=1", "EURO/USD=1"} {
+		_, err := NewManualProvider(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	content := "pair,rate,date\n# ECB reference rates\nEUR/USD,1.0845,2024-01-15\nUSD/MXN,17.05\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	provider, err := NewProvider(config.FXConfig{Provider: "file", RatesFile: path})
+	require.NoError(t, err)
+
+	rates, err := provider.FetchRates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), rates[0].Date)
+	assert.Equal(t, 1.0845, rates[0].Rate)
+	assert.Equal(t, today(), rates[1].Date)
+	assert.Equal(t, "file", rates[1].Source)
+
+	require.NoError(t, os.WriteFile(path, []byte("EUR/USD,1.08\nUSD/MXN,oops\n"), 0o600))
+	_, err = provider.FetchRates(context.Background())
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = NewFileProvider(filepath.Join(t.TempDir(), "missing.csv")).FetchRates(context.Background())
+	assert.Error(t, err)
+}
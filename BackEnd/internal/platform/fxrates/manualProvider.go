@@ -0,0 +1,49 @@
+package fxrates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+)
+
+// ManualProvider serves a fixed list of rates, written as "EUR/USD=1.08,USD/MXN=17.05".
+// Rates entered through the API are stored directly and do not go through a provider.
+type ManualProvider struct {
+	rates []*fx.Rate
+}
+
+// NewManualProvider parses the list up front so a typo is reported at startup.
+func NewManualProvider(rates string) (*ManualProvider, error) {
+	provider := &ManualProvider{}
+	for _, entry := range strings.Split(rates, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pair, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid manual rate %q, expected BASE/QUOTE=RATE", entry)
+		}
+		rate, err := parseRate(pair, value, "", "manual")
+		if err != nil {
+			return nil, err
+		}
+		provider.rates = append(provider.rates, rate)
+	}
+	return provider, nil
+}
+
+func (p *ManualProvider) Name() string {
+	return "manual"
+}
+
+// FetchRates dates the configured rates today, so they stay the latest ones while the configuration holds.
+func (p *ManualProvider) FetchRates(ctx context.Context) ([]*fx.Rate, error) {
+	rates := make([]*fx.Rate, 0, len(p.rates))
+	for _, rate := range p.rates {
+		rates = append(rates, fx.NewRate(rate.Base, rate.Quote, rate.Rate, today(), rate.Source))
+	}
+	return rates, nil
+}
@@ -12,15 +12,17 @@ import (
 // CreateAccount godoc
 //
 //	@Summary		Create a new account
-//	@Description	Create a new bank account for the authenticated user
+//	@Description	Create a new account for the authenticated user. The type defaults to checking; credit cards take a credit limit, statement day and due day, savings accounts and loans an interest rate
 //	@Tags			Accounts
 //	@Accept			json
 //	@Produce		json
 //	@Security		JWT
 //	@Param			account	body	dto.AccountRequest	true	"Account creation data"
+//	@Param			Idempotency-Key	header	string	false	"Key to retry the request safely; a retry with the same key and body gets the first response back"
 //	@Success		201		"Account created successfully"
 //	@Failure		400		{object}	map[string]string	"Bad request - Invalid input"
 //	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		409		{object}	map[string]string	"Idempotency key reused with a different request, or still being processed"
 //	@Failure		500		{object}	map[string]string	"Internal server error"
 //	@Router			/account [post]
 func CreateAccount(accountService *account.AccountService) gin.HandlerFunc {
@@ -35,7 +37,7 @@ func CreateAccount(accountService *account.AccountService) gin.HandlerFunc {
 			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
 			return
 		}
-		err := accountService.CreateAccount(ctx, req.Name, req.Bank, req.InitialBalance, userId)
+		err := accountService.CreateAccount(ctx, req.Name, req.Bank, req.InitialBalance, req.Currency, userId, req.Type, req.Details)
 		if err != nil {
 			_ = ctx.Error(err)
 			return
@@ -47,11 +49,12 @@ func CreateAccount(accountService *account.AccountService) gin.HandlerFunc {
 // FindAllAccount godoc
 //
 //	@Summary		Get all user accounts
-//	@Description	Retrieve all bank accounts for the authenticated user
+//	@Description	Retrieve all accounts for the authenticated user. Liabilities have negative balances; credit cards with a limit also report available credit and utilization. Archived accounts are left out unless include_archived is true
 //	@Tags			Accounts
 //	@Accept			json
 //	@Produce		json
 //	@Security		JWT
+//	@Param			include_archived	query		bool				false	"Include archived accounts"
 //	@Success		200	{array}		dto.AccountResponse	"List of user accounts"
 //	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
 //	@Failure		500	{object}	map[string]string	"Internal server error"
@@ -59,7 +62,7 @@ func CreateAccount(accountService *account.AccountService) gin.HandlerFunc {
 func FindAllAccount(accountService *account.AccountService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userId := ctx.GetString("X-User-Id")
-		accounts, err := accountService.FindAll(ctx, userId)
+		accounts, err := accountService.FindAll(ctx, userId, ctx.Query("include_archived") == "true")
 		if err != nil {
 			_ = ctx.Error(err)
 			return
@@ -99,7 +102,7 @@ func DeleteAccount(accountService *account.AccountService) gin.HandlerFunc {
 // UpdateAccount godoc
 //
 //	@Summary		Update an existing account
-//	@Description	Update name and bank information for a specific account owned by the authenticated user
+//	@Description	Update the name, bank, type and type-specific details of an account owned by the authenticated user. Changing the type clears the details of the previous type
 //	@Tags			Accounts
 //	@Accept			json
 //	@Produce		json
@@ -133,6 +136,54 @@ func UpdateAccount(accountService *account.AccountService) gin.HandlerFunc {
 	}
 }
 
+// ArchiveAccount godoc
+//
+//	@Summary		Archive an account
+//	@Description	Archive an account of the authenticated user. Archived accounts are hidden from listings and search and take no new transactions, but keep their history in analytics and exports
+//	@Tags			Accounts
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Account ID"
+//	@Success		200	{object}	map[string]string	"Account archived successfully"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Account not found or not owned by user"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/account/{id}/archive [post]
+func ArchiveAccount(accountService *account.AccountService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		if err := accountService.ArchiveAccount(ctx, ctx.Param("id"), userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Account archived successfully"})
+	}
+}
+
+// UnarchiveAccount godoc
+//
+//	@Summary		Unarchive an account
+//	@Description	Bring an archived account of the authenticated user back into listings and open it to new transactions
+//	@Tags			Accounts
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Account ID"
+//	@Success		200	{object}	map[string]string	"Account unarchived successfully"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Account not found or not owned by user"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/account/{id}/unarchive [post]
+func UnarchiveAccount(accountService *account.AccountService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		if err := accountService.UnarchiveAccount(ctx, ctx.Param("id"), userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Account unarchived successfully"})
+	}
+}
+
 // FindAccount godoc
 //
 //	@Summary		Get account details
@@ -0,0 +1,73 @@
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/account"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/balance"
+)
+
+// BalanceHistory godoc
+//
+//	@Summary		Get the balance history of an account
+//	@Description	Balance of the account, initial balance included, at the end of every day, week or month of the range. Weeks start on Monday and days are in UTC; the last point is always the to date
+//	@Tags			Accounts
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id			path		string	true	"Account ID"
+//	@Param			from		query		string	false	"First day (YYYY-MM-DD), one month, six months or one year before to by default"
+//	@Param			to			query		string	false	"Last day (YYYY-MM-DD), today by default"
+//	@Param			interval	query		string	false	"Interval between points"	Enums(day, week, month)	default(day)
+//	@Success		200			{array}		account.BalancePoint
+//	@Failure		400			{object}	map[string]string	"Invalid range or interval"
+//	@Failure		404			{object}	map[string]string	"Account not found"
+//	@Router			/account/{id}/balance-history [get]
+func BalanceHistory(balanceService *balance.BalanceService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var req dto.BalanceHistoryRequest
+		if err := req.ParseFromQuery(ctx, time.Now()); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+		points, err := balanceService.History(ctx, ctx.Param("id"), userId, req.From, req.To, req.Interval)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, points)
+	}
+}
+
+// BalanceAt godoc
+//
+//	@Summary		Get the balance of an account at a date
+//	@Description	Balance of the account, initial balance included, at the end of the given day in UTC
+//	@Tags			Accounts
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string	true	"Account ID"
+//	@Param			date	query		string	false	"Day (YYYY-MM-DD), today by default"
+//	@Success		200		{object}	account.BalancePoint
+//	@Failure		400		{object}	map[string]string	"Invalid date"
+//	@Failure		404		{object}	map[string]string	"Account not found"
+//	@Router			/account/{id}/balance [get]
+func BalanceAt(balanceService *balance.BalanceService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		date, err := dto.ParseBalanceDate(ctx, time.Now())
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+		point, err := balanceService.BalanceAt(ctx, ctx.Param("id"), userId, date)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, point)
+	}
+}
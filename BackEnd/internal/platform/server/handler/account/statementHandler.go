@@ -0,0 +1,77 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/account"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/statement"
+)
+
+// ListStatements godoc
+//
+//	@Summary		List the statements of a credit card
+//	@Description	Billing cycles of a credit card with a statement day and a due day, latest first: the cycle running today and the ones before it. Amounts owed are positive; the minimum payment, paid and remaining amounts are only set once a cycle has closed, and paid counts the payments up to the due date
+//	@Tags			Accounts
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string	true	"Account ID"
+//	@Param			count	query		int		false	"Number of statements, up to 24"	default(6)
+//	@Success		200		{array}		account.Statement
+//	@Failure		400		{object}	map[string]string	"Invalid count, or not a credit card with a statement day and a due day"
+//	@Failure		404		{object}	map[string]string	"Account not found"
+//	@Router			/account/{id}/statements [get]
+func ListStatements(statementService *statement.StatementService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		count, err := dto.ParseStatementCount(ctx.Query("count"))
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+		statements, err := statementService.Statements(ctx, ctx.Param("id"), userId, count)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, statements)
+	}
+}
+
+// PayStatement godoc
+//
+//	@Summary		Pay the latest statement of a credit card
+//	@Description	Pay the latest closed statement of a credit card with a transfer from a checking account. Without an amount it pays what is left on the statement
+//	@Tags			Accounts
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id				path		string					true	"Account ID"
+//	@Param			payment			body		dto.PayStatementRequest	true	"Account to pay from and optional amount"
+//	@Param			Idempotency-Key	header		string					false	"Key to retry the request safely; a retry with the same key and body gets the first response back"
+//	@Success		201				{object}	map[string]interface{}	"Transfer that pays the statement, as returned by POST /transfer"
+//	@Failure		400				{object}	map[string]string		"Invalid input, source is not a checking account, or the statement is already paid"
+//	@Failure		404				{object}	map[string]string		"Account not found"
+//	@Failure		409				{object}	map[string]string		"An account is archived, or the idempotency key was reused"
+//	@Router			/account/{id}/statements/pay [post]
+func PayStatement(statementService *statement.StatementService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var req dto.PayStatementRequest
+		if err := ctx.BindJSON(&req); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := req.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+		transfer, err := statementService.PayStatement(ctx, ctx.Param("id"), userId, &req)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, transfer)
+	}
+}
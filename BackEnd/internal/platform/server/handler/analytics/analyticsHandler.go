@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	analyticsdto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/analytics"
 	errorHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/error"
 	"github.com/osmait/gestorDePresupuesto/internal/services/analytics"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 )
 
 func GetCategoryExpenses(analyticsService *analytics.AnalyticsService) gin.HandlerFunc {
@@ -22,6 +24,38 @@ func GetCategoryExpenses(analyticsService *analytics.AnalyticsService) gin.Handl
 	}
 }
 
+func GetTagExpenses(analyticsService *analytics.AnalyticsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("X-User-Id")
+		tagExpenses, err := analyticsService.GetTagExpenses(c.Request.Context(), userID)
+		if err != nil {
+			errorHandler.ResponseByTypeOfErr(err, c)
+			return
+		}
+
+		c.JSON(http.StatusOK, analyticsdto.NewGetTagExpensesResponse(tagExpenses))
+	}
+}
+
+func GetTopPayees(analyticsService *analytics.AnalyticsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("X-User-Id")
+		limit, err := analyticsdto.ParseTopPayeesLimit(c.Query("limit"))
+		if err != nil {
+			errorHandler.ResponseByTypeOfErr(fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error()), c)
+			return
+		}
+
+		payeeExpenses, err := analyticsService.GetTopPayees(c.Request.Context(), userID, limit)
+		if err != nil {
+			errorHandler.ResponseByTypeOfErr(err, c)
+			return
+		}
+
+		c.JSON(http.StatusOK, analyticsdto.NewGetPayeeExpensesResponse(payeeExpenses))
+	}
+}
+
 func GetMonthlySummary(analyticsService *analytics.AnalyticsService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetString("X-User-Id")
@@ -0,0 +1,145 @@
+package attachmentHandler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/attachment"
+	"github.com/rs/zerolog/log"
+)
+
+// UploadAttachment godoc
+//
+//	@Summary		Attach a file to a transaction
+//	@Description	Upload a receipt or document (PDF, JPEG, PNG, WebP or GIF) as multipart field "file". The type is detected from the content; files above the size limit or the user quota are rejected
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string					true	"Transaction ID"
+//	@Param			file	formData	file					true	"File to attach"
+//	@Success		201		{object}	dto.AttachmentResponse	"Attachment created"
+//	@Failure		400		{object}	map[string]string		"Bad request - Missing file, unsupported type, too large or quota exceeded"
+//	@Failure		401		{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string		"Transaction not found"
+//	@Failure		500		{object}	map[string]string		"Internal server error"
+//	@Router			/transaction/{id}/attachment [post]
+func UploadAttachment(attachmentService *attachment.AttachmentService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		transactionId := ctx.Param("id")
+
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", "A file is required"))
+			return
+		}
+		if header.Size > attachmentService.MaxFileSize() {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", fmt.Sprintf("The file must be smaller than %d bytes", attachmentService.MaxFileSize())))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", "The file could not be read"))
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		response, err := attachmentService.Upload(ctx, userId, transactionId, header.Filename, header.Size, file)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// FindAttachments godoc
+//
+//	@Summary		List the attachments of a transaction
+//	@Description	Retrieve the metadata of the files attached to a transaction, oldest first
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string					true	"Transaction ID"
+//	@Success		200	{array}		dto.AttachmentResponse	"List of attachments"
+//	@Failure		401	{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string		"Internal server error"
+//	@Router			/transaction/{id}/attachment [get]
+func FindAttachments(attachmentService *attachment.AttachmentService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		attachments, err := attachmentService.FindByTransaction(ctx, userId, ctx.Param("id"))
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, attachments)
+	}
+}
+
+// DownloadAttachment godoc
+//
+//	@Summary		Download an attachment
+//	@Description	Stream the content of an attached file with its original name
+//	@Tags			Attachments
+//	@Produce		application/pdf
+//	@Produce		image/jpeg
+//	@Produce		image/png
+//	@Produce		image/webp
+//	@Produce		image/gif
+//	@Security		JWT
+//	@Param			id	path		string				true	"Attachment ID"
+//	@Success		200	{file}		file				"Attachment content"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Attachment not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/attachment/{id} [get]
+func DownloadAttachment(attachmentService *attachment.AttachmentService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		attachment, content, err := attachmentService.Download(ctx, userId, ctx.Param("id"))
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		defer func() {
+			if err := content.Close(); err != nil {
+				log.Error().Err(err).Str("attachment_id", attachment.Id).Msg("failed to close attachment")
+			}
+		}()
+
+		headers := map[string]string{
+			"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+			"X-Content-Type-Options": "nosniff",
+		}
+		ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, headers)
+	}
+}
+
+// DeleteAttachment godoc
+//
+//	@Summary		Delete an attachment
+//	@Description	Remove an attached file; the transaction is left untouched
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Attachment ID"
+//	@Success		200	{object}	map[string]string	"Attachment deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Attachment not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/attachment/{id} [delete]
+func DeleteAttachment(attachmentService *attachment.AttachmentService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		if err := attachmentService.DeleteAttachment(ctx, userId, ctx.Param("id")); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
@@ -0,0 +1,74 @@
+package auditHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/audit"
+)
+
+// FindHistory godoc
+//
+//	@Summary		Get the change history of a record
+//	@Description	Retrieve every create, update and delete the user made to a transaction, account, budget, category or investment, oldest first, with snapshots of the record before and after each change
+//	@Tags			Audit
+//	@Produce		json
+//	@Security		JWT
+//	@Param			entity_type	path		string					true	"Record type"	enums(transaction,account,budget,category,investment)
+//	@Param			entity_id	path		string					true	"Record ID"
+//	@Success		200			{array}		dto.AuditEventResponse	"Change history"
+//	@Failure		400			{object}	map[string]string		"Bad request - Unknown record type"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string		"Internal server error"
+//	@Router			/audit/{entity_type}/{entity_id} [get]
+func FindHistory(auditService *audit.AuditService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		events, err := auditService.FindHistory(ctx, ctx.Param("entity_type"), ctx.Param("entity_id"), userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, events)
+	}
+}
+
+// FindEvents godoc
+//
+//	@Summary		Query the audit log
+//	@Description	Retrieve the audit events of every user, newest first. Admin only
+//	@Tags			Audit
+//	@Produce		json
+//	@Security		JWT
+//	@Param			entity_type	query		string					false	"Record type"				enums(transaction,account,budget,category,investment)
+//	@Param			entity_id	query		string					false	"Record ID"
+//	@Param			user_id		query		string					false	"User who made the change"
+//	@Param			action		query		string					false	"Action"					enums(create,update,delete,restore)
+//	@Param			from		query		string					false	"From date (YYYY-MM-DD)"	example("2026-01-01")
+//	@Param			to			query		string					false	"To date (YYYY-MM-DD)"		example("2026-01-31")
+//	@Param			limit		query		int						false	"Events per page"			minimum(1)	maximum(200)	default(50)
+//	@Param			offset		query		int						false	"Events to skip"			minimum(0)	default(0)
+//	@Success		200			{array}		dto.AuditEventResponse	"Audit events"
+//	@Failure		400			{object}	map[string]string		"Bad request - Invalid parameters"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		403			{object}	map[string]string		"Forbidden - Admin only"
+//	@Failure		500			{object}	map[string]string		"Internal server error"
+//	@Router			/audit [get]
+func FindEvents(auditService *audit.AuditService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		filter := dto.NewAuditFilter()
+		if err := filter.ParseFromQuery(ctx); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+
+		events, err := auditService.FindEvents(ctx, filter)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, events)
+	}
+}
@@ -18,9 +18,11 @@ import (
 //	@Produce		json
 //	@Security		JWT
 //	@Param			budget	body		dto.BudgetRequest	true	"Budget creation data"
+//	@Param			Idempotency-Key	header	string	false	"Key to retry the request safely; a retry with the same key and body gets the first response back"
 //	@Success		201		{object}	map[string]string	"Budget created successfully"
 //	@Failure		400		{object}	map[string]string	"Bad request - Invalid input"
 //	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		409		{object}	map[string]string	"Idempotency key reused with a different request, or still being processed"
 //	@Failure		500		{object}	map[string]string	"Internal server error"
 //	@Router			/budget [post]
 func CreateBudget(budgetServices *budget.BudgetServices) gin.HandlerFunc {
@@ -0,0 +1,81 @@
+package exportHandler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/export"
+	"github.com/rs/zerolog/log"
+)
+
+// ExportTransactions godoc
+//
+//	@Summary		Export transactions
+//	@Description	Stream every transaction matching the filters as CSV, NDJSON or an XLSX workbook, with account and category names resolved. Pagination parameters are ignored
+//	@Tags			Export
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		JWT
+//	@Param			format		query		string	false	"File format"					example("csv")	enums(csv,ndjson,xlsx)
+//	@Param			sort_by		query		string	false	"Field to sort by"				example("created_at")	enums(created_at,amount,name,type_transation)
+//	@Param			sort_order	query		string	false	"Sort order"					example("desc")	enums(asc,desc)
+//	@Param			type		query		string	false	"Transaction type filter"		example("all")	enums(income,bill,transfer,all)
+//	@Param			category_id	query		string	false	"Filter by category ID"			example("cat_123456789")
+//	@Param			categories	query		string	false	"Filter by multiple categories (comma-separated)"	example("cat_1,cat_2,cat_3")
+//	@Param			account_id	query		string	false	"Filter by account ID"			example("acc_123456789")
+//	@Param			budget_id	query		string	false	"Filter by budget ID"			example("budget_555666777")
+//	@Param			payee_id	query		string	false	"Filter by payee ID"			example("payee_123456789")
+//	@Param			date_from	query		string	false	"Start date (YYYY/MM/DD or YYYY-MM-DD)"	example("2022/01/01")
+//	@Param			date_to		query		string	false	"End date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/12/31")
+//	@Param			period		query		string	false	"Predefined period filter"		example("this_year")	enums(today,this_week,this_month,this_year,last_week,last_month,last_year)
+//	@Param			amount_min	query		number	false	"Minimum amount filter"			example(0.00)
+//	@Param			amount_max	query		number	false	"Maximum amount filter"			example(1000.00)
+//	@Param			search		query		string	false	"Search in name and description"	example("grocery")
+//	@Success		200			{file}		file	"Exported transactions"
+//	@Failure		400			{object}	map[string]string	"Bad request - Invalid parameters"
+//	@Failure		401			{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string	"Internal server error"
+//	@Router			/export/transactions [get]
+func ExportTransactions(exportService *export.ExportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+
+		format, err := export.ParseFormat(ctx.Query("format"))
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+
+		filter := dto.NewTransactionFilter()
+		if err := filter.ParseFromQuery(ctx); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", "Invalid filter parameters: "+err.Error()))
+			return
+		}
+		if err := filter.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_FILTER", "Invalid filter parameters: "+err.Error()))
+			return
+		}
+
+		filename := fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102"), format.Extension)
+		ctx.Header("Content-Type", format.ContentType)
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		ctx.Status(http.StatusOK)
+
+		if err := exportService.ExportTransactions(ctx, userId, filter, format, ctx.Writer); err != nil {
+			if !ctx.Writer.Written() {
+				ctx.Writer.Header().Del("Content-Type")
+				ctx.Writer.Header().Del("Content-Disposition")
+				_ = ctx.Error(err)
+				return
+			}
+			// Once rows have been sent the status line is gone, so the download is cut short instead
+			log.Error().Err(err).Str("user_id", userId).Msg("transaction export aborted")
+			ctx.Abort()
+		}
+	}
+}
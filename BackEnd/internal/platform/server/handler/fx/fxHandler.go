@@ -0,0 +1,91 @@
+package fxHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/fx"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+)
+
+// FindRates godoc
+//
+//	@Summary		List exchange rates
+//	@Description	Retrieve the most recent exchange rate of every currency pair
+//	@Tags			Exchange rates
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		fx.Rate				"Latest rates"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/fx/rates [get]
+func FindRates(fxService *fx.FxService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rates, err := fxService.FindLatest(ctx)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, rates)
+	}
+}
+
+// SaveRate godoc
+//
+//	@Summary		Enter an exchange rate
+//	@Description	Store a rate by hand, replacing the rate of the same pair and day. Admin only
+//	@Tags			Exchange rates
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			rate	body		dto.RateRequest		true	"Exchange rate"
+//	@Success		201		{object}	fx.Rate				"Rate stored"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid input"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		403		{object}	map[string]string	"Forbidden - Admin role required"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/fx/rates [post]
+func SaveRate(fxService *fx.FxService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request dto.RateRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		rate, err := fxService.SaveRate(ctx, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, rate)
+	}
+}
+
+// SyncRates godoc
+//
+//	@Summary		Refresh exchange rates
+//	@Description	Load the rates of the configured provider right away instead of waiting for the next sync. Admin only
+//	@Tags			Exchange rates
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{object}	map[string]int		"Number of rates stored"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		403	{object}	map[string]string	"Forbidden - Admin role required"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/fx/rates/sync [post]
+func SyncRates(fxService *fx.FxService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		stored, err := fxService.SyncRates(ctx)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"stored": stored})
+	}
+}
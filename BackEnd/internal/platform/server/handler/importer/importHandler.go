@@ -0,0 +1,295 @@
+package importerHandler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/importer"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/importer"
+)
+
+// maxImportFileSize caps uploaded statements at 5 MB.
+const maxImportFileSize = 5 << 20
+
+// CreateImportMapping godoc
+//
+//	@Summary		Create an import mapping
+//	@Description	Save how the columns of a bank CSV statement map to transactions, so later imports are a single call
+//	@Tags			Import
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			mapping	body		dto.ImportMappingRequest	true	"Column mapping"
+//	@Success		201		{object}	dto.ImportMappingResponse	"Mapping created"
+//	@Failure		400		{object}	map[string]string			"Bad request - Invalid input"
+//	@Failure		401		{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string			"Internal server error"
+//	@Router			/import/mapping [post]
+func CreateImportMapping(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.ImportMappingRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		mapping, err := importService.CreateMapping(ctx, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, mapping)
+	}
+}
+
+// FindImportMappings godoc
+//
+//	@Summary		List import mappings
+//	@Description	Retrieve the CSV column mappings saved by the authenticated user
+//	@Tags			Import
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		dto.ImportMappingResponse	"List of mappings"
+//	@Failure		401	{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string			"Internal server error"
+//	@Router			/import/mapping [get]
+func FindImportMappings(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		mappings, err := importService.FindMappings(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, mappings)
+	}
+}
+
+// UpdateImportMapping godoc
+//
+//	@Summary		Update an import mapping
+//	@Description	Replace an existing CSV column mapping
+//	@Tags			Import
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string						true	"Mapping ID"
+//	@Param			mapping	body		dto.ImportMappingRequest	true	"Column mapping"
+//	@Success		200		{object}	map[string]string			"Mapping updated"
+//	@Failure		400		{object}	map[string]string			"Bad request - Invalid input"
+//	@Failure		401		{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string			"Mapping not found"
+//	@Failure		500		{object}	map[string]string			"Internal server error"
+//	@Router			/import/mapping/{id} [put]
+func UpdateImportMapping(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		var request dto.ImportMappingRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := importService.UpdateMapping(ctx, id, userId, &request); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Updated")
+	}
+}
+
+// DeleteImportMapping godoc
+//
+//	@Summary		Delete an import mapping
+//	@Description	Delete a CSV column mapping by ID
+//	@Tags			Import
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Mapping ID"
+//	@Success		200	{object}	map[string]string	"Mapping deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Mapping not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/import/mapping/{id} [delete]
+func DeleteImportMapping(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		if err := importService.DeleteMapping(ctx, id, userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
+
+// PreviewCSVImport godoc
+//
+//	@Summary		Preview a CSV import
+//	@Description	Parse a bank CSV statement with a saved mapping and return the resulting rows without storing anything
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		JWT
+//	@Param			file		formData	file						true	"CSV statement"
+//	@Param			mapping_id	formData	string						true	"Mapping ID"
+//	@Success		200			{object}	dto.ImportPreviewResponse	"Parsed rows"
+//	@Failure		400			{object}	map[string]string			"Bad request - Invalid file"
+//	@Failure		401			{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404			{object}	map[string]string			"Mapping not found"
+//	@Failure		500			{object}	map[string]string			"Internal server error"
+//	@Router			/import/csv/preview [post]
+func PreviewCSVImport(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		file, ok := openUploadedFile(ctx)
+		if !ok {
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		preview, err := importService.PreviewCSV(ctx, userId, ctx.PostForm("mapping_id"), file)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, preview)
+	}
+}
+
+// ImportCSV godoc
+//
+//	@Summary		Import a CSV statement
+//	@Description	Parse a bank CSV statement with a saved mapping and create a transaction in the account for every valid row
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		JWT
+//	@Param			file		formData	file						true	"CSV statement"
+//	@Param			mapping_id	formData	string						true	"Mapping ID"
+//	@Param			account_id	formData	string						true	"Account that receives the transactions"
+//	@Param			category_id	formData	string						false	"Category for the imported transactions, overrides the mapping default"
+//	@Success		201			{object}	dto.ImportResultResponse	"Import summary"
+//	@Failure		400			{object}	map[string]string			"Bad request - Invalid file"
+//	@Failure		401			{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404			{object}	map[string]string			"Mapping not found"
+//	@Failure		500			{object}	map[string]string			"Internal server error"
+//	@Router			/import/csv [post]
+func ImportCSV(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		file, ok := openUploadedFile(ctx)
+		if !ok {
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		result, err := importService.ImportCSV(ctx, userId, ctx.PostForm("mapping_id"), ctx.PostForm("account_id"), ctx.PostForm("category_id"), file)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, result)
+	}
+}
+
+// ImportOFX godoc
+//
+//	@Summary		Import an OFX or QFX statement
+//	@Description	Create a transaction in the account for every entry of an OFX/QFX statement. Entries whose FITID was already imported into the account are reported as duplicates
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		JWT
+//	@Param			file		formData	file						true	"OFX or QFX statement"
+//	@Param			account_id	formData	string						true	"Account that receives the transactions"
+//	@Param			category_id	formData	string						false	"Category for the imported transactions"
+//	@Success		201			{object}	dto.ImportResultResponse	"Import summary"
+//	@Failure		400			{object}	map[string]string			"Bad request - Invalid file"
+//	@Failure		401			{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string			"Internal server error"
+//	@Router			/import/ofx [post]
+func ImportOFX(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		file, ok := openUploadedFile(ctx)
+		if !ok {
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		result, err := importService.ImportOFX(ctx, userId, ctx.PostForm("account_id"), ctx.PostForm("category_id"), file)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, result)
+	}
+}
+
+// ImportQIF godoc
+//
+//	@Summary		Import a QIF statement
+//	@Description	Create a transaction in the account for every entry of a QIF statement. Importing the same file again does not duplicate transactions
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		JWT
+//	@Param			file		formData	file						true	"QIF statement"
+//	@Param			account_id	formData	string						true	"Account that receives the transactions"
+//	@Param			category_id	formData	string						false	"Category for the imported transactions"
+//	@Param			date_format	formData	string						false	"Date format of the file (default MM/DD/YYYY)"
+//	@Success		201			{object}	dto.ImportResultResponse	"Import summary"
+//	@Failure		400			{object}	map[string]string			"Bad request - Invalid file"
+//	@Failure		401			{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string			"Internal server error"
+//	@Router			/import/qif [post]
+func ImportQIF(importService *importer.ImportService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		file, ok := openUploadedFile(ctx)
+		if !ok {
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		result, err := importService.ImportQIF(ctx, userId, ctx.PostForm("account_id"), ctx.PostForm("category_id"), ctx.PostForm("date_format"), file)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, result)
+	}
+}
+
+// openUploadedFile opens the "file" form field, reporting a validation error when it is missing or too large.
+func openUploadedFile(ctx *gin.Context) (io.ReadCloser, bool) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", "A statement file is required"))
+		return nil, false
+	}
+	if header.Size > maxImportFileSize {
+		_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", "The statement file must be smaller than 5 MB"))
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		_ = ctx.Error(apperrors.NewValidationError("INVALID_FILE", "The statement file could not be read"))
+		return nil, false
+	}
+	return file, true
+}
@@ -1,10 +1,12 @@
 package investment
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/investment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	investmentService "github.com/osmait/gestorDePresupuesto/internal/services/investment"
 	"github.com/segmentio/ksuid"
 )
@@ -26,6 +28,7 @@ func (h *InvestmentHandler) Create(ctx *gin.Context) {
 		Quantity      float64                   `json:"quantity" binding:"required"`
 		PurchasePrice float64                   `json:"purchase_price" binding:"required"`
 		CurrentPrice  float64                   `json:"current_price" binding:"required"`
+		AccountId     string                    `json:"account_id"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -43,8 +46,8 @@ func (h *InvestmentHandler) Create(ctx *gin.Context) {
 		req.ID = ksuid.New().String()
 	}
 
-	if err := h.service.Create(ctx, req.ID, userId, req.Type, req.Name, req.Symbol, req.Quantity, req.PurchasePrice, req.CurrentPrice); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.Create(ctx, req.ID, userId, req.Type, req.Name, req.Symbol, req.Quantity, req.PurchasePrice, req.CurrentPrice, req.AccountId); err != nil {
+		ctx.JSON(statusOf(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -74,9 +77,9 @@ func (h *InvestmentHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	// In a real app we might want to fetch first to verify ownership, assuming service/repo handles or strict ID checks
-	if err := h.service.Update(ctx, &req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	userId := ctx.GetString("X-User-Id")
+	if err := h.service.Update(ctx, &req, userId); err != nil {
+		ctx.JSON(statusOf(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -85,9 +88,22 @@ func (h *InvestmentHandler) Update(ctx *gin.Context) {
 
 func (h *InvestmentHandler) Delete(ctx *gin.Context) {
 	id := ctx.Param("id")
-	if err := h.service.Delete(ctx, id); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	userId := ctx.GetString("X-User-Id")
+	if err := h.service.Delete(ctx, id, userId); err != nil {
+		ctx.JSON(statusOf(err), gin.H{"error": err.Error()})
 		return
 	}
 	ctx.Status(http.StatusOK)
 }
+
+// statusOf answers 404 for investments that do not exist or belong to another user, and 400 for links to
+// accounts that are not brokerage accounts of the user.
+func statusOf(err error) int {
+	if errors.Is(err, errorhttp.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, errorhttp.ErrBadRequest) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,65 @@
+package networthHandler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/networth"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/networth"
+)
+
+// CurrentNetWorth godoc
+//
+//	@Summary		Get the current net worth
+//	@Description	Assets, liabilities and investments of the user in their base currency, with the value of every account and investment. Liabilities are credit cards and loans, reported as the amount owed; investments are valued at quantity times current price. Accounts in a currency without an exchange rate are listed but left out of the totals
+//	@Tags			Net worth
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{object}	networth.Snapshot
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/net-worth [get]
+func CurrentNetWorth(netWorthService *networth.NetWorthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		snapshot, err := netWorthService.Current(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, snapshot)
+	}
+}
+
+// NetWorthHistory godoc
+//
+//	@Summary		Get the monthly net worth
+//	@Description	Net worth recorded for every month of the range, oldest first. Each month keeps the last value recorded during it; months before the first record are left out
+//	@Tags			Net worth
+//	@Produce		json
+//	@Security		JWT
+//	@Param			from	query		string	false	"First month (YYYY-MM), eleven months before to by default"	example("2026-01")
+//	@Param			to		query		string	false	"Last month (YYYY-MM), the current month by default"		example("2026-12")
+//	@Success		200		{array}		dto.MonthlyNetWorthResponse
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid range"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/net-worth/history [get]
+func NetWorthHistory(netWorthService *networth.NetWorthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var req dto.HistoryRequest
+		if err := req.ParseFromQuery(ctx, time.Now()); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", err.Error()))
+			return
+		}
+		records, err := netWorthService.History(ctx, userId, req.From, req.To)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, dto.NewMonthlyNetWorthResponses(records))
+	}
+}
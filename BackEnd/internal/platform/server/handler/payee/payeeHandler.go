@@ -0,0 +1,133 @@
+package payeeHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/payee"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/payee"
+)
+
+// CreatePayee godoc
+//
+//	@Summary		Create a payee
+//	@Description	Create a payee with its alias patterns. Transactions without a payee whose name matches the payee are linked to it
+//	@Tags			Payees
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			payee	body		dto.PayeeRequest	true	"Payee"
+//	@Success		201		{object}	dto.PayeeResponse	"Payee created"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid input or name already in use"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/payee [post]
+func CreatePayee(payeeService *payee.PayeeService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.PayeeRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := payeeService.CreatePayee(ctx, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// FindPayees godoc
+//
+//	@Summary		List payees
+//	@Description	Retrieve the payees of the authenticated user with their aliases, in alphabetical order
+//	@Tags			Payees
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		dto.PayeeResponse	"List of payees"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/payee [get]
+func FindPayees(payeeService *payee.PayeeService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		payees, err := payeeService.FindPayees(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, payees)
+	}
+}
+
+// UpdatePayee godoc
+//
+//	@Summary		Update a payee
+//	@Description	Rename a payee and replace its aliases. Transactions without a payee that match the new aliases are linked to it
+//	@Tags			Payees
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string				true	"Payee ID"
+//	@Param			payee	body		dto.PayeeRequest	true	"Payee"
+//	@Success		200		{object}	dto.PayeeResponse	"Payee updated"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid input or name already in use"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string	"Payee not found"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/payee/{id} [put]
+func UpdatePayee(payeeService *payee.PayeeService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		var request dto.PayeeRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := payeeService.UpdatePayee(ctx, id, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// DeletePayee godoc
+//
+//	@Summary		Delete a payee
+//	@Description	Delete a payee; its transactions are kept without a payee
+//	@Tags			Payees
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Payee ID"
+//	@Success		200	{object}	map[string]string	"Payee deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Payee not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/payee/{id} [delete]
+func DeletePayee(payeeService *payee.PayeeService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		if err := payeeService.DeletePayee(ctx, id, userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
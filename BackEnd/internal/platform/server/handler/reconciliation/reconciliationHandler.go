@@ -0,0 +1,125 @@
+package reconciliationHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/reconciliation"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/reconciliation"
+)
+
+// StartReconciliation godoc
+//
+//	@Summary		Start a statement reconciliation
+//	@Description	Enter the closing date and balance of a bank statement for an account. It opens a reconciliation, or updates the open one, and returns the difference against the cleared transactions up to that date
+//	@Tags			Reconciliations
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id				path		string						true	"Account ID"
+//	@Param			reconciliation	body		dto.ReconciliationRequest	true	"Statement"
+//	@Success		200				{object}	dto.ReconciliationResponse	"Open reconciliation"
+//	@Failure		400				{object}	map[string]string			"Bad request - Invalid input"
+//	@Failure		401				{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404				{object}	map[string]string			"Account not found"
+//	@Failure		500				{object}	map[string]string			"Internal server error"
+//	@Router			/account/{id}/reconciliation [post]
+func StartReconciliation(reconciliationService *reconciliation.ReconciliationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.ReconciliationRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := reconciliationService.StartReconciliation(ctx, ctx.Param("id"), userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// FindReconciliations godoc
+//
+//	@Summary		List the reconciliations of an account
+//	@Description	Retrieve the reconciliations of an account, latest statement first
+//	@Tags			Reconciliations
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string						true	"Account ID"
+//	@Success		200	{array}		dto.ReconciliationResponse	"List of reconciliations"
+//	@Failure		401	{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string			"Account not found"
+//	@Failure		500	{object}	map[string]string			"Internal server error"
+//	@Router			/account/{id}/reconciliation [get]
+func FindReconciliations(reconciliationService *reconciliation.ReconciliationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		reconciliations, err := reconciliationService.FindReconciliations(ctx, ctx.Param("id"), userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, reconciliations)
+	}
+}
+
+// FindReconciliation godoc
+//
+//	@Summary		Get a reconciliation
+//	@Description	Retrieve a reconciliation. The difference of an open reconciliation reflects the transactions cleared so far
+//	@Tags			Reconciliations
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string						true	"Reconciliation ID"
+//	@Success		200	{object}	dto.ReconciliationResponse	"Reconciliation"
+//	@Failure		401	{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string			"Reconciliation not found"
+//	@Failure		500	{object}	map[string]string			"Internal server error"
+//	@Router			/reconciliation/{id} [get]
+func FindReconciliation(reconciliationService *reconciliation.ReconciliationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		response, err := reconciliationService.FindReconciliation(ctx, ctx.Param("id"), userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// CompleteReconciliation godoc
+//
+//	@Summary		Complete a reconciliation
+//	@Description	Lock the cleared transactions of the statement as reconciled. It fails while the difference is not zero
+//	@Tags			Reconciliations
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string						true	"Reconciliation ID"
+//	@Success		200	{object}	dto.ReconciliationResponse	"Completed reconciliation"
+//	@Failure		400	{object}	map[string]string			"Cleared transactions do not match the statement"
+//	@Failure		401	{object}	map[string]string			"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string			"Reconciliation not found"
+//	@Failure		409	{object}	map[string]string			"Reconciliation already completed"
+//	@Failure		500	{object}	map[string]string			"Internal server error"
+//	@Router			/reconciliation/{id}/complete [post]
+func CompleteReconciliation(reconciliationService *reconciliation.ReconciliationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		response, err := reconciliationService.CompleteReconciliation(ctx, ctx.Param("id"), userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,178 @@
+package ruleHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/rule"
+	transactionDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// CreateRule godoc
+//
+//	@Summary		Create a categorization rule
+//	@Description	Save a rule that categorizes, budgets or renames matching transactions when they are created, imported or generated by a recurring transaction
+//	@Tags			Rules
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			rule	body		dto.RuleRequest		true	"Rule"
+//	@Success		201		{object}	dto.RuleResponse	"Rule created"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid input"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/rule [post]
+func CreateRule(ruleService *rule.RuleService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.RuleRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := ruleService.CreateRule(ctx, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// FindRules godoc
+//
+//	@Summary		List categorization rules
+//	@Description	Retrieve the rules of the authenticated user in evaluation order (ascending priority)
+//	@Tags			Rules
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		dto.RuleResponse	"List of rules"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/rule [get]
+func FindRules(ruleService *rule.RuleService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		rules, err := ruleService.FindRules(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, rules)
+	}
+}
+
+// UpdateRule godoc
+//
+//	@Summary		Update a categorization rule
+//	@Description	Replace the conditions and actions of an existing rule
+//	@Tags			Rules
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string				true	"Rule ID"
+//	@Param			rule	body		dto.RuleRequest		true	"Rule"
+//	@Success		200		{object}	map[string]string	"Rule updated"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid input"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string	"Rule not found"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/rule/{id} [put]
+func UpdateRule(ruleService *rule.RuleService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		var request dto.RuleRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := ruleService.UpdateRule(ctx, id, userId, &request); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Updated")
+	}
+}
+
+// DeleteRule godoc
+//
+//	@Summary		Delete a categorization rule
+//	@Description	Delete a rule by ID. Transactions it already categorized keep their category
+//	@Tags			Rules
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Rule ID"
+//	@Success		200	{object}	map[string]string	"Rule deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Rule not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/rule/{id} [delete]
+func DeleteRule(ruleService *rule.RuleService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		if err := ruleService.DeleteRule(ctx, id, userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
+
+// ApplyRules godoc
+//
+//	@Summary		Apply categorization rules retroactively
+//	@Description	Run the rules over the transactions selected by the filters (same parameters as GET /transaction, pagination ignored). With dry_run=true nothing is stored and the response lists what would change
+//	@Tags			Rules
+//	@Produce		json
+//	@Security		JWT
+//	@Param			dry_run		query		bool	false	"Only report the changes"		example(true)
+//	@Param			type		query		string	false	"Transaction type filter"		example("all")	enums(income,bill,all)
+//	@Param			category_id	query		string	false	"Filter by category ID"			example("cat_123456789")
+//	@Param			account_id	query		string	false	"Filter by account ID"			example("acc_123456789")
+//	@Param			date_from	query		string	false	"Start date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/01/01")
+//	@Param			date_to		query		string	false	"End date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/12/31")
+//	@Param			period		query		string	false	"Predefined period filter"		example("this_year")	enums(today,this_week,this_month,this_year,last_week,last_month,last_year)
+//	@Param			search		query		string	false	"Search in name and description"	example("uber")
+//	@Success		200			{object}	dto.ApplyRulesResponse	"Changes made or, on a dry run, proposed"
+//	@Failure		400			{object}	map[string]string		"Bad request - Invalid parameters"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string		"Internal server error"
+//	@Router			/rule/apply [post]
+func ApplyRules(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+
+		filter := transactionDto.NewTransactionFilter()
+		if err := filter.ParseFromQuery(ctx); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", "Invalid filter parameters: "+err.Error()))
+			return
+		}
+		if err := filter.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_FILTER", "Invalid filter parameters: "+err.Error()))
+			return
+		}
+		dryRun := ctx.Query("dry_run") == "true"
+
+		response, err := transactionService.ApplyRules(ctx, userId, filter, dryRun)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
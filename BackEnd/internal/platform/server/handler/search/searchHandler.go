@@ -33,7 +33,7 @@ func (h *SearchHandler) Search(ctx *gin.Context) {
 		return
 	}
 
-	response, err := h.service.Search(ctx, userId, query)
+	response, err := h.service.Search(ctx, userId, query, ctx.Query("include_archived") == "true")
 	if err != nil {
 		_ = ctx.Error(apperrors.NewInternalError("Error executing search", err))
 		return
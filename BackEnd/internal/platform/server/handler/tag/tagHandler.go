@@ -0,0 +1,132 @@
+package tagHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/tag"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/tag"
+)
+
+// CreateTag godoc
+//
+//	@Summary		Create a tag
+//	@Description	Create a tag for the authenticated user. Names are trimmed and lowercased; creating an existing name returns that tag
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			tag	body		dto.TagRequest		true	"Tag"
+//	@Success		201	{object}	dto.TagResponse		"Tag created"
+//	@Failure		400	{object}	map[string]string	"Bad request - Invalid input"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/tag [post]
+func CreateTag(tagService *tag.TagService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.TagRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := tagService.CreateTag(ctx, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// FindTags godoc
+//
+//	@Summary		List tags
+//	@Description	Retrieve the tags of the authenticated user in alphabetical order
+//	@Tags			Tags
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		dto.TagResponse		"List of tags"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/tag [get]
+func FindTags(tagService *tag.TagService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		tags, err := tagService.FindTags(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, tags)
+	}
+}
+
+// RenameTag godoc
+//
+//	@Summary		Rename a tag
+//	@Description	Change the name of a tag; every transaction carrying it shows the new name
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Tag ID"
+//	@Param			tag	body		dto.TagRequest		true	"Tag"
+//	@Success		200	{object}	map[string]string	"Tag renamed"
+//	@Failure		400	{object}	map[string]string	"Bad request - Invalid input or name already in use"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Tag not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/tag/{id} [put]
+func RenameTag(tagService *tag.TagService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		var request dto.TagRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := tagService.RenameTag(ctx, id, userId, &request); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Updated")
+	}
+}
+
+// DeleteTag godoc
+//
+//	@Summary		Delete a tag
+//	@Description	Delete a tag and remove it from every transaction; the transactions themselves are kept
+//	@Tags			Tags
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Tag ID"
+//	@Success		200	{object}	map[string]string	"Tag deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"Tag not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/tag/{id} [delete]
+func DeleteTag(tagService *tag.TagService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		if err := tagService.DeleteTag(ctx, id, userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
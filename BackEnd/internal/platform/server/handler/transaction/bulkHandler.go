@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// BulkTransactions godoc
+//
+//	@Summary		Change many transactions at once
+//	@Description	Delete, change the category or account of, or add or remove a tag on up to 1000 transactions in a single database transaction. The transactions are selected with either a list of ids or a filter. Transactions the action cannot apply to, such as reconciled ones, are reported as failed and the rest are changed
+//	@Tags			Transactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			request	body		dto.BulkRequest		true	"Action and selection"
+//	@Success		200		{object}	dto.BulkResponse	"Result for each selected transaction"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid action, selection or target"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/transaction/bulk [post]
+func BulkTransactions(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var bulkRequest dto.BulkRequest
+		if err := ctx.BindJSON(&bulkRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := bulkRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := transactionService.Bulk(ctx, userID, &bulkRequest)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
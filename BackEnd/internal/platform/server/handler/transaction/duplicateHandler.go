@@ -0,0 +1,113 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// FindDuplicates godoc
+//
+//	@Summary		List possible duplicate transactions
+//	@Description	Retrieve pairs of transactions in the same account with the same amount, dates less than three days apart and similar names. Dismissed pairs are left out
+//	@Tags			Transactions
+//	@Produce		json
+//	@Security		JWT
+//	@Param			days	query		int						false	"Days to look back (default 90, max 365)"
+//	@Success		200		{array}		dto.DuplicateResponse	"Possible duplicates, newest first"
+//	@Failure		400		{object}	map[string]string		"Bad request - Invalid days"
+//	@Failure		401		{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string		"Internal server error"
+//	@Router			/transaction/duplicates [get]
+func FindDuplicates(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		days, err := dto.ParseDuplicateDays(ctx.Query("days"))
+		if err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		duplicates, err := transactionService.FindDuplicates(ctx, userID, days)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, duplicates)
+	}
+}
+
+// DismissDuplicate godoc
+//
+//	@Summary		Dismiss a possible duplicate
+//	@Description	Mark a pair of transactions as different charges so it is never reported as a duplicate again
+//	@Tags			Transactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			pair	body		dto.DuplicateRequest	true	"Pair of transactions"
+//	@Success		200		{object}	map[string]string		"Duplicate dismissed successfully"
+//	@Failure		400		{object}	map[string]string		"Bad request - Invalid input"
+//	@Failure		404		{object}	map[string]string		"Transaction not found"
+//	@Router			/transaction/duplicates/dismiss [post]
+func DismissDuplicate(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var duplicateRequest dto.DuplicateRequest
+		if err := ctx.BindJSON(&duplicateRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := duplicateRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := transactionService.DismissDuplicate(ctx, userID, &duplicateRequest); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Duplicate dismissed successfully"})
+	}
+}
+
+// MergeDuplicate godoc
+//
+//	@Summary		Merge a duplicate transaction
+//	@Description	Keep transaction_id and move duplicate_id to the trash. The tags and attachments of the duplicate move to the one that is kept; a duplicate with refunds cannot be merged
+//	@Tags			Transactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			pair	body		dto.DuplicateRequest	true	"Transaction to keep and duplicate to remove"
+//	@Success		200		{object}	map[string]string		"Duplicate merged successfully"
+//	@Failure		400		{object}	map[string]string		"Bad request - Invalid input, transfer or duplicate with refunds"
+//	@Failure		404		{object}	map[string]string		"Transaction not found"
+//	@Failure		409		{object}	map[string]string		"Duplicate is reconciled"
+//	@Router			/transaction/duplicates/merge [post]
+func MergeDuplicate(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var duplicateRequest dto.DuplicateRequest
+		if err := ctx.BindJSON(&duplicateRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := duplicateRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := transactionService.MergeDuplicate(ctx, userID, &duplicateRequest); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Duplicate merged successfully"})
+	}
+}
@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// LinkRefund godoc
+//
+//	@Summary		Link an income as the refund of a bill
+//	@Description	Mark an income transaction as the refund of an earlier bill. The refund takes the category and budget of the bill and nets against its spending instead of counting as income
+//	@Tags			Transactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string				true	"Income transaction ID"
+//	@Param			refund	body		dto.RefundRequest	true	"Refunded bill"
+//	@Success		200		{object}	map[string]string	"Refund linked successfully"
+//	@Failure		400		{object}	map[string]string	"Bad request - The transactions cannot be linked"
+//	@Failure		404		{object}	map[string]string	"Transaction not found"
+//	@Failure		409		{object}	map[string]string	"Transaction is reconciled"
+//	@Router			/transaction/{id}/refund [put]
+func LinkRefund(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var refundRequest dto.RefundRequest
+		if err := ctx.BindJSON(&refundRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+
+		if err := transactionService.LinkRefund(ctx, ctx.Param("id"), userID, &refundRequest); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Refund linked successfully"})
+	}
+}
+
+// UnlinkRefund godoc
+//
+//	@Summary		Unlink a refund
+//	@Description	Turn a refund back into a plain income. Its category is kept
+//	@Tags			Transactions
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Refund transaction ID"
+//	@Success		200	{object}	map[string]string	"Refund unlinked successfully"
+//	@Failure		404	{object}	map[string]string	"Refund not found"
+//	@Failure		409	{object}	map[string]string	"Transaction is reconciled"
+//	@Router			/transaction/{id}/refund [delete]
+func UnlinkRefund(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		if err := transactionService.UnlinkRefund(ctx, ctx.Param("id"), userID); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Refund unlinked successfully"})
+	}
+}
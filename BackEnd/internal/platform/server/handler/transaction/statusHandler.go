@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// UpdateTransactionStatus godoc
+//
+//	@Summary		Mark a transaction as pending or cleared
+//	@Description	Set whether the bank has confirmed a transaction. Transactions become reconciled only by completing a reconciliation
+//	@Tags			Transactions
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string				true	"Transaction ID"
+//	@Param			status	body		dto.StatusRequest	true	"New status"
+//	@Success		200		{object}	map[string]string	"Status updated successfully"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid status"
+//	@Failure		404		{object}	map[string]string	"Transaction not found"
+//	@Failure		409		{object}	map[string]string	"Transaction is reconciled"
+//	@Router			/transaction/{id}/status [patch]
+func UpdateTransactionStatus(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var statusRequest dto.StatusRequest
+		if err := ctx.BindJSON(&statusRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := statusRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := transactionService.UpdateStatus(ctx, ctx.Param("id"), userID, &statusRequest); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Status updated successfully"})
+	}
+}
+
+// UnlockTransaction godoc
+//
+//	@Summary		Unlock a reconciled transaction
+//	@Description	Take a transaction out of its reconciliation so it can be edited or deleted again. It goes back to cleared
+//	@Tags			Transactions
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Transaction ID"
+//	@Success		200	{object}	map[string]string	"Transaction unlocked successfully"
+//	@Failure		404	{object}	map[string]string	"Reconciled transaction not found"
+//	@Router			/transaction/{id}/unlock [post]
+func UnlockTransaction(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		if err := transactionService.Unlock(ctx, ctx.Param("id"), userID); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Transaction unlocked successfully"})
+	}
+}
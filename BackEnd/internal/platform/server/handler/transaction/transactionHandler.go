@@ -7,23 +7,27 @@ import (
 	"github.com/gin-gonic/gin"
 	domain "github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	viewDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/view"
 	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
 	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
 	"github.com/rs/zerolog/log"
 )
 
 // CreateTransaction godoc
 //
 //	@Summary		Create a new transaction
-//	@Description	Create a new financial transaction (income or expense)
+//	@Description	Create a new financial transaction (income or expense). Provide splits to spread the amount across several categories
 //	@Tags			Transactions
 //	@Accept			json
 //	@Produce		json
 //	@Security		JWT
 //	@Param			transaction	body		dto.TransactionRequest	true	"Transaction creation data"
+//	@Param			Idempotency-Key	header	string	false	"Key to retry the request safely; a retry with the same key and body gets the first response back"
 //	@Success		201			{object}	map[string]string		"Transaction created successfully"
 //	@Failure		400			{object}	map[string]string		"Bad request - Invalid input"
 //	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		409		{object}	map[string]string	"Idempotency key reused with a different request, or still being processed"
 //	@Failure		500			{object}	map[string]string		"Internal server error"
 //	@Router			/transaction [post]
 func CreateTransaction(transactionservice *transaction.TransactionService) gin.HandlerFunc {
@@ -51,6 +55,8 @@ func CreateTransaction(transactionservice *transaction.TransactionService) gin.H
 			transactionRequest.CategoryId,
 			transactionRequest.BudgetId,
 			transactionRequest.CreatedAt,
+			transactionRequest.Tags,
+			toDomainSplits(transactionRequest.Splits)...,
 		)
 		if err != nil {
 			_ = ctx.Error(err)
@@ -72,6 +78,7 @@ func CreateTransaction(transactionservice *transaction.TransactionService) gin.H
 //	@Param			page			query		int					false	"Page number for pagination"		example(1)
 //	@Param			limit			query		int					false	"Number of records per page (max 100)"	example(20)
 //	@Param			offset			query		int					false	"Number of records to skip"		example(0)
+//	@Param			cursor			query		string					false	"Opaque cursor from next_cursor or prev_cursor, replaces page and offset (requires sort_by=created_at)"
 //	@Param			sort_by			query		string					false	"Field to sort by"				example("created_at") 	enums(created_at,amount,name,type_transation)
 //	@Param			sort_order		query		string					false	"Sort order"					example("desc") 		enums(asc,desc)
 //	@Param			type			query		string					false	"Transaction type filter"		example("all") 			enums(income,expense,all)
@@ -79,6 +86,7 @@ func CreateTransaction(transactionservice *transaction.TransactionService) gin.H
 //	@Param			categories		query		string					false	"Filter by multiple categories (comma-separated)"	example("cat_1,cat_2,cat_3")
 //	@Param			account_id		query		string					false	"Filter by account ID"			example("acc_123456789")
 //	@Param			budget_id		query		string					false	"Filter by budget ID"			example("budget_555666777")
+//	@Param			payee_id		query		string					false	"Filter by payee ID"			example("payee_123456789")
 //	@Param			date_from		query		string					false	"Start date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/01/01")
 //	@Param			date_to			query		string					false	"End date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/12/31")
 //	@Param			period			query		string					false	"Predefined period filter"		example("this_month") 	enums(today,this_week,this_month,this_year,last_week,last_month,last_year)
@@ -86,18 +94,31 @@ func CreateTransaction(transactionservice *transaction.TransactionService) gin.H
 //	@Param			amount_max		query		number					false	"Maximum amount filter"			example(1000.00)
 //	@Param			search			query		string					false	"Search in name and description"	example("grocery")
 //	@Param			include_summary	query		bool					false	"Include summary statistics"	example(true)
+//	@Param			view_id			query		string					false	"Saved view to apply; other parameters override those of the view"	example("view_123456789")
 //	@Success		200		{object}	dto.PaginatedTransactionResponse	"Paginated list of transactions"
 //	@Failure		400		{object}	map[string]string				"Bad request - Invalid parameters"
 //	@Failure		401		{object}	map[string]string				"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string				"Saved view not found"
 //	@Failure		500		{object}	map[string]string				"Internal server error"
 //	@Router			/transaction [get]
-func FindAllTransactionOfAllAccount(transactionService *transaction.TransactionService) gin.HandlerFunc {
+func FindAllTransactionOfAllAccount(transactionService *transaction.TransactionService, viewService *view.ViewService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userID := ctx.GetString("X-User-Id")
 
+		// Expand the saved view, if any, under the parameters of the request
+		query := ctx.Request.URL.Query()
+		if viewId := query.Get(viewDto.ViewParam); viewId != "" {
+			expanded, err := viewService.Expand(ctx, userID, viewId, query)
+			if err != nil {
+				_ = ctx.Error(err)
+				return
+			}
+			query = expanded
+		}
+
 		// Parse filter parameters
 		filter := dto.NewTransactionFilter()
-		if err := filter.ParseFromQuery(ctx); err != nil {
+		if err := filter.ParseFromValues(query); err != nil {
 			log.Error().Err(err).Msg("failed to parse filter parameters")
 			_ = ctx.Error(apperrors.NewValidationError("INVALID_QUERY_PARAMS", "Invalid filter parameters: "+err.Error()))
 			return
@@ -159,12 +180,14 @@ func FindAllTransactionOfAllAccount(transactionService *transaction.TransactionS
 //	@Param			page			query		int					false	"Page number for pagination"		example(1)
 //	@Param			limit			query		int					false	"Number of records per page (max 100)"	example(20)
 //	@Param			offset			query		int					false	"Number of records to skip"		example(0)
+//	@Param			cursor			query		string					false	"Opaque cursor from next_cursor or prev_cursor, replaces page and offset (requires sort_by=created_at)"
 //	@Param			sort_by			query		string					false	"Field to sort by"				example("created_at") 	enums(created_at,amount,name,type_transation)
 //	@Param			sort_order		query		string					false	"Sort order"					example("desc") 		enums(asc,desc)
 //	@Param			type			query		string					false	"Transaction type filter"		example("all") 			enums(income,expense,all)
 //	@Param			category_id		query		string					false	"Filter by category ID"			example("cat_123456789")
 //	@Param			categories		query		string					false	"Filter by multiple categories (comma-separated)"	example("cat_1,cat_2,cat_3")
 //	@Param			budget_id		query		string					false	"Filter by budget ID"			example("budget_555666777")
+//	@Param			payee_id		query		string					false	"Filter by payee ID"			example("payee_123456789")
 //	@Param			date_from		query		string					false	"Start date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/01/01")
 //	@Param			date_to			query		string					false	"End date (YYYY/MM/DD or YYYY-MM-DD)"	example("2024/12/31")
 //	@Param			period			query		string					false	"Predefined period filter"		example("this_month") 	enums(today,this_week,this_month,this_year,last_week,last_month,last_year)
@@ -181,6 +204,7 @@ func FindAllTransactionOfAllAccount(transactionService *transaction.TransactionS
 func FindAllTransaction(transactionService *transaction.TransactionService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		accountId := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
 
 		// Parse filter parameters
 		filter := dto.NewTransactionFilter()
@@ -204,7 +228,7 @@ func FindAllTransaction(transactionService *transaction.TransactionService) gin.
 		includeSummary := ctx.Query("include_summary") == "true"
 
 		// Get filtered and paginated transactions
-		result, err := transactionService.FindAllWithFilters(ctx, filter, includeSummary)
+		result, err := transactionService.FindAllWithFilters(ctx, userId, filter, includeSummary)
 		if err != nil {
 			_ = ctx.Error(err)
 			return
@@ -274,17 +298,38 @@ func UpdateTransaction(s *transaction.TransactionService) gin.HandlerFunc {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
 		}
+		if err := transactionRequest.ValidateSplits(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+		if err := transactionRequest.ValidateTags(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
 		userId := ctx.MustGet("X-User-Id").(string)
 		transactionObj := domain.NewTransaction(id, transactionRequest.Name, transactionRequest.Description, transactionRequest.TypeTransation, transactionRequest.AccountId, transactionRequest.CategoryId, transactionRequest.Amount)
 		if !transactionRequest.CreatedAt.IsZero() {
 			transactionObj.CreatedAt = transactionRequest.CreatedAt
 		}
 		transactionObj.UserId = userId
+		transactionObj.Splits = toDomainSplits(transactionRequest.Splits)
+		transactionObj.Tags = transactionRequest.Tags
 
 		if err := s.UpdateTransaction(ctx, id, transactionObj); err != nil {
-			ctx.JSON(http.StatusInternalServerError, err.Error())
+			_ = ctx.Error(err)
 			return
 		}
 		ctx.Status(http.StatusOK)
 	}
 }
+
+// toDomainSplits maps the split lines of a request to domain splits; ids and budgets are assigned by the service.
+func toDomainSplits(requests []dto.SplitRequest) []*domain.Split {
+	var splits []*domain.Split
+	for _, request := range requests {
+		split := domain.NewSplit("", "", request.CategoryId, request.Amount)
+		split.Description = request.Description
+		splits = append(splits, split)
+	}
+	return splits
+}
@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+// CreateTransfer godoc
+//
+//	@Summary		Transfer money between accounts
+//	@Description	Create an account-to-account transfer. Both legs are written atomically, share a transfer id and are excluded from income/expense analytics and budgets.
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			transfer	body		dto.TransferRequest		true	"Transfer data"
+//	@Param			Idempotency-Key	header	string	false	"Key to retry the request safely; a retry with the same key and body gets the first response back"
+//	@Success		201			{object}	dto.TransferResponse	"Transfer created successfully"
+//	@Failure		400			{object}	map[string]string		"Bad request - Invalid input"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		409		{object}	map[string]string	"Idempotency key reused with a different request, or still being processed"
+//	@Failure		500			{object}	map[string]string		"Internal server error"
+//	@Router			/transfer [post]
+func CreateTransfer(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var transferRequest dto.TransferRequest
+		if err := ctx.BindJSON(&transferRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := transferRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		transfer, err := transactionService.CreateTransfer(ctx, userID, &transferRequest)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, transfer)
+	}
+}
+
+// FindTransfer godoc
+//
+//	@Summary		Get a transfer
+//	@Description	Retrieve both legs of an account-to-account transfer
+//	@Tags			Transfers
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string					true	"Transfer ID"
+//	@Success		200	{object}	dto.TransferResponse	"Transfer legs"
+//	@Failure		401	{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string		"Transfer not found"
+//	@Router			/transfer/{id} [get]
+func FindTransfer(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+		transfer, err := transactionService.FindTransfer(ctx, ctx.Param("id"), userID)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, transfer)
+	}
+}
+
+// UpdateTransfer godoc
+//
+//	@Summary		Update a transfer
+//	@Description	Update amount, accounts, name, description or date of both legs of a transfer at once
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id			path		string				true	"Transfer ID"
+//	@Param			transfer	body		dto.TransferRequest	true	"Transfer data"
+//	@Success		200			{object}	map[string]string	"Transfer updated successfully"
+//	@Failure		400			{object}	map[string]string	"Bad request - Invalid input"
+//	@Failure		404			{object}	map[string]string	"Transfer not found"
+//	@Router			/transfer/{id} [put]
+func UpdateTransfer(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+
+		var transferRequest dto.TransferRequest
+		if err := ctx.BindJSON(&transferRequest); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := transferRequest.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		if err := transactionService.UpdateTransfer(ctx, ctx.Param("id"), userID, &transferRequest); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Transfer updated successfully"})
+	}
+}
+
+// DeleteTransfer godoc
+//
+//	@Summary		Delete a transfer
+//	@Description	Delete both legs of an account-to-account transfer
+//	@Tags			Transfers
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"Transfer ID"
+//	@Success		200	{object}	map[string]string	"Transfer deleted successfully"
+//	@Failure		404	{object}	map[string]string	"Transfer not found"
+//	@Router			/transfer/{id} [delete]
+func DeleteTransfer(transactionService *transaction.TransactionService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := ctx.GetString("X-User-Id")
+		if err := transactionService.DeleteTransfer(ctx, ctx.Param("id"), userID); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
@@ -0,0 +1,58 @@
+package trashHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/osmait/gestorDePresupuesto/internal/services/trash"
+)
+
+// FindTrash godoc
+//
+//	@Summary		List the trash
+//	@Description	Retrieve the deleted transactions, accounts or categories of the user that can still be restored, most recently deleted first
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		JWT
+//	@Param			entity_type	path		string					true	"Record type"	enums(transaction,account,category)
+//	@Success		200			{array}		dto.TrashItemResponse	"Trashed records"
+//	@Failure		400			{object}	map[string]string		"Bad request - Unknown record type"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Failure		500			{object}	map[string]string		"Internal server error"
+//	@Router			/trash/{entity_type} [get]
+func FindTrash(trashService *trash.TrashService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		items, err := trashService.FindTrash(ctx, ctx.Param("entity_type"), userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, items)
+	}
+}
+
+// Restore godoc
+//
+//	@Summary		Restore a record from the trash
+//	@Description	Take a deleted transaction, account or category out of the trash. Restoring an account also restores the transactions deleted with it
+//	@Tags			Trash
+//	@Produce		json
+//	@Security		JWT
+//	@Param			entity_type	path		string				true	"Record type"	enums(transaction,account,category)
+//	@Param			id			path		string				true	"Record ID"
+//	@Success		200			{object}	map[string]string	"Record restored successfully"
+//	@Failure		400			{object}	map[string]string	"Bad request - Unknown record type"
+//	@Failure		404			{object}	map[string]string	"Record not found in the trash"
+//	@Failure		409			{object}	map[string]string	"Conflict - The account of the transaction is in the trash"
+//	@Router			/trash/{entity_type}/{id}/restore [post]
+func Restore(trashService *trash.TrashService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		if err := trashService.Restore(ctx, ctx.Param("entity_type"), ctx.Param("id"), userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Record restored successfully"})
+	}
+}
@@ -33,6 +33,42 @@ func GetProfile(userService *user.UserService) gin.HandlerFunc {
 	}
 }
 
+// UpdateBaseCurrency godoc
+//
+//	@Summary		Change the base currency
+//	@Description	Set the currency the reports of the authenticated user are converted into
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			currency	body		dto.BaseCurrencyRequest	true	"ISO 4217 currency code"
+//	@Success		200			{object}	dto.UserResponse		"Updated profile"
+//	@Failure		400			{object}	map[string]string		"Bad request - Invalid currency"
+//	@Failure		401			{object}	map[string]string		"Unauthorized - Invalid JWT token"
+//	@Router			/profile/currency [put]
+func UpdateBaseCurrency(userService *user.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userid := c.GetString("X-User-Id")
+		var request dto.BaseCurrencyRequest
+		if err := c.BindJSON(&request); err != nil {
+			_ = c.Error(apperrors.NewValidationError("INVALID_JSON", err.Error()))
+			return
+		}
+
+		if err := userService.UpdateBaseCurrency(c, userid, request.BaseCurrency); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		user, err := userService.FindUserById(c, userid)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, user)
+	}
+}
+
 func CreateUser(userService *user.UserService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var user dto.UserRequest
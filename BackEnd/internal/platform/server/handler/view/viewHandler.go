@@ -0,0 +1,159 @@
+package viewHandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/view"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
+)
+
+// CreateView godoc
+//
+//	@Summary		Create a saved view
+//	@Description	Save a named transaction filter. The query takes the filter parameters of GET /transaction; relative periods are resolved each time the view is used
+//	@Tags			Views
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			view	body		dto.ViewRequest		true	"View"
+//	@Success		201		{object}	dto.ViewResponse	"View created"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid filter or name already in use"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/view [post]
+func CreateView(viewService *view.ViewService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		var request dto.ViewRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := viewService.CreateView(ctx, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// FindViews godoc
+//
+//	@Summary		List saved views
+//	@Description	Retrieve the saved views of the authenticated user in alphabetical order
+//	@Tags			Views
+//	@Produce		json
+//	@Security		JWT
+//	@Success		200	{array}		dto.ViewResponse	"List of views"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/view [get]
+func FindViews(viewService *view.ViewService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetString("X-User-Id")
+		views, err := viewService.FindViews(ctx, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, views)
+	}
+}
+
+// FindView godoc
+//
+//	@Summary		Get a saved view
+//	@Description	Retrieve one saved view of the authenticated user
+//	@Tags			Views
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"View ID"
+//	@Success		200	{object}	dto.ViewResponse	"View"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"View not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/view/{id} [get]
+func FindView(viewService *view.ViewService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		response, err := viewService.FindView(ctx, id, userId)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdateView godoc
+//
+//	@Summary		Update a saved view
+//	@Description	Rename a saved view and replace its filter
+//	@Tags			Views
+//	@Accept			json
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id		path		string				true	"View ID"
+//	@Param			view	body		dto.ViewRequest		true	"View"
+//	@Success		200		{object}	dto.ViewResponse	"View updated"
+//	@Failure		400		{object}	map[string]string	"Bad request - Invalid filter or name already in use"
+//	@Failure		401		{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404		{object}	map[string]string	"View not found"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/view/{id} [put]
+func UpdateView(viewService *view.ViewService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		var request dto.ViewRequest
+		if err := ctx.BindJSON(&request); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("INVALID_JSON", "Error fields required"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			_ = ctx.Error(apperrors.NewValidationError("VALIDATION_FAILED", err.Error()))
+			return
+		}
+
+		response, err := viewService.UpdateView(ctx, id, userId, &request)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// DeleteView godoc
+//
+//	@Summary		Delete a saved view
+//	@Description	Delete a saved view
+//	@Tags			Views
+//	@Produce		json
+//	@Security		JWT
+//	@Param			id	path		string				true	"View ID"
+//	@Success		200	{object}	map[string]string	"View deleted"
+//	@Failure		401	{object}	map[string]string	"Unauthorized - Invalid JWT token"
+//	@Failure		404	{object}	map[string]string	"View not found"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/view/{id} [delete]
+func DeleteView(viewService *view.ViewService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		userId := ctx.GetString("X-User-Id")
+		if err := viewService.DeleteView(ctx, id, userId); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, "Deleted")
+	}
+}
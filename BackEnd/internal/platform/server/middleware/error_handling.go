@@ -121,6 +121,11 @@ func handleErrors(c *gin.Context, errors []*gin.Error, config ErrorHandlerConfig
 			WithCause(lastError.Err).
 			WithContext(ctx).
 			WithOperation(c.Request.Method + " " + c.Request.URL.Path)
+	} else if errorhttp.IsErrConflict(lastError.Err) {
+		appErr = apperrors.NewConflictError("Resource", lastError.Err.Error()).
+			WithCause(lastError.Err).
+			WithContext(ctx).
+			WithOperation(c.Request.Method + " " + c.Request.URL.Path)
 	} else if errorhttp.IsErrNotBadRequest(lastError.Err) {
 		appErr = apperrors.NewValidationError("BAD_REQUEST", lastError.Err.Error()).
 			WithCause(lastError.Err).
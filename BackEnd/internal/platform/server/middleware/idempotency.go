@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	domain "github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/idempotency"
+	"github.com/rs/zerolog/log"
+)
+
+// IdempotentReplayHeader marks a response replayed from an earlier request with the same idempotency key.
+const IdempotentReplayHeader = "Idempotent-Replayed"
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to retry. The first request runs as
+// usual and its successful response is stored; a retry with the same key and body gets that response back
+// without running the handler again, and reusing the key with a different body is a conflict. Requests that fail
+// are not stored, so they can be retried. It must run after authentication, since keys are scoped to the user.
+func Idempotency(idempotencyService *idempotency.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(domain.Header)
+		userId := c.GetString("X-User-Id")
+		if key == "" || userId == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+		if err := domain.ValidateKey(key); err != nil {
+			_ = c.Error(apperrors.NewValidationError("INVALID_IDEMPOTENCY_KEY", err.Error()))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			_ = c.Error(apperrors.NewValidationError("INVALID_BODY", "Request body could not be read"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := idempotencyService.Begin(c, userId, key, domain.HashRequest(c.Request.Method, c.Request.URL.Path, body))
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		if record != nil {
+			c.Header(IdempotentReplayHeader, "true")
+			c.Data(record.StatusCode, record.ContentType, record.Response)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+		c.Next()
+
+		// The outcome is stored even if the client went away, since that is exactly when it will retry
+		ctx := context.WithoutCancel(c.Request.Context())
+		status := recorder.Status()
+		if len(c.Errors) == 0 && status >= http.StatusOK && status < http.StatusMultipleChoices {
+			err = idempotencyService.Complete(ctx, userId, key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+		} else {
+			err = idempotencyService.Release(ctx, userId, key)
+		}
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("failed to store the outcome of an idempotent request")
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	domain "github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+	"github.com/osmait/gestorDePresupuesto/internal/services/idempotency"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyRepository keeps idempotency records in a map, enough to drive the middleware.
+type memoryIdempotencyRepository struct {
+	records map[string]*domain.Record
+}
+
+func (m *memoryIdempotencyRepository) Reserve(ctx context.Context, record *domain.Record) (bool, error) {
+	if existing, ok := m.records[record.UserId+record.Key]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return false, nil
+	}
+	m.records[record.UserId+record.Key] = record
+	return true, nil
+}
+
+func (m *memoryIdempotencyRepository) FindByKey(ctx context.Context, userId string, key string) (*domain.Record, error) {
+	if record, ok := m.records[userId+key]; ok {
+		return record, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memoryIdempotencyRepository) Complete(ctx context.Context, record *domain.Record) error {
+	stored := m.records[record.UserId+record.Key]
+	stored.StatusCode, stored.ContentType, stored.Response = record.StatusCode, record.ContentType, record.Response
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Release(ctx context.Context, userId string, key string) error {
+	if record, ok := m.records[userId+key]; ok && !record.Completed() {
+		delete(m.records, userId+key)
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func setupIdempotentRouter(created *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	service := idempotency.NewIdempotencyService(&memoryIdempotencyRepository{records: map[string]*domain.Record{}}, time.Hour)
+
+	r := gin.New()
+	r.Use(ErrorHandler(DefaultErrorHandlerConfig()))
+	r.Use(func(c *gin.Context) { c.Set("X-User-Id", c.GetHeader("X-Test-User")) })
+	r.POST("/transaction", Idempotency(service), func(c *gin.Context) {
+		var body struct {
+			Amount float64 `json:"amount"`
+		}
+		if err := c.BindJSON(&body); err != nil || body.Amount == 0 {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		*created++
+		c.JSON(http.StatusCreated, gin.H{"created": *created})
+	})
+	return r
+}
+
+func postTransaction(r *gin.Engine, user string, key string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/transaction", strings.NewReader(body))
+	req.Header.Set("X-Test-User", user)
+	if key != "" {
+		req.Header.Set(domain.Header, key)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency(t *testing.T) {
+	t.Run("a retry replays the first response", func(t *testing.T) {
+		created := 0
+		r := setupIdempotentRouter(&created)
+
+		first := postTransaction(r, "user_1", "key-1", `{"amount": 10}`)
+		retry := postTransaction(r, "user_1", "key-1", `{"amount":10}`)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayHeader))
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("a key reused with a different body is a conflict", func(t *testing.T) {
+		created := 0
+		r := setupIdempotentRouter(&created)
+
+		postTransaction(r, "user_1", "key-1", `{"amount": 10}`)
+		w := postTransaction(r, "user_1", "key-1", `{"amount": 20}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("keys are scoped to the user and optional", func(t *testing.T) {
+		created := 0
+		r := setupIdempotentRouter(&created)
+
+		postTransaction(r, "user_1", "key-1", `{"amount": 10}`)
+		postTransaction(r, "user_2", "key-1", `{"amount": 10}`)
+		postTransaction(r, "user_1", "", `{"amount": 10}`)
+		postTransaction(r, "user_1", "", `{"amount": 10}`)
+		assert.Equal(t, 4, created)
+	})
+
+	t.Run("failed requests can be retried", func(t *testing.T) {
+		created := 0
+		r := setupIdempotentRouter(&created)
+
+		assert.Equal(t, http.StatusBadRequest, postTransaction(r, "user_1", "key-1", `{"amount": 0}`).Code)
+		assert.Equal(t, http.StatusBadRequest, postTransaction(r, "user_1", "key-1", `{"amount": 0}`).Code)
+		assert.Equal(t, http.StatusCreated, postTransaction(r, "user_1", "key-2", `{"amount": 10}`).Code)
+	})
+
+	t.Run("rejects keys that are too long", func(t *testing.T) {
+		created := 0
+		r := setupIdempotentRouter(&created)
+
+		w := postTransaction(r, "user_1", strings.Repeat("k", domain.MaxKeyLength+1), `{"amount": 10}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, 0, created)
+	})
+}
@@ -2,14 +2,17 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/osmait/gestorDePresupuesto/internal/config"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
 )
 
 // LoggingConfig holds configuration for logging middleware
@@ -338,3 +341,19 @@ func CorrelationID() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequestID middleware tags every request with the X-Request-ID header, or a new id when the client sent none, and
+// stores it in the request context so errors and audit events can be traced back to the request
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = ksuid.New().String()
+		}
+
+		c.Header("X-Request-ID", requestID)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), apperrors.ContextKeyRequestID, requestID))
+
+		c.Next()
+	}
+}
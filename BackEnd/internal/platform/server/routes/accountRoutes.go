@@ -4,12 +4,17 @@ import (
 	"github.com/gin-gonic/gin"
 	handler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/account"
 	"github.com/osmait/gestorDePresupuesto/internal/services/account"
+	"github.com/osmait/gestorDePresupuesto/internal/services/balance"
 )
 
-func AccountRotes(s *gin.Engine, acountService *account.AccountService) {
-	s.POST("/account", handler.CreateAccount(acountService))
+func AccountRotes(s *gin.Engine, acountService *account.AccountService, balanceService *balance.BalanceService, idempotent gin.HandlerFunc) {
+	s.POST("/account", idempotent, handler.CreateAccount(acountService))
 	s.GET("/account", handler.FindAllAccount(acountService))
 	s.DELETE("/account/:id", handler.DeleteAccount(acountService))
 	s.GET("/account/:id", handler.FindAccount(acountService))
 	s.PUT("/account/:id", handler.UpdateAccount(acountService))
+	s.POST("/account/:id/archive", handler.ArchiveAccount(acountService))
+	s.POST("/account/:id/unarchive", handler.UnarchiveAccount(acountService))
+	s.GET("/account/:id/balance", handler.BalanceAt(balanceService))
+	s.GET("/account/:id/balance-history", handler.BalanceHistory(balanceService))
 }
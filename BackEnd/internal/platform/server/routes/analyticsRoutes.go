@@ -9,5 +9,7 @@ import (
 func AnalyticsRoutes(r *gin.Engine, analyticsService *analytics.AnalyticsService) {
 	analytics := r.Group("/analytics")
 	analytics.GET("/category-expenses", analyticsHandler.GetCategoryExpenses(analyticsService))
+	analytics.GET("/tag-expenses", analyticsHandler.GetTagExpenses(analyticsService))
+	analytics.GET("/top-payees", analyticsHandler.GetTopPayees(analyticsService))
 	analytics.GET("/monthly-summary", analyticsHandler.GetMonthlySummary(analyticsService))
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	attachmentHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/attachment"
+)
+
+func AttachmentRoutes(s *gin.Engine, attachmentService *attachment.AttachmentService) {
+	s.POST("/transaction/:id/attachment", attachmentHandler.UploadAttachment(attachmentService))
+	s.GET("/transaction/:id/attachment", attachmentHandler.FindAttachments(attachmentService))
+	s.GET("/attachment/:id", attachmentHandler.DownloadAttachment(attachmentService))
+	s.DELETE("/attachment/:id", attachmentHandler.DeleteAttachment(attachmentService))
+}
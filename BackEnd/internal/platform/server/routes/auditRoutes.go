@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	auditHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/server/middleware"
+	"github.com/osmait/gestorDePresupuesto/internal/services/audit"
+)
+
+func AuditRoutes(s *gin.Engine, auditService *audit.AuditService) {
+	s.GET("/audit", middleware.RequireRole("ADMIN"), auditHandler.FindEvents(auditService))
+	s.GET("/audit/:entity_type/:entity_id", auditHandler.FindHistory(auditService))
+}
@@ -6,8 +6,8 @@ import (
 	"github.com/osmait/gestorDePresupuesto/internal/services/budget"
 )
 
-func BudgetRoutes(s *gin.Engine, budgetServices *budget.BudgetServices) {
-	s.POST("/budget", budgetHandler.CreateBudget(budgetServices))
+func BudgetRoutes(s *gin.Engine, budgetServices *budget.BudgetServices, idempotent gin.HandlerFunc) {
+	s.POST("/budget", idempotent, budgetHandler.CreateBudget(budgetServices))
 	s.GET("/budget", budgetHandler.FindAllBudget(budgetServices))
 	s.DELETE("/budget/:id", budgetHandler.DeleteBudget(budgetServices))
 	s.PUT("/budget/:id", budgetHandler.UpdateBudget(budgetServices))
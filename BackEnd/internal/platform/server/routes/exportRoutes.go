@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	exportHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/export"
+	"github.com/osmait/gestorDePresupuesto/internal/services/export"
+)
+
+func ExportRoutes(s *gin.Engine, exportService *export.ExportService) {
+	s.GET("/export/transactions", exportHandler.ExportTransactions(exportService))
+}
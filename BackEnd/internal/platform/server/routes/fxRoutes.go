@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	fxHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/server/middleware"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+)
+
+func FxRoutes(s *gin.Engine, fxService *fx.FxService) {
+	s.GET("/fx/rates", fxHandler.FindRates(fxService))
+	s.POST("/fx/rates", middleware.RequireRole("ADMIN"), fxHandler.SaveRate(fxService))
+	s.POST("/fx/rates/sync", middleware.RequireRole("ADMIN"), fxHandler.SyncRates(fxService))
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	importerHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/services/importer"
+)
+
+func ImportRoutes(s *gin.Engine, importService *importer.ImportService) {
+	s.POST("/import/mapping", importerHandler.CreateImportMapping(importService))
+	s.GET("/import/mapping", importerHandler.FindImportMappings(importService))
+	s.PUT("/import/mapping/:id", importerHandler.UpdateImportMapping(importService))
+	s.DELETE("/import/mapping/:id", importerHandler.DeleteImportMapping(importService))
+
+	s.POST("/import/csv/preview", importerHandler.PreviewCSVImport(importService))
+	s.POST("/import/csv", importerHandler.ImportCSV(importService))
+	s.POST("/import/ofx", importerHandler.ImportOFX(importService))
+	s.POST("/import/qif", importerHandler.ImportQIF(importService))
+}
@@ -6,11 +6,11 @@ import (
 	investmentService "github.com/osmait/gestorDePresupuesto/internal/services/investment"
 )
 
-func InvestmentRoutes(r *gin.Engine, service *investmentService.InvestmentService) {
+func InvestmentRoutes(r *gin.Engine, service *investmentService.InvestmentService, idempotent gin.HandlerFunc) {
 	handler := investmentHandler.NewInvestmentHandler(service)
 	routes := r.Group("/investments")
 	{
-		routes.POST("", handler.Create)
+		routes.POST("", idempotent, handler.Create)
 		routes.GET("", handler.FindAll)
 		routes.PUT("", handler.Update)
 		routes.DELETE("/:id", handler.Delete)
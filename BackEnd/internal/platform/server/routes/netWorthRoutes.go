@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	networthHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/networth"
+	"github.com/osmait/gestorDePresupuesto/internal/services/networth"
+)
+
+func NetWorthRoutes(s *gin.Engine, netWorthService *networth.NetWorthService) {
+	s.GET("/net-worth", networthHandler.CurrentNetWorth(netWorthService))
+	s.GET("/net-worth/history", networthHandler.NetWorthHistory(netWorthService))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	payeeHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/services/payee"
+)
+
+func PayeeRoutes(s *gin.Engine, payeeService *payee.PayeeService) {
+	s.POST("/payee", payeeHandler.CreatePayee(payeeService))
+	s.GET("/payee", payeeHandler.FindPayees(payeeService))
+	s.PUT("/payee/:id", payeeHandler.UpdatePayee(payeeService))
+	s.DELETE("/payee/:id", payeeHandler.DeletePayee(payeeService))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	reconciliationHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/reconciliation"
+	"github.com/osmait/gestorDePresupuesto/internal/services/reconciliation"
+)
+
+func ReconciliationRoutes(s *gin.Engine, reconciliationService *reconciliation.ReconciliationService) {
+	s.POST("/account/:id/reconciliation", reconciliationHandler.StartReconciliation(reconciliationService))
+	s.GET("/account/:id/reconciliation", reconciliationHandler.FindReconciliations(reconciliationService))
+	s.GET("/reconciliation/:id", reconciliationHandler.FindReconciliation(reconciliationService))
+	s.POST("/reconciliation/:id/complete", reconciliationHandler.CompleteReconciliation(reconciliationService))
+}
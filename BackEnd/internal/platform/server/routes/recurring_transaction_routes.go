@@ -6,11 +6,11 @@ import (
 	"github.com/osmait/gestorDePresupuesto/internal/services/recurring_transaction"
 )
 
-func RecurringTransactionRoutes(s *gin.Engine, service *recurring_transaction.RecurringTransactionService) {
+func RecurringTransactionRoutes(s *gin.Engine, service *recurring_transaction.RecurringTransactionService, idempotent gin.HandlerFunc) {
 	h := handler.NewRecurringTransactionHandler(service)
 	group := s.Group("/recurring-transactions")
 	{
-		group.POST("", h.Create)
+		group.POST("", idempotent, h.Create)
 		group.POST("/process", h.Process)
 		group.GET("", h.FindAll)
 		group.PUT("/:id", h.Update)
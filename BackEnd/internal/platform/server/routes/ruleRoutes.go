@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	ruleHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/services/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+)
+
+func RuleRoutes(s *gin.Engine, ruleService *rule.RuleService, transactionService *transaction.TransactionService) {
+	s.POST("/rule", ruleHandler.CreateRule(ruleService))
+	s.GET("/rule", ruleHandler.FindRules(ruleService))
+	s.PUT("/rule/:id", ruleHandler.UpdateRule(ruleService))
+	s.DELETE("/rule/:id", ruleHandler.DeleteRule(ruleService))
+
+	s.POST("/rule/apply", ruleHandler.ApplyRules(transactionService))
+}
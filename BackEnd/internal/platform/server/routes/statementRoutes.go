@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	handler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/account"
+	"github.com/osmait/gestorDePresupuesto/internal/services/statement"
+)
+
+func StatementRoutes(s *gin.Engine, statementService *statement.StatementService, idempotent gin.HandlerFunc) {
+	s.GET("/account/:id/statements", handler.ListStatements(statementService))
+	s.POST("/account/:id/statements/pay", idempotent, handler.PayStatement(statementService))
+}
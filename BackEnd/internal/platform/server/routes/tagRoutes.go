@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	tagHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/services/tag"
+)
+
+func TagRoutes(s *gin.Engine, tagService *tag.TagService) {
+	s.POST("/tag", tagHandler.CreateTag(tagService))
+	s.GET("/tag", tagHandler.FindTags(tagService))
+	s.PUT("/tag/:id", tagHandler.RenameTag(tagService))
+	s.DELETE("/tag/:id", tagHandler.DeleteTag(tagService))
+}
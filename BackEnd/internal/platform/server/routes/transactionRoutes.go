@@ -5,14 +5,28 @@ package routes
 import (
 	handler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/transaction"
 	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
 
 	"github.com/gin-gonic/gin"
 )
 
-func TransactionRoutes(s *gin.Engine, transactionService *transaction.TransactionService) {
-	s.POST("/transaction", handler.CreateTransaction(transactionService))
+func TransactionRoutes(s *gin.Engine, transactionService *transaction.TransactionService, viewService *view.ViewService, idempotent gin.HandlerFunc) {
+	s.POST("/transaction", idempotent, handler.CreateTransaction(transactionService))
 	s.GET("/transaction/:id", handler.FindAllTransaction(transactionService))
-	s.GET("/transaction", handler.FindAllTransactionOfAllAccount(transactionService))
+	s.GET("/transaction", handler.FindAllTransactionOfAllAccount(transactionService, viewService))
 	s.DELETE("/transaction/:id", handler.DeleteTransaction(transactionService))
 	s.PUT("/transaction/:id", handler.UpdateTransaction(transactionService))
+	s.PATCH("/transaction/:id/status", handler.UpdateTransactionStatus(transactionService))
+	s.POST("/transaction/:id/unlock", handler.UnlockTransaction(transactionService))
+	s.PUT("/transaction/:id/refund", handler.LinkRefund(transactionService))
+	s.DELETE("/transaction/:id/refund", handler.UnlinkRefund(transactionService))
+	s.POST("/transaction/bulk", handler.BulkTransactions(transactionService))
+	s.GET("/transaction/duplicates", handler.FindDuplicates(transactionService))
+	s.POST("/transaction/duplicates/dismiss", handler.DismissDuplicate(transactionService))
+	s.POST("/transaction/duplicates/merge", handler.MergeDuplicate(transactionService))
+
+	s.POST("/transfer", idempotent, handler.CreateTransfer(transactionService))
+	s.GET("/transfer/:id", handler.FindTransfer(transactionService))
+	s.PUT("/transfer/:id", handler.UpdateTransfer(transactionService))
+	s.DELETE("/transfer/:id", handler.DeleteTransfer(transactionService))
 }
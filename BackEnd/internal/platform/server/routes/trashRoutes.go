@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	trashHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/trash"
+	"github.com/osmait/gestorDePresupuesto/internal/services/trash"
+)
+
+func TrashRoutes(s *gin.Engine, trashService *trash.TrashService) {
+	s.GET("/trash/:entity_type", trashHandler.FindTrash(trashService))
+	s.POST("/trash/:entity_type/:id/restore", trashHandler.Restore(trashService))
+}
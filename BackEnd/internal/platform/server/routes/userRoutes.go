@@ -10,6 +10,7 @@ import (
 func UserRoute(s *gin.Engine, userService *user.UserService) {
 	s.GET("user/:id", handler.GetUser(userService))
 	s.GET("/profile", handler.GetProfile(userService))
+	s.PUT("/profile/currency", handler.UpdateBaseCurrency(userService))
 	s.POST("user", handler.CreateUser(userService))
 	s.DELETE("/users/demos", middleware.RequireRole("ADMIN"), handler.CleanupDemoUsers(userService))
 	s.GET("/users", middleware.RequireRole("ADMIN"), handler.GetUsers(userService))
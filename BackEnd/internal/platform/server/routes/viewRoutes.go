@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	viewHandler "github.com/osmait/gestorDePresupuesto/internal/platform/server/handler/view"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
+)
+
+func ViewRoutes(s *gin.Engine, viewService *view.ViewService) {
+	s.POST("/view", viewHandler.CreateView(viewService))
+	s.GET("/view", viewHandler.FindViews(viewService))
+	s.GET("/view/:id", viewHandler.FindView(viewService))
+	s.PUT("/view/:id", viewHandler.UpdateView(viewService))
+	s.DELETE("/view/:id", viewHandler.DeleteView(viewService))
+}
@@ -17,16 +17,31 @@ import (
 	"github.com/osmait/gestorDePresupuesto/internal/platform/server/routes"
 	"github.com/osmait/gestorDePresupuesto/internal/services/account"
 	"github.com/osmait/gestorDePresupuesto/internal/services/analytics"
+	"github.com/osmait/gestorDePresupuesto/internal/services/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/services/auth"
+	"github.com/osmait/gestorDePresupuesto/internal/services/balance"
 	"github.com/osmait/gestorDePresupuesto/internal/services/budget"
 	"github.com/osmait/gestorDePresupuesto/internal/services/category"
+	"github.com/osmait/gestorDePresupuesto/internal/services/export"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/services/idempotency"
+	"github.com/osmait/gestorDePresupuesto/internal/services/importer"
 	investmentService "github.com/osmait/gestorDePresupuesto/internal/services/investment"
+	"github.com/osmait/gestorDePresupuesto/internal/services/networth"
 	"github.com/osmait/gestorDePresupuesto/internal/services/notification"
+	"github.com/osmait/gestorDePresupuesto/internal/services/payee"
 	"github.com/osmait/gestorDePresupuesto/internal/services/quote"
+	"github.com/osmait/gestorDePresupuesto/internal/services/reconciliation"
 	"github.com/osmait/gestorDePresupuesto/internal/services/recurring_transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/rule"
 	"github.com/osmait/gestorDePresupuesto/internal/services/search"
+	"github.com/osmait/gestorDePresupuesto/internal/services/statement"
+	"github.com/osmait/gestorDePresupuesto/internal/services/tag"
 	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/trash"
 	"github.com/osmait/gestorDePresupuesto/internal/services/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/view"
 
 	_ "github.com/osmait/gestorDePresupuesto/docs"
 
@@ -36,23 +51,38 @@ import (
 )
 
 type Server struct {
-	httpAddr            string
-	Engine              *gin.Engine
-	servicesAccunt      *account.AccountService
-	servicesTransaction *transaction.TransactionService
-	servicesUser        *user.UserService
-	servicesAuth        *auth.AuthService
-	servicesBudget      *budget.BudgetServices
-	servicesCategory    *category.CategoryServices
-	analyticsService    *analytics.AnalyticsService
-	recurringService    *recurring_transaction.RecurringTransactionService
-	searchService       *search.SearchService
-	investmentService   *investmentService.InvestmentService
-	quoteService        *quote.QuoteService
-	notificationService *notification.NotificationService
-	shutdownTimeout     *time.Duration
-	db                  *sql.DB
-	config              *config.Config
+	httpAddr              string
+	Engine                *gin.Engine
+	servicesAccunt        *account.AccountService
+	servicesTransaction   *transaction.TransactionService
+	servicesUser          *user.UserService
+	servicesAuth          *auth.AuthService
+	servicesBudget        *budget.BudgetServices
+	servicesCategory      *category.CategoryServices
+	analyticsService      *analytics.AnalyticsService
+	recurringService      *recurring_transaction.RecurringTransactionService
+	searchService         *search.SearchService
+	investmentService     *investmentService.InvestmentService
+	quoteService          *quote.QuoteService
+	notificationService   *notification.NotificationService
+	importService         *importer.ImportService
+	exportService         *export.ExportService
+	ruleService           *rule.RuleService
+	tagService            *tag.TagService
+	attachmentService     *attachment.AttachmentService
+	fxService             *fx.FxService
+	reconciliationService *reconciliation.ReconciliationService
+	auditService          *audit.AuditService
+	trashService          *trash.TrashService
+	payeeService          *payee.PayeeService
+	viewService           *view.ViewService
+	idempotencyService    *idempotency.IdempotencyService
+	balanceService        *balance.BalanceService
+	netWorthService       *networth.NetWorthService
+	statementService      *statement.StatementService
+	shutdownTimeout       *time.Duration
+	db                    *sql.DB
+	config                *config.Config
 }
 
 func New(ctx context.Context,
@@ -73,26 +103,58 @@ func New(ctx context.Context,
 	cfg *config.Config,
 	quoteService *quote.QuoteService,
 	notificationService *notification.NotificationService,
+	importService *importer.ImportService,
+	exportService *export.ExportService,
+	ruleService *rule.RuleService,
+	tagService *tag.TagService,
+	attachmentService *attachment.AttachmentService,
+	fxService *fx.FxService,
+	reconciliationService *reconciliation.ReconciliationService,
+	auditService *audit.AuditService,
+	trashService *trash.TrashService,
+	payeeService *payee.PayeeService,
+	viewService *view.ViewService,
+	idempotencyService *idempotency.IdempotencyService,
+	balanceService *balance.BalanceService,
+	netWorthService *networth.NetWorthService,
+	statementService *statement.StatementService,
 ) (context.Context, *Server) {
 	srv := Server{
-		Engine:              gin.New(),
-		httpAddr:            fmt.Sprintf("%s:%d", host, port),
-		servicesAccunt:      servicesAccount,
-		servicesTransaction: transactionService,
-		servicesUser:        userService,
-		servicesAuth:        authService,
-		servicesBudget:      budgetService,
-		servicesCategory:    categoryServices,
-		analyticsService:    analyticsService,
-		recurringService:    recurringService,
-		searchService:       searchService,
-		investmentService:   investmentService,
-		quoteService:        quoteService,
-		notificationService: notificationService,
-		shutdownTimeout:     shutdownTimeout,
-		db:                  db,
-		config:              cfg,
+		Engine:                gin.New(),
+		httpAddr:              fmt.Sprintf("%s:%d", host, port),
+		servicesAccunt:        servicesAccount,
+		servicesTransaction:   transactionService,
+		servicesUser:          userService,
+		servicesAuth:          authService,
+		servicesBudget:        budgetService,
+		servicesCategory:      categoryServices,
+		analyticsService:      analyticsService,
+		recurringService:      recurringService,
+		searchService:         searchService,
+		investmentService:     investmentService,
+		quoteService:          quoteService,
+		notificationService:   notificationService,
+		importService:         importService,
+		exportService:         exportService,
+		ruleService:           ruleService,
+		tagService:            tagService,
+		attachmentService:     attachmentService,
+		fxService:             fxService,
+		reconciliationService: reconciliationService,
+		auditService:          auditService,
+		trashService:          trashService,
+		payeeService:          payeeService,
+		viewService:           viewService,
+		idempotencyService:    idempotencyService,
+		balanceService:        balanceService,
+		netWorthService:       netWorthService,
+		statementService:      statementService,
+		shutdownTimeout:       shutdownTimeout,
+		db:                    db,
+		config:                cfg,
 	}
+	// Let the services read request-scoped values, like the request id, through the gin context they receive
+	srv.Engine.ContextWithFallback = true
 	srv.registerRoutes()
 	return serverContext(ctx), &srv
 }
@@ -100,6 +162,7 @@ func New(ctx context.Context,
 func (s *Server) registerRoutes() {
 	// Basic middleware
 	s.Engine.Use(cors.AllowAll())
+	s.Engine.Use(middleware.RequestID())
 
 	// Error handling middleware (must be first)
 	s.Engine.Use(middleware.ErrorHandler(middleware.DefaultErrorHandlerConfig()))
@@ -126,17 +189,33 @@ func (s *Server) registerRoutes() {
 	s.Engine.PATCH("/notifications/read-all", notificationH.MarkAllAsRead)
 	s.Engine.DELETE("/notifications", notificationH.DeleteAll)
 
+	// Create endpoints replay their first response to retries sent with the same Idempotency-Key
+	idempotent := middleware.Idempotency(s.idempotencyService)
+
 	// Application routes
 	routes.AuhtRoutes(s.Engine, s.servicesAuth)
 	routes.UserRoute(s.Engine, s.servicesUser)
-	routes.AccountRotes(s.Engine, s.servicesAccunt)
-	routes.TransactionRoutes(s.Engine, s.servicesTransaction)
+	routes.AccountRotes(s.Engine, s.servicesAccunt, s.balanceService, idempotent)
+	routes.TransactionRoutes(s.Engine, s.servicesTransaction, s.viewService, idempotent)
 	routes.CategoryRoutes(s.Engine, s.servicesCategory)
-	routes.BudgetRoutes(s.Engine, s.servicesBudget)
+	routes.BudgetRoutes(s.Engine, s.servicesBudget, idempotent)
 	routes.AnalyticsRoutes(s.Engine, s.analyticsService)
-	routes.RecurringTransactionRoutes(s.Engine, s.recurringService)
+	routes.RecurringTransactionRoutes(s.Engine, s.recurringService, idempotent)
 	routes.SearchRoutes(s.Engine, s.searchService)
-	routes.InvestmentRoutes(s.Engine, s.investmentService)
+	routes.InvestmentRoutes(s.Engine, s.investmentService, idempotent)
+	routes.ImportRoutes(s.Engine, s.importService)
+	routes.ExportRoutes(s.Engine, s.exportService)
+	routes.RuleRoutes(s.Engine, s.ruleService, s.servicesTransaction)
+	routes.TagRoutes(s.Engine, s.tagService)
+	routes.AttachmentRoutes(s.Engine, s.attachmentService)
+	routes.FxRoutes(s.Engine, s.fxService)
+	routes.ReconciliationRoutes(s.Engine, s.reconciliationService)
+	routes.AuditRoutes(s.Engine, s.auditService)
+	routes.TrashRoutes(s.Engine, s.trashService)
+	routes.PayeeRoutes(s.Engine, s.payeeService)
+	routes.ViewRoutes(s.Engine, s.viewService)
+	routes.NetWorthRoutes(s.Engine, s.netWorthService)
+	routes.StatementRoutes(s.Engine, s.statementService, idempotent)
 }
 
 func (s *Server) Run(ctx context.Context) error {
@@ -0,0 +1,33 @@
+// Package blob stores opaque files, such as transaction attachments, behind a small interface so the backend
+// can move from the local filesystem to an S3-compatible service without touching the callers.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/osmait/gestorDePresupuesto/internal/config"
+)
+
+// ErrNotFound is returned by Get when no blob is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store saves, reads and removes blobs by key. Keys are slash-separated paths such as "user/transaction/file".
+type Store interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes a blob; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore creates the store selected by the configuration.
+func NewStore(cfg config.AttachmentsConfig) (Store, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.LocalPath)
+	default:
+		return nil, fmt.Errorf("unsupported attachments driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,35 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "user_1/txn_1/att_1", strings.NewReader("%PDF-1.4")))
+
+	reader, err := store.Get(ctx, "user_1/txn_1/att_1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "user_1/txn_1/att_1"))
+	assert.NoError(t, store.Delete(ctx, "user_1/txn_1/att_1"), "deleting twice is not an error")
+	_, err = store.Get(ctx, "user_1/txn_1/att_1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "user_1/../../outside"} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("x")), key)
+	}
+}
@@ -3,11 +3,16 @@ package postgress
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/rs/zerolog/log"
 )
 
+const accountColumns = "id, name_account, bank, balance, currency, account_type, credit_limit, statement_day, due_day, interest_rate, user_id, created_at, archived_at"
+
 type AccountRepository struct {
 	db *sql.DB
 }
@@ -18,13 +23,21 @@ func NewAccountRepository(db *sql.DB) *AccountRepository {
 	}
 }
 
-func (repo *AccountRepository) Save(ctx context.Context, account *account.Account) error {
-	_, err := repo.db.ExecContext(ctx, "INSERT INTO account (id,name_account,bank,balance,user_id) VALUES ($1,$2,$3,$4,$5)", account.Id, account.Name, account.Bank, account.InitialBalance, account.UserId)
+func (repo *AccountRepository) Save(ctx context.Context, acc *account.Account) error {
+	if acc.Currency == "" {
+		acc.Currency = fx.DefaultCurrency
+	}
+	if acc.Type == "" {
+		acc.Type = account.TypeChecking
+	}
+	_, err := repo.db.ExecContext(ctx, "INSERT INTO account (id,name_account,bank,balance,user_id,currency,account_type,credit_limit,statement_day,due_day,interest_rate) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)",
+		acc.Id, acc.Name, acc.Bank, acc.InitialBalance, acc.UserId, acc.Currency, acc.Type, acc.CreditLimit, acc.StatementDay, acc.DueDay, acc.InterestRate)
 	return err
 }
 
-func (repo *AccountRepository) FindAll(ctx context.Context, userId string) ([]*account.Account, error) {
-	rows, err := repo.db.QueryContext(ctx, "SELECT id,name_account,bank,balance FROM account WHERE user_id = $1", userId)
+// FindAll leaves archived accounts out unless includeArchived is set.
+func (repo *AccountRepository) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT "+accountColumns+" FROM account WHERE user_id = $1 AND deleted_at IS NULL"+archivedFilter(includeArchived), userId)
 	if err != nil {
 		return nil, err
 	}
@@ -36,11 +49,9 @@ func (repo *AccountRepository) FindAll(ctx context.Context, userId string) ([]*a
 	}()
 	var accounts []*account.Account
 	for rows.Next() {
-		account := account.Account{}
-		if err = rows.Scan(&account.Id, &account.Name, &account.Bank, &account.InitialBalance); err == nil {
-			accounts = append(accounts, &account)
+		if account, scanErr := scanAccount(rows); scanErr == nil {
+			accounts = append(accounts, account)
 		}
-
 	}
 
 	if err = rows.Err(); err != nil {
@@ -49,8 +60,19 @@ func (repo *AccountRepository) FindAll(ctx context.Context, userId string) ([]*a
 	return accounts, nil
 }
 
+// Delete moves an account to the trash along with its transactions and the opposite legs of its transfers. They all
+// share the same deletion time, which is how Restore finds them again.
 func (repo *AccountRepository) Delete(ctx context.Context, id string, userId string) error {
-	result, err := repo.db.ExecContext(ctx, "DELETE FROM account WHERE id = $1 AND user_id = $2", id, userId)
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	deletedAt := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, "UPDATE account SET deleted_at = $1 WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL", deletedAt, id, userId)
 	if err != nil {
 		return err
 	}
@@ -61,11 +83,90 @@ func (repo *AccountRepository) Delete(ctx context.Context, id string, userId str
 	if rowsAffected == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+
+	_, err = tx.ExecContext(ctx, `UPDATE transactions SET deleted_at = $1 WHERE user_id = $2 AND deleted_at IS NULL AND (account_id = $3 OR transfer_id IN (
+		SELECT transfer_id FROM transactions WHERE account_id = $3 AND user_id = $2 AND transfer_id IS NOT NULL))`, deletedAt, userId, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindTrash retrieves the trashed accounts of a user, most recently deleted first.
+func (repo *AccountRepository) FindTrash(ctx context.Context, userId string) ([]*account.Account, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT "+accountColumns+", deleted_at FROM account WHERE user_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var accounts []*account.Account
+	for rows.Next() {
+		var deletedAt sql.NullTime
+		acc, err := scanAccount(rows, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		if deletedAt.Valid {
+			acc.DeletedAt = &deletedAt.Time
+		}
+		accounts = append(accounts, acc)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// Restore takes an account out of the trash together with the transactions that were trashed along with it.
+// Transactions deleted on their own before the account stay in the trash.
+func (repo *AccountRepository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var deletedAt time.Time
+	err = tx.QueryRowContext(ctx, "SELECT deleted_at FROM account WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL", id, userId).Scan(&deletedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE transactions SET deleted_at = NULL WHERE user_id = $1 AND deleted_at = $2 AND (account_id = $3 OR transfer_id IN (
+		SELECT transfer_id FROM transactions WHERE account_id = $3 AND user_id = $1 AND transfer_id IS NOT NULL))`, userId, deletedAt, id)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, "UPDATE account SET deleted_at = NULL WHERE id = $1 AND user_id = $2", id, userId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PurgeDeleted permanently removes the accounts trashed before the given time and returns how many were removed.
+// Accounts that still have transactions are kept until those are purged as well.
+func (repo *AccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	result, err := repo.db.ExecContext(ctx, `DELETE FROM account WHERE deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.account_id = account.id)`, before)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
 }
 
-func (repo *AccountRepository) Balance(ctx context.Context, id string) (float64, error) {
-	rows, err := repo.db.QueryContext(ctx, "SELECT   sum(amount)  as TOTAL  FROM  transactions   WHERE account_id = $1 ", id)
+func (repo *AccountRepository) Balance(ctx context.Context, id string) (money.Money, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT   sum(amount)  as TOTAL  FROM  transactions   WHERE account_id = $1 AND deleted_at IS NULL", id)
 	if err != nil {
 		return 0, err
 	}
@@ -75,7 +176,7 @@ func (repo *AccountRepository) Balance(ctx context.Context, id string) (float64,
 			log.Error().Err(err).Msg("failed to close database rows")
 		}
 	}()
-	var total float64
+	var total money.Money
 	for rows.Next() {
 		if err = rows.Scan(&total); err == nil {
 			return total, nil
@@ -89,8 +190,8 @@ func (repo *AccountRepository) Balance(ctx context.Context, id string) (float64,
 	return total, nil
 }
 
-func (repo *AccountRepository) Balances(ctx context.Context, userId string) (map[string]float64, error) {
-	rows, err := repo.db.QueryContext(ctx, "SELECT account_id, sum(amount) as TOTAL FROM transactions WHERE user_id = $1 GROUP BY account_id", userId)
+func (repo *AccountRepository) Balances(ctx context.Context, userId string) (map[string]money.Money, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT account_id, sum(amount) as TOTAL FROM transactions WHERE user_id = $1 AND deleted_at IS NULL GROUP BY account_id", userId)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +202,10 @@ func (repo *AccountRepository) Balances(ctx context.Context, userId string) (map
 		}
 	}()
 
-	balances := make(map[string]float64)
+	balances := make(map[string]money.Money)
 	for rows.Next() {
 		var accountId string
-		var total float64
+		var total money.Money
 		if err = rows.Scan(&accountId, &total); err == nil {
 			balances[accountId] = total
 		}
@@ -115,8 +216,11 @@ func (repo *AccountRepository) Balances(ctx context.Context, userId string) (map
 	return balances, nil
 }
 
-func (repo *AccountRepository) Update(ctx context.Context, id string, name string, bank string, userId string) error {
-	result, err := repo.db.ExecContext(ctx, "UPDATE account SET name_account = $1, bank = $2 WHERE id = $3 AND user_id = $4", name, bank, id, userId)
+// Update changes the name, bank, type and type details of an account; its balance and currency stay as they are.
+func (repo *AccountRepository) Update(ctx context.Context, account *account.Account) error {
+	result, err := repo.db.ExecContext(ctx, `UPDATE account SET name_account = $1, bank = $2, account_type = $3, credit_limit = $4, statement_day = $5, due_day = $6, interest_rate = $7
+		WHERE id = $8 AND user_id = $9 AND deleted_at IS NULL`,
+		account.Name, account.Bank, account.Type, account.CreditLimit, account.StatementDay, account.DueDay, account.InterestRate, account.Id, account.UserId)
 	if err != nil {
 		return err
 	}
@@ -134,20 +238,13 @@ func (repo *AccountRepository) Update(ctx context.Context, id string, name strin
 }
 
 func (repo *AccountRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error) {
-	row := repo.db.QueryRowContext(ctx, "SELECT id, name_account, bank, balance, user_id, created_at FROM account WHERE id = $1 AND user_id = $2", id, userId)
-
-	account := &account.Account{}
-	err := row.Scan(&account.Id, &account.Name, &account.Bank, &account.InitialBalance, &account.UserId, &account.CreatedAt)
-	if err != nil {
-		return nil, err
-	}
-
-	return account, nil
+	row := repo.db.QueryRowContext(ctx, "SELECT "+accountColumns+" FROM account WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", id, userId)
+	return scanAccount(row)
 }
 
-func (repo *AccountRepository) Search(ctx context.Context, userId string, query string) ([]*account.Account, error) {
+func (repo *AccountRepository) Search(ctx context.Context, userId string, query string, includeArchived bool) ([]*account.Account, error) {
 	searchTerm := "%" + query + "%"
-	rows, err := repo.db.QueryContext(ctx, "SELECT id, name_account, bank, balance, user_id, created_at FROM account WHERE user_id = $1 AND (name_account ILIKE $2 OR bank ILIKE $2)", userId, searchTerm)
+	rows, err := repo.db.QueryContext(ctx, "SELECT "+accountColumns+" FROM account WHERE user_id = $1 AND deleted_at IS NULL AND (name_account ILIKE $2 OR bank ILIKE $2)"+archivedFilter(includeArchived), userId, searchTerm)
 	if err != nil {
 		return nil, err
 	}
@@ -160,9 +257,8 @@ func (repo *AccountRepository) Search(ctx context.Context, userId string, query
 
 	var accounts []*account.Account
 	for rows.Next() {
-		acc := account.Account{}
-		if err = rows.Scan(&acc.Id, &acc.Name, &acc.Bank, &acc.InitialBalance, &acc.UserId, &acc.CreatedAt); err == nil {
-			accounts = append(accounts, &acc)
+		if acc, scanErr := scanAccount(rows); scanErr == nil {
+			accounts = append(accounts, acc)
 		}
 	}
 	if err = rows.Err(); err != nil {
@@ -170,3 +266,72 @@ func (repo *AccountRepository) Search(ctx context.Context, userId string, query
 	}
 	return accounts, nil
 }
+
+// Archive hides the account from listings and closes it to new transactions. Archiving an archived account
+// keeps its original archive date.
+func (repo *AccountRepository) Archive(ctx context.Context, id string, userId string, archivedAt time.Time) error {
+	return repo.setArchived(ctx, "UPDATE account SET archived_at = COALESCE(archived_at, $1) WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL", archivedAt, id, userId)
+}
+
+func (repo *AccountRepository) Unarchive(ctx context.Context, id string, userId string) error {
+	return repo.setArchived(ctx, "UPDATE account SET archived_at = NULL WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", id, userId)
+}
+
+func (repo *AccountRepository) setArchived(ctx context.Context, query string, args ...any) error {
+	result, err := repo.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// archivedFilter is appended to the conditions of a listing to leave archived accounts out.
+func archivedFilter(includeArchived bool) string {
+	if includeArchived {
+		return ""
+	}
+	return " AND archived_at IS NULL"
+}
+
+type accountScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAccount reads the accountColumns of a row, followed by any extra columns selected after them.
+func scanAccount(row accountScanner, extra ...any) (*account.Account, error) {
+	acc := &account.Account{}
+	var creditLimit sql.Null[money.Money]
+	var statementDay, dueDay sql.NullInt32
+	var interestRate sql.NullFloat64
+	var archivedAt sql.NullTime
+	dest := append([]any{&acc.Id, &acc.Name, &acc.Bank, &acc.InitialBalance, &acc.Currency, &acc.Type,
+		&creditLimit, &statementDay, &dueDay, &interestRate, &acc.UserId, &acc.CreatedAt, &archivedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if creditLimit.Valid {
+		acc.CreditLimit = &creditLimit.V
+	}
+	if statementDay.Valid {
+		day := int(statementDay.Int32)
+		acc.StatementDay = &day
+	}
+	if dueDay.Valid {
+		day := int(dueDay.Int32)
+		acc.DueDay = &day
+	}
+	if interestRate.Valid {
+		acc.InterestRate = &interestRate.Float64
+	}
+	if archivedAt.Valid {
+		acc.ArchivedAt = &archivedAt.Time
+	}
+	return acc, nil
+}
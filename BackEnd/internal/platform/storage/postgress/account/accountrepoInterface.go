@@ -2,17 +2,30 @@ package postgress
 
 import (
 	"context"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 )
 
 type AccountRepositoryInterface interface {
 	Save(ctx context.Context, account *account.Account) error
-	FindAll(ctx context.Context, userId string) ([]*account.Account, error)
+	// FindAll and Search leave archived accounts out unless includeArchived is set
+	FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error)
 	Delete(ctx context.Context, id string, userId string) error
-	Balance(ctx context.Context, id string) (float64, error)
-	Balances(ctx context.Context, userId string) (map[string]float64, error)
-	Update(ctx context.Context, id string, name string, bank string, userId string) error
+	Balance(ctx context.Context, id string) (money.Money, error)
+	Balances(ctx context.Context, userId string) (map[string]money.Money, error)
+	// Update changes the name, bank, type and type details of an account
+	Update(ctx context.Context, account *account.Account) error
 	FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error)
-	Search(ctx context.Context, userId string, query string) ([]*account.Account, error)
+	Search(ctx context.Context, userId string, query string, includeArchived bool) ([]*account.Account, error)
+
+	// Archived accounts keep their history but take no new transactions
+	Archive(ctx context.Context, id string, userId string, archivedAt time.Time) error
+	Unarchive(ctx context.Context, id string, userId string) error
+
+	// Deleting an account trashes it with its transactions; restoring brings them back together
+	FindTrash(ctx context.Context, userId string) ([]*account.Account, error)
+	Restore(ctx context.Context, id string, userId string) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 }
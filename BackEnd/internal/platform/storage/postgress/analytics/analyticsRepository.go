@@ -18,7 +18,14 @@ type AnalyticsRepository struct {
 }
 
 func (a *AnalyticsRepository) GetCategoryExpenses(ctx context.Context, userID string) ([]*analytics.CategoryExpenseRepository, error) {
-	query := `SELECT c.name, SUM(t.amount), c.color FROM transactions t JOIN categorys c ON t.category_id = c.id WHERE t.user_id = $1 AND t. type_transation = 'bill' GROUP BY c.name, c.color`
+	// Split transactions carry no category of their own, so their lines are counted instead.
+	// Refunds carry the category of their bill and bring its spending back down.
+	// Amounts in different currencies are never added up here; there is one row per category and currency.
+	query := `SELECT c.name, lines.currency, SUM(lines.amount), c.color FROM (
+			SELECT t.category_id, t.currency, t.amount FROM transactions t WHERE t.user_id = $1 AND (t.type_transation = 'bill' OR t.refund_of IS NOT NULL) AND t.deleted_at IS NULL
+			UNION ALL
+			SELECT s.category_id, t.currency, s.amount FROM transaction_splits s JOIN transactions t ON t.id = s.transaction_id WHERE t.user_id = $1 AND t.type_transation = 'bill' AND t.deleted_at IS NULL
+		) lines JOIN categorys c ON lines.category_id = c.id AND c.deleted_at IS NULL GROUP BY c.name, c.color, lines.currency ORDER BY c.name, lines.currency`
 
 	rows, err := a.db.QueryContext(ctx, query, userID)
 	if err != nil {
@@ -31,7 +38,7 @@ func (a *AnalyticsRepository) GetCategoryExpenses(ctx context.Context, userID st
 
 	for rows.Next() {
 		var categoryExpense analytics.CategoryExpenseRepository
-		err := rows.Scan(&categoryExpense.CategoryName, &categoryExpense.TotalAmount, &categoryExpense.CategoryColor)
+		err := rows.Scan(&categoryExpense.CategoryName, &categoryExpense.Currency, &categoryExpense.TotalAmount, &categoryExpense.CategoryColor)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning category expenses: %w", err)
 		}
@@ -41,12 +48,68 @@ func (a *AnalyticsRepository) GetCategoryExpenses(ctx context.Context, userID st
 	return categoryExpenses, nil
 }
 
+func (a *AnalyticsRepository) GetTagExpenses(ctx context.Context, userID string) ([]*analytics.TagExpenseRepository, error) {
+	query := `SELECT g.name, t.currency, SUM(t.amount), COUNT(t.id) FROM transaction_tags tt
+			JOIN tags g ON g.id = tt.tag_id
+			JOIN transactions t ON t.id = tt.transaction_id
+		WHERE g.user_id = $1 AND (t.type_transation = 'bill' OR t.refund_of IS NOT NULL) AND t.deleted_at IS NULL GROUP BY g.name, t.currency ORDER BY g.name, t.currency`
+
+	rows, err := a.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag expenses: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var tagExpenses []*analytics.TagExpenseRepository
+
+	for rows.Next() {
+		var tagExpense analytics.TagExpenseRepository
+		err := rows.Scan(&tagExpense.TagName, &tagExpense.Currency, &tagExpense.TotalAmount, &tagExpense.TransactionCount)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning tag expenses: %w", err)
+		}
+		tagExpenses = append(tagExpenses, &tagExpense)
+	}
+
+	return tagExpenses, nil
+}
+
+func (a *AnalyticsRepository) GetPayeeExpenses(ctx context.Context, userID string) ([]*analytics.PayeeExpenseRepository, error) {
+	query := `SELECT p.id, p.name, t.currency, SUM(t.amount), COUNT(t.id) FROM transactions t
+			JOIN payees p ON p.id = t.payee_id
+		WHERE t.user_id = $1 AND (t.type_transation = 'bill' OR t.refund_of IS NOT NULL) AND t.deleted_at IS NULL GROUP BY p.id, p.name, t.currency ORDER BY p.name, t.currency`
+
+	rows, err := a.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payee expenses: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var payeeExpenses []*analytics.PayeeExpenseRepository
+
+	for rows.Next() {
+		var payeeExpense analytics.PayeeExpenseRepository
+		err := rows.Scan(&payeeExpense.PayeeId, &payeeExpense.PayeeName, &payeeExpense.Currency, &payeeExpense.TotalAmount, &payeeExpense.TransactionCount)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning payee expenses: %w", err)
+		}
+		payeeExpenses = append(payeeExpenses, &payeeExpense)
+	}
+
+	return payeeExpenses, nil
+}
+
+// GetMonthlySummary adds up income and expenses per month. Refunds are netted against the expenses of the month
+// they arrive in rather than counted as income.
 func (a *AnalyticsRepository) GetMonthlySummary(ctx context.Context, userID string) ([]*analytics.MonthlySummaryRepository, error) {
 	query := `SELECT EXTRACT(YEAR FROM created_at) as year, 
                EXTRACT(MONTH FROM created_at) as month, 
-               SUM(CASE WHEN type_transation = 'income' THEN amount ELSE 0 END) as total_income, 
-               SUM(CASE WHEN type_transation = 'bill' THEN amount ELSE 0 END) as total_bill 
-               FROM transactions WHERE user_id = $1 GROUP BY year, month ORDER BY year, month`
+               currency,
+               SUM(CASE WHEN type_transation = 'income' AND refund_of IS NULL THEN amount ELSE 0 END) as total_income, 
+               SUM(CASE WHEN type_transation = 'bill' OR refund_of IS NOT NULL THEN amount ELSE 0 END) as total_bill 
+               FROM transactions WHERE user_id = $1 AND type_transation <> 'transfer' AND deleted_at IS NULL GROUP BY year, month, currency ORDER BY year, month, currency`
 
 	rows, err := a.db.QueryContext(ctx, query, userID)
 	if err != nil {
@@ -61,7 +124,7 @@ func (a *AnalyticsRepository) GetMonthlySummary(ctx context.Context, userID stri
 		var monthlySummary analytics.MonthlySummaryRepository
 		var year int
 		var month time.Month
-		err := rows.Scan(&year, &month, &monthlySummary.TotalIncome, &monthlySummary.TotalBill)
+		err := rows.Scan(&year, &month, &monthlySummary.Currency, &monthlySummary.TotalIncome, &monthlySummary.TotalBill)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning monthly summary: %w", err)
 		}
@@ -0,0 +1,20 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+)
+
+type AttachmentRepoInterface interface {
+	Save(ctx context.Context, attachment *attachment.Attachment) error
+	FindById(ctx context.Context, id string, userId string) (*attachment.Attachment, error)
+	FindByTransaction(ctx context.Context, transactionId string, userId string) ([]*attachment.Attachment, error)
+	// TotalSize returns the bytes used by all the attachments of a user
+	TotalSize(ctx context.Context, userId string) (int64, error)
+	// Delete removes an attachment and queues its blob for deletion
+	Delete(ctx context.Context, id string, userId string) error
+	// FindPendingDeletions returns up to limit storage keys whose blob still has to be removed
+	FindPendingDeletions(ctx context.Context, limit int) ([]string, error)
+	ClearPendingDeletions(ctx context.Context, storageKeys []string) error
+}
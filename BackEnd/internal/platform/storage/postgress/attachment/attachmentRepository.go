@@ -0,0 +1,140 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+	"github.com/rs/zerolog/log"
+)
+
+const attachmentColumns = "id, user_id, transaction_id, file_name, content_type, size_bytes, storage_key, created_at"
+
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{
+		db: db,
+	}
+}
+
+func (r *AttachmentRepository) Save(ctx context.Context, attachment *attachment.Attachment) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO attachments ("+attachmentColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		attachment.Id, attachment.UserId, attachment.TransactionId, attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt)
+	return err
+}
+
+// FindById returns sql.ErrNoRows when the attachment does not exist or belongs to another user.
+func (r *AttachmentRepository) FindById(ctx context.Context, id string, userId string) (*attachment.Attachment, error) {
+	attachments, err := r.find(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return attachments[0], nil
+}
+
+func (r *AttachmentRepository) FindByTransaction(ctx context.Context, transactionId string, userId string) ([]*attachment.Attachment, error) {
+	return r.find(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE transaction_id = $1 AND user_id = $2 ORDER BY created_at, id", transactionId, userId)
+}
+
+func (r *AttachmentRepository) TotalSize(ctx context.Context, userId string) (int64, error) {
+	var total int64
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(size_bytes), 0) FROM attachments WHERE user_id = $1", userId).Scan(&total)
+	return total, err
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "INSERT INTO attachment_deletions (storage_key) SELECT storage_key FROM attachments WHERE id = $1 AND user_id = $2", id, userId); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, "DELETE FROM attachments WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+func (r *AttachmentRepository) FindPendingDeletions(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT storage_key FROM attachment_deletions ORDER BY created_at LIMIT $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var storageKeys []string
+	for rows.Next() {
+		var storageKey string
+		if err := rows.Scan(&storageKey); err != nil {
+			return nil, err
+		}
+		storageKeys = append(storageKeys, storageKey)
+	}
+	return storageKeys, rows.Err()
+}
+
+func (r *AttachmentRepository) ClearPendingDeletions(ctx context.Context, storageKeys []string) error {
+	if len(storageKeys) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(storageKeys))
+	args := make([]interface{}, len(storageKeys))
+	for i, storageKey := range storageKeys {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = storageKey
+	}
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM attachment_deletions WHERE storage_key IN (%s)", strings.Join(placeholders, ", ")), args...)
+	return err
+}
+
+func (r *AttachmentRepository) find(ctx context.Context, query string, args ...interface{}) ([]*attachment.Attachment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var attachments []*attachment.Attachment
+	for rows.Next() {
+		var attachment attachment.Attachment
+		err := rows.Scan(&attachment.Id, &attachment.UserId, &attachment.TransactionId, &attachment.FileName, &attachment.ContentType,
+			&attachment.Size, &attachment.StorageKey, &attachment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
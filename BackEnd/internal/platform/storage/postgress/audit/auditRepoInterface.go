@@ -0,0 +1,17 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+)
+
+// AuditRepoInterface only appends and reads: audit events are never updated or deleted
+type AuditRepoInterface interface {
+	Save(ctx context.Context, event *audit.Event) error
+	// FindByEntity returns the history of a record changed by the user, oldest event first
+	FindByEntity(ctx context.Context, entityType string, entityId string, userId string) ([]*audit.Event, error)
+	// Find returns the events matching the filter across all users, newest first
+	Find(ctx context.Context, filter *dto.AuditFilter) ([]*audit.Event, error)
+}
@@ -0,0 +1,112 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+	"github.com/rs/zerolog/log"
+)
+
+const auditColumns = "id, entity_type, entity_id, action, user_id, request_id, before_snapshot, after_snapshot, created_at"
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) Save(ctx context.Context, event *audit.Event) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO audit_events ("+auditColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		event.Id, event.EntityType, event.EntityId, event.Action, event.UserId, nullIfEmpty(event.RequestId),
+		nullIfEmpty(string(event.Before)), nullIfEmpty(string(event.After)), event.CreatedAt)
+	return err
+}
+
+func (r *AuditRepository) FindByEntity(ctx context.Context, entityType string, entityId string, userId string) ([]*audit.Event, error) {
+	return r.find(ctx, "SELECT "+auditColumns+" FROM audit_events WHERE entity_type = $1 AND entity_id = $2 AND user_id = $3 ORDER BY created_at, id",
+		entityType, entityId, userId)
+}
+
+func (r *AuditRepository) Find(ctx context.Context, filter *dto.AuditFilter) ([]*audit.Event, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.EntityType != "" {
+		add("entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityId != "" {
+		add("entity_id = $%d", filter.EntityId)
+	}
+	if filter.UserId != "" {
+		add("user_id = $%d", filter.UserId)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("created_at < $%d", filter.To)
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	return r.find(ctx, query, args...)
+}
+
+func (r *AuditRepository) find(ctx context.Context, query string, args ...interface{}) ([]*audit.Event, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var events []*audit.Event
+	for rows.Next() {
+		var event audit.Event
+		var requestId, before, after sql.NullString
+		if err := rows.Scan(&event.Id, &event.EntityType, &event.EntityId, &event.Action, &event.UserId, &requestId,
+			&before, &after, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		event.RequestId = requestId.String
+		if before.Valid {
+			event.Before = []byte(before.String)
+		}
+		if after.Valid {
+			event.After = []byte(after.String)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
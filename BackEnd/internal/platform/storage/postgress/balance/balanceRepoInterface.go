@@ -0,0 +1,22 @@
+package postgress
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+type BalanceRepositoryInterface interface {
+	// Last returns the most recent daily balance stored for the account, or sql.ErrNoRows when there is none
+	Last(ctx context.Context, accountId string) (*account.DailyBalance, error)
+	// FindRange returns the stored daily balances between from and to, both days included
+	FindRange(ctx context.Context, accountId string, from time.Time, to time.Time) ([]account.DailyBalance, error)
+	Save(ctx context.Context, balances []account.DailyBalance) error
+
+	// FirstTransactionDay returns the day of the oldest transaction of the account, or sql.ErrNoRows when it has none
+	FirstTransactionDay(ctx context.Context, accountId string) (time.Time, error)
+	// DailyChanges sums the transactions of the account per day between from and to, both days included
+	DailyChanges(ctx context.Context, accountId string, from time.Time, to time.Time) (map[time.Time]money.Money, error)
+}
@@ -0,0 +1,123 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/rs/zerolog/log"
+)
+
+// BalanceRepository stores the closing balance of each account per day. Rows are dropped by a database trigger
+// whenever a transaction on or before their day changes, so the stored days are always up to date.
+type BalanceRepository struct {
+	db *sql.DB
+}
+
+func NewBalanceRepository(db *sql.DB) *BalanceRepository {
+	return &BalanceRepository{
+		db: db,
+	}
+}
+
+func (r *BalanceRepository) Last(ctx context.Context, accountId string) (*account.DailyBalance, error) {
+	balance := &account.DailyBalance{AccountId: accountId}
+	err := r.db.QueryRowContext(ctx, "SELECT day, balance FROM account_daily_balances WHERE account_id = $1 ORDER BY day DESC LIMIT 1", accountId).
+		Scan(&balance.Day, &balance.Balance)
+	if err != nil {
+		return nil, err
+	}
+	balance.Day = account.Day(balance.Day)
+	return balance, nil
+}
+
+func (r *BalanceRepository) FindRange(ctx context.Context, accountId string, from time.Time, to time.Time) ([]account.DailyBalance, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT day, balance FROM account_daily_balances WHERE account_id = $1 AND day >= $2 AND day <= $3 ORDER BY day",
+		accountId, from.Format(account.DateLayout), to.Format(account.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close database rows")
+		}
+	}()
+
+	var balances []account.DailyBalance
+	for rows.Next() {
+		balance := account.DailyBalance{AccountId: accountId}
+		if err := rows.Scan(&balance.Day, &balance.Balance); err != nil {
+			return nil, err
+		}
+		balance.Day = account.Day(balance.Day)
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *BalanceRepository) Save(ctx context.Context, balances []account.DailyBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, balance := range balances {
+		_, err = tx.ExecContext(ctx, `INSERT INTO account_daily_balances (account_id, day, balance) VALUES ($1, $2, $3)
+			ON CONFLICT (account_id, day) DO UPDATE SET balance = excluded.balance`,
+			balance.AccountId, balance.Day.Format(account.DateLayout), balance.Balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *BalanceRepository) FirstTransactionDay(ctx context.Context, accountId string) (time.Time, error) {
+	var createdAt time.Time
+	err := r.db.QueryRowContext(ctx, "SELECT created_at FROM transactions WHERE account_id = $1 AND deleted_at IS NULL ORDER BY created_at LIMIT 1", accountId).
+		Scan(&createdAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return account.Day(createdAt), nil
+}
+
+func (r *BalanceRepository) DailyChanges(ctx context.Context, accountId string, from time.Time, to time.Time) (map[time.Time]money.Money, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT created_at, amount FROM transactions WHERE account_id = $1 AND deleted_at IS NULL AND created_at >= $2 AND created_at < $3",
+		accountId, account.Day(from), account.Day(to).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close database rows")
+		}
+	}()
+
+	changes := make(map[time.Time]money.Money)
+	for rows.Next() {
+		var createdAt time.Time
+		var amount money.Money
+		if err := rows.Scan(&createdAt, &amount); err != nil {
+			return nil, err
+		}
+		changes[account.Day(createdAt)] += amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
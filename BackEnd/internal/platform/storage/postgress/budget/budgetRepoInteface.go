@@ -11,6 +11,7 @@ type BudgetRepoInterface interface {
 	FindAll(ctx context.Context, userId string) ([]*budget.Budget, error)
 	Delete(ctx context.Context, id string, userId string) error
 	FindOne(ctx context.Context, id string) (*budget.Budget, error)
+	FindByIdAndUserId(ctx context.Context, id string, userId string) (*budget.Budget, error)
 	FindByCategory(ctx context.Context, categoryID string) (*budget.Budget, error)
 	Update(ctx context.Context, budget *budget.Budget) error
 	Search(ctx context.Context, userId string, query string) ([]*budget.Budget, error)
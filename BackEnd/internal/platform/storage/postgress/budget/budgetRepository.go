@@ -8,6 +8,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// activeCategory leaves out the budgets whose category is in the trash.
+const activeCategory = "category_id NOT IN (SELECT id FROM categorys WHERE deleted_at IS NOT NULL)"
+
 type BudgetRepository struct {
 	db *sql.DB
 }
@@ -29,7 +32,7 @@ func (b *BudgetRepository) Update(ctx context.Context, budget *budget.Budget) er
 }
 
 func (b *BudgetRepository) FindAll(ctx context.Context, userId string) ([]*budget.Budget, error) {
-	rows, err := b.db.QueryContext(ctx, "SELECT id,category_id,user_id,amount,created_at FROM budgets WHERE user_id = $1 ", userId)
+	rows, err := b.db.QueryContext(ctx, "SELECT id,category_id,user_id,amount,created_at FROM budgets WHERE user_id = $1 AND "+activeCategory, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +83,17 @@ func (b *BudgetRepository) FindOne(ctx context.Context, id string) (*budget.Budg
 	return &budget, nil
 }
 
+// FindByIdAndUserId retrieves a budget owned by the given user, or sql.ErrNoRows.
+func (b *BudgetRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*budget.Budget, error) {
+	var budget budget.Budget
+	err := b.db.QueryRowContext(ctx, "SELECT id,category_id,user_id,amount,created_at FROM budgets WHERE id = $1 AND user_id = $2", id, userId).
+		Scan(&budget.Id, &budget.CategoryId, &budget.UserId, &budget.Amount, &budget.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
 func (b *BudgetRepository) Delete(ctx context.Context, id string, userId string) error {
 	result, err := b.db.ExecContext(ctx, "DELETE FROM budgets WHERE id = $1 AND user_id = $2", id, userId)
 	if err != nil {
@@ -96,7 +110,7 @@ func (b *BudgetRepository) Delete(ctx context.Context, id string, userId string)
 }
 
 func (b *BudgetRepository) FindByCategory(ctx context.Context, categoryID string) (*budget.Budget, error) {
-	rows, err := b.db.QueryContext(ctx, "SELECT id,category_id,user_id,amount,created_at FROM budgets WHERE category_id = $1 ", categoryID)
+	rows, err := b.db.QueryContext(ctx, "SELECT id,category_id,user_id,amount,created_at FROM budgets WHERE category_id = $1 AND "+activeCategory, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +138,7 @@ func (b *BudgetRepository) Search(ctx context.Context, userId string, query stri
 	querySQL := `
 		SELECT b.id, b.category_id, b.user_id, b.amount, b.created_at, c.name 
 		FROM budgets b
-		LEFT JOIN categorys c ON b.category_id = c.id
+		LEFT JOIN categorys c ON b.category_id = c.id AND c.deleted_at IS NULL
 		WHERE b.user_id = $1 AND c.name ILIKE $2
 	`
 	rows, err := b.db.QueryContext(ctx, querySQL, userId, searchTerm)
@@ -2,6 +2,7 @@ package postgress
 
 import (
 	"context"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
 )
@@ -10,7 +11,13 @@ type CategoryRepoInterface interface {
 	Save(ctx context.Context, category *category.Category) error
 	FindAll(ctx context.Context, userId string) ([]*category.Category, error)
 	FindOne(ctx context.Context, id string) (*category.Category, error)
+	FindByIdAndUserId(ctx context.Context, id string, userId string) (*category.Category, error)
 	Delete(ctx context.Context, id string, userId string) error
 	Update(ctx context.Context, category *category.Category) error
 	Search(ctx context.Context, userId string, query string) ([]*category.Category, error)
+
+	// Deleted categories stay in the trash until they are restored or purged after the retention period
+	FindTrash(ctx context.Context, userId string) ([]*category.Category, error)
+	Restore(ctx context.Context, id string, userId string) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 }
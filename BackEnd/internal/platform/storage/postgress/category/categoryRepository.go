@@ -3,6 +3,7 @@ package postgress
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
 	"github.com/rs/zerolog/log"
@@ -24,7 +25,7 @@ func (c *CategoryRespository) Save(ctx context.Context, category *category.Categ
 }
 
 func (c *CategoryRespository) FindAll(ctx context.Context, userId string) ([]*category.Category, error) {
-	rows, err := c.db.QueryContext(ctx, "SELECT id, name ,icon ,color ,user_id,created_at FROM categorys WHERE user_id = $1 ", userId)
+	rows, err := c.db.QueryContext(ctx, "SELECT id, name ,icon ,color ,user_id,created_at FROM categorys WHERE user_id = $1 AND deleted_at IS NULL", userId)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +51,7 @@ func (c *CategoryRespository) FindAll(ctx context.Context, userId string) ([]*ca
 }
 
 func (c *CategoryRespository) FindOne(ctx context.Context, id string) (*category.Category, error) {
-	rows, err := c.db.QueryContext(ctx, "SELECT id, name ,icon ,color,user_id,created_at FROM categorys  WHERE id = $1 ", id)
+	rows, err := c.db.QueryContext(ctx, "SELECT id, name ,icon ,color,user_id,created_at FROM categorys  WHERE id = $1 AND deleted_at IS NULL", id)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +75,67 @@ func (c *CategoryRespository) FindOne(ctx context.Context, id string) (*category
 	return &category, nil
 }
 
+// FindByIdAndUserId retrieves a category owned by the given user that is not in the trash, or sql.ErrNoRows.
+func (c *CategoryRespository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*category.Category, error) {
+	var category category.Category
+	err := c.db.QueryRowContext(ctx, "SELECT id, name ,icon ,color,user_id,created_at FROM categorys WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", id, userId).
+		Scan(&category.Id, &category.Name, &category.Icon, &category.Color, &category.UserId, &category.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Delete moves a category to the trash. Transactions and budgets keep pointing at it, so restoring it needs no
+// further work.
 func (c *CategoryRespository) Delete(ctx context.Context, id string, userId string) error {
-	result, err := c.db.ExecContext(ctx, "DELETE FROM categorys WHERE id = $1 AND user_id = $2", id, userId)
+	result, err := c.db.ExecContext(ctx, "UPDATE categorys SET deleted_at = $1 WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL", time.Now().UTC(), id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindTrash retrieves the trashed categories of a user, most recently deleted first.
+func (c *CategoryRespository) FindTrash(ctx context.Context, userId string) ([]*category.Category, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT id, name, icon, color, user_id, created_at, deleted_at FROM categorys WHERE user_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var categories []*category.Category
+	for rows.Next() {
+		cat := &category.Category{}
+		var deletedAt sql.NullTime
+		if err = rows.Scan(&cat.Id, &cat.Name, &cat.Icon, &cat.Color, &cat.UserId, &cat.CreatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		if deletedAt.Valid {
+			cat.DeletedAt = &deletedAt.Time
+		}
+		categories = append(categories, cat)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// Restore takes a category out of the trash.
+func (c *CategoryRespository) Restore(ctx context.Context, id string, userId string) error {
+	result, err := c.db.ExecContext(ctx, "UPDATE categorys SET deleted_at = NULL WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL", id, userId)
 	if err != nil {
 		return err
 	}
@@ -89,14 +149,31 @@ func (c *CategoryRespository) Delete(ctx context.Context, id string, userId stri
 	return nil
 }
 
+// PurgeDeleted permanently removes the categories trashed before the given time and returns how many were removed.
+// Categories still used by a transaction, a split line or a budget are kept.
+func (c *CategoryRespository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	result, err := c.db.ExecContext(ctx, `DELETE FROM categorys WHERE deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.category_id = categorys.id)
+		AND NOT EXISTS (SELECT 1 FROM transaction_splits WHERE transaction_splits.category_id = categorys.id)
+		AND NOT EXISTS (SELECT 1 FROM budgets WHERE budgets.category_id = categorys.id)`, before)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
+
 func (c *CategoryRespository) Update(ctx context.Context, category *category.Category) error {
-	_, err := c.db.ExecContext(ctx, "UPDATE categorys SET name = $1, icon = $2, color = $3 WHERE id = $4 AND user_id = $5", category.Name, category.Icon, category.Color, category.Id, category.UserId)
+	_, err := c.db.ExecContext(ctx, "UPDATE categorys SET name = $1, icon = $2, color = $3 WHERE id = $4 AND user_id = $5 AND deleted_at IS NULL", category.Name, category.Icon, category.Color, category.Id, category.UserId)
 	return err
 }
 
 func (c *CategoryRespository) Search(ctx context.Context, userId string, query string) ([]*category.Category, error) {
 	searchTerm := "%" + query + "%"
-	rows, err := c.db.QueryContext(ctx, "SELECT id, name, icon, color, user_id, created_at FROM categorys WHERE user_id = $1 AND name ILIKE $2", userId, searchTerm)
+	rows, err := c.db.QueryContext(ctx, "SELECT id, name, icon, color, user_id, created_at FROM categorys WHERE user_id = $1 AND deleted_at IS NULL AND name ILIKE $2", userId, searchTerm)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,14 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+)
+
+type FxRepoInterface interface {
+	// SaveRates stores the rates, replacing the ones already stored for the same pair and day
+	SaveRates(ctx context.Context, rates []*fx.Rate) error
+	// FindLatest returns the most recent rate of every currency pair
+	FindLatest(ctx context.Context) ([]*fx.Rate, error)
+}
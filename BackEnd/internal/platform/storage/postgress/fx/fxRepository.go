@@ -0,0 +1,71 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/rs/zerolog/log"
+)
+
+type FxRepository struct {
+	db *sql.DB
+}
+
+func NewFxRepository(db *sql.DB) *FxRepository {
+	return &FxRepository{
+		db: db,
+	}
+}
+
+func (r *FxRepository) SaveRates(ctx context.Context, rates []*fx.Rate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, rate := range rates {
+		_, err = tx.ExecContext(ctx, `INSERT INTO fx_rates (base_currency, quote_currency, rate, rate_date, source) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (base_currency, quote_currency, rate_date) DO UPDATE SET rate = excluded.rate, source = excluded.source`,
+			rate.Base, rate.Quote, rate.Rate, rate.Date.Format("2006-01-02"), rate.Source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *FxRepository) FindLatest(ctx context.Context) ([]*fx.Rate, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT r.base_currency, r.quote_currency, r.rate, r.rate_date, r.source FROM fx_rates r
+		WHERE r.rate_date = (SELECT MAX(m.rate_date) FROM fx_rates m WHERE m.base_currency = r.base_currency AND m.quote_currency = r.quote_currency)
+		ORDER BY r.base_currency, r.quote_currency`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close database rows")
+		}
+	}()
+
+	var rates []*fx.Rate
+	for rows.Next() {
+		rate := &fx.Rate{}
+		if err := rows.Scan(&rate.Base, &rate.Quote, &rate.Rate, &rate.Date, &rate.Source); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,18 @@
+package postgress
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+)
+
+type IdempotencyRepoInterface interface {
+	// Reserve stores the record unless the user already holds an unexpired record with the same key
+	Reserve(ctx context.Context, record *idempotency.Record) (bool, error)
+	FindByKey(ctx context.Context, userId string, key string) (*idempotency.Record, error)
+	// Complete stores the response of a reserved record; Release drops a reservation whose request failed
+	Complete(ctx context.Context, record *idempotency.Record) error
+	Release(ctx context.Context, userId string, key string) error
+	PurgeExpired(ctx context.Context, before time.Time) (int, error)
+}
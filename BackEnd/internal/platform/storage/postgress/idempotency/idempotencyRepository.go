@@ -0,0 +1,74 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+)
+
+const idempotencyColumns = "user_id, idempotency_key, request_hash, status_code, content_type, response, created_at, expires_at"
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+// Reserve drops an expired record with the same key first, so keys can be reused once their TTL is over.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *idempotency.Record) (bool, error) {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND expires_at <= $3", record.UserId, record.Key, record.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, "INSERT INTO idempotency_keys ("+idempotencyColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id, idempotency_key) DO NOTHING",
+		record.UserId, record.Key, record.RequestHash, record.StatusCode, record.ContentType, record.Response, record.CreatedAt, record.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// FindByKey returns sql.ErrNoRows when the user has no record with that key.
+func (r *IdempotencyRepository) FindByKey(ctx context.Context, userId string, key string) (*idempotency.Record, error) {
+	var record idempotency.Record
+	err := r.db.QueryRowContext(ctx, "SELECT "+idempotencyColumns+" FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2", userId, key).
+		Scan(&record.UserId, &record.Key, &record.RequestHash, &record.StatusCode, &record.ContentType, &record.Response, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *idempotency.Record) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE idempotency_keys SET status_code = $1, content_type = $2, response = $3 WHERE user_id = $4 AND idempotency_key = $5",
+		record.StatusCode, record.ContentType, record.Response, record.UserId, record.Key)
+	return err
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, userId string, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND status_code = 0", userId, key)
+	return err
+}
+
+func (r *IdempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", before)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
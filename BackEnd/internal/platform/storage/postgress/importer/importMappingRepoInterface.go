@@ -0,0 +1,15 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+)
+
+type ImportMappingRepoInterface interface {
+	Save(ctx context.Context, mapping *importer.ImportMapping) error
+	Update(ctx context.Context, mapping *importer.ImportMapping) error
+	FindAll(ctx context.Context, userId string) ([]*importer.ImportMapping, error)
+	FindById(ctx context.Context, id string, userId string) (*importer.ImportMapping, error)
+	Delete(ctx context.Context, id string, userId string) error
+}
@@ -0,0 +1,127 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/rs/zerolog/log"
+)
+
+const importMappingColumns = "id, user_id, name, delimiter, has_header, date_column, amount_column, description_column, date_format, sign_convention, decimal_separator, category_id, created_at, updated_at"
+
+type ImportMappingRepository struct {
+	db *sql.DB
+}
+
+func NewImportMappingRepository(db *sql.DB) *ImportMappingRepository {
+	return &ImportMappingRepository{
+		db: db,
+	}
+}
+
+func (r *ImportMappingRepository) Save(ctx context.Context, mapping *importer.ImportMapping) error {
+	query := `INSERT INTO import_mappings (` + importMappingColumns + `)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	_, err := r.db.ExecContext(ctx, query, mapping.Id, mapping.UserId, mapping.Name, mapping.Delimiter, mapping.HasHeader, mapping.DateColumn, mapping.AmountColumn, mapping.DescriptionColumn,
+		mapping.DateFormat, mapping.SignConvention, mapping.DecimalSeparator, nullIfEmpty(mapping.CategoryId), mapping.CreatedAt, mapping.UpdatedAt)
+	return err
+}
+
+func (r *ImportMappingRepository) Update(ctx context.Context, mapping *importer.ImportMapping) error {
+	query := `UPDATE import_mappings
+			  SET name = $1, delimiter = $2, has_header = $3, date_column = $4, amount_column = $5, description_column = $6,
+			      date_format = $7, sign_convention = $8, decimal_separator = $9, category_id = $10, updated_at = $11
+			  WHERE id = $12 AND user_id = $13`
+	result, err := r.db.ExecContext(ctx, query, mapping.Name, mapping.Delimiter, mapping.HasHeader, mapping.DateColumn, mapping.AmountColumn, mapping.DescriptionColumn,
+		mapping.DateFormat, mapping.SignConvention, mapping.DecimalSeparator, nullIfEmpty(mapping.CategoryId), mapping.UpdatedAt, mapping.Id, mapping.UserId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ImportMappingRepository) FindAll(ctx context.Context, userId string) ([]*importer.ImportMapping, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+importMappingColumns+" FROM import_mappings WHERE user_id = $1 ORDER BY name", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	return scanMappings(rows)
+}
+
+// FindById returns sql.ErrNoRows when the mapping does not exist or belongs to another user.
+func (r *ImportMappingRepository) FindById(ctx context.Context, id string, userId string) (*importer.ImportMapping, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+importMappingColumns+" FROM import_mappings WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	mappings, err := scanMappings(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return mappings[0], nil
+}
+
+func (r *ImportMappingRepository) Delete(ctx context.Context, id string, userId string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM import_mappings WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanMappings(rows *sql.Rows) ([]*importer.ImportMapping, error) {
+	var mappings []*importer.ImportMapping
+	for rows.Next() {
+		var mapping importer.ImportMapping
+		var categoryID sql.NullString
+		err := rows.Scan(&mapping.Id, &mapping.UserId, &mapping.Name, &mapping.Delimiter, &mapping.HasHeader, &mapping.DateColumn, &mapping.AmountColumn, &mapping.DescriptionColumn,
+			&mapping.DateFormat, &mapping.SignConvention, &mapping.DecimalSeparator, &categoryID, &mapping.CreatedAt, &mapping.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		mapping.CategoryId = categoryID.String
+		mappings = append(mappings, &mapping)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// nullIfEmpty maps an empty string to SQL NULL for optional foreign keys.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
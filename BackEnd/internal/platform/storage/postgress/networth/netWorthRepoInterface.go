@@ -0,0 +1,15 @@
+package postgress
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+)
+
+type NetWorthRepoInterface interface {
+	// Save records the net worth of the user for its month, replacing what was recorded for it before
+	Save(ctx context.Context, record *networth.MonthlyNetWorth) error
+	// FindRange returns the months recorded between from and to, both included, oldest first
+	FindRange(ctx context.Context, userId string, from time.Time, to time.Time) ([]*networth.MonthlyNetWorth, error)
+}
@@ -0,0 +1,57 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+	"github.com/rs/zerolog/log"
+)
+
+const netWorthColumns = "user_id, month, currency, assets, liabilities, investments, net_worth, updated_at"
+
+type NetWorthRepository struct {
+	db *sql.DB
+}
+
+func NewNetWorthRepository(db *sql.DB) *NetWorthRepository {
+	return &NetWorthRepository{
+		db: db,
+	}
+}
+
+func (r *NetWorthRepository) Save(ctx context.Context, record *networth.MonthlyNetWorth) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO net_worth_snapshots ("+netWorthColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, month) DO UPDATE SET currency = excluded.currency, assets = excluded.assets, liabilities = excluded.liabilities,
+		investments = excluded.investments, net_worth = excluded.net_worth, updated_at = excluded.updated_at`,
+		record.UserId, record.Month.Format("2006-01-02"), record.Currency, record.Assets, record.Liabilities, record.Investments, record.NetWorth, record.UpdatedAt)
+	return err
+}
+
+func (r *NetWorthRepository) FindRange(ctx context.Context, userId string, from time.Time, to time.Time) ([]*networth.MonthlyNetWorth, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+netWorthColumns+" FROM net_worth_snapshots WHERE user_id = $1 AND month >= $2 AND month <= $3 ORDER BY month",
+		userId, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close database rows")
+		}
+	}()
+
+	var records []*networth.MonthlyNetWorth
+	for rows.Next() {
+		record := &networth.MonthlyNetWorth{}
+		if err := rows.Scan(&record.UserId, &record.Month, &record.Currency, &record.Assets, &record.Liabilities, &record.Investments, &record.NetWorth, &record.UpdatedAt); err != nil {
+			return nil, err
+		}
+		record.Month = networth.MonthStart(record.Month)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,23 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+)
+
+type PayeeRepoInterface interface {
+	// Save stores a payee together with its aliases
+	Save(ctx context.Context, payee *payee.Payee) error
+	// Update renames a payee and replaces its aliases
+	Update(ctx context.Context, payee *payee.Payee) error
+	FindAll(ctx context.Context, userId string) ([]*payee.Payee, error)
+	FindById(ctx context.Context, id string, userId string) (*payee.Payee, error)
+	FindByName(ctx context.Context, name string, userId string) (*payee.Payee, error)
+	// Delete removes a payee and unlinks the transactions that pointed at it
+	Delete(ctx context.Context, id string, userId string) error
+	// FindCandidates returns the live, non-transfer transactions of the user that have no payee yet
+	FindCandidates(ctx context.Context, userId string) ([]*payee.Candidate, error)
+	// Assign links the given transactions to a payee, skipping those that already have one, and returns how many changed
+	Assign(ctx context.Context, userId string, payeeId string, transactionIds []string) (int, error)
+}
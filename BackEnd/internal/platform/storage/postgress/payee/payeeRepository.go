@@ -0,0 +1,241 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	"github.com/rs/zerolog/log"
+)
+
+const payeeColumns = "id, user_id, name, created_at"
+
+type PayeeRepository struct {
+	db *sql.DB
+}
+
+func NewPayeeRepository(db *sql.DB) *PayeeRepository {
+	return &PayeeRepository{
+		db: db,
+	}
+}
+
+func (r *PayeeRepository) Save(ctx context.Context, payee *payee.Payee) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO payees ("+payeeColumns+") VALUES ($1, $2, $3, $4)", payee.Id, payee.UserId, payee.Name, payee.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if err = insertAliases(ctx, tx, payee); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PayeeRepository) Update(ctx context.Context, payee *payee.Payee) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.ExecContext(ctx, "UPDATE payees SET name = $1 WHERE id = $2 AND user_id = $3", payee.Name, payee.Id, payee.UserId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM payee_aliases WHERE payee_id = $1", payee.Id); err != nil {
+		return err
+	}
+	if err = insertAliases(ctx, tx, payee); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertAliases stores the aliases of a payee inside an open database transaction.
+func insertAliases(ctx context.Context, tx *sql.Tx, payee *payee.Payee) error {
+	for _, alias := range payee.Aliases {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO payee_aliases (payee_id, pattern) VALUES ($1, $2)", payee.Id, alias); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PayeeRepository) FindAll(ctx context.Context, userId string) ([]*payee.Payee, error) {
+	return r.find(ctx, "SELECT "+payeeColumns+" FROM payees WHERE user_id = $1 ORDER BY name", userId)
+}
+
+// FindById returns sql.ErrNoRows when the payee does not exist or belongs to another user.
+func (r *PayeeRepository) FindById(ctx context.Context, id string, userId string) (*payee.Payee, error) {
+	return r.findOne(ctx, "SELECT "+payeeColumns+" FROM payees WHERE id = $1 AND user_id = $2", id, userId)
+}
+
+// FindByName returns sql.ErrNoRows when the user has no payee with that name.
+func (r *PayeeRepository) FindByName(ctx context.Context, name string, userId string) (*payee.Payee, error) {
+	return r.findOne(ctx, "SELECT "+payeeColumns+" FROM payees WHERE name = $1 AND user_id = $2", name, userId)
+}
+
+func (r *PayeeRepository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "UPDATE transactions SET payee_id = NULL WHERE payee_id = $1 AND user_id = $2", id, userId); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM payee_aliases WHERE payee_id IN (SELECT id FROM payees WHERE id = $1 AND user_id = $2)", id, userId); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM payees WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+func (r *PayeeRepository) FindCandidates(ctx context.Context, userId string) ([]*payee.Candidate, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, transaction_name FROM transactions WHERE user_id = $1 AND payee_id IS NULL AND transfer_id IS NULL AND deleted_at IS NULL", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var candidates []*payee.Candidate
+	for rows.Next() {
+		var candidate payee.Candidate
+		if err := rows.Scan(&candidate.TransactionId, &candidate.Name); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &candidate)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *PayeeRepository) Assign(ctx context.Context, userId string, payeeId string, transactionIds []string) (int, error) {
+	if len(transactionIds) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	assigned := 0
+	for _, id := range transactionIds {
+		result, err := tx.ExecContext(ctx, "UPDATE transactions SET payee_id = $1 WHERE id = $2 AND user_id = $3 AND payee_id IS NULL", payeeId, id, userId)
+		if err != nil {
+			return 0, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		assigned += int(rowsAffected)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return assigned, nil
+}
+
+func (r *PayeeRepository) findOne(ctx context.Context, query string, args ...interface{}) (*payee.Payee, error) {
+	payees, err := r.find(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(payees) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return payees[0], nil
+}
+
+// find loads the payees selected by query and then their aliases, in alphabetical order.
+func (r *PayeeRepository) find(ctx context.Context, query string, args ...interface{}) ([]*payee.Payee, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var payees []*payee.Payee
+	byId := make(map[string]*payee.Payee)
+	for rows.Next() {
+		var p payee.Payee
+		if err := rows.Scan(&p.Id, &p.UserId, &p.Name, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		p.Aliases = []string{}
+		payees = append(payees, &p)
+		byId[p.Id] = &p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(payees) == 0 {
+		return payees, nil
+	}
+
+	aliasRows, err := r.db.QueryContext(ctx,
+		"SELECT a.payee_id, a.pattern FROM payee_aliases a JOIN payees p ON p.id = a.payee_id WHERE p.user_id = $1 ORDER BY a.pattern", payees[0].UserId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := aliasRows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+	for aliasRows.Next() {
+		var payeeId, pattern string
+		if err := aliasRows.Scan(&payeeId, &pattern); err != nil {
+			return nil, err
+		}
+		if p, found := byId[payeeId]; found {
+			p.Aliases = append(p.Aliases, pattern)
+		}
+	}
+	return payees, aliasRows.Err()
+}
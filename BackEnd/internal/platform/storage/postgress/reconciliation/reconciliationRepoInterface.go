@@ -0,0 +1,23 @@
+package postgress
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+)
+
+type ReconciliationRepoInterface interface {
+	Save(ctx context.Context, reconciliation *reconciliation.Reconciliation) error
+	// Update changes the statement of an open reconciliation
+	Update(ctx context.Context, reconciliation *reconciliation.Reconciliation) error
+	FindById(ctx context.Context, id string, userId string) (*reconciliation.Reconciliation, error)
+	// FindOpen returns the reconciliation of the account that is still in progress, or sql.ErrNoRows
+	FindOpen(ctx context.Context, accountId string, userId string) (*reconciliation.Reconciliation, error)
+	FindByAccount(ctx context.Context, accountId string, userId string) ([]*reconciliation.Reconciliation, error)
+	// ClearedBalance sums the cleared and reconciled transactions of the account created before until
+	ClearedBalance(ctx context.Context, accountId string, userId string, until time.Time) (money.Money, error)
+	// Complete marks the cleared transactions of the statement as reconciled and closes the reconciliation
+	Complete(ctx context.Context, reconciliation *reconciliation.Reconciliation) error
+}
@@ -0,0 +1,141 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	"github.com/rs/zerolog/log"
+)
+
+const reconciliationColumns = "id, account_id, user_id, statement_date, statement_balance, cleared_balance, status, created_at, completed_at"
+
+type ReconciliationRepository struct {
+	db *sql.DB
+}
+
+func NewReconciliationRepository(db *sql.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		db: db,
+	}
+}
+
+func (r *ReconciliationRepository) Save(ctx context.Context, reconciliation *reconciliation.Reconciliation) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO reconciliations ("+reconciliationColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		reconciliation.Id, reconciliation.AccountId, reconciliation.UserId, reconciliation.StatementDate, reconciliation.StatementBalance,
+		reconciliation.ClearedBalance, reconciliation.Status, reconciliation.CreatedAt, reconciliation.CompletedAt)
+	return err
+}
+
+func (r *ReconciliationRepository) Update(ctx context.Context, rec *reconciliation.Reconciliation) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE reconciliations SET statement_date = $1, statement_balance = $2, cleared_balance = $3 WHERE id = $4 AND user_id = $5 AND status = $6",
+		rec.StatementDate, rec.StatementBalance, rec.ClearedBalance, rec.Id, rec.UserId, reconciliation.StatusOpen)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindById returns sql.ErrNoRows when the reconciliation does not exist or belongs to another user.
+func (r *ReconciliationRepository) FindById(ctx context.Context, id string, userId string) (*reconciliation.Reconciliation, error) {
+	return r.findOne(ctx, "SELECT "+reconciliationColumns+" FROM reconciliations WHERE id = $1 AND user_id = $2", id, userId)
+}
+
+func (r *ReconciliationRepository) FindOpen(ctx context.Context, accountId string, userId string) (*reconciliation.Reconciliation, error) {
+	return r.findOne(ctx, "SELECT "+reconciliationColumns+" FROM reconciliations WHERE account_id = $1 AND user_id = $2 AND status = $3",
+		accountId, userId, reconciliation.StatusOpen)
+}
+
+// FindByAccount returns the reconciliations of an account, latest statement first.
+func (r *ReconciliationRepository) FindByAccount(ctx context.Context, accountId string, userId string) ([]*reconciliation.Reconciliation, error) {
+	return r.find(ctx, "SELECT "+reconciliationColumns+" FROM reconciliations WHERE account_id = $1 AND user_id = $2 ORDER BY statement_date DESC, created_at DESC",
+		accountId, userId)
+}
+
+func (r *ReconciliationRepository) ClearedBalance(ctx context.Context, accountId string, userId string, until time.Time) (money.Money, error) {
+	var total money.Money
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE account_id = $1 AND user_id = $2 AND status IN ($3, $4) AND created_at < $5 AND deleted_at IS NULL",
+		accountId, userId, transaction.StatusCleared, transaction.StatusReconciled, until).Scan(&total)
+	return total, err
+}
+
+func (r *ReconciliationRepository) Complete(ctx context.Context, rec *reconciliation.Reconciliation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, "UPDATE transactions SET status = $1, reconciliation_id = $2 WHERE account_id = $3 AND user_id = $4 AND status = $5 AND created_at < $6 AND deleted_at IS NULL",
+		transaction.StatusReconciled, rec.Id, rec.AccountId, rec.UserId, transaction.StatusCleared, rec.Until())
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE reconciliations SET status = $1, cleared_balance = $2, completed_at = $3 WHERE id = $4 AND user_id = $5 AND status = $6",
+		reconciliation.StatusCompleted, rec.ClearedBalance, rec.CompletedAt, rec.Id, rec.UserId, reconciliation.StatusOpen)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+func (r *ReconciliationRepository) findOne(ctx context.Context, query string, args ...interface{}) (*reconciliation.Reconciliation, error) {
+	reconciliations, err := r.find(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(reconciliations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return reconciliations[0], nil
+}
+
+func (r *ReconciliationRepository) find(ctx context.Context, query string, args ...interface{}) ([]*reconciliation.Reconciliation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var reconciliations []*reconciliation.Reconciliation
+	for rows.Next() {
+		var rec reconciliation.Reconciliation
+		var completedAt sql.NullTime
+		if err := rows.Scan(&rec.Id, &rec.AccountId, &rec.UserId, &rec.StatementDate, &rec.StatementBalance, &rec.ClearedBalance,
+			&rec.Status, &rec.CreatedAt, &completedAt); err != nil {
+			return nil, err
+		}
+		if completedAt.Valid {
+			rec.CompletedAt = &completedAt.Time
+		}
+		reconciliations = append(reconciliations, &rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reconciliations, nil
+}
@@ -0,0 +1,16 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+)
+
+type RuleRepoInterface interface {
+	Save(ctx context.Context, rule *rule.Rule) error
+	Update(ctx context.Context, rule *rule.Rule) error
+	// FindAll returns the rules of a user in evaluation order
+	FindAll(ctx context.Context, userId string) ([]*rule.Rule, error)
+	FindById(ctx context.Context, id string, userId string) (*rule.Rule, error)
+	Delete(ctx context.Context, id string, userId string) error
+}
@@ -0,0 +1,139 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	"github.com/rs/zerolog/log"
+)
+
+const ruleColumns = "id, user_id, name, priority, enabled, name_contains, amount_min, amount_max, account_id, category_id, budget_id, rename_to, created_at, updated_at"
+
+type RuleRepository struct {
+	db *sql.DB
+}
+
+func NewRuleRepository(db *sql.DB) *RuleRepository {
+	return &RuleRepository{
+		db: db,
+	}
+}
+
+func (r *RuleRepository) Save(ctx context.Context, rule *rule.Rule) error {
+	query := `INSERT INTO categorization_rules (` + ruleColumns + `)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	_, err := r.db.ExecContext(ctx, query, rule.Id, rule.UserId, rule.Name, rule.Priority, rule.Enabled, nullIfEmpty(rule.NameContains), rule.AmountMin, rule.AmountMax,
+		nullIfEmpty(rule.AccountId), nullIfEmpty(rule.CategoryId), nullIfEmpty(rule.BudgetId), nullIfEmpty(rule.RenameTo), rule.CreatedAt, rule.UpdatedAt)
+	return err
+}
+
+func (r *RuleRepository) Update(ctx context.Context, rule *rule.Rule) error {
+	query := `UPDATE categorization_rules
+			  SET name = $1, priority = $2, enabled = $3, name_contains = $4, amount_min = $5, amount_max = $6,
+			      account_id = $7, category_id = $8, budget_id = $9, rename_to = $10, updated_at = $11
+			  WHERE id = $12 AND user_id = $13`
+	result, err := r.db.ExecContext(ctx, query, rule.Name, rule.Priority, rule.Enabled, nullIfEmpty(rule.NameContains), rule.AmountMin, rule.AmountMax,
+		nullIfEmpty(rule.AccountId), nullIfEmpty(rule.CategoryId), nullIfEmpty(rule.BudgetId), nullIfEmpty(rule.RenameTo), rule.UpdatedAt, rule.Id, rule.UserId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *RuleRepository) FindAll(ctx context.Context, userId string) ([]*rule.Rule, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+ruleColumns+" FROM categorization_rules WHERE user_id = $1 ORDER BY priority, created_at", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	return scanRules(rows)
+}
+
+// FindById returns sql.ErrNoRows when the rule does not exist or belongs to another user.
+func (r *RuleRepository) FindById(ctx context.Context, id string, userId string) (*rule.Rule, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+ruleColumns+" FROM categorization_rules WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	rules, err := scanRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return rules[0], nil
+}
+
+func (r *RuleRepository) Delete(ctx context.Context, id string, userId string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM categorization_rules WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanRules(rows *sql.Rows) ([]*rule.Rule, error) {
+	var rules []*rule.Rule
+	for rows.Next() {
+		var rule rule.Rule
+		var nameContains, accountID, categoryID, budgetID, renameTo sql.NullString
+		var amountMin, amountMax sql.Null[money.Money]
+		err := rows.Scan(&rule.Id, &rule.UserId, &rule.Name, &rule.Priority, &rule.Enabled, &nameContains, &amountMin, &amountMax,
+			&accountID, &categoryID, &budgetID, &renameTo, &rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		rule.NameContains = nameContains.String
+		rule.AccountId = accountID.String
+		rule.CategoryId = categoryID.String
+		rule.BudgetId = budgetID.String
+		rule.RenameTo = renameTo.String
+		if amountMin.Valid {
+			rule.AmountMin = &amountMin.V
+		}
+		if amountMax.Valid {
+			rule.AmountMax = &amountMax.V
+		}
+		rules = append(rules, &rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// nullIfEmpty maps an empty string to SQL NULL for optional columns.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,16 @@
+package postgress
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+)
+
+type StatementRepoInterface interface {
+	// Totals sums the transactions of the account a statement of the period is built from
+	Totals(ctx context.Context, accountId string, period account.StatementPeriod) (*account.StatementTotals, error)
+	// MarkReminded records that the due date reminder of the statement closing on closingDate was sent. It reports
+	// false when it had already been recorded
+	MarkReminded(ctx context.Context, accountId string, closingDate time.Time) (bool, error)
+}
@@ -0,0 +1,48 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+)
+
+type StatementRepository struct {
+	db *sql.DB
+}
+
+func NewStatementRepository(db *sql.DB) *StatementRepository {
+	return &StatementRepository{
+		db: db,
+	}
+}
+
+func (r *StatementRepository) Totals(ctx context.Context, accountId string, period account.StatementPeriod) (*account.StatementTotals, error) {
+	totals := &account.StatementTotals{}
+	err := r.db.QueryRowContext(ctx, `SELECT
+			COALESCE(SUM(CASE WHEN created_at < $1 THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN created_at >= $1 AND created_at < $2 AND amount < 0 THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN created_at >= $1 AND created_at < $2 AND amount > 0 THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN created_at >= $2 AND created_at < $3 AND amount > 0 THEN amount ELSE 0 END), 0)
+		FROM transactions WHERE account_id = $4 AND deleted_at IS NULL`,
+		period.Start, period.Close.AddDate(0, 0, 1), period.Due.AddDate(0, 0, 1), accountId).
+		Scan(&totals.Before, &totals.Charges, &totals.Credits, &totals.Paid)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+func (r *StatementRepository) MarkReminded(ctx context.Context, accountId string, closingDate time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "INSERT INTO statement_reminders (account_id, closing_date) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		accountId, closingDate.Format(account.DateLayout))
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,19 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+)
+
+type TagRepoInterface interface {
+	Save(ctx context.Context, tag *tag.Tag) error
+	// SaveIfMissing stores the tags whose name the user does not have yet and ignores the rest
+	SaveIfMissing(ctx context.Context, tags []*tag.Tag) error
+	Update(ctx context.Context, tag *tag.Tag) error
+	FindAll(ctx context.Context, userId string) ([]*tag.Tag, error)
+	FindById(ctx context.Context, id string, userId string) (*tag.Tag, error)
+	FindByName(ctx context.Context, name string, userId string) (*tag.Tag, error)
+	// Delete removes a tag and detaches it from every transaction
+	Delete(ctx context.Context, id string, userId string) error
+}
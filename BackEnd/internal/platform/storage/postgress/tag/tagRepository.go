@@ -0,0 +1,142 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	"github.com/rs/zerolog/log"
+)
+
+const tagColumns = "id, user_id, name, created_at"
+
+type TagRepository struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
+
+func (r *TagRepository) Save(ctx context.Context, tag *tag.Tag) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO tags ("+tagColumns+") VALUES ($1, $2, $3, $4)", tag.Id, tag.UserId, tag.Name, tag.CreatedAt)
+	return err
+}
+
+func (r *TagRepository) SaveIfMissing(ctx context.Context, tags []*tag.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, tag := range tags {
+		_, err = tx.ExecContext(ctx, "INSERT INTO tags ("+tagColumns+") VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, name) DO NOTHING",
+			tag.Id, tag.UserId, tag.Name, tag.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *tag.Tag) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE tags SET name = $1 WHERE id = $2 AND user_id = $3", tag.Name, tag.Id, tag.UserId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *TagRepository) FindAll(ctx context.Context, userId string) ([]*tag.Tag, error) {
+	return r.find(ctx, "SELECT "+tagColumns+" FROM tags WHERE user_id = $1 ORDER BY name", userId)
+}
+
+// FindById returns sql.ErrNoRows when the tag does not exist or belongs to another user.
+func (r *TagRepository) FindById(ctx context.Context, id string, userId string) (*tag.Tag, error) {
+	return r.findOne(ctx, "SELECT "+tagColumns+" FROM tags WHERE id = $1 AND user_id = $2", id, userId)
+}
+
+// FindByName returns sql.ErrNoRows when the user has no tag with that normalized name.
+func (r *TagRepository) FindByName(ctx context.Context, name string, userId string) (*tag.Tag, error) {
+	return r.findOne(ctx, "SELECT "+tagColumns+" FROM tags WHERE name = $1 AND user_id = $2", name, userId)
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE tag_id IN (SELECT id FROM tags WHERE id = $1 AND user_id = $2)", id, userId); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+func (r *TagRepository) findOne(ctx context.Context, query string, args ...interface{}) (*tag.Tag, error) {
+	tags, err := r.find(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return tags[0], nil
+}
+
+func (r *TagRepository) find(ctx context.Context, query string, args ...interface{}) ([]*tag.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var tags []*tag.Tag
+	for rows.Next() {
+		var tag tag.Tag
+		if err := rows.Scan(&tag.Id, &tag.UserId, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 	"github.com/stretchr/testify/assert"
 
@@ -25,21 +28,53 @@ func TestAccountRepository(t *testing.T) {
 	err = accountRepo.Save(ctx, account)
 	assert.NoError(t, err)
 
-	listofAccout, err := accountRepo.FindAll(ctx, user.Id)
+	listofAccout, err := accountRepo.FindAll(ctx, user.Id, false)
 	assert.NoError(t, err)
 	assert.Equal(t, account.Id, listofAccout[len(listofAccout)-1].Id)
 
 	balance, err := accountRepo.Balance(ctx, account.Id)
 	assert.NoError(t, err)
-	assert.Equal(t, 0.0, balance)
+	assert.Equal(t, money.FromUnits(0), balance)
 
 	err = accountRepo.Delete(ctx, account.Id, user.Id)
 	assert.NoError(t, err)
 
-	novalue, err := accountRepo.FindAll(ctx, account.UserId)
+	novalue, err := accountRepo.FindAll(ctx, account.UserId, false)
 	assert.NoError(t, err)
 	assert.Empty(t, novalue)
 
 	err = userRepo.Delete(ctx, user.Id)
 	assert.NoError(t, err)
 }
+
+func TestAccountRepositoryTypes(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.NewUserRepository(db).Save(ctx, user))
+	accountRepo := postgress.NewAccountRepository(db)
+
+	limit := money.FromUnits(3000)
+	statementDay, dueDay := 25, 10
+	card := account.NewAccount(money.FromUnits(-150), "acc_card", "Visa", "Bank")
+	card.UserId = user.Id
+	card.Type = account.TypeCreditCard
+	card.Details = account.Details{CreditLimit: &limit, StatementDay: &statementDay, DueDay: &dueDay}
+	assert.NoError(t, accountRepo.Save(ctx, card))
+
+	found, err := accountRepo.FindByIdAndUserId(ctx, card.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, account.TypeCreditCard, found.Type)
+	assert.Equal(t, card.Details, found.Details)
+	assert.Equal(t, money.FromUnits(-150), found.InitialBalance)
+
+	rate := 4.25
+	found.Type = account.TypeLoan
+	found.Details = account.Details{InterestRate: &rate}
+	assert.NoError(t, accountRepo.Update(ctx, found))
+
+	updated, err := accountRepo.FindByIdAndUserId(ctx, card.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, account.TypeLoan, updated.Type)
+	assert.Equal(t, account.Details{InterestRate: &rate}, updated.Details)
+}
@@ -0,0 +1,90 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	postgress "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive_Accounts(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+
+	user := utils.GetNewRandomUser()
+	closed := utils.GetNewRandomAccount()
+	open := utils.GetNewRandomAccount()
+	closed.UserId = user.Id
+	open.UserId = user.Id
+	assert.NoError(t, userRepo.NewUserRepository(db).Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, closed))
+	assert.NoError(t, accountRepo.Save(ctx, open))
+
+	rent := transaction.NewTransaction("txn_archive_rent", "Rent", "", "bill", closed.Id, "", money.FromUnits(-500))
+	rent.UserId = user.Id
+	rent.CreatedAt = time.Now()
+	assert.NoError(t, transactionRepo.Save(ctx, rent))
+
+	archivedAt := time.Now()
+	assert.NoError(t, accountRepo.Archive(ctx, closed.Id, user.Id, archivedAt))
+	assert.ErrorIs(t, accountRepo.Archive(ctx, closed.Id, "someone_else", archivedAt), sql.ErrNoRows)
+
+	// Archived accounts are hidden from listings unless asked for
+	accounts, err := accountRepo.FindAll(ctx, user.Id, false)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, open.Id, accounts[0].Id)
+	accounts, err = accountRepo.FindAll(ctx, user.Id, true)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	archived, err := accountRepo.FindByIdAndUserId(ctx, closed.Id, user.Id)
+	assert.NoError(t, err)
+	assert.True(t, archived.IsArchived())
+
+	// Its history stays, but it takes no new transactions
+	balance, err := accountRepo.Balance(ctx, closed.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(-500), balance)
+
+	coffee := transaction.NewTransaction("txn_archive_coffee", "Coffee", "", "bill", closed.Id, "", money.FromUnits(-5))
+	coffee.UserId = user.Id
+	coffee.CreatedAt = time.Now()
+	assert.ErrorIs(t, transactionRepo.Save(ctx, coffee), postgress.ErrAccountArchived)
+	_, err = transactionRepo.SaveIfNew(ctx, coffee)
+	assert.ErrorIs(t, err, postgress.ErrAccountArchived)
+
+	outgoing := transaction.NewTransaction("txn_archive_out", "Savings", "", "transfer", open.Id, "", money.FromUnits(-100))
+	incoming := transaction.NewTransaction("txn_archive_in", "Savings", "", "transfer", closed.Id, "", money.FromUnits(100))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_archive"
+		leg.CreatedAt = time.Now()
+	}
+	assert.ErrorIs(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming), postgress.ErrAccountArchived)
+
+	// A transaction already there can still be edited, but none can move in
+	rent.Name = "Last rent"
+	assert.NoError(t, transactionRepo.Update(ctx, rent.Id, rent))
+	coffee.AccountId = open.Id
+	assert.NoError(t, transactionRepo.Save(ctx, coffee))
+	coffee.AccountId = closed.Id
+	assert.ErrorIs(t, transactionRepo.Update(ctx, coffee.Id, coffee), postgress.ErrAccountArchived)
+
+	// Unarchiving opens the account again
+	assert.NoError(t, accountRepo.Unarchive(ctx, closed.Id, user.Id))
+	accounts, err = accountRepo.FindAll(ctx, user.Id, false)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+	assert.NoError(t, transactionRepo.Update(ctx, coffee.Id, coffee))
+}
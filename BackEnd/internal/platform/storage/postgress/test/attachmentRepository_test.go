@@ -0,0 +1,95 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	attachmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/attachment"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	attachmentRepo := attachmentRepo.NewAttachmentRepository(db)
+	transactionRepo := transactionRepo.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	for _, id := range []string{"txn_hotel", "txn_dinner"} {
+		txn := transaction.NewTransaction(id, id, "", "bill", account.Id, "cat_travel", money.FromUnits(-100))
+		txn.UserId = user.Id
+		txn.CreatedAt = time.Now()
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+
+	invoice := attachment.NewAttachment("att_invoice", user.Id, "txn_hotel", "invoice.pdf", "application/pdf", 2048)
+	receipt := attachment.NewAttachment("att_receipt", user.Id, "txn_hotel", "receipt.jpg", "image/jpeg", 1024)
+	ticket := attachment.NewAttachment("att_ticket", user.Id, "txn_dinner", "ticket.png", "image/png", 512)
+	for _, a := range []*attachment.Attachment{invoice, receipt, ticket} {
+		assert.NoError(t, attachmentRepo.Save(ctx, a))
+	}
+
+	attachments, err := attachmentRepo.FindByTransaction(ctx, "txn_hotel", user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 2)
+
+	found, err := attachmentRepo.FindById(ctx, invoice.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, invoice.StorageKey, found.StorageKey)
+	assert.Equal(t, int64(2048), found.Size)
+
+	_, err = attachmentRepo.FindById(ctx, invoice.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	used, err := attachmentRepo.TotalSize(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3584), used)
+
+	// Deleting an attachment queues its blob
+	assert.NoError(t, attachmentRepo.Delete(ctx, ticket.Id, user.Id))
+	assert.ErrorIs(t, attachmentRepo.Delete(ctx, ticket.Id, user.Id), sql.ErrNoRows)
+
+	pending, err := attachmentRepo.FindPendingDeletions(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ticket.StorageKey}, pending)
+	assert.NoError(t, attachmentRepo.ClearPendingDeletions(ctx, pending))
+
+	// Trashing the transaction keeps its attachments so it can be restored
+	assert.NoError(t, transactionRepo.Delete(ctx, "txn_hotel", user.Id))
+	attachments, err = attachmentRepo.FindByTransaction(ctx, "txn_hotel", user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 2)
+
+	// Purging the transaction removes its attachments and queues their blobs
+	purged, err := transactionRepo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	attachments, err = attachmentRepo.FindByTransaction(ctx, "txn_hotel", user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, attachments)
+
+	pending, err = attachmentRepo.FindPendingDeletions(ctx, 10)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{invoice.StorageKey, receipt.StorageKey}, pending)
+
+	used, err = attachmentRepo.TotalSize(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), used)
+}
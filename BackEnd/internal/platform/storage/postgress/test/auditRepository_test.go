@@ -0,0 +1,79 @@
+package postgress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+	auditRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	auditRepo := auditRepo.NewAuditRepository(db)
+
+	before := category.NewCategory("cat_audit", "Food", "🍔", "#fff")
+	after := category.NewCategory("cat_audit", "Groceries", "🍔", "#fff")
+	save := func(id, action, userId string, before, after any, createdAt time.Time) {
+		event, err := audit.NewEvent(id, audit.EntityCategory, "cat_audit", action, userId, "req_audit", before, after)
+		assert.NoError(t, err)
+		event.CreatedAt = createdAt
+		assert.NoError(t, auditRepo.Save(ctx, event))
+	}
+	start := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	save("evt_audit_1", audit.ActionCreate, "user_audit", nil, before, start)
+	save("evt_audit_2", audit.ActionUpdate, "user_audit", before, after, start.Add(time.Hour))
+	save("evt_audit_3", audit.ActionDelete, "user_audit_admin", after, nil, start.AddDate(0, 0, 2))
+
+	// The history of a record only holds the changes of the user, oldest first
+	history, err := auditRepo.FindByEntity(ctx, audit.EntityCategory, "cat_audit", "user_audit")
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, "evt_audit_1", history[0].Id)
+		assert.Empty(t, history[0].Before)
+		assert.JSONEq(t, `{"id":"cat_audit","name":"Food","icon":"🍔","color":"#fff","user_id":"","created_at":"0001-01-01T00:00:00Z"}`, string(history[0].After))
+		assert.Equal(t, "req_audit", history[0].RequestId)
+		assert.Equal(t, "evt_audit_2", history[1].Id)
+		assert.Equal(t, audit.ActionUpdate, history[1].Action)
+		assert.Contains(t, string(history[1].Before), `"Food"`)
+		assert.Contains(t, string(history[1].After), `"Groceries"`)
+	}
+
+	filter := dto.NewAuditFilter()
+	filter.EntityId = "cat_audit"
+	events, err := auditRepo.Find(ctx, filter)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "evt_audit_3", events[0].Id)
+		assert.Empty(t, events[0].After)
+	}
+
+	filter.Action = audit.ActionUpdate
+	events, err = auditRepo.Find(ctx, filter)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	filter = dto.NewAuditFilter()
+	filter.EntityId = "cat_audit"
+	filter.From = start.AddDate(0, 0, 1)
+	events, err = auditRepo.Find(ctx, filter)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "user_audit_admin", events[0].UserId)
+	}
+
+	filter = dto.NewAuditFilter()
+	filter.EntityId = "cat_audit"
+	filter.Limit = 1
+	filter.Offset = 1
+	events, err = auditRepo.Find(ctx, filter)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "evt_audit_2", events[0].Id)
+	}
+}
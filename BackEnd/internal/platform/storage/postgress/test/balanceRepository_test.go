@@ -0,0 +1,81 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	balanceRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/balance"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	balanceRepo := balanceRepo.NewBalanceRepository(db)
+	transactionRepo := transactionRepo.NewTransactionRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.NewUserRepository(db).Save(ctx, user))
+	acc := utils.GetNewRandomAccount()
+	acc.UserId = user.Id
+	assert.NoError(t, accountRepo.NewAccountRepository(db).Save(ctx, acc))
+
+	_, err := balanceRepo.FirstTransactionDay(ctx, acc.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = balanceRepo.Last(ctx, acc.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	march1 := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var ids []string
+	for i, amount := range []int64{100, -30, 50} {
+		txn := utils.GetNewRandomTransaction()
+		txn.UserId = user.Id
+		txn.AccountId = acc.Id
+		txn.Amount = money.FromUnits(amount)
+		txn.CreatedAt = march1.Add(time.Duration(i*12+10) * time.Hour)
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+		ids = append(ids, txn.Id)
+	}
+
+	first, err := balanceRepo.FirstTransactionDay(ctx, acc.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, march1, first)
+	changes, err := balanceRepo.DailyChanges(ctx, acc.Id, march1, march1.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, map[time.Time]money.Money{march1: money.FromUnits(70), march1.AddDate(0, 0, 1): money.FromUnits(50)}, changes)
+
+	var balances []account.DailyBalance
+	for i := 0; i < 5; i++ {
+		balances = append(balances, account.DailyBalance{AccountId: acc.Id, Day: march1.AddDate(0, 0, i), Balance: money.FromUnits(120)})
+	}
+	balances[0].Balance = money.FromUnits(70)
+	assert.NoError(t, balanceRepo.Save(ctx, balances))
+
+	stored, err := balanceRepo.FindRange(ctx, acc.Id, march1.AddDate(0, 0, 1), march1.AddDate(0, 0, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, balances[1:3], stored)
+
+	// A transaction on March 3rd drops the stored days from March 3rd on
+	txn := utils.GetNewRandomTransaction()
+	txn.UserId = user.Id
+	txn.AccountId = acc.Id
+	txn.CreatedAt = march1.AddDate(0, 0, 2).Add(9 * time.Hour)
+	assert.NoError(t, transactionRepo.Save(ctx, txn))
+	last, err := balanceRepo.Last(ctx, acc.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, march1.AddDate(0, 0, 1), last.Day)
+
+	// So does deleting a transaction of March 1st
+	assert.NoError(t, balanceRepo.Save(ctx, balances))
+	assert.NoError(t, transactionRepo.Delete(ctx, ids[0], user.Id))
+	_, err = balanceRepo.Last(ctx, acc.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -2,8 +2,10 @@ package postgress
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
 	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
 	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
@@ -49,6 +51,13 @@ func TestBudgetRepository(t *testing.T) {
 	assert.Equal(t, budget.Amount, foundBudget.Amount)
 	assert.NotZero(t, foundBudget.CreatedAt)
 
+	// Test FindByIdAndUserId
+	foundBudget, err = budgetRepository.FindByIdAndUserId(ctx, budget.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, budget.Id, foundBudget.Id)
+	_, err = budgetRepository.FindByIdAndUserId(ctx, budget.Id, "someone_else")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
 	// Test FindAll
 	budgets, err := budgetRepository.FindAll(ctx, user.Id)
 	assert.NoError(t, err)
@@ -56,6 +65,11 @@ func TestBudgetRepository(t *testing.T) {
 	assert.Equal(t, 1, len(budgets))
 	assert.Equal(t, budget.Id, budgets[0].Id)
 
+	// Test FindByCategory
+	foundBudget, err = budgetRepository.FindByCategory(ctx, category.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, budget.Id, foundBudget.Id)
+
 	// Note: Test Delete has a bug in the repository (uses 'budget' table instead of 'budgets')
 	// This test documents the expected behavior when the bug is fixed
 	err = budgetRepository.Delete(ctx, budget.Id, user.Id)
@@ -108,7 +122,7 @@ func TestBudgetRepository_Multiple_Budgets(t *testing.T) {
 
 	// Create multiple categories and budgets
 	var createdBudgets []string
-	budgetAmounts := []float64{1000.0, 500.0, 750.0, 300.0, 1200.0}
+	budgetAmounts := []money.Money{money.FromUnits(1000), money.FromUnits(500), money.FromUnits(750), money.FromUnits(300), money.FromUnits(1200)}
 
 	for i, amount := range budgetAmounts {
 		// Create category for each budget
@@ -135,14 +149,14 @@ func TestBudgetRepository_Multiple_Budgets(t *testing.T) {
 	assert.Equal(t, len(budgetAmounts), len(budgets))
 
 	// Verify all budgets are found and belong to user
-	totalAmount := 0.0
+	totalAmount := money.Zero
 	for _, budget := range budgets {
 		assert.Equal(t, user.Id, budget.UserId)
 		totalAmount += budget.Amount
 	}
 
 	// Verify total amount matches expected
-	expectedTotal := 0.0
+	expectedTotal := money.Zero
 	for _, amount := range budgetAmounts {
 		expectedTotal += amount
 	}
@@ -193,12 +207,12 @@ func TestBudgetRepository_User_Isolation(t *testing.T) {
 	budget1 := utils.GetNewRandomBudget()
 	budget1.UserId = user1.Id
 	budget1.CategoryId = category1.Id
-	budget1.Amount = 1000.0
+	budget1.Amount = money.FromUnits(1000)
 
 	budget2 := utils.GetNewRandomBudget()
 	budget2.UserId = user2.Id
 	budget2.CategoryId = category2.Id
-	budget2.Amount = 2000.0
+	budget2.Amount = money.FromUnits(2000)
 
 	err = budgetRepository.Save(ctx, budget1)
 	assert.NoError(t, err)
@@ -209,12 +223,12 @@ func TestBudgetRepository_User_Isolation(t *testing.T) {
 	user1Budgets, err := budgetRepository.FindAll(ctx, user1.Id)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(user1Budgets))
-	assert.Equal(t, 1000.0, user1Budgets[0].Amount)
+	assert.Equal(t, money.FromUnits(1000), user1Budgets[0].Amount)
 
 	user2Budgets, err := budgetRepository.FindAll(ctx, user2.Id)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(user2Budgets))
-	assert.Equal(t, 2000.0, user2Budgets[0].Amount)
+	assert.Equal(t, money.FromUnits(2000), user2Budgets[0].Amount)
 
 	// Cleanup
 	err = categoryRepository.Delete(ctx, category1.Id, user1.Id)
@@ -226,3 +240,36 @@ func TestBudgetRepository_User_Isolation(t *testing.T) {
 	err = userRepository.Delete(ctx, user2.Id)
 	assert.NoError(t, err)
 }
+
+func TestBudgetRepository_TrashedCategory(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+
+	userRepository := userRepo.NewUserRepository(db)
+	categoryRepository := categoryRepo.NewCategoryRepository(db)
+	budgetRepository := budgetRepo.NewBudgetRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepository.Save(ctx, user))
+	category := utils.GetNewRandomCategory()
+	category.UserId = user.Id
+	assert.NoError(t, categoryRepository.Save(ctx, category))
+	budget := utils.GetNewRandomBudget()
+	budget.UserId = user.Id
+	budget.CategoryId = category.Id
+	assert.NoError(t, budgetRepository.Save(ctx, budget))
+
+	// Budgets of a trashed category are left out of the reads until the category is restored
+	assert.NoError(t, categoryRepository.Delete(ctx, category.Id, user.Id))
+	budgets, err := budgetRepository.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, budgets)
+	found, err := budgetRepository.FindByCategory(ctx, category.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, found.Id)
+
+	assert.NoError(t, categoryRepository.Restore(ctx, category.Id, user.Id))
+	budgets, err = budgetRepository.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, budgets, 1)
+}
@@ -2,6 +2,7 @@ package postgress
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
@@ -41,6 +42,13 @@ func TestCategoryRepository(t *testing.T) {
 	assert.Equal(t, category.UserId, foundCategory.UserId)
 	assert.NotZero(t, foundCategory.CreatedAt)
 
+	// Test FindByIdAndUserId
+	foundCategory, err = categoryRepository.FindByIdAndUserId(ctx, category.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, category.Id, foundCategory.Id)
+	_, err = categoryRepository.FindByIdAndUserId(ctx, category.Id, "someone_else")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
 	// Test FindAll
 	categories, err := categoryRepository.FindAll(ctx, user.Id)
 	assert.NoError(t, err)
@@ -0,0 +1,38 @@
+package postgress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	fxRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/fx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFxRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	fxRepo := fxRepo.NewFxRepository(db)
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	require.NoError(t, fxRepo.SaveRates(ctx, []*fx.Rate{
+		fx.NewRate("EUR", "USD", 1.09, monday, "file"),
+		fx.NewRate("EUR", "USD", 1.1, tuesday, "file"),
+		fx.NewRate("USD", "MXN", 17.05, monday, "file"),
+	}))
+
+	// The same pair and day is replaced, not duplicated
+	require.NoError(t, fxRepo.SaveRates(ctx, []*fx.Rate{fx.NewRate("EUR", "USD", 1.08, tuesday, "manual")}))
+
+	rates, err := fxRepo.FindLatest(ctx)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "EUR", rates[0].Base)
+	assert.Equal(t, 1.08, rates[0].Rate)
+	assert.Equal(t, "manual", rates[0].Source)
+	assert.True(t, tuesday.Equal(rates[0].Date))
+	assert.Equal(t, "MXN", rates[1].Quote)
+}
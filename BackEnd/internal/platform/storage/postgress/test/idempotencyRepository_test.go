@@ -0,0 +1,66 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+	idempotencyRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/idempotency"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	idempotencyRepo := idempotencyRepo.NewIdempotencyRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.Save(ctx, user))
+
+	record := idempotency.NewRecord(user.Id, "key-1", "hash-1", time.Hour)
+	reserved, err := idempotencyRepo.Reserve(ctx, record)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = idempotencyRepo.Reserve(ctx, idempotency.NewRecord(user.Id, "key-1", "hash-2", time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+
+	record.StatusCode = 201
+	record.ContentType = "application/json"
+	record.Response = []byte(`{"id":"txn_1"}`)
+	assert.NoError(t, idempotencyRepo.Complete(ctx, record))
+	found, err := idempotencyRepo.FindByKey(ctx, user.Id, "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "hash-1", found.RequestHash)
+	assert.Equal(t, 201, found.StatusCode)
+	assert.Equal(t, []byte(`{"id":"txn_1"}`), found.Response)
+
+	// Completed records survive a release; reservations do not
+	assert.NoError(t, idempotencyRepo.Release(ctx, user.Id, "key-1"))
+	_, err = idempotencyRepo.FindByKey(ctx, user.Id, "key-1")
+	assert.NoError(t, err)
+	_, err = idempotencyRepo.Reserve(ctx, idempotency.NewRecord(user.Id, "key-2", "hash-1", time.Hour))
+	assert.NoError(t, err)
+	assert.NoError(t, idempotencyRepo.Release(ctx, user.Id, "key-2"))
+	_, err = idempotencyRepo.FindByKey(ctx, user.Id, "key-2")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// An expired key can be reserved again, and is purged
+	expired := idempotency.NewRecord(user.Id, "key-3", "hash-1", -time.Minute)
+	reserved, err = idempotencyRepo.Reserve(ctx, expired)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = idempotencyRepo.Reserve(ctx, idempotency.NewRecord(user.Id, "key-3", "hash-2", time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	assert.NoError(t, idempotencyRepo.Complete(ctx, &idempotency.Record{UserId: user.Id, Key: "key-1", StatusCode: 201}))
+	purged, err := idempotencyRepo.PurgeExpired(ctx, time.Now().UTC().Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+}
@@ -0,0 +1,51 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	importerRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/importer"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportMappingRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	mappingRepo := importerRepo.NewImportMappingRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.Save(ctx, user))
+
+	mapping := importer.NewImportMapping("map_1", user.Id, "Checking", 0, 2, 1, "DD/MM/YYYY")
+	mapping.Delimiter = ";"
+	mapping.DecimalSeparator = ","
+	assert.NoError(t, mappingRepo.Save(ctx, mapping))
+
+	found, err := mappingRepo.FindById(ctx, mapping.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, ";", found.Delimiter)
+	assert.Equal(t, ",", found.DecimalSeparator)
+	assert.True(t, found.HasHeader)
+	assert.Equal(t, "", found.CategoryId)
+
+	_, err = mappingRepo.FindById(ctx, mapping.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	mapping.Name = "Checking (new layout)"
+	mapping.HasHeader = false
+	assert.NoError(t, mappingRepo.Update(ctx, mapping))
+
+	mappings, err := mappingRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, mappings, 1)
+	assert.Equal(t, "Checking (new layout)", mappings[0].Name)
+	assert.False(t, mappings[0].HasHeader)
+
+	assert.NoError(t, mappingRepo.Delete(ctx, mapping.Id, user.Id))
+	assert.ErrorIs(t, mappingRepo.Delete(ctx, mapping.Id, user.Id), sql.ErrNoRows)
+}
@@ -0,0 +1,56 @@
+package postgress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+	netWorthRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/networth"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetWorthRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	netWorthRepo := netWorthRepo.NewNetWorthRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.NewUserRepository(db).Save(ctx, user))
+
+	january := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, netWorthRepo.Save(ctx, &networth.MonthlyNetWorth{
+			UserId: user.Id,
+			Month:  january.AddDate(0, i, 0),
+			Totals: networth.Totals{
+				Currency:    "EUR",
+				Assets:      money.FromUnits(int64(1000 * (i + 1))),
+				Liabilities: money.FromUnits(200),
+				Investments: money.FromUnits(50),
+				NetWorth:    money.FromUnits(int64(1000*(i+1) - 150)),
+			},
+			UpdatedAt: january.AddDate(0, i, 20).Truncate(time.Second),
+		}))
+	}
+
+	// A later record of February replaces the first one
+	assert.NoError(t, netWorthRepo.Save(ctx, &networth.MonthlyNetWorth{
+		UserId:    user.Id,
+		Month:     january.AddDate(0, 1, 0),
+		Totals:    networth.Totals{Currency: "EUR", Assets: money.FromUnits(2500), NetWorth: money.FromUnits(2500)},
+		UpdatedAt: january.AddDate(0, 1, 27),
+	}))
+
+	records, err := netWorthRepo.FindRange(ctx, user.Id, january.AddDate(0, 1, 0), january.AddDate(0, 2, 0))
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, january.AddDate(0, 1, 0), records[0].Month)
+	assert.Equal(t, money.FromUnits(2500), records[0].NetWorth)
+	assert.Equal(t, money.Money(0), records[0].Liabilities)
+	assert.Equal(t, money.FromUnits(2850), records[1].NetWorth)
+	assert.Equal(t, "EUR", records[1].Currency)
+}
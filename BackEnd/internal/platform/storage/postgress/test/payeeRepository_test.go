@@ -0,0 +1,103 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	analyticsRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/analytics"
+	payeeRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/payee"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayeeRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	payeeRepo := payeeRepo.NewPayeeRepository(db)
+	transactionRepo := transactionRepo.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	uber := payee.NewPayee("payee_uber", user.Id, "Uber", []string{"uber trip", "UBER *EATS"})
+	assert.NoError(t, payeeRepo.Save(ctx, uber))
+	assert.NoError(t, payeeRepo.Save(ctx, payee.NewPayee("payee_mercadona", user.Id, "Mercadona", nil)))
+
+	payees, err := payeeRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, payees, 2)
+	assert.Equal(t, "Mercadona", payees[0].Name)
+	assert.Equal(t, []string{"uber eats", "uber trip"}, payees[1].Aliases)
+
+	found, err := payeeRepo.FindByName(ctx, "Uber", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, uber.Id, found.Id)
+	_, err = payeeRepo.FindById(ctx, uber.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	uber.Aliases = []string{"uber trip"}
+	assert.NoError(t, payeeRepo.Update(ctx, uber))
+	found, err = payeeRepo.FindById(ctx, uber.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"uber trip"}, found.Aliases)
+
+	save := func(id string, name string, amount money.Money, payeeId string) {
+		txn := transaction.NewTransaction(id, name, "", "bill", account.Id, "cat_transport", amount)
+		txn.UserId = user.Id
+		txn.CreatedAt = time.Now()
+		txn.PayeeId = payeeId
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+	save("txn_ride", "UBER *TRIP 8812", money.FromUnits(-12), "")
+	save("txn_ride_2", "Uber Trip", money.FromUnits(-8), uber.Id)
+	save("txn_groceries", "MERCADONA 0042", money.FromUnits(-60), "")
+
+	candidates, err := payeeRepo.FindCandidates(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 2)
+
+	// Transactions that already have a payee are left alone
+	linked, err := payeeRepo.Assign(ctx, user.Id, uber.Id, []string{"txn_ride", "txn_ride_2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, linked)
+
+	filter := dto.NewTransactionFilter()
+	filter.CalculatedDateFrom = time.Now().AddDate(0, 0, -1)
+	filter.CalculatedDateTo = time.Now().AddDate(0, 0, 1)
+	filter.PayeeId = uber.Id
+	rides, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Len(t, rides, 2)
+	assert.Equal(t, uber.Id, rides[0].PayeeId)
+
+	payeeExpenses, err := analyticsRepo.NewAnalyticsRepository(db).GetPayeeExpenses(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, payeeExpenses, 1)
+	assert.Equal(t, "Uber", payeeExpenses[0].PayeeName)
+	assert.Equal(t, money.FromUnits(-20), payeeExpenses[0].TotalAmount)
+	assert.Equal(t, 2, payeeExpenses[0].TransactionCount)
+
+	// Deleting a payee keeps its transactions without a payee
+	assert.NoError(t, payeeRepo.Delete(ctx, uber.Id, user.Id))
+	assert.ErrorIs(t, payeeRepo.Delete(ctx, uber.Id, user.Id), sql.ErrNoRows)
+	ride, err := transactionRepo.FindById(ctx, "txn_ride", user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, ride.PayeeId)
+	var aliases int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM payee_aliases").Scan(&aliases))
+	assert.Equal(t, 0, aliases)
+}
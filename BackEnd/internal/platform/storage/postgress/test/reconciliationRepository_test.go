@@ -0,0 +1,101 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	reconciliationRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/reconciliation"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	reconciliationRepo := reconciliationRepo.NewReconciliationRepository(db)
+	transactionRepository := transactionRepo.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	statementDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	save := func(id string, amount int64, createdAt time.Time) {
+		txn := transaction.NewTransaction(id, id, "", "bill", account.Id, "", money.FromUnits(amount))
+		txn.UserId = user.Id
+		txn.CreatedAt = createdAt
+		assert.NoError(t, transactionRepository.Save(ctx, txn))
+	}
+	save("txn_rec_cleared", -40, statementDate.Add(20*time.Hour))
+	save("txn_rec_pending", -15, statementDate.Add(-time.Hour))
+	save("txn_rec_later", -5, statementDate.AddDate(0, 0, 2))
+
+	saved, err := transactionRepository.FindById(ctx, "txn_rec_pending", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusPending, saved.Status)
+
+	assert.NoError(t, transactionRepository.UpdateStatus(ctx, "txn_rec_cleared", user.Id, transaction.StatusCleared))
+	assert.NoError(t, transactionRepository.UpdateStatus(ctx, "txn_rec_later", user.Id, transaction.StatusCleared))
+
+	// Only cleared transactions up to the end of the statement date count
+	cleared, err := reconciliationRepo.ClearedBalance(ctx, account.Id, user.Id, statementDate.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(-40), cleared)
+
+	rec := reconciliation.NewReconciliation("rec_january", account.Id, user.Id, statementDate, money.FromUnits(60))
+	rec.ClearedBalance = money.FromUnits(60)
+	assert.NoError(t, reconciliationRepo.Save(ctx, rec))
+
+	open, err := reconciliationRepo.FindOpen(ctx, account.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "rec_january", open.Id)
+	assert.Equal(t, statementDate, open.StatementDate.UTC())
+	assert.Nil(t, open.CompletedAt)
+
+	completedAt := time.Now().UTC()
+	rec.Status = reconciliation.StatusCompleted
+	rec.CompletedAt = &completedAt
+	assert.NoError(t, reconciliationRepo.Complete(ctx, rec))
+	assert.ErrorIs(t, reconciliationRepo.Complete(ctx, rec), sql.ErrNoRows)
+
+	_, err = reconciliationRepo.FindOpen(ctx, account.Id, user.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	completed, err := reconciliationRepo.FindById(ctx, "rec_january", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, reconciliation.StatusCompleted, completed.Status)
+	assert.NotNil(t, completed.CompletedAt)
+
+	// The cleared transaction of the statement is locked, the others keep their status
+	locked, err := transactionRepository.FindById(ctx, "txn_rec_cleared", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusReconciled, locked.Status)
+	later, err := transactionRepository.FindById(ctx, "txn_rec_later", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusCleared, later.Status)
+
+	assert.ErrorIs(t, transactionRepository.Delete(ctx, "txn_rec_cleared", user.Id), transactionRepo.ErrReconciled)
+	assert.ErrorIs(t, transactionRepository.UpdateStatus(ctx, "txn_rec_cleared", user.Id, transaction.StatusPending), sql.ErrNoRows)
+
+	assert.NoError(t, transactionRepository.Unlock(ctx, "txn_rec_cleared", user.Id))
+	assert.ErrorIs(t, transactionRepository.Unlock(ctx, "txn_rec_cleared", user.Id), sql.ErrNoRows)
+	unlocked, err := transactionRepository.FindById(ctx, "txn_rec_cleared", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusCleared, unlocked.Status)
+	assert.NoError(t, transactionRepository.Delete(ctx, "txn_rec_cleared", user.Id))
+
+	reconciliations, err := reconciliationRepo.FindByAccount(ctx, account.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, reconciliations, 1)
+}
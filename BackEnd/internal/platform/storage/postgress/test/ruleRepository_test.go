@@ -0,0 +1,59 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	ruleRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/rule"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	ruleRepo := ruleRepo.NewRuleRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.Save(ctx, user))
+
+	min := money.FromUnits(5)
+	rides := rule.NewRule("rule_rides", user.Id, "Rides", 20)
+	rides.NameContains = "uber"
+	rides.AmountMin = &min
+	rides.CategoryId = "cat_transport"
+	assert.NoError(t, ruleRepo.Save(ctx, rides))
+
+	streaming := rule.NewRule("rule_streaming", user.Id, "Streaming", 10)
+	streaming.NameContains = "netflix"
+	streaming.RenameTo = "Netflix"
+	assert.NoError(t, ruleRepo.Save(ctx, streaming))
+
+	// Rules come back in priority order
+	rules, err := ruleRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "rule_streaming", rules[0].Id)
+	assert.Nil(t, rules[0].AmountMin)
+	assert.Equal(t, money.FromUnits(5), *rules[1].AmountMin)
+	assert.Equal(t, "", rules[1].RenameTo)
+
+	_, err = ruleRepo.FindById(ctx, rides.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	rides.Enabled = false
+	rides.AmountMin = nil
+	assert.NoError(t, ruleRepo.Update(ctx, rides))
+	found, err := ruleRepo.FindById(ctx, rides.Id, user.Id)
+	assert.NoError(t, err)
+	assert.False(t, found.Enabled)
+	assert.Nil(t, found.AmountMin)
+
+	assert.NoError(t, ruleRepo.Delete(ctx, rides.Id, user.Id))
+	assert.ErrorIs(t, ruleRepo.Delete(ctx, rides.Id, user.Id), sql.ErrNoRows)
+}
@@ -0,0 +1,69 @@
+package postgress
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	statementRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/statement"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	statementRepo := statementRepo.NewStatementRepository(db)
+	transactionRepo := transactionRepo.NewTransactionRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.NewUserRepository(db).Save(ctx, user))
+	statementDay, dueDay := 25, 10
+	card := account.NewAccount(0, "acc_statement_card", "Visa", "Bank")
+	card.UserId = user.Id
+	card.Type = account.TypeCreditCard
+	card.Details = account.Details{StatementDay: &statementDay, DueDay: &dueDay}
+	assert.NoError(t, accountRepo.NewAccountRepository(db).Save(ctx, card))
+
+	// The cycle runs from February 26 to March 25 and is due on April 10
+	period := card.StatementPeriodOn(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+	for i, movement := range []struct {
+		amount    money.Money
+		createdAt time.Time
+	}{
+		{money.FromUnits(-80), time.Date(2024, time.February, 25, 22, 0, 0, 0, time.UTC)},
+		{money.FromUnits(-120), time.Date(2024, time.February, 26, 9, 0, 0, 0, time.UTC)},
+		{money.FromUnits(80), time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC)},
+		{money.FromUnits(-30), time.Date(2024, time.March, 25, 23, 0, 0, 0, time.UTC)},
+		{money.FromUnits(100), time.Date(2024, time.April, 10, 12, 0, 0, 0, time.UTC)},
+		{money.FromUnits(50), time.Date(2024, time.April, 11, 12, 0, 0, 0, time.UTC)},
+	} {
+		moved := transaction.NewTransaction(fmt.Sprintf("txn_statement_%d", i), "Movement", "", "bill", card.Id, "", movement.amount)
+		moved.UserId = user.Id
+		moved.CreatedAt = movement.createdAt
+		assert.NoError(t, transactionRepo.Save(ctx, moved))
+	}
+
+	totals, err := statementRepo.Totals(ctx, card.Id, period)
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatementTotals{
+		Before:  money.FromUnits(-80),
+		Charges: money.FromUnits(-150),
+		Credits: money.FromUnits(80),
+		Paid:    money.FromUnits(100),
+	}, *totals)
+
+	reminded, err := statementRepo.MarkReminded(ctx, card.Id, period.Close)
+	assert.NoError(t, err)
+	assert.True(t, reminded)
+	reminded, err = statementRepo.MarkReminded(ctx, card.Id, period.Close)
+	assert.NoError(t, err)
+	assert.False(t, reminded, "each statement is only reminded once")
+}
@@ -0,0 +1,54 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	tagRepo := tagRepo.NewTagRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.Save(ctx, user))
+
+	vacation := tag.NewTag("tag_vacation", user.Id, "Vacation  2026")
+	assert.NoError(t, tagRepo.Save(ctx, vacation))
+
+	// Existing names are skipped, new ones are created
+	assert.NoError(t, tagRepo.SaveIfMissing(ctx, []*tag.Tag{
+		tag.NewTag("tag_duplicate", user.Id, "vacation 2026"),
+		tag.NewTag("tag_reimbursable", user.Id, "reimbursable"),
+	}))
+
+	tags, err := tagRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "reimbursable", tags[0].Name)
+	assert.Equal(t, "tag_vacation", tags[1].Id)
+
+	found, err := tagRepo.FindByName(ctx, "vacation 2026", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, vacation.Id, found.Id)
+
+	_, err = tagRepo.FindById(ctx, vacation.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	vacation.Name = "summer 2026"
+	assert.NoError(t, tagRepo.Update(ctx, vacation))
+	found, err = tagRepo.FindById(ctx, vacation.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "summer 2026", found.Name)
+
+	assert.NoError(t, tagRepo.Delete(ctx, vacation.Id, user.Id))
+	assert.ErrorIs(t, tagRepo.Delete(ctx, vacation.Id, user.Id), sql.ErrNoRows)
+}
@@ -2,11 +2,20 @@ package postgress
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
 	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	analyticsRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/analytics"
+	attachmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/attachment"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
 	postgress "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
 	"github.com/stretchr/testify/assert"
@@ -63,3 +72,714 @@ func TestTransactionRepository(t *testing.T) {
 	err = categoryRepo.Delete(ctx, category.Id, user.Id)
 	assert.NoError(t, err)
 }
+
+func TestTransactionRepository_Transfer(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	from := utils.GetNewRandomAccount()
+	to := utils.GetNewRandomAccount()
+	from.UserId = user.Id
+	to.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, from))
+	assert.NoError(t, accountRepo.Save(ctx, to))
+
+	outgoing := transaction.NewTransaction("leg_out", "Savings", "", "transfer", from.Id, "", money.FromUnits(-150))
+	incoming := transaction.NewTransaction("leg_in", "Savings", "", "transfer", to.Id, "", money.FromUnits(150))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_1"
+		leg.CreatedAt = time.Now()
+	}
+	assert.NoError(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming))
+
+	legs, err := transactionRepo.FindByTransferId(ctx, "trf_1", user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, legs, 2)
+	assert.Equal(t, "leg_out", legs[0].Id)
+	assert.Equal(t, "", legs[0].CategoryId)
+
+	balance, err := accountRepo.Balance(ctx, from.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(-150), balance)
+
+	// The loaded legs carry everything an update needs
+	assert.Equal(t, user.Id, legs[0].UserId)
+	legs[0].Amount = money.FromUnits(-200)
+	legs[1].Amount = money.FromUnits(200)
+	assert.NoError(t, transactionRepo.UpdateTransfer(ctx, legs[0], legs[1]))
+	leg, err := transactionRepo.FindById(ctx, "leg_in", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(200), leg.Amount)
+
+	// Deleting a single leg removes the whole transfer
+	assert.NoError(t, transactionRepo.Delete(ctx, "leg_in", user.Id))
+	legs, err = transactionRepo.FindByTransferId(ctx, "trf_1", user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, legs)
+}
+
+func TestTransactionRepository_TransferCurrency(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	from := utils.GetNewRandomAccount()
+	to := utils.GetNewRandomAccount()
+	from.UserId = user.Id
+	to.UserId = user.Id
+	from.Currency = "EUR"
+	to.Currency = "USD"
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, from))
+	assert.NoError(t, accountRepo.Save(ctx, to))
+
+	// Legs take the currency of their account
+	single := transaction.NewTransaction("txn_eur", "Groceries", "", "bill", from.Id, "", money.FromUnits(-20))
+	single.UserId = user.Id
+	single.CreatedAt = time.Now()
+	assert.NoError(t, transactionRepo.Save(ctx, single))
+	saved, err := transactionRepo.FindById(ctx, "txn_eur", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", saved.Currency)
+
+	outgoing := transaction.NewTransaction("leg_out", "Savings", "", "transfer", from.Id, "", money.FromUnits(-150))
+	incoming := transaction.NewTransaction("leg_in", "Savings", "", "transfer", to.Id, "", money.FromUnits(150))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_fx"
+		leg.CreatedAt = time.Now()
+	}
+	assert.ErrorIs(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming), postgress.ErrCurrencyMismatch)
+
+	legs, err := transactionRepo.FindByTransferId(ctx, "trf_fx", user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, legs)
+}
+
+func TestTransactionRepository_Splits(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	parent := transaction.NewTransaction("txn_split", "Supermarket", "", "bill", account.Id, "", money.FromUnits(-100))
+	parent.UserId = user.Id
+	parent.CreatedAt = time.Now()
+	parent.Splits = []*transaction.Split{
+		transaction.NewSplit("split_1", parent.Id, "cat_food", money.FromUnits(-70)),
+		transaction.NewSplit("split_2", parent.Id, "cat_pharmacy", money.FromUnits(-30)),
+	}
+	assert.NoError(t, transactionRepo.Save(ctx, parent))
+
+	found, err := transactionRepo.FindById(ctx, parent.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, found.Splits, 2)
+	assert.Equal(t, "cat_food", found.Splits[0].CategoryId)
+	assert.Equal(t, money.FromUnits(-30), found.Splits[1].Amount)
+
+	// Filtering by a split category returns the parent transaction
+	filter := dto.NewTransactionFilter()
+	filter.CategoryId = "cat_pharmacy"
+	filter.CalculatedDateFrom = time.Now().AddDate(0, 0, -1)
+	filter.CalculatedDateTo = time.Now().AddDate(0, 0, 1)
+	filtered, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Len(t, filtered[0].Splits, 2)
+
+	// Updating replaces the split lines
+	parent.Splits = []*transaction.Split{
+		transaction.NewSplit("split_3", parent.Id, "cat_food", money.FromUnits(-50)),
+		transaction.NewSplit("split_4", parent.Id, "cat_home", money.FromUnits(-50)),
+	}
+	assert.NoError(t, transactionRepo.Update(ctx, parent.Id, parent))
+	found, err = transactionRepo.FindById(ctx, parent.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, found.Splits, 2)
+	assert.Equal(t, "cat_home", found.Splits[1].CategoryId)
+
+	// The split lines stay with a trashed transaction and go away when it is purged
+	assert.NoError(t, transactionRepo.Delete(ctx, parent.Id, user.Id))
+	var remaining int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transaction_splits WHERE transaction_id = $1", parent.Id).Scan(&remaining))
+	assert.Equal(t, 2, remaining)
+
+	_, err = transactionRepo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transaction_splits WHERE transaction_id = $1", parent.Id).Scan(&remaining))
+	assert.Equal(t, 0, remaining)
+}
+
+func TestTransactionRepository_Tags(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+	tagRepo := tagRepo.NewTagRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+	assert.NoError(t, tagRepo.SaveIfMissing(ctx, []*tag.Tag{
+		tag.NewTag("tag_vacation", user.Id, "vacation 2026"),
+		tag.NewTag("tag_reimbursable", user.Id, "reimbursable"),
+	}))
+
+	save := func(id string, amount money.Money, tags ...string) {
+		txn := transaction.NewTransaction(id, id, "", "bill", account.Id, "cat_travel", amount)
+		txn.UserId = user.Id
+		txn.CreatedAt = time.Now()
+		txn.Tags = tags
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+	save("txn_hotel", money.FromUnits(-300), "vacation 2026", "reimbursable")
+	save("txn_museum", money.FromUnits(-20), "vacation 2026")
+	save("txn_taxi", money.FromUnits(-15), "reimbursable")
+	save("txn_groceries", money.FromUnits(-60))
+
+	found, err := transactionRepo.FindById(ctx, "txn_hotel", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reimbursable", "vacation 2026"}, found.Tags)
+
+	filter := dto.NewTransactionFilter()
+	filter.CalculatedDateFrom = time.Now().AddDate(0, 0, -1)
+	filter.CalculatedDateTo = time.Now().AddDate(0, 0, 1)
+	filter.Tags = []string{"vacation 2026", "reimbursable"}
+
+	anyTag, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Len(t, anyTag, 3)
+
+	filter.TagsMode = dto.TagsModeAll
+	allTags, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Len(t, allTags, 1)
+	assert.Equal(t, "txn_hotel", allTags[0].Id)
+	count, err := transactionRepo.CountWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	tagExpenses, err := analyticsRepo.NewAnalyticsRepository(db).GetTagExpenses(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, tagExpenses, 2)
+	assert.Equal(t, "reimbursable", tagExpenses[0].TagName)
+	assert.Equal(t, money.FromUnits(-315), tagExpenses[0].TotalAmount)
+	assert.Equal(t, 2, tagExpenses[1].TransactionCount)
+
+	// Updating replaces the tags
+	found.Tags = []string{"vacation 2026"}
+	found.UserId = user.Id
+	assert.NoError(t, transactionRepo.Update(ctx, found.Id, found))
+	found, err = transactionRepo.FindById(ctx, "txn_hotel", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vacation 2026"}, found.Tags)
+
+	// Deleting a transaction or a tag removes the links
+	assert.NoError(t, transactionRepo.Delete(ctx, "txn_museum", user.Id))
+	assert.NoError(t, tagRepo.Delete(ctx, "tag_vacation", user.Id))
+	var remaining int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transaction_tags").Scan(&remaining))
+	assert.Equal(t, 1, remaining)
+}
+
+func TestTransactionRepository_SaveIfNew(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	first := transaction.NewTransaction("txn_import_1", "Coffee", "Coffee", "bill", account.Id, "", money.Money(-350))
+	first.UserId = user.Id
+	first.ExternalId = "FIT-001"
+	first.CreatedAt = time.Now()
+	created, err := transactionRepo.SaveIfNew(ctx, first)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// The same FITID in the same account is skipped
+	again := transaction.NewTransaction("txn_import_2", "Coffee", "Coffee", "bill", account.Id, "", money.Money(-350))
+	again.UserId = user.Id
+	again.ExternalId = "FIT-001"
+	again.CreatedAt = time.Now()
+	created, err = transactionRepo.SaveIfNew(ctx, again)
+	assert.NoError(t, err)
+	assert.False(t, created)
+
+	found, err := transactionRepo.FindById(ctx, first.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "FIT-001", found.ExternalId)
+}
+
+func TestTransactionRepository_StreamWithFilters(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	now := time.Now()
+	for i, amount := range []money.Money{money.FromUnits(-10), money.FromUnits(250), money.FromUnits(-30)} {
+		txn := transaction.NewTransaction(fmt.Sprintf("txn_stream_%d", i), fmt.Sprintf("Transaction %d", i), "", "bill", account.Id, "cat_1", amount)
+		txn.UserId = user.Id
+		txn.CreatedAt = now.Add(time.Duration(-i) * time.Hour)
+		if i == 2 {
+			txn.CategoryId = ""
+			txn.Splits = []*transaction.Split{
+				transaction.NewSplit("split_stream_1", txn.Id, "cat_1", money.FromUnits(-20)),
+				transaction.NewSplit("split_stream_2", txn.Id, "cat_2", money.FromUnits(-10)),
+			}
+		}
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+
+	// Pagination is ignored and split lines are grouped under their parent
+	filter := dto.NewTransactionFilter()
+	filter.Limit = 1
+	filter.CalculatedDateFrom = now.AddDate(0, 0, -1)
+	filter.CalculatedDateTo = now.AddDate(0, 0, 1)
+	var streamed []*transaction.Transaction
+	err := transactionRepo.StreamWithFilters(ctx, user.Id, filter, func(txn *transaction.Transaction) error {
+		streamed = append(streamed, txn)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, streamed, 3)
+	assert.Equal(t, "txn_stream_0", streamed[0].Id)
+	assert.Equal(t, "txn_stream_2", streamed[2].Id)
+	assert.Empty(t, streamed[0].Splits)
+	assert.Len(t, streamed[2].Splits, 2)
+	assert.Equal(t, "cat_2", streamed[2].Splits[1].CategoryId)
+
+	// Errors from the callback stop the stream
+	calls := 0
+	err = transactionRepo.StreamWithFilters(ctx, user.Id, filter, func(txn *transaction.Transaction) error {
+		calls++
+		return fmt.Errorf("client went away")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransactionRepository_Duplicates(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+	tagRepo := tagRepo.NewTagRepository(db)
+	attachmentRepo := attachmentRepo.NewAttachmentRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+	assert.NoError(t, tagRepo.SaveIfMissing(ctx, []*tag.Tag{
+		tag.NewTag("tag_streaming", user.Id, "streaming"),
+		tag.NewTag("tag_shared", user.Id, "shared"),
+	}))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	save := func(id string, createdAt time.Time, tags ...string) {
+		txn := transaction.NewTransaction(id, "Netflix", "", "bill", account.Id, "", money.FromUnits(-15))
+		txn.UserId = user.Id
+		txn.CreatedAt = createdAt
+		txn.Tags = tags
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+	save("txn_dup_manual", now, "streaming")
+	save("txn_dup_imported", now.Add(-24*time.Hour), "streaming", "shared")
+	save("txn_dup_old", now.AddDate(0, -2, 0))
+	assert.NoError(t, attachmentRepo.Save(ctx, attachment.NewAttachment("att_dup", user.Id, "txn_dup_imported", "receipt.pdf", "application/pdf", 10)))
+
+	candidates, err := transactionRepo.FindDuplicateCandidates(ctx, user.Id, now.AddDate(0, 0, -7), now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_dup_manual", "txn_dup_imported"}, transactionIds(candidates))
+
+	pair := transaction.NewDuplicatePair("txn_dup_old", "txn_dup_manual")
+	assert.NoError(t, transactionRepo.DismissDuplicate(ctx, user.Id, pair))
+	assert.NoError(t, transactionRepo.DismissDuplicate(ctx, user.Id, pair))
+	dismissed, err := transactionRepo.FindDismissedDuplicates(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []transaction.DuplicatePair{pair}, dismissed)
+
+	// Merging keeps one transaction with the tags and attachments of both
+	assert.NoError(t, transactionRepo.MergeDuplicate(ctx, "txn_dup_manual", "txn_dup_imported", user.Id))
+	_, err = transactionRepo.FindById(ctx, "txn_dup_imported", user.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	kept, err := transactionRepo.FindById(ctx, "txn_dup_manual", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shared", "streaming"}, kept.Tags)
+	attachments, err := attachmentRepo.FindByTransaction(ctx, "txn_dup_manual", user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+
+	// The duplicate goes to the trash and can be restored with its own tags
+	trashed, err := transactionRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_dup_imported"}, transactionIds(trashed))
+	assert.ErrorIs(t, transactionRepo.MergeDuplicate(ctx, "txn_dup_manual", "txn_dup_imported", user.Id), sql.ErrNoRows)
+	assert.NoError(t, transactionRepo.Restore(ctx, "txn_dup_imported", user.Id))
+	restored, err := transactionRepo.FindById(ctx, "txn_dup_imported", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shared", "streaming"}, restored.Tags)
+}
+
+func TestTransactionRepository_CursorPagination(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	// txn_cursor_1 and txn_cursor_2 share a timestamp, the id breaks the tie
+	now := time.Now().Truncate(time.Second)
+	for i, offset := range []int{0, 1, 1, 2, 3} {
+		txn := transaction.NewTransaction(fmt.Sprintf("txn_cursor_%d", i), fmt.Sprintf("Transaction %d", i), "", "bill", account.Id, "", money.FromUnits(-10))
+		txn.UserId = user.Id
+		txn.CreatedAt = now.Add(time.Duration(-offset) * time.Hour)
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+
+	filter := dto.NewTransactionFilter()
+	filter.Limit = 2
+	filter.CalculatedDateFrom = now.AddDate(0, 0, -1)
+	filter.CalculatedDateTo = now.AddDate(0, 0, 1)
+	firstPage, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_cursor_0", "txn_cursor_2"}, transactionIds(firstPage))
+
+	// A row added on top of the listing doesn't shift the next page
+	newer := transaction.NewTransaction("txn_cursor_new", "Newer", "", "bill", account.Id, "", money.FromUnits(-10))
+	newer.UserId = user.Id
+	newer.CreatedAt = now.Add(time.Minute)
+	assert.NoError(t, transactionRepo.Save(ctx, newer))
+
+	last := firstPage[len(firstPage)-1]
+	filter.DecodedCursor = dto.NewCursor(last.CreatedAt, last.Id, dto.CursorNext)
+	secondPage, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_cursor_1", "txn_cursor_3"}, transactionIds(secondPage))
+
+	// Going back returns the rows before the cursor in sort order
+	filter.DecodedCursor = dto.NewCursor(secondPage[0].CreatedAt, secondPage[0].Id, dto.CursorPrev)
+	previousPage, err := transactionRepo.FindAllOfAllAccountsWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_cursor_0", "txn_cursor_2"}, transactionIds(previousPage))
+
+	// The count still covers the whole filtered set
+	count, err := transactionRepo.CountWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), count)
+}
+
+func transactionIds(transactions []*transaction.Transaction) []string {
+	ids := make([]string, 0, len(transactions))
+	for _, txn := range transactions {
+		ids = append(ids, txn.Id)
+	}
+	return ids
+}
+
+func TestTransactionRepository_ApplyRuleChanges(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	categoryRepo := categoryRepo.NewCategoryRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+	tagRepo := tagRepo.NewTagRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	transport := utils.GetNewRandomCategory()
+	transport.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+	assert.NoError(t, categoryRepo.Save(ctx, transport))
+	assert.NoError(t, tagRepo.SaveIfMissing(ctx, []*tag.Tag{tag.NewTag("tag_rules_work", user.Id, "work")}))
+
+	ride := transaction.NewTransaction("txn_rules_ride", "UBER *TRIP", "", "bill", account.Id, "", money.FromUnits(-12))
+	ride.UserId = user.Id
+	ride.CreatedAt = time.Now()
+	ride.Tags = []string{"work"}
+	assert.NoError(t, transactionRepo.Save(ctx, ride))
+
+	ride.Name = "Uber"
+	ride.CategoryId = transport.Id
+	assert.NoError(t, transactionRepo.ApplyRuleChanges(ctx, user.Id, []*transaction.Transaction{ride}))
+	found, err := transactionRepo.FindById(ctx, ride.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Uber", found.Name)
+	assert.Equal(t, transport.Id, found.CategoryId)
+	assert.Equal(t, []string{"work"}, found.Tags, "only the fields rules set are written")
+
+	// Transactions of other users are left alone
+	ride.Name = "Someone else"
+	assert.NoError(t, transactionRepo.ApplyRuleChanges(ctx, "someone_else", []*transaction.Transaction{ride}))
+	found, err = transactionRepo.FindById(ctx, ride.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Uber", found.Name)
+
+	// Reconciled transactions are locked
+	assert.NoError(t, transactionRepo.UpdateStatus(ctx, ride.Id, user.Id, transaction.StatusReconciled))
+	ride.Name = "Locked"
+	assert.NoError(t, transactionRepo.ApplyRuleChanges(ctx, user.Id, []*transaction.Transaction{ride}))
+	found, err = transactionRepo.FindById(ctx, ride.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Uber", found.Name)
+}
+
+func TestTransactionRepository_Bulk(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	categoryRepo := categoryRepo.NewCategoryRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+	tagRepo := tagRepo.NewTagRepository(db)
+
+	user := utils.GetNewRandomUser()
+	checking := utils.GetNewRandomAccount()
+	savings := utils.GetNewRandomAccount()
+	checking.UserId = user.Id
+	savings.UserId = user.Id
+	groceries := utils.GetNewRandomCategory()
+	groceries.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, checking))
+	assert.NoError(t, accountRepo.Save(ctx, savings))
+	assert.NoError(t, categoryRepo.Save(ctx, groceries))
+	assert.NoError(t, tagRepo.SaveIfMissing(ctx, []*tag.Tag{tag.NewTag("tag_bulk_shared", user.Id, "shared")}))
+
+	save := func(id string, tags ...string) *transaction.Transaction {
+		txn := transaction.NewTransaction(id, id, "", "bill", checking.Id, "", money.FromUnits(-10))
+		txn.UserId = user.Id
+		txn.CreatedAt = time.Now()
+		txn.Tags = tags
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+		return txn
+	}
+	save("txn_bulk_milk")
+	save("txn_bulk_bread", "shared")
+	save("txn_bulk_locked")
+	assert.NoError(t, transactionRepo.UpdateStatus(ctx, "txn_bulk_locked", user.Id, transaction.StatusReconciled))
+	split := transaction.NewTransaction("txn_bulk_split", "Market", "", "bill", checking.Id, "", money.FromUnits(-20))
+	split.UserId = user.Id
+	split.CreatedAt = time.Now()
+	split.Splits = []*transaction.Split{
+		transaction.NewSplit("split_bulk_1", split.Id, groceries.Id, money.FromUnits(-15)),
+		transaction.NewSplit("split_bulk_2", split.Id, "", money.FromUnits(-5)),
+	}
+	assert.NoError(t, transactionRepo.Save(ctx, split))
+	outgoing := transaction.NewTransaction("txn_bulk_out", "Savings", "", "transfer", checking.Id, "", money.FromUnits(-100))
+	incoming := transaction.NewTransaction("txn_bulk_in", "Savings", "", "transfer", savings.Id, "", money.FromUnits(100))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_bulk"
+		leg.CreatedAt = time.Now()
+	}
+	assert.NoError(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming))
+
+	found, err := transactionRepo.FindByIds(ctx, []string{"txn_bulk_milk", "txn_bulk_missing", "txn_bulk_split"}, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	// Only the transactions the action applies to are changed
+	setCategory := &dto.BulkRequest{Action: dto.BulkActionSetCategory, CategoryId: groceries.Id}
+	all := []string{"txn_bulk_milk", "txn_bulk_bread", "txn_bulk_locked", "txn_bulk_split", "txn_bulk_out"}
+	changed, err := transactionRepo.ApplyBulk(ctx, user.Id, setCategory, all)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"txn_bulk_milk", "txn_bulk_bread"}, changed)
+	milk, err := transactionRepo.FindById(ctx, "txn_bulk_milk", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, groceries.Id, milk.CategoryId)
+
+	_, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionSetCategory, CategoryId: "cat_bulk_missing"}, all)
+	assert.ErrorIs(t, err, postgress.ErrCategoryNotFound)
+	_, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionSetAccount, AccountId: "acc_bulk_missing"}, all)
+	assert.ErrorIs(t, err, postgress.ErrAccountNotFound)
+
+	changed, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionSetAccount, AccountId: savings.Id}, all)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"txn_bulk_milk", "txn_bulk_bread", "txn_bulk_split"}, changed)
+	balance, err := accountRepo.Balance(ctx, checking.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(-110), balance)
+
+	// Tag actions are idempotent
+	changed, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionAddTag, Tag: "shared"}, all)
+	assert.NoError(t, err)
+	assert.Len(t, changed, 4)
+	bread, err := transactionRepo.FindById(ctx, "txn_bulk_bread", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shared"}, bread.Tags)
+	changed, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionRemoveTag, Tag: "shared"}, []string{"txn_bulk_milk"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txn_bulk_milk"}, changed)
+	milk, err = transactionRepo.FindById(ctx, "txn_bulk_milk", user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, milk.Tags)
+
+	// Deleting a transfer leg trashes the other leg too, while reconciled transactions stay
+	changed, err = transactionRepo.ApplyBulk(ctx, user.Id, &dto.BulkRequest{Action: dto.BulkActionDelete}, []string{"txn_bulk_milk", "txn_bulk_locked", "txn_bulk_out"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"txn_bulk_milk", "txn_bulk_out", "txn_bulk_in"}, changed)
+	trashed, err := transactionRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, trashed, 3)
+	_, err = transactionRepo.FindById(ctx, "txn_bulk_locked", user.Id)
+	assert.NoError(t, err)
+}
+
+func TestTransactionRepository_Refunds(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+	categoryRepo := categoryRepo.NewCategoryRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	category := utils.GetNewRandomCategory()
+	category.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+	assert.NoError(t, categoryRepo.Save(ctx, category))
+
+	bill := transaction.NewTransaction("txn_shoes", "Shoes", "", "bill", account.Id, category.Id, money.FromUnits(-80))
+	bill.UserId = user.Id
+	bill.CreatedAt = time.Now().Add(-time.Hour)
+	assert.NoError(t, transactionRepo.Save(ctx, bill))
+	refund := transaction.NewTransaction("txn_shoes_refund", "Shoes refund", "", "income", account.Id, "", money.FromUnits(30))
+	refund.UserId = user.Id
+	refund.CreatedAt = time.Now()
+	assert.NoError(t, transactionRepo.Save(ctx, refund))
+
+	assert.NoError(t, transactionRepo.LinkRefund(ctx, refund.Id, user.Id, bill))
+	assert.ErrorIs(t, transactionRepo.LinkRefund(ctx, refund.Id, "another_user", bill), sql.ErrNoRows)
+
+	found, err := transactionRepo.FindById(ctx, refund.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, bill.Id, found.RefundOf)
+	assert.Equal(t, category.Id, found.CategoryId)
+	found, err = transactionRepo.FindById(ctx, bill.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{refund.Id}, found.RefundedBy)
+
+	// The refund nets against the spending of the category of the bill
+	categoryExpenses, err := analyticsRepo.NewAnalyticsRepository(db).GetCategoryExpenses(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, categoryExpenses, 1)
+	assert.Equal(t, money.FromUnits(-50), categoryExpenses[0].TotalAmount)
+
+	assert.NoError(t, transactionRepo.UnlinkRefund(ctx, refund.Id, user.Id))
+	assert.ErrorIs(t, transactionRepo.UnlinkRefund(ctx, refund.Id, user.Id), sql.ErrNoRows)
+	found, err = transactionRepo.FindById(ctx, bill.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, found.RefundedBy)
+}
+
+func TestTransactionRepository_SummarizeWithFilters(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	account := utils.GetNewRandomAccount()
+	account.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, account))
+
+	now := time.Now()
+	for i, amount := range []int64{-20, -50, 300} {
+		typeTransation, categoryId := "bill", "cat_summary_food"
+		if amount > 0 {
+			typeTransation, categoryId = "income", ""
+		}
+		txn := transaction.NewTransaction(fmt.Sprintf("txn_summary_%d", i), "Summary", "", typeTransation, account.Id, "", money.FromUnits(amount))
+		txn.UserId = user.Id
+		txn.CategoryId = categoryId
+		txn.CreatedAt = now
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+	split := transaction.NewTransaction("txn_summary_split", "Supermarket", "", "bill", account.Id, "", money.FromUnits(-100))
+	split.UserId = user.Id
+	split.CreatedAt = now
+	split.Splits = []*transaction.Split{
+		transaction.NewSplit("split_summary_1", split.Id, "cat_summary_food", money.FromUnits(-70)),
+		transaction.NewSplit("split_summary_2", split.Id, "cat_summary_home", money.FromUnits(-30)),
+	}
+	assert.NoError(t, transactionRepo.Save(ctx, split))
+
+	// The limit of a page doesn't narrow the totals
+	filter := dto.NewTransactionFilter()
+	filter.Limit = 1
+	filter.CalculatedDateFrom = now.AddDate(0, 0, -1)
+	filter.CalculatedDateTo = now.AddDate(0, 0, 1)
+	totals, err := transactionRepo.SummarizeWithFilters(ctx, user.Id, filter)
+	assert.NoError(t, err)
+
+	types := make(map[string]dto.SummaryTotal)
+	for _, total := range totals.Types {
+		types[total.TypeTransation] = total
+	}
+	assert.Len(t, types, 2)
+	assert.Equal(t, money.FromUnits(-170), types["bill"].Total)
+	assert.Equal(t, 3, types["bill"].Count)
+	assert.Equal(t, money.FromUnits(-20), types["bill"].Largest)
+	assert.Equal(t, money.FromUnits(300), types["income"].Total)
+
+	// Split lines count towards their own categories
+	categories := make(map[string]dto.SummaryCategoryTotal)
+	for _, total := range totals.Categories {
+		categories[total.CategoryId] = total
+	}
+	assert.Len(t, categories, 2)
+	assert.Equal(t, money.FromUnits(-140), categories["cat_summary_food"].Total)
+	assert.Equal(t, 3, categories["cat_summary_food"].Count)
+	assert.Equal(t, money.FromUnits(-30), categories["cat_summary_home"].Total)
+}
@@ -0,0 +1,219 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	postgress "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrash_Transactions(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	from := utils.GetNewRandomAccount()
+	to := utils.GetNewRandomAccount()
+	from.UserId = user.Id
+	to.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, from))
+	assert.NoError(t, accountRepo.Save(ctx, to))
+
+	coffee := transaction.NewTransaction("txn_trash_coffee", "Coffee", "", "bill", from.Id, "", money.FromUnits(-5))
+	coffee.UserId = user.Id
+	coffee.CreatedAt = time.Now()
+	assert.NoError(t, transactionRepo.Save(ctx, coffee))
+	outgoing := transaction.NewTransaction("txn_trash_out", "Savings", "", "transfer", from.Id, "", money.FromUnits(-100))
+	incoming := transaction.NewTransaction("txn_trash_in", "Savings", "", "transfer", to.Id, "", money.FromUnits(100))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_trash"
+		leg.CreatedAt = time.Now()
+	}
+	assert.NoError(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming))
+
+	// A trashed transaction disappears from every read
+	assert.NoError(t, transactionRepo.Delete(ctx, coffee.Id, user.Id))
+	_, err := transactionRepo.FindById(ctx, coffee.Id, user.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	all, err := transactionRepo.FindAllOfAllAccounts(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	balance, err := accountRepo.Balance(ctx, from.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(-100), balance)
+	assert.ErrorIs(t, transactionRepo.Delete(ctx, coffee.Id, user.Id), sql.ErrNoRows)
+
+	// Trashing one leg of a transfer trashes the other one too
+	assert.NoError(t, transactionRepo.Delete(ctx, incoming.Id, user.Id))
+	trashed, err := transactionRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, trashed, 3)
+	for _, item := range trashed {
+		assert.NotNil(t, item.DeletedAt)
+	}
+
+	// Restoring one leg brings the whole transfer back
+	assert.NoError(t, transactionRepo.Restore(ctx, outgoing.Id, user.Id))
+	legs, err := transactionRepo.FindByTransferId(ctx, "trf_trash", user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, legs, 2)
+	assert.ErrorIs(t, transactionRepo.Restore(ctx, outgoing.Id, user.Id), sql.ErrNoRows)
+
+	// Only transactions trashed before the cutoff are purged
+	purged, err := transactionRepo.PurgeDeleted(ctx, time.Now().UTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	purged, err = transactionRepo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	trashed, err = transactionRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, trashed)
+}
+
+func TestTrash_Accounts(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	accountRepo := accountRepo.NewAccountRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	checking := utils.GetNewRandomAccount()
+	savings := utils.GetNewRandomAccount()
+	checking.UserId = user.Id
+	savings.UserId = user.Id
+	assert.NoError(t, userRepo.Save(ctx, user))
+	assert.NoError(t, accountRepo.Save(ctx, checking))
+	assert.NoError(t, accountRepo.Save(ctx, savings))
+
+	rent := transaction.NewTransaction("txn_trash_rent", "Rent", "", "bill", checking.Id, "", money.FromUnits(-800))
+	gym := transaction.NewTransaction("txn_trash_gym", "Gym", "", "bill", checking.Id, "", money.FromUnits(-30))
+	for _, txn := range []*transaction.Transaction{rent, gym} {
+		txn.UserId = user.Id
+		txn.CreatedAt = time.Now()
+		assert.NoError(t, transactionRepo.Save(ctx, txn))
+	}
+	outgoing := transaction.NewTransaction("txn_trash_acc_out", "Savings", "", "transfer", checking.Id, "", money.FromUnits(-200))
+	incoming := transaction.NewTransaction("txn_trash_acc_in", "Savings", "", "transfer", savings.Id, "", money.FromUnits(200))
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = user.Id
+		leg.TransferId = "trf_trash_acc"
+		leg.CreatedAt = time.Now()
+	}
+	assert.NoError(t, transactionRepo.SaveTransfer(ctx, outgoing, incoming))
+
+	// The gym transaction was trashed on its own before the account
+	assert.NoError(t, transactionRepo.Delete(ctx, gym.Id, user.Id))
+	time.Sleep(10 * time.Millisecond)
+
+	// Trashing the account takes its transactions and the other leg of its transfers with it
+	assert.NoError(t, accountRepo.Delete(ctx, checking.Id, user.Id))
+	accounts, err := accountRepo.FindAll(ctx, user.Id, false)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	_, err = accountRepo.FindByIdAndUserId(ctx, checking.Id, user.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	balances, err := accountRepo.Balances(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, balances)
+	assert.ErrorIs(t, accountRepo.Delete(ctx, checking.Id, user.Id), sql.ErrNoRows)
+
+	trashedAccounts, err := accountRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, trashedAccounts, 1) {
+		assert.Equal(t, checking.Id, trashedAccounts[0].Id)
+		assert.NotNil(t, trashedAccounts[0].DeletedAt)
+	}
+
+	// A transaction cannot come back while its account is in the trash
+	assert.ErrorIs(t, transactionRepo.Restore(ctx, incoming.Id, user.Id), postgress.ErrAccountTrashed)
+
+	// Restoring the account restores what was trashed with it, but not the gym transaction
+	assert.NoError(t, accountRepo.Restore(ctx, checking.Id, user.Id))
+	all, err := transactionRepo.FindAllOfAllAccounts(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+	trashed, err := transactionRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, trashed, 1) {
+		assert.Equal(t, gym.Id, trashed[0].Id)
+	}
+	assert.ErrorIs(t, accountRepo.Restore(ctx, checking.Id, user.Id), sql.ErrNoRows)
+
+	// An account is purged only once none of its transactions are left
+	assert.NoError(t, accountRepo.Delete(ctx, savings.Id, user.Id))
+	cutoff := time.Now().UTC().Add(time.Minute)
+	purged, err := accountRepo.PurgeDeleted(ctx, cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	_, err = transactionRepo.PurgeDeleted(ctx, cutoff)
+	assert.NoError(t, err)
+	purged, err = accountRepo.PurgeDeleted(ctx, cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestTrash_Categories(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	transactionRepo := postgress.NewTransactionRepository(db)
+	categoryRepo := categoryRepo.NewCategoryRepository(db)
+
+	user := utils.GetNewRandomUser()
+	used := utils.GetNewRandomCategory()
+	unused := utils.GetNewRandomCategory()
+	used.UserId = user.Id
+	unused.UserId = user.Id
+	assert.NoError(t, categoryRepo.Save(ctx, used))
+	assert.NoError(t, categoryRepo.Save(ctx, unused))
+
+	txn := utils.GetNewRandomTransaction()
+	txn.UserId = user.Id
+	txn.CategoryId = used.Id
+	assert.NoError(t, transactionRepo.Save(ctx, txn))
+
+	assert.NoError(t, categoryRepo.Delete(ctx, used.Id, user.Id))
+	assert.NoError(t, categoryRepo.Delete(ctx, unused.Id, user.Id))
+	categories, err := categoryRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, categories)
+	found, err := categoryRepo.FindOne(ctx, used.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, found.Id)
+
+	trashed, err := categoryRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, trashed, 2)
+
+	assert.NoError(t, categoryRepo.Restore(ctx, used.Id, user.Id))
+	assert.ErrorIs(t, categoryRepo.Restore(ctx, used.Id, user.Id), sql.ErrNoRows)
+	categories, err = categoryRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, categories, 1)
+
+	// A category still used by a transaction is kept by the purge
+	assert.NoError(t, categoryRepo.Delete(ctx, used.Id, user.Id))
+	purged, err := categoryRepo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	trashed, err = categoryRepo.FindTrash(ctx, user.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, trashed, 1) {
+		assert.Equal(t, used.Id, trashed[0].Id)
+	}
+}
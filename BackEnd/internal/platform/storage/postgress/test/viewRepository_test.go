@@ -0,0 +1,52 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	viewRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/view"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewRepository(t *testing.T) {
+	db := SetUpTest()
+	ctx := context.Background()
+	viewRepo := viewRepo.NewViewRepository(db)
+	userRepo := userRepo.NewUserRepository(db)
+
+	user := utils.GetNewRandomUser()
+	assert.NoError(t, userRepo.Save(ctx, user))
+
+	food := view.NewView("view_food", user.Id, "Food this month", "categories=cat_food&period=this_month")
+	assert.NoError(t, viewRepo.Save(ctx, food))
+	assert.NoError(t, viewRepo.Save(ctx, view.NewView("view_big", user.Id, "Big expenses", "amount_min=500&type=expense")))
+
+	views, err := viewRepo.FindAll(ctx, user.Id)
+	assert.NoError(t, err)
+	assert.Len(t, views, 2)
+	assert.Equal(t, "Big expenses", views[0].Name)
+	assert.Equal(t, "categories=cat_food&period=this_month", views[1].Query)
+
+	found, err := viewRepo.FindByName(ctx, "Food this month", user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, food.Id, found.Id)
+	_, err = viewRepo.FindById(ctx, food.Id, "another_user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	food.Name = "Food last month"
+	food.Query = "categories=cat_food&period=last_month"
+	assert.NoError(t, viewRepo.Update(ctx, food))
+	found, err = viewRepo.FindById(ctx, food.Id, user.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Food last month", found.Name)
+	assert.Equal(t, "categories=cat_food&period=last_month", found.Query)
+
+	assert.ErrorIs(t, viewRepo.Delete(ctx, food.Id, "another_user"), sql.ErrNoRows)
+	assert.NoError(t, viewRepo.Delete(ctx, food.Id, user.Id))
+	_, err = viewRepo.FindById(ctx, food.Id, user.Id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
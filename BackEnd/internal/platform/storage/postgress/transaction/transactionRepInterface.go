@@ -2,7 +2,9 @@ package postgress
 
 import (
 	"context"
+	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
 )
@@ -10,15 +12,53 @@ import (
 type TransactionRepositoryInterface interface {
 	Save(ctx context.Context, transaction *transaction.Transaction) error
 	FindAll(ctx context.Context, date1 string, date2 string, id string) ([]*transaction.Transaction, error)
-	FindCurrentBudget(ctx context.Context, budgetId string) (float64, error)
-	FindCurrentBudgets(ctx context.Context, userId string) (map[string]float64, error)
+	FindCurrentBudget(ctx context.Context, budgetId string) (money.Money, error)
+	FindCurrentBudgets(ctx context.Context, userId string) (map[string]money.Money, error)
 	FindAllOfAllAccounts(ctx context.Context, id string) ([]*transaction.Transaction, error)
 	Delete(ctx context.Context, id string, userId string) error
 	// Update updates a transaction
 	Update(ctx context.Context, id string, transaction *transaction.Transaction) error
+	FindById(ctx context.Context, id string, userId string) (*transaction.Transaction, error)
+	// SaveIfNew skips transactions whose external id already exists in the account
+	SaveIfNew(ctx context.Context, transaction *transaction.Transaction) (bool, error)
+	// UpdateStatus moves a transaction between pending and cleared; reconciled transactions are left untouched
+	UpdateStatus(ctx context.Context, id string, userId string, status string) error
+	// Unlock takes a reconciled transaction out of its reconciliation and back to cleared
+	Unlock(ctx context.Context, id string, userId string) error
+	// LinkRefund makes an income the refund of a bill, taking its category and budget; UnlinkRefund undoes the link
+	LinkRefund(ctx context.Context, id string, userId string, original *transaction.Transaction) error
+	UnlinkRefund(ctx context.Context, id string, userId string) error
+
+	// Duplicate detection works on candidates within a date range; dismissed pairs are never reported again
+	FindDuplicateCandidates(ctx context.Context, userId string, from time.Time, to time.Time) ([]*transaction.Transaction, error)
+	FindDismissedDuplicates(ctx context.Context, userId string) ([]transaction.DuplicatePair, error)
+	DismissDuplicate(ctx context.Context, userId string, pair transaction.DuplicatePair) error
+	// MergeDuplicate keeps one transaction, moves the tags and attachments of the duplicate onto it and removes the duplicate
+	MergeDuplicate(ctx context.Context, keepId string, duplicateId string, userId string) error
+
+	// Bulk operations resolve their selection with FindByIds and apply the change in one database transaction
+	FindByIds(ctx context.Context, ids []string, userId string) ([]*transaction.Transaction, error)
+	ApplyBulk(ctx context.Context, userId string, request *dto.BulkRequest, ids []string) ([]string, error)
+	// ApplyRuleChanges stores what a retroactive run of the categorization rules changed, all or nothing
+	ApplyRuleChanges(ctx context.Context, userId string, transactions []*transaction.Transaction) error
+
+	// Transfer legs are always written and removed together
+	SaveTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error
+	UpdateTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error
+	FindByTransferId(ctx context.Context, transferId string, userId string) ([]*transaction.Transaction, error)
+	DeleteTransfer(ctx context.Context, transferId string, userId string) error
+
+	// Deleted transactions stay in the trash until they are restored or purged after the retention period
+	FindTrash(ctx context.Context, userId string) ([]*transaction.Transaction, error)
+	Restore(ctx context.Context, id string, userId string) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 
 	// New methods for filtering and pagination
 	FindAllOfAllAccountsWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter) ([]*transaction.Transaction, error)
 	FindAllWithFilters(ctx context.Context, filter *dto.TransactionFilter) ([]*transaction.Transaction, error)
 	CountWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter) (int64, error)
+	// SummarizeWithFilters aggregates every matching transaction in the database, for listing summaries
+	SummarizeWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter) (*dto.SummaryTotals, error)
+	// StreamWithFilters walks every matching transaction without pagination, for exports
+	StreamWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter, fn func(*transaction.Transaction) error) error
 }
@@ -3,14 +3,22 @@ package postgress
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
 	"github.com/rs/zerolog/log"
 )
 
+// transactionColumns is the column list expected by the transaction row scanners.
+const transactionColumns = "id, transaction_name, transaction_description, amount, type_transation, account_id, category_id, budget_id, transfer_id, external_id, created_at, currency, status, payee_id, refund_of, user_id"
+
 type TransactionRepository struct {
 	db *sql.DB
 }
@@ -21,15 +29,272 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	}
 }
 
+// insertTransactionQuery takes the currency from the account, so a transaction is always in the currency of its account.
+// An empty status ($13) stores the transaction as pending and $14 is the payee, if any.
+const insertTransactionQuery = "INSERT INTO transactions (id,transaction_name,transaction_description,amount,type_transation,account_id,user_id,category_id,budget_id,transfer_id, created_at, external_id, currency, status, payee_id) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10, $11, $12, " + accountCurrency + ", COALESCE($13, '" + transaction.StatusPending + "'), $14)"
+
+// accountCurrency looks up the currency of the account bound to $6.
+const accountCurrency = "COALESCE((SELECT currency FROM account WHERE id = $6), '" + fx.DefaultCurrency + "')"
+
+// ErrCurrencyMismatch is returned when the legs of a transfer belong to accounts in different currencies.
+var ErrCurrencyMismatch = errors.New("transfers between accounts in different currencies are not supported")
+
+// ErrReconciled is returned when a delete would remove a reconciled transaction.
+var ErrReconciled = errors.New("transaction is reconciled")
+
+// ErrAccountTrashed is returned when a transaction is restored while its account is still in the trash.
+var ErrAccountTrashed = errors.New("account is in the trash")
+
+// ErrAccountArchived is returned when a transaction would be added to, or moved into, an archived account.
+var ErrAccountArchived = errors.New("account is archived")
+
+// ErrCategoryNotFound and ErrAccountNotFound are returned when a bulk change points at a category or account the
+// user does not have.
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrAccountNotFound  = errors.New("account not found")
+)
+
+const insertSplitQuery = "INSERT INTO transaction_splits (id, transaction_id, user_id, category_id, budget_id, amount, description) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+
+// insertTagQuery links a transaction to one of the user's tags by name; the tag must already exist.
+const insertTagQuery = "INSERT INTO transaction_tags (transaction_id, tag_id) SELECT $1, id FROM tags WHERE user_id = $2 AND name = $3"
+
+// deleteAttachmentsQueries queue the blobs of the attachments matched by a condition for removal and then drop the
+// attachment rows. The condition is appended to both statements.
+var deleteAttachmentsQueries = []string{
+	"INSERT INTO attachment_deletions (storage_key) SELECT storage_key FROM attachments WHERE ",
+	"DELETE FROM attachments WHERE ",
+}
+
+// splitBatchSize bounds the number of placeholders used when loading split lines and tags.
+const splitBatchSize = 500
+
 func (repo *TransactionRepository) Save(ctx context.Context, transaction *transaction.Transaction) error {
-	_, err := repo.db.ExecContext(ctx, "INSERT INTO transactions (id,transaction_name,transaction_description,amount,type_transation,account_id,user_id,category_id,budget_id, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9, $10)", transaction.Id, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, transaction.CategoryId, transaction.BudgetId, transaction.CreatedAt)
+	if !transaction.IsSplit() && len(transaction.Tags) == 0 {
+		if err := checkNotArchived(ctx, repo.db, transaction.AccountId); err != nil {
+			return err
+		}
+		_, err := repo.db.ExecContext(ctx, insertTransactionQuery, transaction.Id, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, nullIfEmpty(transaction.CategoryId), transaction.BudgetId, nullIfEmpty(transaction.TransferId), transaction.CreatedAt, nullIfEmpty(transaction.ExternalId), nullIfEmpty(transaction.Status), nullIfEmpty(transaction.PayeeId))
+		return err
+	}
 
-	return err
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = checkNotArchived(ctx, tx, transaction.AccountId); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, insertTransactionQuery, transaction.Id, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, nullIfEmpty(transaction.CategoryId), nullIfEmpty(transaction.BudgetId), nullIfEmpty(transaction.TransferId), transaction.CreatedAt, nullIfEmpty(transaction.ExternalId), nullIfEmpty(transaction.Status), nullIfEmpty(transaction.PayeeId))
+	if err != nil {
+		return err
+	}
+	if err = insertSplits(ctx, tx, transaction); err != nil {
+		return err
+	}
+	if err = insertTags(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveIfNew inserts an imported transaction unless its account already holds one with the same external id.
+// It reports whether the row was inserted.
+func (repo *TransactionRepository) SaveIfNew(ctx context.Context, transaction *transaction.Transaction) (bool, error) {
+	if err := checkNotArchived(ctx, repo.db, transaction.AccountId); err != nil {
+		return false, err
+	}
+	result, err := repo.db.ExecContext(ctx, insertTransactionQuery+" ON CONFLICT DO NOTHING", transaction.Id, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, nullIfEmpty(transaction.CategoryId), nullIfEmpty(transaction.BudgetId), nullIfEmpty(transaction.TransferId), transaction.CreatedAt, nullIfEmpty(transaction.ExternalId), nullIfEmpty(transaction.Status), nullIfEmpty(transaction.PayeeId))
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// insertSplits stores the split lines of a transaction inside an open database transaction.
+func insertSplits(ctx context.Context, tx *sql.Tx, transaction *transaction.Transaction) error {
+	for _, split := range transaction.Splits {
+		_, err := tx.ExecContext(ctx, insertSplitQuery, split.Id, transaction.Id, transaction.UserId, split.CategoryId, nullIfEmpty(split.BudgetId), split.Amount, split.Description)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertTags links a transaction to its tags inside an open database transaction.
+func insertTags(ctx context.Context, tx *sql.Tx, transaction *transaction.Transaction) error {
+	for _, name := range transaction.Tags {
+		if _, err := tx.ExecContext(ctx, insertTagQuery, transaction.Id, transaction.UserId, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveTransfer inserts both legs of a transfer in a single database transaction.
+func (repo *TransactionRepository) SaveTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = checkSameCurrency(ctx, tx, outgoing.AccountId, incoming.AccountId); err != nil {
+		return err
+	}
+	if err = checkNotArchived(ctx, tx, outgoing.AccountId, incoming.AccountId); err != nil {
+		return err
+	}
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		_, err = tx.ExecContext(ctx, insertTransactionQuery, leg.Id, leg.Name, leg.Description, leg.Amount, leg.TypeTransation, leg.AccountId, leg.UserId, nullIfEmpty(leg.CategoryId), nil, leg.TransferId, leg.CreatedAt, nil, nullIfEmpty(leg.Status), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateTransfer rewrites both legs of a transfer in a single database transaction.
+func (repo *TransactionRepository) UpdateTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = checkSameCurrency(ctx, tx, outgoing.AccountId, incoming.AccountId); err != nil {
+		return err
+	}
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		if err = checkMoveNotArchived(ctx, tx, leg.Id, leg.AccountId); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, "UPDATE transactions SET transaction_name = $1, transaction_description = $2, amount = $3, account_id = $4, created_at = $5, currency = COALESCE((SELECT currency FROM account WHERE id = $4), currency) WHERE id = $6 AND user_id = $7 AND transfer_id = $8",
+			leg.Name, leg.Description, leg.Amount, leg.AccountId, leg.CreatedAt, leg.Id, leg.UserId, leg.TransferId)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkSameCurrency returns ErrCurrencyMismatch unless both accounts share a currency.
+func checkSameCurrency(ctx context.Context, tx *sql.Tx, fromAccountId, toAccountId string) error {
+	var currencies int
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(DISTINCT currency) FROM account WHERE id IN ($1, $2)", fromAccountId, toAccountId).Scan(&currencies)
+	if err != nil {
+		return err
+	}
+	if currencies > 1 {
+		return ErrCurrencyMismatch
+	}
+	return nil
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// checkNotArchived returns ErrAccountArchived if any of the accounts is archived.
+func checkNotArchived(ctx context.Context, q rowQuerier, accountIds ...string) error {
+	for _, accountId := range accountIds {
+		var archived int
+		err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM account WHERE id = $1 AND archived_at IS NOT NULL", accountId).Scan(&archived)
+		if err != nil {
+			return err
+		}
+		if archived > 0 {
+			return ErrAccountArchived
+		}
+	}
+	return nil
+}
+
+// checkMoveNotArchived returns ErrAccountArchived when a transaction would move into an archived account. A
+// transaction already in an archived account can still be edited there.
+func checkMoveNotArchived(ctx context.Context, q rowQuerier, transactionId string, accountId string) error {
+	var archived int
+	err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM account WHERE id = $1 AND archived_at IS NOT NULL
+		AND id NOT IN (SELECT account_id FROM transactions WHERE id = $2)`, accountId, transactionId).Scan(&archived)
+	if err != nil {
+		return err
+	}
+	if archived > 0 {
+		return ErrAccountArchived
+	}
+	return nil
+}
+
+// FindById retrieves a single transaction owned by the given user.
+func (repo *TransactionRepository) FindById(ctx context.Context, id string, userId string) (*transaction.Transaction, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	transactions, err := repo.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions[0], nil
+}
+
+// FindByTransferId retrieves both legs of a transfer, outgoing leg first.
+func (repo *TransactionRepository) FindByTransferId(ctx context.Context, transferId string, userId string) ([]*transaction.Transaction, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE transfer_id = $1 AND user_id = $2 AND deleted_at IS NULL ORDER BY amount ASC", transferId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	return repo.scanTransactions(rows)
 }
 
 func (repo *TransactionRepository) FindAllOfAllAccounts(ctx context.Context, id string) ([]*transaction.Transaction, error) {
 	rows, err := repo.db.QueryContext(ctx,
-		"SELECT id,transaction_name,transaction_description,amount,type_transation,account_id,category_id,budget_id,created_at FROM transactions WHERE  user_id = $1 ORDER BY created_at DESC", id)
+		"SELECT "+transactionColumns+" FROM transactions WHERE  user_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC", id)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +308,14 @@ func (repo *TransactionRepository) FindAllOfAllAccounts(ctx context.Context, id
 	var transactions []*transaction.Transaction
 	for rows.Next() {
 		transaction := transaction.Transaction{}
-		var budgetID sql.NullString
-		if err = rows.Scan(&transaction.Id, &transaction.Name, &transaction.Description, &transaction.Amount, &transaction.TypeTransation, &transaction.AccountId, &transaction.CategoryId, &budgetID, &transaction.CreatedAt); err == nil {
-			if budgetID.Valid {
-				transaction.BudgetId = budgetID.String
-			}
+		var categoryID, budgetID, transferID, externalID, payeeID, refundOf sql.NullString
+		if err = rows.Scan(&transaction.Id, &transaction.Name, &transaction.Description, &transaction.Amount, &transaction.TypeTransation, &transaction.AccountId, &categoryID, &budgetID, &transferID, &externalID, &transaction.CreatedAt, &transaction.Currency, &transaction.Status, &payeeID, &refundOf, &transaction.UserId); err == nil {
+			transaction.CategoryId = categoryID.String
+			transaction.BudgetId = budgetID.String
+			transaction.TransferId = transferID.String
+			transaction.ExternalId = externalID.String
+			transaction.PayeeId = payeeID.String
+			transaction.RefundOf = refundOf.String
 			transactions = append(transactions, &transaction)
 		} else {
 			log.Error().Err(err).Msg("failed to scan row in FindAllOfAllAccounts")
@@ -58,12 +326,15 @@ func (repo *TransactionRepository) FindAllOfAllAccounts(ctx context.Context, id
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
 	return transactions, nil
 }
 
 func (repo *TransactionRepository) FindAll(ctx context.Context, date1 string, date2 string, id string) ([]*transaction.Transaction, error) {
 	rows, err := repo.db.QueryContext(ctx,
-		"SELECT id,transaction_name,transaction_description,amount,type_transation,account_id,category_id,budget_id,created_at FROM transactions WHERE  account_id = $1 and created_at BETWEEN $2 and $3 ORDER BY created_at DESC", id, date1, date2)
+		"SELECT "+transactionColumns+" FROM transactions WHERE  account_id = $1 and created_at BETWEEN $2 and $3 AND deleted_at IS NULL ORDER BY created_at DESC", id, date1, date2)
 	if err != nil {
 		return nil, err
 	}
@@ -77,11 +348,14 @@ func (repo *TransactionRepository) FindAll(ctx context.Context, date1 string, da
 	var transactions []*transaction.Transaction
 	for rows.Next() {
 		transaction := transaction.Transaction{}
-		var budgetID sql.NullString
-		if err = rows.Scan(&transaction.Id, &transaction.Name, &transaction.Description, &transaction.Amount, &transaction.TypeTransation, &transaction.AccountId, &transaction.CategoryId, &budgetID, &transaction.CreatedAt); err == nil {
-			if budgetID.Valid {
-				transaction.BudgetId = budgetID.String
-			}
+		var categoryID, budgetID, transferID, externalID, payeeID, refundOf sql.NullString
+		if err = rows.Scan(&transaction.Id, &transaction.Name, &transaction.Description, &transaction.Amount, &transaction.TypeTransation, &transaction.AccountId, &categoryID, &budgetID, &transferID, &externalID, &transaction.CreatedAt, &transaction.Currency, &transaction.Status, &payeeID, &refundOf, &transaction.UserId); err == nil {
+			transaction.CategoryId = categoryID.String
+			transaction.BudgetId = budgetID.String
+			transaction.TransferId = transferID.String
+			transaction.ExternalId = externalID.String
+			transaction.PayeeId = payeeID.String
+			transaction.RefundOf = refundOf.String
 			transactions = append(transactions, &transaction)
 		} else {
 			log.Error().Err(err).Msg("failed to scan row in FindAll")
@@ -92,13 +366,23 @@ func (repo *TransactionRepository) FindAll(ctx context.Context, date1 string, da
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
 
 	return transactions, nil
 }
 
-func (repo *TransactionRepository) FindCurrentBudget(ctx context.Context, budgetID string) (float64, error) {
+// FindCurrentBudget adds up the spending of a budget in the current month. Refunds linked to a bill carry its
+// budget and are positive, so they bring the spending back down.
+func (repo *TransactionRepository) FindCurrentBudget(ctx context.Context, budgetID string) (money.Money, error) {
 	rows, err := repo.db.QueryContext(ctx,
-		"SELECT   sum(amount)  as currentBudget FROM  transactions   WHERE  budget_id = $1 AND type_transation = 'bill' AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE) ", budgetID)
+		`SELECT COALESCE(sum(amount), 0) as currentBudget FROM (
+			SELECT amount FROM transactions WHERE budget_id = $1 AND (type_transation = 'bill' OR refund_of IS NOT NULL) AND deleted_at IS NULL AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
+			UNION ALL
+			SELECT s.amount FROM transaction_splits s JOIN transactions t ON t.id = s.transaction_id
+			WHERE s.budget_id = $1 AND t.type_transation = 'bill' AND t.deleted_at IS NULL AND date_trunc('month', t.created_at) = date_trunc('month', CURRENT_DATE)
+		) budget_lines`, budgetID)
 	if err != nil {
 		return 0, err
 	}
@@ -108,7 +392,7 @@ func (repo *TransactionRepository) FindCurrentBudget(ctx context.Context, budget
 			log.Error().Err(err).Msg("failed to close database rows")
 		}
 	}()
-	var currentBudget float64
+	var currentBudget money.Money
 	for rows.Next() {
 		if err = rows.Scan(&currentBudget); err == nil {
 			return currentBudget, nil
@@ -122,9 +406,15 @@ func (repo *TransactionRepository) FindCurrentBudget(ctx context.Context, budget
 	return currentBudget, nil
 }
 
-func (repo *TransactionRepository) FindCurrentBudgets(ctx context.Context, userId string) (map[string]float64, error) {
+func (repo *TransactionRepository) FindCurrentBudgets(ctx context.Context, userId string) (map[string]money.Money, error) {
 	rows, err := repo.db.QueryContext(ctx,
-		"SELECT budget_id, sum(amount) as currentBudget FROM transactions WHERE user_id = $1 AND budget_id IS NOT NULL AND budget_id != '' AND type_transation = 'bill' AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE) GROUP BY budget_id", userId)
+		`SELECT budget_id, sum(amount) as currentBudget FROM (
+			SELECT budget_id, amount FROM transactions
+			WHERE user_id = $1 AND budget_id IS NOT NULL AND budget_id != '' AND (type_transation = 'bill' OR refund_of IS NOT NULL) AND deleted_at IS NULL AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
+			UNION ALL
+			SELECT s.budget_id, s.amount FROM transaction_splits s JOIN transactions t ON t.id = s.transaction_id
+			WHERE t.user_id = $1 AND s.budget_id IS NOT NULL AND s.budget_id != '' AND t.type_transation = 'bill' AND t.deleted_at IS NULL AND date_trunc('month', t.created_at) = date_trunc('month', CURRENT_DATE)
+		) budget_lines GROUP BY budget_id`, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -135,39 +425,633 @@ func (repo *TransactionRepository) FindCurrentBudgets(ctx context.Context, userI
 		}
 	}()
 
-	budgets := make(map[string]float64)
+	budgets := make(map[string]money.Money)
 	for rows.Next() {
 		var budgetID string
-		var currentBudget float64
+		var currentBudget money.Money
 		if err = rows.Scan(&budgetID, &currentBudget); err == nil {
 			budgets[budgetID] = currentBudget
 		}
 	}
-	if err = rows.Err(); err != nil {
-		log.Error().Err(err).Msg("error iterating over transaction rows (FindCurrentBudgets)")
-		return nil, err
-	}
-
-	return budgets, nil
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("error iterating over transaction rows (FindCurrentBudgets)")
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+// Update rewrites a transaction and replaces its split lines and tags with the ones provided.
+func (r *TransactionRepository) Update(ctx context.Context, id string, transaction *transaction.Transaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = checkMoveNotArchived(ctx, tx, id, transaction.AccountId); err != nil {
+		return err
+	}
+	query := `UPDATE transactions SET transaction_name = $1, transaction_description = $2, amount = $3, type_transation = $4, account_id = $5, category_id = $6, budget_id = $7, created_at = $8,
+		currency = COALESCE((SELECT currency FROM account WHERE id = $5), currency), payee_id = $9 WHERE id = $10`
+	_, err = tx.ExecContext(ctx, query, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, nullIfEmpty(transaction.CategoryId), nullIfEmpty(transaction.BudgetId), transaction.CreatedAt, nullIfEmpty(transaction.PayeeId), id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM transaction_splits WHERE transaction_id = $1", id); err != nil {
+		return err
+	}
+	transaction.Id = id
+	if err = insertSplits(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE transaction_id = $1", id); err != nil {
+		return err
+	}
+	if err = insertTags(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateStatus sets the status of a transaction that is not reconciled.
+func (repo *TransactionRepository) UpdateStatus(ctx context.Context, id string, userId string, status string) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE transactions SET status = $1 WHERE id = $2 AND user_id = $3 AND status <> $4 AND deleted_at IS NULL",
+		status, id, userId, transaction.StatusReconciled)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Unlock moves a reconciled transaction back to cleared and detaches it from its reconciliation.
+func (repo *TransactionRepository) Unlock(ctx context.Context, id string, userId string) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE transactions SET status = $1, reconciliation_id = NULL WHERE id = $2 AND user_id = $3 AND status = $4 AND deleted_at IS NULL",
+		transaction.StatusCleared, id, userId, transaction.StatusReconciled)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// LinkRefund marks a transaction as the refund of an earlier bill and moves it to the category and budget of the
+// bill, so it nets against the same spending.
+func (repo *TransactionRepository) LinkRefund(ctx context.Context, id string, userId string, original *transaction.Transaction) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE transactions SET refund_of = $1, category_id = $2, budget_id = $3 WHERE id = $4 AND user_id = $5 AND deleted_at IS NULL",
+		original.Id, nullIfEmpty(original.CategoryId), nullIfEmpty(original.BudgetId), id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UnlinkRefund turns a refund back into a plain income. Its category and budget are kept.
+func (repo *TransactionRepository) UnlinkRefund(ctx context.Context, id string, userId string) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE transactions SET refund_of = NULL WHERE id = $1 AND user_id = $2 AND refund_of IS NOT NULL AND deleted_at IS NULL", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindDuplicateCandidates retrieves the transactions of a user created between from and to that can be duplicates
+// of each other, which leaves out transfer legs.
+func (repo *TransactionRepository) FindDuplicateCandidates(ctx context.Context, userId string, from time.Time, to time.Time) ([]*transaction.Transaction, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE user_id = $1 AND created_at >= $2 AND created_at <= $3 AND transfer_id IS NULL AND deleted_at IS NULL ORDER BY created_at DESC",
+		userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	transactions, err := repo.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// FindDismissedDuplicates retrieves the pairs the user marked as not being duplicates.
+func (repo *TransactionRepository) FindDismissedDuplicates(ctx context.Context, userId string) ([]transaction.DuplicatePair, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT transaction_id, duplicate_id FROM duplicate_dismissals WHERE user_id = $1", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var pairs []transaction.DuplicatePair
+	for rows.Next() {
+		var pair transaction.DuplicatePair
+		if err := rows.Scan(&pair.TransactionId, &pair.DuplicateId); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// DismissDuplicate records that a pair is not a duplicate. Dismissing a pair twice is not an error.
+func (repo *TransactionRepository) DismissDuplicate(ctx context.Context, userId string, pair transaction.DuplicatePair) error {
+	_, err := repo.db.ExecContext(ctx,
+		"INSERT INTO duplicate_dismissals (transaction_id, duplicate_id, user_id, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (transaction_id, duplicate_id) DO NOTHING",
+		pair.TransactionId, pair.DuplicateId, userId, time.Now().UTC())
+	return err
+}
+
+// MergeDuplicate moves the tags and attachments of a duplicate onto the transaction that is kept and moves the
+// duplicate to the trash, all in one database transaction. The duplicate keeps its own splits and tags, so
+// restoring it brings it back as it was.
+func (repo *TransactionRepository) MergeDuplicate(ctx context.Context, keepId string, duplicateId string, userId string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var reconciled int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions WHERE id = $1 AND user_id = $2 AND status = $3",
+		duplicateId, userId, transaction.StatusReconciled).Scan(&reconciled)
+	if err != nil {
+		return err
+	}
+	if reconciled > 0 {
+		return ErrReconciled
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE attachments SET transaction_id = $1 WHERE transaction_id = $2 AND user_id = $3", keepId, duplicateId, userId); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO transaction_tags (transaction_id, tag_id) SELECT $1, tag_id FROM transaction_tags
+		WHERE transaction_id = $2 AND tag_id NOT IN (SELECT tag_id FROM transaction_tags WHERE transaction_id = $1)`, keepId, duplicateId)
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, "UPDATE transactions SET deleted_at = $1 WHERE id = $2 AND user_id = $3 AND transfer_id IS NULL AND deleted_at IS NULL",
+		time.Now().UTC(), duplicateId, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// Delete moves a transaction to the trash. When it is a transfer leg, the opposite leg goes with it. Splits, tags and
+// attachments stay in place so a restore brings the transaction back whole; PurgeDeleted removes them for good.
+func (repo *TransactionRepository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var reconciled int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM transactions WHERE (id = $1 OR transfer_id IN (
+		SELECT transfer_id FROM transactions WHERE id = $1 AND user_id = $2 AND transfer_id IS NOT NULL)) AND user_id = $2 AND status = $3`,
+		id, userId, transaction.StatusReconciled).Scan(&reconciled)
+	if err != nil {
+		return err
+	}
+	if reconciled > 0 {
+		return ErrReconciled
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE transactions SET deleted_at = $1 WHERE (id = $2 OR transfer_id IN (
+		SELECT transfer_id FROM transactions WHERE id = $2 AND user_id = $3 AND transfer_id IS NOT NULL)) AND user_id = $3 AND deleted_at IS NULL`,
+		time.Now().UTC(), id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// DeleteTransfer moves both legs of a transfer to the trash.
+func (repo *TransactionRepository) DeleteTransfer(ctx context.Context, transferId string, userId string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var reconciled int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions WHERE transfer_id = $1 AND user_id = $2 AND status = $3",
+		transferId, userId, transaction.StatusReconciled).Scan(&reconciled)
+	if err != nil {
+		return err
+	}
+	if reconciled > 0 {
+		return ErrReconciled
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE transactions SET deleted_at = $1 WHERE transfer_id = $2 AND user_id = $3 AND deleted_at IS NULL",
+		time.Now().UTC(), transferId, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// FindTrash retrieves the trashed transactions of a user, most recently deleted first.
+func (repo *TransactionRepository) FindTrash(ctx context.Context, userId string) ([]*transaction.Transaction, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+transactionColumns+", deleted_at FROM transactions WHERE user_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		trashed := &transaction.Transaction{}
+		var categoryID, budgetID, transferID, externalID, payeeID, refundOf sql.NullString
+		var deletedAt sql.NullTime
+		err := rows.Scan(&trashed.Id, &trashed.Name, &trashed.Description, &trashed.Amount, &trashed.TypeTransation, &trashed.AccountId,
+			&categoryID, &budgetID, &transferID, &externalID, &trashed.CreatedAt, &trashed.Currency, &trashed.Status, &payeeID, &refundOf, &trashed.UserId, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trashed transaction row: %w", err)
+		}
+		trashed.CategoryId = categoryID.String
+		trashed.BudgetId = budgetID.String
+		trashed.TransferId = transferID.String
+		trashed.ExternalId = externalID.String
+		trashed.PayeeId = payeeID.String
+		trashed.RefundOf = refundOf.String
+		if deletedAt.Valid {
+			trashed.DeletedAt = &deletedAt.Time
+		}
+		transactions = append(transactions, trashed)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// Restore takes a transaction out of the trash together with the opposite leg of its transfer. It fails with
+// ErrAccountTrashed while any of their accounts is still in the trash.
+func (repo *TransactionRepository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var trashedAccounts int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM account WHERE deleted_at IS NOT NULL AND id IN (
+		SELECT account_id FROM transactions WHERE (id = $1 OR transfer_id IN (
+			SELECT transfer_id FROM transactions WHERE id = $1 AND user_id = $2 AND transfer_id IS NOT NULL)) AND user_id = $2)`,
+		id, userId).Scan(&trashedAccounts)
+	if err != nil {
+		return err
+	}
+	if trashedAccounts > 0 {
+		return ErrAccountTrashed
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE transactions SET deleted_at = NULL WHERE (id = $1 OR transfer_id IN (
+		SELECT transfer_id FROM transactions WHERE id = $1 AND user_id = $2 AND transfer_id IS NOT NULL AND deleted_at IS NOT NULL)) AND user_id = $2 AND deleted_at IS NOT NULL`,
+		id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// purgeTransactionDetailsQueries remove the rows hanging off the transactions trashed before $1.
+var purgeTransactionDetailsQueries = []string{
+	"DELETE FROM transaction_splits WHERE transaction_id IN (SELECT id FROM transactions WHERE deleted_at < $1)",
+	"DELETE FROM transaction_tags WHERE transaction_id IN (SELECT id FROM transactions WHERE deleted_at < $1)",
+	"DELETE FROM duplicate_dismissals WHERE transaction_id IN (SELECT id FROM transactions WHERE deleted_at < $1) OR duplicate_id IN (SELECT id FROM transactions WHERE deleted_at < $1)",
+}
+
+// PurgeDeleted permanently removes the transactions trashed before the given time, with their splits, tags and
+// attachments, and returns how many transactions were removed.
+func (repo *TransactionRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, query := range purgeTransactionDetailsQueries {
+		if _, err = tx.ExecContext(ctx, query, before); err != nil {
+			return 0, err
+		}
+	}
+	if err = deleteAttachments(ctx, tx, "transaction_id IN (SELECT id FROM transactions WHERE deleted_at < $1)", before); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM transactions WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
+
+// FindByIds retrieves the transactions of a user with the given ids. Ids that do not exist are left out.
+func (repo *TransactionRepository) FindByIds(ctx context.Context, ids []string, userId string) ([]*transaction.Transaction, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, userId)
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, id)
+	}
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE user_id = $1 AND id IN ("+strings.Join(placeholders, ", ")+") AND deleted_at IS NULL ORDER BY created_at DESC, id",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	transactions, err := repo.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// ApplyBulk applies the action of a bulk request to the given transactions in one database transaction and returns
+// the ids it changed. Transactions that are reconciled, trashed or do not take the action are skipped; deleting a
+// transfer leg also trashes the opposite leg, whose id is returned as well. The tag of a tag action must exist.
+func (repo *TransactionRepository) ApplyBulk(ctx context.Context, userId string, request *dto.BulkRequest, ids []string) ([]string, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var changed []string
+	switch request.Action {
+	case dto.BulkActionDelete:
+		changed, err = bulkDelete(ctx, tx, userId, ids)
+	case dto.BulkActionSetCategory:
+		var found int
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM categorys WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", request.CategoryId, userId).Scan(&found)
+		if err == nil && found == 0 {
+			return nil, ErrCategoryNotFound
+		}
+		if err == nil {
+			changed, err = bulkUpdate(ctx, tx, `UPDATE transactions SET category_id = $1 WHERE id = $2 AND user_id = $3 AND status <> $4 AND deleted_at IS NULL
+				AND transfer_id IS NULL AND id NOT IN (SELECT transaction_id FROM transaction_splits)`, request.CategoryId, userId, ids)
+		}
+	case dto.BulkActionSetAccount:
+		var found int
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM account WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", request.AccountId, userId).Scan(&found)
+		if err == nil && found == 0 {
+			return nil, ErrAccountNotFound
+		}
+		if err == nil {
+			err = checkNotArchived(ctx, tx, request.AccountId)
+		}
+		if err == nil {
+			changed, err = bulkUpdate(ctx, tx, `UPDATE transactions SET account_id = $1, currency = COALESCE((SELECT currency FROM account WHERE id = $1), currency)
+				WHERE id = $2 AND user_id = $3 AND status <> $4 AND deleted_at IS NULL AND transfer_id IS NULL`, request.AccountId, userId, ids)
+		}
+	case dto.BulkActionAddTag:
+		changed, err = bulkTag(ctx, tx, `INSERT INTO transaction_tags (transaction_id, tag_id) SELECT $1, id FROM tags WHERE user_id = $2 AND name = $3
+			AND id NOT IN (SELECT tag_id FROM transaction_tags WHERE transaction_id = $1)`, request.Tag, userId, ids)
+	case dto.BulkActionRemoveTag:
+		changed, err = bulkTag(ctx, tx, "DELETE FROM transaction_tags WHERE transaction_id = $1 AND tag_id IN (SELECT id FROM tags WHERE user_id = $2 AND name = $3)",
+			request.Tag, userId, ids)
+	default:
+		return nil, fmt.Errorf("unknown bulk action %q", request.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// ApplyRuleChanges stores the name, category and budget the categorization rules chose for the given transactions
+// in one database transaction, so a failure leaves all of them untouched. Reconciled transactions are never written.
+func (repo *TransactionRepository) ApplyRuleChanges(ctx context.Context, userId string, transactions []*transaction.Transaction) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, t := range transactions {
+		_, err = tx.ExecContext(ctx, "UPDATE transactions SET transaction_name = $1, category_id = $2, budget_id = $3 WHERE id = $4 AND user_id = $5 AND status <> $6 AND deleted_at IS NULL",
+			t.Name, nullIfEmpty(t.CategoryId), nullIfEmpty(t.BudgetId), t.Id, userId, transaction.StatusReconciled)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// bulkDelete trashes the given transactions and the opposite legs of their transfers, skipping a transaction when
+// it or its opposite leg is reconciled.
+func bulkDelete(ctx context.Context, tx *sql.Tx, userId string, ids []string) ([]string, error) {
+	deletedAt := time.Now().UTC()
+	for _, id := range ids {
+		var reconciled int
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM transactions WHERE (id = $1 OR transfer_id IN (
+			SELECT transfer_id FROM transactions WHERE id = $1 AND user_id = $2 AND transfer_id IS NOT NULL)) AND user_id = $2 AND status = $3`,
+			id, userId, transaction.StatusReconciled).Scan(&reconciled)
+		if err != nil {
+			return nil, err
+		}
+		if reconciled > 0 {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE transactions SET deleted_at = $1 WHERE (id = $2 OR transfer_id IN (
+			SELECT transfer_id FROM transactions WHERE id = $2 AND user_id = $3 AND transfer_id IS NOT NULL)) AND user_id = $3 AND deleted_at IS NULL`,
+			deletedAt, id, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM transactions WHERE user_id = $1 AND deleted_at = $2", userId, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+	var changed []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		changed = append(changed, id)
+	}
+	return changed, rows.Err()
+}
+
+// bulkUpdate runs query once per transaction. The query binds the new value to $1, the transaction to $2, the
+// user to $3 and the reconciled status to $4, and only counts as a change when it touches the row.
+func bulkUpdate(ctx context.Context, tx *sql.Tx, query string, value string, userId string, ids []string) ([]string, error) {
+	var changed []string
+	for _, id := range ids {
+		result, err := tx.ExecContext(ctx, query, value, id, userId, transaction.StatusReconciled)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected > 0 {
+			changed = append(changed, id)
+		}
+	}
+	return changed, nil
 }
 
-func (r *TransactionRepository) Update(ctx context.Context, id string, transaction *transaction.Transaction) error {
-	query := `UPDATE transactions SET transaction_name = $1, transaction_description = $2, amount = $3, type_transation = $4, account_id = $5, category_id = $6, budget_id = $7, created_at = $8 WHERE id = $9`
-	_, err := r.db.ExecContext(ctx, query, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.CategoryId, transaction.BudgetId, transaction.CreatedAt, id)
-	return err
+// bulkTag runs query on every live transaction that is not reconciled. The query binds the transaction to $1, the
+// user to $2 and the tag name to $3. Adding a tag a transaction already has, or removing one it lacks, still counts
+// as done.
+func bulkTag(ctx context.Context, tx *sql.Tx, query string, name string, userId string, ids []string) ([]string, error) {
+	var changed []string
+	for _, id := range ids {
+		var editable int
+		err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM transactions WHERE id = $1 AND user_id = $2 AND status <> $3 AND deleted_at IS NULL",
+			id, userId, transaction.StatusReconciled).Scan(&editable)
+		if err != nil {
+			return nil, err
+		}
+		if editable == 0 {
+			continue
+		}
+		if _, err = tx.ExecContext(ctx, query, id, userId, name); err != nil {
+			return nil, err
+		}
+		changed = append(changed, id)
+	}
+	return changed, nil
 }
 
-func (repo *TransactionRepository) Delete(ctx context.Context, id string, userId string) error {
-	result, err := repo.db.ExecContext(ctx, "DELETE FROM transactions WHERE id = $1 AND user_id = $2", id, userId)
-	if err != nil {
-		return err
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+// deleteAttachments removes the attachments matching condition inside an open database transaction, queueing
+// their blobs so the attachment worker deletes the files.
+func deleteAttachments(ctx context.Context, tx *sql.Tx, condition string, args ...interface{}) error {
+	for _, query := range deleteAttachmentsQueries {
+		if _, err := tx.ExecContext(ctx, query+condition, args...); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -188,7 +1072,15 @@ func (repo *TransactionRepository) FindAllOfAllAccountsWithFilters(ctx context.C
 		}
 	}()
 
-	return repo.scanTransactions(rows)
+	transactions, err := repo.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	restoreCursorOrder(filter, transactions)
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
 }
 
 // FindAllWithFilters retrieves transactions for a specific account with filtering and pagination
@@ -207,7 +1099,15 @@ func (repo *TransactionRepository) FindAllWithFilters(ctx context.Context, filte
 		}
 	}()
 
-	return repo.scanTransactions(rows)
+	transactions, err := repo.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	restoreCursorOrder(filter, transactions)
+	if err = repo.attachDetails(ctx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
 }
 
 // CountWithFilters counts transactions with applied filters
@@ -225,21 +1125,231 @@ func (repo *TransactionRepository) CountWithFilters(ctx context.Context, userId
 	return count, nil
 }
 
+// SummarizeWithFilters aggregates every transaction matching the filter, ignoring pagination, by type and currency
+// and by category and currency. Split transactions are counted towards the category of each of their lines.
+func (repo *TransactionRepository) SummarizeWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter) (*dto.SummaryTotals, error) {
+	whereConditions, args, _ := buildTransactionConditions(userId, filter)
+	where := " WHERE " + strings.Join(whereConditions, " AND ")
+	totals := &dto.SummaryTotals{}
+
+	typeQuery := "SELECT type_transation, currency, COALESCE(SUM(amount), 0), COUNT(*), COALESCE(MAX(amount), 0) FROM transactions" + where +
+		" GROUP BY type_transation, currency"
+	log.Debug().Str("summary_query", typeQuery).Interface("args", args).Msg("executing transaction summary query")
+	if err := repo.scanSummary(ctx, typeQuery, args, func(rows *sql.Rows) error {
+		var total dto.SummaryTotal
+		if err := rows.Scan(&total.TypeTransation, &total.Currency, &total.Total, &total.Count, &total.Largest); err != nil {
+			return err
+		}
+		totals.Types = append(totals.Types, total)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	categoryQuery := "SELECT COALESCE(s.category_id, f.category_id), f.currency, COALESCE(SUM(COALESCE(s.amount, f.amount)), 0), COUNT(*) FROM (SELECT id, category_id, amount, currency FROM transactions" + where +
+		") f LEFT JOIN transaction_splits s ON s.transaction_id = f.id WHERE COALESCE(s.category_id, f.category_id) <> '' GROUP BY COALESCE(s.category_id, f.category_id), f.currency"
+	log.Debug().Str("summary_query", categoryQuery).Interface("args", args).Msg("executing transaction category summary query")
+	if err := repo.scanSummary(ctx, categoryQuery, args, func(rows *sql.Rows) error {
+		var total dto.SummaryCategoryTotal
+		if err := rows.Scan(&total.CategoryId, &total.Currency, &total.Total, &total.Count); err != nil {
+			return err
+		}
+		totals.Categories = append(totals.Categories, total)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// scanSummary runs a summary query and calls scan for each of its rows.
+func (repo *TransactionRepository) scanSummary(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute transaction summary query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("failed to scan transaction summary row: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+// StreamWithFilters calls fn for every transaction matching the filter, ignoring pagination. Rows are read from
+// a single cursor with their split lines joined in, so the result set is never held in memory.
+func (repo *TransactionRepository) StreamWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter, fn func(*transaction.Transaction) error) error {
+	whereConditions, args, _ := buildTransactionConditions(userId, filter)
+
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT f.id, f.transaction_name, f.transaction_description, f.amount, f.type_transation, f.account_id, f.category_id, f.budget_id, f.transfer_id, f.external_id, f.created_at, f.currency, f.status, f.payee_id, f.refund_of, f.user_id, ")
+	queryBuilder.WriteString("s.id, s.category_id, s.budget_id, s.amount, s.description FROM (SELECT " + transactionColumns + " FROM transactions")
+	if len(whereConditions) > 0 {
+		queryBuilder.WriteString(" WHERE ")
+		queryBuilder.WriteString(strings.Join(whereConditions, " AND "))
+	}
+	queryBuilder.WriteString(") f LEFT JOIN transaction_splits s ON s.transaction_id = f.id")
+	queryBuilder.WriteString(transactionOrderBy(filter, "f."))
+	queryBuilder.WriteString(", f.id, s.id")
+	query := queryBuilder.String()
+
+	log.Debug().Str("query", query).Interface("args", args).Msg("executing transaction stream query")
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute transaction query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	// Split lines arrive as consecutive rows of the same parent, which is emitted once the next parent starts.
+	var current *transaction.Transaction
+	for rows.Next() {
+		t := &transaction.Transaction{}
+		var categoryID, budgetID, transferID, externalID, payeeID, refundOf sql.NullString
+		var splitID, splitCategoryID, splitBudgetID, splitDescription sql.NullString
+		var splitAmount sql.Null[money.Money]
+
+		err := rows.Scan(
+			&t.Id,
+			&t.Name,
+			&t.Description,
+			&t.Amount,
+			&t.TypeTransation,
+			&t.AccountId,
+			&categoryID,
+			&budgetID,
+			&transferID,
+			&externalID,
+			&t.CreatedAt,
+			&t.Currency,
+			&t.Status,
+			&payeeID,
+			&refundOf,
+			&t.UserId,
+			&splitID,
+			&splitCategoryID,
+			&splitBudgetID,
+			&splitAmount,
+			&splitDescription,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan transaction row: %w", err)
+		}
+
+		if current == nil || current.Id != t.Id {
+			if current != nil {
+				if err := fn(current); err != nil {
+					return err
+				}
+			}
+			t.CategoryId = categoryID.String
+			t.BudgetId = budgetID.String
+			t.TransferId = transferID.String
+			t.ExternalId = externalID.String
+			t.PayeeId = payeeID.String
+			t.RefundOf = refundOf.String
+			current = t
+		}
+
+		if splitID.Valid {
+			current.Splits = append(current.Splits, &transaction.Split{
+				Id:            splitID.String,
+				TransactionId: current.Id,
+				CategoryId:    splitCategoryID.String,
+				BudgetId:      splitBudgetID.String,
+				Amount:        splitAmount.V,
+				Description:   splitDescription.String,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over transaction rows: %w", err)
+	}
+
+	if current != nil {
+		return fn(current)
+	}
+	return nil
+}
+
 // buildTransactionQuery constructs the SQL query with filters, sorting, and pagination
 func (repo *TransactionRepository) buildTransactionQuery(userId string, filter *dto.TransactionFilter, isCount bool) (string, []interface{}) {
 	var queryBuilder strings.Builder
-	var args []interface{}
-	argIndex := 1
 
 	// SELECT clause
 	if isCount {
 		queryBuilder.WriteString("SELECT COUNT(*) FROM transactions")
 	} else {
-		queryBuilder.WriteString("SELECT id, transaction_name, transaction_description, amount, type_transation, account_id, category_id, budget_id, created_at FROM transactions")
+		queryBuilder.WriteString("SELECT " + transactionColumns + " FROM transactions")
 	}
 
 	// WHERE clause
-	var whereConditions []string
+	whereConditions, args, argIndex := buildTransactionConditions(userId, filter)
+
+	// Keyset condition, left out of counts so they cover the whole filtered set
+	if !isCount && filter.DecodedCursor != nil {
+		op := "<"
+		if cursorAscending(filter) {
+			op = ">"
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("(created_at %s $%d OR (created_at = $%d AND id %s $%d))", op, argIndex, argIndex, op, argIndex+1))
+		args = append(args, filter.DecodedCursor.CreatedAt, filter.DecodedCursor.Id)
+		argIndex += 2
+	}
+
+	// Add WHERE conditions
+	if len(whereConditions) > 0 {
+		queryBuilder.WriteString(" WHERE ")
+		queryBuilder.WriteString(strings.Join(whereConditions, " AND "))
+	}
+
+	// For count queries, we don't need ORDER BY, LIMIT, or OFFSET
+	if isCount {
+		return queryBuilder.String(), args
+	}
+
+	// ORDER BY clause, with the id breaking ties between rows created at the same time
+	if filter.SortsByCreatedAt() {
+		direction := "DESC"
+		if cursorAscending(filter) {
+			direction = "ASC"
+		}
+		queryBuilder.WriteString(" ORDER BY created_at " + direction + ", id " + direction)
+	} else {
+		queryBuilder.WriteString(transactionOrderBy(filter, ""))
+	}
+
+	// LIMIT and OFFSET for pagination (only if limit > 0)
+	if filter.Limit > 0 {
+		queryBuilder.WriteString(fmt.Sprintf(" LIMIT $%d", argIndex))
+		args = append(args, filter.Limit)
+		argIndex++
+
+		if filter.Offset > 0 && filter.DecodedCursor == nil {
+			queryBuilder.WriteString(fmt.Sprintf(" OFFSET $%d", argIndex))
+			args = append(args, filter.Offset)
+		}
+	}
+
+	return queryBuilder.String(), args
+}
+
+// buildTransactionConditions returns the WHERE conditions of a filter, their arguments and the next placeholder index.
+func buildTransactionConditions(userId string, filter *dto.TransactionFilter) ([]string, []interface{}, int) {
+	// Trashed transactions only show up in the trash listing
+	whereConditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	argIndex := 1
 
 	// User ID filter (for all accounts query)
 	if userId != "" {
@@ -255,6 +1365,13 @@ func (repo *TransactionRepository) buildTransactionQuery(userId string, filter *
 		argIndex++
 	}
 
+	// Payee filter
+	if filter.PayeeId != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("payee_id = $%d", argIndex))
+		args = append(args, filter.PayeeId)
+		argIndex++
+	}
+
 	// Transaction type filter
 	if filter.Type != "all" && filter.Type != "" {
 		whereConditions = append(whereConditions, fmt.Sprintf("type_transation = $%d", argIndex))
@@ -262,9 +1379,9 @@ func (repo *TransactionRepository) buildTransactionQuery(userId string, filter *
 		argIndex++
 	}
 
-	// Category ID filter
+	// Category ID filter (matches the transaction itself or any of its split lines)
 	if filter.CategoryId != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("category_id = $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("(category_id = $%d OR id IN (SELECT transaction_id FROM transaction_splits WHERE category_id = $%d))", argIndex, argIndex))
 		args = append(args, filter.CategoryId)
 		argIndex++
 	}
@@ -277,16 +1394,24 @@ func (repo *TransactionRepository) buildTransactionQuery(userId string, filter *
 			args = append(args, category)
 			argIndex++
 		}
-		whereConditions = append(whereConditions, fmt.Sprintf("category_id IN (%s)", strings.Join(placeholders, ", ")))
+		inList := strings.Join(placeholders, ", ")
+		whereConditions = append(whereConditions, fmt.Sprintf("(category_id IN (%s) OR id IN (SELECT transaction_id FROM transaction_splits WHERE category_id IN (%s)))", inList, inList))
 	}
 
 	// Budget ID filter
 	if filter.BudgetId != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("budget_id = $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("(budget_id = $%d OR id IN (SELECT transaction_id FROM transaction_splits WHERE budget_id = $%d))", argIndex, argIndex))
 		args = append(args, filter.BudgetId)
 		argIndex++
 	}
 
+	// Status filter
+	if filter.Status != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, filter.Status)
+		argIndex++
+	}
+
 	// Date range filter
 	if !filter.CalculatedDateFrom.IsZero() {
 		whereConditions = append(whereConditions, fmt.Sprintf("created_at >= $%d", argIndex))
@@ -321,49 +1446,58 @@ func (repo *TransactionRepository) buildTransactionQuery(userId string, filter *
 		argIndex += 2
 	}
 
-	// Add WHERE conditions
-	if len(whereConditions) > 0 {
-		queryBuilder.WriteString(" WHERE ")
-		queryBuilder.WriteString(strings.Join(whereConditions, " AND "))
-	}
-
-	// For count queries, we don't need ORDER BY, LIMIT, or OFFSET
-	if isCount {
-		return queryBuilder.String(), args
+	// Tag filter, "all" requires the transaction to carry every requested tag
+	if len(filter.Tags) > 0 {
+		placeholders := make([]string, len(filter.Tags))
+		for i, name := range filter.Tags {
+			placeholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, name)
+			argIndex++
+		}
+		tagQuery := fmt.Sprintf("SELECT tt.transaction_id FROM transaction_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name IN (%s)", strings.Join(placeholders, ", "))
+		if filter.TagsMode == dto.TagsModeAll {
+			tagQuery += fmt.Sprintf(" GROUP BY tt.transaction_id HAVING COUNT(DISTINCT g.name) = %d", len(filter.Tags))
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("id IN (%s)", tagQuery))
 	}
 
-	// ORDER BY clause
-	if filter.SortBy != "" {
-		queryBuilder.WriteString(" ORDER BY ")
-		if filter.SortBy == "amount" {
-			queryBuilder.WriteString("ABS(amount)")
-		} else {
-			queryBuilder.WriteString(filter.SortBy)
-		}
+	return whereConditions, args, argIndex
+}
 
-		if filter.SortOrder == "asc" || filter.SortOrder == "desc" {
-			queryBuilder.WriteString(" ")
-			queryBuilder.WriteString(strings.ToUpper(filter.SortOrder))
-		} else {
-			queryBuilder.WriteString(" DESC") // Default to DESC
-		}
-	} else {
-		queryBuilder.WriteString(" ORDER BY created_at DESC") // Default sorting
+// cursorAscending returns true if rows are read in ascending (created_at, id) order. A prev cursor reads
+// backwards from its position, against the sort order.
+func cursorAscending(filter *dto.TransactionFilter) bool {
+	ascending := filter.SortOrder == "asc"
+	if filter.DecodedCursor != nil && filter.DecodedCursor.Direction == dto.CursorPrev {
+		return !ascending
 	}
+	return ascending
+}
 
-	// LIMIT and OFFSET for pagination (only if limit > 0)
-	if filter.Limit > 0 {
-		queryBuilder.WriteString(fmt.Sprintf(" LIMIT $%d", argIndex))
-		args = append(args, filter.Limit)
-		argIndex++
+// restoreCursorOrder puts the rows read backwards by a prev cursor back in the sort order.
+func restoreCursorOrder(filter *dto.TransactionFilter, transactions []*transaction.Transaction) {
+	if filter.DecodedCursor != nil && filter.DecodedCursor.Direction == dto.CursorPrev {
+		slices.Reverse(transactions)
+	}
+}
 
-		if filter.Offset > 0 {
-			queryBuilder.WriteString(fmt.Sprintf(" OFFSET $%d", argIndex))
-			args = append(args, filter.Offset)
-		}
+// transactionOrderBy returns the ORDER BY clause of a filter. prefix qualifies the columns (e.g. "f.").
+func transactionOrderBy(filter *dto.TransactionFilter, prefix string) string {
+	if filter.SortBy == "" {
+		return " ORDER BY " + prefix + "created_at DESC" // Default sorting
 	}
 
-	return queryBuilder.String(), args
+	column := prefix + filter.SortBy
+	switch filter.SortBy {
+	case "amount":
+		column = "ABS(" + prefix + "amount)"
+	case "name":
+		column = prefix + "transaction_name"
+	}
+	if filter.SortOrder == "asc" || filter.SortOrder == "desc" {
+		return " ORDER BY " + column + " " + strings.ToUpper(filter.SortOrder)
+	}
+	return " ORDER BY " + column + " DESC" // Default to DESC
 }
 
 // scanTransactions scans database rows into Transaction objects
@@ -372,7 +1506,7 @@ func (repo *TransactionRepository) scanTransactions(rows *sql.Rows) ([]*transact
 
 	for rows.Next() {
 		transaction := &transaction.Transaction{}
-		var budgetID sql.NullString
+		var categoryID, budgetID, transferID, externalID, payeeID, refundOf sql.NullString
 
 		err := rows.Scan(
 			&transaction.Id,
@@ -381,17 +1515,27 @@ func (repo *TransactionRepository) scanTransactions(rows *sql.Rows) ([]*transact
 			&transaction.Amount,
 			&transaction.TypeTransation,
 			&transaction.AccountId,
-			&transaction.CategoryId,
+			&categoryID,
 			&budgetID,
+			&transferID,
+			&externalID,
 			&transaction.CreatedAt,
+			&transaction.Currency,
+			&transaction.Status,
+			&payeeID,
+			&refundOf,
+			&transaction.UserId,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction row: %w", err)
 		}
 
-		if budgetID.Valid {
-			transaction.BudgetId = budgetID.String
-		}
+		transaction.CategoryId = categoryID.String
+		transaction.BudgetId = budgetID.String
+		transaction.TransferId = transferID.String
+		transaction.ExternalId = externalID.String
+		transaction.PayeeId = payeeID.String
+		transaction.RefundOf = refundOf.String
 
 		transactions = append(transactions, transaction)
 	}
@@ -402,3 +1546,159 @@ func (repo *TransactionRepository) scanTransactions(rows *sql.Rows) ([]*transact
 
 	return transactions, nil
 }
+
+// attachDetails loads the split lines and tags of the given transactions and attaches them to their parents.
+func (repo *TransactionRepository) attachDetails(ctx context.Context, transactions []*transaction.Transaction) error {
+	if err := repo.attachSplits(ctx, transactions); err != nil {
+		return err
+	}
+	if err := repo.attachTags(ctx, transactions); err != nil {
+		return err
+	}
+	return repo.attachRefunds(ctx, transactions)
+}
+
+// attachSplits loads the split lines of the given transactions and attaches them to their parents.
+func (repo *TransactionRepository) attachSplits(ctx context.Context, transactions []*transaction.Transaction) error {
+	byId := indexById(transactions)
+	return forEachIdBatch(transactions, func(batch []interface{}, inList string) error {
+		query := fmt.Sprintf("SELECT id, transaction_id, category_id, budget_id, amount, description FROM transaction_splits WHERE transaction_id IN (%s) ORDER BY id", inList)
+		return repo.scanSplits(ctx, query, batch, byId)
+	})
+}
+
+// attachTags loads the tag names of the given transactions, in alphabetical order.
+func (repo *TransactionRepository) attachTags(ctx context.Context, transactions []*transaction.Transaction) error {
+	byId := indexById(transactions)
+	return forEachIdBatch(transactions, func(batch []interface{}, inList string) error {
+		query := fmt.Sprintf("SELECT tt.transaction_id, g.name FROM transaction_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.transaction_id IN (%s) ORDER BY g.name", inList)
+		return repo.scanTags(ctx, query, batch, byId)
+	})
+}
+
+// attachRefunds loads the ids of the live refunds of the given transactions, oldest first.
+func (repo *TransactionRepository) attachRefunds(ctx context.Context, transactions []*transaction.Transaction) error {
+	byId := indexById(transactions)
+	return forEachIdBatch(transactions, func(batch []interface{}, inList string) error {
+		query := fmt.Sprintf("SELECT refund_of, id FROM transactions WHERE refund_of IN (%s) AND deleted_at IS NULL ORDER BY created_at, id", inList)
+		return repo.scanRefunds(ctx, query, batch, byId)
+	})
+}
+
+func indexById(transactions []*transaction.Transaction) map[string]*transaction.Transaction {
+	byId := make(map[string]*transaction.Transaction, len(transactions))
+	for _, t := range transactions {
+		byId[t.Id] = t
+	}
+	return byId
+}
+
+// forEachIdBatch calls fn with the ids of the transactions in batches of splitBatchSize, together with the
+// matching "$1, $2, ..." placeholder list.
+func forEachIdBatch(transactions []*transaction.Transaction, fn func(batch []interface{}, inList string) error) error {
+	ids := make([]interface{}, 0, len(transactions))
+	for _, t := range transactions {
+		ids = append(ids, t.Id)
+	}
+
+	for start := 0; start < len(ids); start += splitBatchSize {
+		end := start + splitBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+		if err := fn(batch, strings.Join(placeholders, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *TransactionRepository) scanSplits(ctx context.Context, query string, args []interface{}, parents map[string]*transaction.Transaction) error {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load transaction splits: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	for rows.Next() {
+		split := &transaction.Split{}
+		var budgetID, description sql.NullString
+		if err := rows.Scan(&split.Id, &split.TransactionId, &split.CategoryId, &budgetID, &split.Amount, &description); err != nil {
+			return fmt.Errorf("failed to scan transaction split row: %w", err)
+		}
+		split.BudgetId = budgetID.String
+		split.Description = description.String
+
+		if parent, ok := parents[split.TransactionId]; ok {
+			parent.Splits = append(parent.Splits, split)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo *TransactionRepository) scanTags(ctx context.Context, query string, args []interface{}, parents map[string]*transaction.Transaction) error {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load transaction tags: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	for rows.Next() {
+		var transactionId, name string
+		if err := rows.Scan(&transactionId, &name); err != nil {
+			return fmt.Errorf("failed to scan transaction tag row: %w", err)
+		}
+		if parent, ok := parents[transactionId]; ok {
+			parent.Tags = append(parent.Tags, name)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo *TransactionRepository) scanRefunds(ctx context.Context, query string, args []interface{}, originals map[string]*transaction.Transaction) error {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load transaction refunds: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	for rows.Next() {
+		var originalId, refundId string
+		if err := rows.Scan(&originalId, &refundId); err != nil {
+			return fmt.Errorf("failed to scan transaction refund row: %w", err)
+		}
+		if original, ok := originals[originalId]; ok {
+			original.RefundedBy = append(original.RefundedBy, refundId)
+		}
+	}
+
+	return rows.Err()
+}
+
+// nullIfEmpty maps an empty string to SQL NULL for optional foreign keys.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -31,6 +31,20 @@ func (u *UserRepository) DeleteDemoUsersOlderThan(ctx context.Context, olderThan
 		_ = tx.Rollback()
 	}()
 
+	// 0. Delete attachments, queueing their files for the attachment worker
+	for _, query := range []string{
+		"INSERT INTO attachment_deletions (storage_key) SELECT storage_key FROM attachments",
+		"DELETE FROM attachments",
+	} {
+		_, err = tx.ExecContext(ctx, query+`
+		WHERE user_id IN (
+			SELECT id FROM users WHERE is_demo = true AND created_at < $1
+		)`, olderThan)
+		if err != nil {
+			return err
+		}
+	}
+
 	// 1. Delete transactions
 	_, err = tx.ExecContext(ctx, `
 		DELETE FROM transactions 
@@ -142,13 +156,13 @@ func (u *UserRepository) Save(ctx context.Context, user *domainUser.User) error
 }
 
 func (u *UserRepository) Update(ctx context.Context, user *domainUser.User) error {
-	_, err := u.db.ExecContext(ctx, "UPDATE users SET name = $1, last_name = $2, email = $3, password = $4, token = $5, confirmed = $6, is_demo = $7, ip_address = $8, role = $9 WHERE id = $10",
-		user.Name, user.LastName, user.Email, user.Password, user.Token, user.Confirmed, user.IsDemo, user.IpAddress, user.Role, user.Id)
+	_, err := u.db.ExecContext(ctx, "UPDATE users SET name = $1, last_name = $2, email = $3, password = $4, token = $5, confirmed = $6, is_demo = $7, ip_address = $8, role = $9, base_currency = COALESCE(NULLIF($10, ''), base_currency) WHERE id = $11",
+		user.Name, user.LastName, user.Email, user.Password, user.Token, user.Confirmed, user.IsDemo, user.IpAddress, user.Role, user.BaseCurrency, user.Id)
 	return err
 }
 
 func (u *UserRepository) FindUserById(ctx context.Context, id string) (*domainUser.User, error) {
-	rows, err := u.db.QueryContext(ctx, "SELECT id, name, last_name, email, password, confirmed, is_demo, COALESCE(ip_address, ''), role, created_at FROM users WHERE id = $1", id)
+	rows, err := u.db.QueryContext(ctx, "SELECT id, name, last_name, email, password, confirmed, is_demo, COALESCE(ip_address, ''), role, base_currency, created_at FROM users WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +175,7 @@ func (u *UserRepository) FindUserById(ctx context.Context, id string) (*domainUs
 
 	user := domainUser.User{}
 	for rows.Next() {
-		if err = rows.Scan(&user.Id, &user.Name, &user.LastName, &user.Email, &user.Password, &user.Confirmed, &user.IsDemo, &user.IpAddress, &user.Role, &user.CreatedAt); err == nil {
+		if err = rows.Scan(&user.Id, &user.Name, &user.LastName, &user.Email, &user.Password, &user.Confirmed, &user.IsDemo, &user.IpAddress, &user.Role, &user.BaseCurrency, &user.CreatedAt); err == nil {
 			return &user, nil
 		}
 	}
@@ -234,7 +248,7 @@ func (u *UserRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (u *UserRepository) FindAll(ctx context.Context) ([]*domainUser.User, error) {
-	rows, err := u.db.QueryContext(ctx, "SELECT id, name, last_name, email, password, confirmed, is_demo, COALESCE(ip_address, ''), role, created_at FROM users ORDER BY created_at DESC")
+	rows, err := u.db.QueryContext(ctx, "SELECT id, name, last_name, email, password, confirmed, is_demo, COALESCE(ip_address, ''), role, base_currency, created_at FROM users ORDER BY created_at DESC")
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +262,7 @@ func (u *UserRepository) FindAll(ctx context.Context) ([]*domainUser.User, error
 	var users []*domainUser.User
 	for rows.Next() {
 		var user domainUser.User
-		if err = rows.Scan(&user.Id, &user.Name, &user.LastName, &user.Email, &user.Password, &user.Confirmed, &user.IsDemo, &user.IpAddress, &user.Role, &user.CreatedAt); err != nil {
+		if err = rows.Scan(&user.Id, &user.Name, &user.LastName, &user.Email, &user.Password, &user.Confirmed, &user.IsDemo, &user.IpAddress, &user.Role, &user.BaseCurrency, &user.CreatedAt); err != nil {
 			return nil, err
 		}
 		users = append(users, &user)
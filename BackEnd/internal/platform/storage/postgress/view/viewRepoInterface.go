@@ -0,0 +1,17 @@
+package postgress
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+)
+
+type ViewRepoInterface interface {
+	Save(ctx context.Context, view *view.View) error
+	// Update renames a view and replaces its query
+	Update(ctx context.Context, view *view.View) error
+	FindAll(ctx context.Context, userId string) ([]*view.View, error)
+	FindById(ctx context.Context, id string, userId string) (*view.View, error)
+	FindByName(ctx context.Context, name string, userId string) (*view.View, error)
+	Delete(ctx context.Context, id string, userId string) error
+}
@@ -0,0 +1,106 @@
+package postgress
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+	"github.com/rs/zerolog/log"
+)
+
+const viewColumns = "id, user_id, name, query, created_at"
+
+type ViewRepository struct {
+	db *sql.DB
+}
+
+func NewViewRepository(db *sql.DB) *ViewRepository {
+	return &ViewRepository{
+		db: db,
+	}
+}
+
+func (r *ViewRepository) Save(ctx context.Context, view *view.View) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO saved_views ("+viewColumns+") VALUES ($1, $2, $3, $4, $5)", view.Id, view.UserId, view.Name, view.Query, view.CreatedAt)
+	return err
+}
+
+func (r *ViewRepository) Update(ctx context.Context, view *view.View) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE saved_views SET name = $1, query = $2 WHERE id = $3 AND user_id = $4", view.Name, view.Query, view.Id, view.UserId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ViewRepository) FindAll(ctx context.Context, userId string) ([]*view.View, error) {
+	return r.find(ctx, "SELECT "+viewColumns+" FROM saved_views WHERE user_id = $1 ORDER BY name", userId)
+}
+
+// FindById returns sql.ErrNoRows when the view does not exist or belongs to another user.
+func (r *ViewRepository) FindById(ctx context.Context, id string, userId string) (*view.View, error) {
+	return r.findOne(ctx, "SELECT "+viewColumns+" FROM saved_views WHERE id = $1 AND user_id = $2", id, userId)
+}
+
+// FindByName returns sql.ErrNoRows when the user has no view with that name.
+func (r *ViewRepository) FindByName(ctx context.Context, name string, userId string) (*view.View, error) {
+	return r.findOne(ctx, "SELECT "+viewColumns+" FROM saved_views WHERE name = $1 AND user_id = $2", name, userId)
+}
+
+func (r *ViewRepository) Delete(ctx context.Context, id string, userId string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM saved_views WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ViewRepository) findOne(ctx context.Context, query string, args ...interface{}) (*view.View, error) {
+	views, err := r.find(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return views[0], nil
+}
+
+func (r *ViewRepository) find(ctx context.Context, query string, args ...interface{}) ([]*view.View, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close database rows")
+		}
+	}()
+
+	var views []*view.View
+	for rows.Next() {
+		var view view.View
+		if err := rows.Scan(&view.Id, &view.UserId, &view.Name, &view.Query, &view.CreatedAt); err != nil {
+			return nil, err
+		}
+		views = append(views, &view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
@@ -88,6 +88,7 @@ func CreateInMemorySQLiteDB() *sql.DB {
 func SetupPostgreSQLSchema(db *sql.DB) error {
 	schema := `
 	-- PostgreSQL schema for E2E testing
+	DROP TABLE IF EXISTS transaction_splits CASCADE;
 	DROP TABLE IF EXISTS transactions CASCADE;
 	DROP TABLE IF EXISTS budgets CASCADE;
 	DROP TABLE IF EXISTS categorys CASCADE;
@@ -98,11 +99,28 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 	DROP TABLE IF EXISTS notifications CASCADE;
 	DROP TABLE IF EXISTS recurring_transactions CASCADE;
 	DROP TABLE IF EXISTS investments CASCADE;
+	DROP TABLE IF EXISTS import_mappings CASCADE;
+	DROP TABLE IF EXISTS categorization_rules CASCADE;
+	DROP TABLE IF EXISTS transaction_tags CASCADE;
+	DROP TABLE IF EXISTS tags CASCADE;
+	DROP TABLE IF EXISTS attachments CASCADE;
+	DROP TABLE IF EXISTS attachment_deletions CASCADE;
+	DROP TABLE IF EXISTS fx_rates CASCADE;
+	DROP TABLE IF EXISTS reconciliations CASCADE;
+	DROP TABLE IF EXISTS duplicate_dismissals CASCADE;
+	DROP TABLE IF EXISTS audit_events CASCADE;
+	DROP TABLE IF EXISTS saved_views CASCADE;
+	DROP TABLE IF EXISTS idempotency_keys CASCADE;
+	DROP TABLE IF EXISTS account_daily_balances CASCADE;
+	DROP TABLE IF EXISTS net_worth_snapshots CASCADE;
+	DROP TABLE IF EXISTS payee_aliases CASCADE;
+	DROP TABLE IF EXISTS payees CASCADE;
 	DROP TYPE IF EXISTS TypeTransaction CASCADE;
 
 	CREATE TYPE TypeTransaction AS ENUM (
 		'bill',
-		'income'
+		'income',
+		'transfer'
 	);
 
 	CREATE TABLE users(
@@ -115,6 +133,7 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 		confirmed BOOLEAN DEFAULT false,
 		ip_address VARCHAR(45),
 		role VARCHAR(20) DEFAULT 'USER',
+		base_currency VARCHAR(3) NOT NULL DEFAULT 'USD',
 		created_at timestamptz NOT NULL DEFAULT (now())
 	);
 
@@ -122,9 +141,17 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 		id VARCHAR(32) PRIMARY KEY,
 		name_account VARCHAR(255),
 		bank VARCHAR(255),
-		balance float,
+		balance NUMERIC(15, 2),
+		currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+		account_type VARCHAR(20) NOT NULL DEFAULT 'checking',
+		credit_limit NUMERIC(15, 2),
+		statement_day SMALLINT,
+		due_day SMALLINT,
+		interest_rate NUMERIC(6, 3),
 		user_id VARCHAR NOT NULL,
 		created_at timestamptz NOT NULL DEFAULT (now()),
+		archived_at timestamptz,
+		deleted_at timestamptz,
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
@@ -135,31 +162,141 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 		color VARCHAR(255) NOT NULL,
 		created_at timestamptz NOT NULL DEFAULT (now()),
 		user_id VARCHAR NOT NULL,
+		deleted_at timestamptz,
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
 	CREATE TABLE budgets (
 		id VARCHAR PRIMARY KEY,
 		category_id VARCHAR NOT NULL,
-		amount float NOT NULL,
+		amount NUMERIC(15, 2) NOT NULL,
 		created_at timestamptz NOT NULL DEFAULT (now()),
 		user_id VARCHAR NOT NULL,
 		FOREIGN KEY (category_id) REFERENCES categorys (id),
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
+	CREATE TABLE reconciliations (
+		id VARCHAR PRIMARY KEY,
+		account_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		statement_date DATE NOT NULL,
+		statement_balance NUMERIC(15, 2) NOT NULL,
+		cleared_balance NUMERIC(15, 2) NOT NULL DEFAULT 0,
+		status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		completed_at timestamptz,
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE saved_views (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		query TEXT NOT NULL DEFAULT '',
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE net_worth_snapshots (
+		user_id VARCHAR NOT NULL,
+		month DATE NOT NULL,
+		currency VARCHAR(3) NOT NULL,
+		assets NUMERIC(15, 2) NOT NULL,
+		liabilities NUMERIC(15, 2) NOT NULL,
+		investments NUMERIC(15, 2) NOT NULL,
+		net_worth NUMERIC(15, 2) NOT NULL,
+		updated_at timestamptz NOT NULL DEFAULT (now()),
+		PRIMARY KEY (user_id, month),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE statement_reminders (
+		account_id VARCHAR NOT NULL,
+		closing_date DATE NOT NULL,
+		sent_at timestamptz NOT NULL DEFAULT (now()),
+		PRIMARY KEY (account_id, closing_date),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE idempotency_keys (
+		user_id VARCHAR NOT NULL,
+		idempotency_key VARCHAR(255) NOT NULL,
+		request_hash VARCHAR(64) NOT NULL,
+		status_code INTEGER NOT NULL DEFAULT 0,
+		content_type VARCHAR(255) NOT NULL DEFAULT '',
+		response BYTEA,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		expires_at timestamptz NOT NULL,
+		PRIMARY KEY (user_id, idempotency_key),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE payees (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);
+
+	CREATE TABLE payee_aliases (
+		payee_id VARCHAR NOT NULL,
+		pattern VARCHAR(100) NOT NULL,
+		PRIMARY KEY (payee_id, pattern),
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE CASCADE
+	);
+
 	CREATE TABLE transactions (
 		id VARCHAR PRIMARY KEY,
 		transaction_name VARCHAR NOT NULL,
 		transaction_description TEXT,
-		amount float NOT NULL,
+		amount NUMERIC(15, 2) NOT NULL,
 		type_transation TypeTransaction NOT NULL,
 		account_id VARCHAR NOT NULL,
 		user_id VARCHAR NOT NULL,
-		category_id VARCHAR NOT NULL,
+		category_id VARCHAR,
 		budget_id VARCHAR,
+		transfer_id VARCHAR,
+		external_id VARCHAR,
+		currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+		status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cleared', 'reconciled')),
+		reconciliation_id VARCHAR,
+		payee_id VARCHAR,
+		refund_of VARCHAR,
 		created_at timestamptz NOT NULL DEFAULT (now()),
+		deleted_at timestamptz,
 		FOREIGN KEY (account_id) REFERENCES account (id),
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE SET NULL,
+		FOREIGN KEY (refund_of) REFERENCES transactions (id) ON DELETE SET NULL,
+		FOREIGN KEY (reconciliation_id) REFERENCES reconciliations (id) ON DELETE SET NULL,
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (category_id) REFERENCES categorys (id),
+		FOREIGN KEY (budget_id) REFERENCES budgets (id),
+		UNIQUE (account_id, external_id)
+	);
+
+	CREATE TABLE account_daily_balances (
+		account_id VARCHAR NOT NULL,
+		day DATE NOT NULL,
+		balance NUMERIC(15, 2) NOT NULL,
+		PRIMARY KEY (account_id, day),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE transaction_splits (
+		id VARCHAR PRIMARY KEY,
+		transaction_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		category_id VARCHAR NOT NULL,
+		budget_id VARCHAR,
+		amount NUMERIC(15, 2) NOT NULL,
+		description TEXT,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
 		FOREIGN KEY (user_id) REFERENCES users (id),
 		FOREIGN KEY (category_id) REFERENCES categorys (id),
 		FOREIGN KEY (budget_id) REFERENCES budgets (id)
@@ -185,8 +322,115 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 		quantity float NOT NULL,
 		created_at timestamptz NOT NULL DEFAULT (now()),
 		user_id VARCHAR NOT NULL,
+		account_id VARCHAR,
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE import_mappings (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+		has_header BOOLEAN NOT NULL DEFAULT true,
+		date_column INTEGER NOT NULL,
+		amount_column INTEGER NOT NULL,
+		description_column INTEGER NOT NULL,
+		date_format VARCHAR(20) NOT NULL,
+		sign_convention VARCHAR(20) NOT NULL DEFAULT 'negative_is_bill',
+		decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+		category_id VARCHAR,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		updated_at timestamptz NOT NULL DEFAULT (now()),
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (category_id) REFERENCES categorys (id)
+	);
+
+	CREATE TABLE categorization_rules (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		priority INTEGER NOT NULL DEFAULT 0,
+		enabled BOOLEAN NOT NULL DEFAULT true,
+		name_contains VARCHAR(255),
+		amount_min NUMERIC(15, 2),
+		amount_max NUMERIC(15, 2),
+		account_id VARCHAR,
+		category_id VARCHAR,
+		budget_id VARCHAR,
+		rename_to VARCHAR(255),
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		updated_at timestamptz NOT NULL DEFAULT (now()),
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
+
+	CREATE TABLE tags (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(50) NOT NULL,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);
+
+	CREATE TABLE transaction_tags (
+		transaction_id VARCHAR NOT NULL,
+		tag_id VARCHAR NOT NULL,
+		PRIMARY KEY (transaction_id, tag_id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id),
+		FOREIGN KEY (tag_id) REFERENCES tags (id)
+	);
+
+	CREATE TABLE attachments (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		transaction_id VARCHAR NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		content_type VARCHAR(100) NOT NULL,
+		size_bytes BIGINT NOT NULL,
+		storage_key VARCHAR NOT NULL UNIQUE,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id)
+	);
+
+	CREATE TABLE attachment_deletions (
+		storage_key VARCHAR PRIMARY KEY,
+		created_at timestamptz NOT NULL DEFAULT (now())
+	);
+
+	CREATE TABLE fx_rates (
+		base_currency VARCHAR(3) NOT NULL,
+		quote_currency VARCHAR(3) NOT NULL,
+		rate NUMERIC(20, 10) NOT NULL,
+		rate_date DATE NOT NULL,
+		source VARCHAR(50) NOT NULL,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		PRIMARY KEY (base_currency, quote_currency, rate_date)
+	);
+
+	CREATE TABLE duplicate_dismissals (
+		transaction_id VARCHAR NOT NULL,
+		duplicate_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		created_at timestamptz NOT NULL DEFAULT (now()),
+		PRIMARY KEY (transaction_id, duplicate_id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (duplicate_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE audit_events (
+		id VARCHAR PRIMARY KEY,
+		entity_type VARCHAR(30) NOT NULL,
+		entity_id VARCHAR NOT NULL,
+		action VARCHAR(10) NOT NULL,
+		user_id VARCHAR NOT NULL,
+		request_id VARCHAR,
+		before_snapshot JSONB,
+		after_snapshot JSONB,
+		created_at timestamptz NOT NULL DEFAULT (now())
+	);
 	`
 
 	// Split the schema into individual statements
@@ -203,6 +447,14 @@ func SetupPostgreSQLSchema(db *sql.DB) error {
 		}
 	}
 
+	// Triggers have semicolons of their own, so they cannot go through the split above
+	for _, trigger := range postgresTriggers {
+		if _, err := db.Exec(trigger); err != nil {
+			log.Error().Err(err).Str("statement", trigger).Msg("failed to execute PostgreSQL schema statement")
+			return err
+		}
+	}
+
 	log.Info().Msg("PostgreSQL schema setup successfully")
 	return nil
 }
@@ -222,6 +474,7 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		confirmed BOOLEAN DEFAULT 0,
 		ip_address VARCHAR(45),
 		role VARCHAR(20) DEFAULT 'USER',
+		base_currency VARCHAR(3) NOT NULL DEFAULT 'USD',
 		created_at DATETIME NOT NULL DEFAULT (datetime('now'))
 	);
 
@@ -230,8 +483,16 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		name_account VARCHAR(255),
 		bank VARCHAR(255),
 		balance REAL,
+		currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+		account_type VARCHAR(20) NOT NULL DEFAULT 'checking',
+		credit_limit REAL,
+		statement_day INTEGER,
+		due_day INTEGER,
+		interest_rate REAL,
 		user_id VARCHAR NOT NULL,
 		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		archived_at DATETIME,
+		deleted_at DATETIME,
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
@@ -242,6 +503,7 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		color VARCHAR(255) NOT NULL,
 		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
 		user_id VARCHAR NOT NULL,
+		deleted_at DATETIME,
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
@@ -255,18 +517,127 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		FOREIGN KEY (user_id) REFERENCES users (id)
 	);
 
+	CREATE TABLE IF NOT EXISTS reconciliations (
+		id VARCHAR PRIMARY KEY,
+		account_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		statement_date DATE NOT NULL,
+		statement_balance REAL NOT NULL,
+		cleared_balance REAL NOT NULL DEFAULT 0,
+		status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		completed_at DATETIME,
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS saved_views (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		query TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+		user_id VARCHAR NOT NULL,
+		month DATE NOT NULL,
+		currency VARCHAR(3) NOT NULL,
+		assets DECIMAL(15, 2) NOT NULL,
+		liabilities DECIMAL(15, 2) NOT NULL,
+		investments DECIMAL(15, 2) NOT NULL,
+		net_worth DECIMAL(15, 2) NOT NULL,
+		updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		PRIMARY KEY (user_id, month),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS statement_reminders (
+		account_id VARCHAR NOT NULL,
+		closing_date DATE NOT NULL,
+		sent_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		PRIMARY KEY (account_id, closing_date),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		user_id VARCHAR NOT NULL,
+		idempotency_key VARCHAR(255) NOT NULL,
+		request_hash VARCHAR(64) NOT NULL,
+		status_code INTEGER NOT NULL DEFAULT 0,
+		content_type VARCHAR(255) NOT NULL DEFAULT '',
+		response BLOB,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		expires_at DATETIME NOT NULL,
+		PRIMARY KEY (user_id, idempotency_key),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS payees (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS payee_aliases (
+		payee_id VARCHAR NOT NULL,
+		pattern VARCHAR(100) NOT NULL,
+		PRIMARY KEY (payee_id, pattern),
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE CASCADE
+	);
+
 	CREATE TABLE IF NOT EXISTS transactions (
 		id VARCHAR PRIMARY KEY,
 		transaction_name VARCHAR NOT NULL,
 		transaction_description TEXT,
 		amount REAL NOT NULL,
-		type_transation VARCHAR NOT NULL CHECK (type_transation IN ('bill', 'income')),
+		type_transation VARCHAR NOT NULL CHECK (type_transation IN ('bill', 'income', 'transfer')),
 		account_id VARCHAR NOT NULL,
 		user_id VARCHAR NOT NULL,
-		category_id VARCHAR NOT NULL,
+		category_id VARCHAR,
 		budget_id VARCHAR,
+		transfer_id VARCHAR,
+		external_id VARCHAR,
+		currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+		status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cleared', 'reconciled')),
+		reconciliation_id VARCHAR,
+		payee_id VARCHAR,
+		refund_of VARCHAR,
 		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		deleted_at DATETIME,
 		FOREIGN KEY (account_id) REFERENCES account (id),
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE SET NULL,
+		FOREIGN KEY (refund_of) REFERENCES transactions (id) ON DELETE SET NULL,
+		FOREIGN KEY (reconciliation_id) REFERENCES reconciliations (id) ON DELETE SET NULL,
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (category_id) REFERENCES categorys (id),
+		FOREIGN KEY (budget_id) REFERENCES budgets (id),
+		UNIQUE (account_id, external_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS account_daily_balances (
+		account_id VARCHAR NOT NULL,
+		day DATE NOT NULL,
+		balance DECIMAL(15, 2) NOT NULL,
+		PRIMARY KEY (account_id, day),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS transaction_splits (
+		id VARCHAR PRIMARY KEY,
+		transaction_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		category_id VARCHAR NOT NULL,
+		budget_id VARCHAR,
+		amount REAL NOT NULL,
+		description TEXT,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
 		FOREIGN KEY (user_id) REFERENCES users (id),
 		FOREIGN KEY (category_id) REFERENCES categorys (id),
 		FOREIGN KEY (budget_id) REFERENCES budgets (id)
@@ -295,7 +666,9 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
 		updated_at DATETIME,
 		user_id VARCHAR NOT NULL,
-		FOREIGN KEY (user_id) REFERENCES users (id)
+		account_id VARCHAR,
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE SET NULL
 	);
 	CREATE TABLE IF NOT EXISTS recurring_transactions (
 		id VARCHAR PRIMARY KEY,
@@ -326,6 +699,111 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		is_read BOOLEAN DEFAULT 0,
 		created_at DATETIME NOT NULL DEFAULT (datetime('now'))
 	);
+
+	CREATE TABLE IF NOT EXISTS import_mappings (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+		has_header BOOLEAN NOT NULL DEFAULT 1,
+		date_column INTEGER NOT NULL,
+		amount_column INTEGER NOT NULL,
+		description_column INTEGER NOT NULL,
+		date_format VARCHAR(20) NOT NULL,
+		sign_convention VARCHAR(20) NOT NULL DEFAULT 'negative_is_bill',
+		decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+		category_id VARCHAR,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (category_id) REFERENCES categorys (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS categorization_rules (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		priority INTEGER NOT NULL DEFAULT 0,
+		enabled BOOLEAN NOT NULL DEFAULT 1,
+		name_contains VARCHAR(255),
+		amount_min REAL,
+		amount_max REAL,
+		account_id VARCHAR,
+		category_id VARCHAR,
+		budget_id VARCHAR,
+		rename_to VARCHAR(255),
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS tags (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		name VARCHAR(50) NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		UNIQUE (user_id, name),
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS transaction_tags (
+		transaction_id VARCHAR NOT NULL,
+		tag_id VARCHAR NOT NULL,
+		PRIMARY KEY (transaction_id, tag_id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id),
+		FOREIGN KEY (tag_id) REFERENCES tags (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS attachments (
+		id VARCHAR PRIMARY KEY,
+		user_id VARCHAR NOT NULL,
+		transaction_id VARCHAR NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		content_type VARCHAR(100) NOT NULL,
+		size_bytes INTEGER NOT NULL,
+		storage_key VARCHAR NOT NULL UNIQUE,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		FOREIGN KEY (user_id) REFERENCES users (id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id)
+	);
+
+	CREATE TABLE IF NOT EXISTS attachment_deletions (
+		storage_key VARCHAR PRIMARY KEY,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+	);
+
+	CREATE TABLE IF NOT EXISTS fx_rates (
+		base_currency VARCHAR(3) NOT NULL,
+		quote_currency VARCHAR(3) NOT NULL,
+		rate REAL NOT NULL,
+		rate_date DATE NOT NULL,
+		source VARCHAR(50) NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		PRIMARY KEY (base_currency, quote_currency, rate_date)
+	);
+
+	CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+		transaction_id VARCHAR NOT NULL,
+		duplicate_id VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+		PRIMARY KEY (transaction_id, duplicate_id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (duplicate_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id VARCHAR PRIMARY KEY,
+		entity_type VARCHAR(30) NOT NULL,
+		entity_id VARCHAR NOT NULL,
+		action VARCHAR(10) NOT NULL,
+		user_id VARCHAR NOT NULL,
+		request_id VARCHAR,
+		before_snapshot TEXT,
+		after_snapshot TEXT,
+		created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+	);
 	`
 
 	// Split the schema into individual statements
@@ -342,6 +820,48 @@ func SetupSQLiteSchema(db *sql.DB) error {
 		}
 	}
 
+	for _, trigger := range sqliteTriggers {
+		if _, err := db.Exec(trigger); err != nil {
+			log.Error().Err(err).Str("statement", trigger).Msg("failed to execute schema statement")
+			return err
+		}
+	}
+
 	log.Info().Msg("SQLite schema setup successfully")
 	return nil
 }
+
+// Changes to the amount, account, date or deletion of a transaction drop the daily balances of its account from
+// the day of the transaction on, so they are rebuilt on the next read.
+var postgresTriggers = []string{
+	`CREATE OR REPLACE FUNCTION account_daily_balances_invalidate() RETURNS trigger AS $$
+	BEGIN
+		IF TG_OP <> 'INSERT' THEN
+			DELETE FROM account_daily_balances WHERE account_id = OLD.account_id AND day >= (OLD.created_at AT TIME ZONE 'UTC')::date;
+		END IF;
+		IF TG_OP <> 'DELETE' THEN
+			DELETE FROM account_daily_balances WHERE account_id = NEW.account_id AND day >= (NEW.created_at AT TIME ZONE 'UTC')::date;
+		END IF;
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`,
+	`CREATE TRIGGER account_daily_balances_invalidate
+		AFTER INSERT OR DELETE OR UPDATE OF amount, account_id, created_at, deleted_at ON transactions
+		FOR EACH ROW EXECUTE FUNCTION account_daily_balances_invalidate()`,
+}
+
+var sqliteTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS account_daily_balances_insert AFTER INSERT ON transactions
+	BEGIN
+		DELETE FROM account_daily_balances WHERE account_id = NEW.account_id AND day >= DATE(NEW.created_at);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS account_daily_balances_update AFTER UPDATE OF amount, account_id, created_at, deleted_at ON transactions
+	BEGIN
+		DELETE FROM account_daily_balances WHERE account_id = OLD.account_id AND day >= DATE(OLD.created_at);
+		DELETE FROM account_daily_balances WHERE account_id = NEW.account_id AND day >= DATE(NEW.created_at);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS account_daily_balances_delete AFTER DELETE ON transactions
+	BEGIN
+		DELETE FROM account_daily_balances WHERE account_id = OLD.account_id AND day >= DATE(OLD.created_at);
+	END`,
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/investment"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/user"
 )
@@ -20,7 +21,7 @@ func GetNewRandomUser() *user.User {
 func GetNewRandomAccount() *account.Account {
 	balances, _ := faker.RandomInt(1, 10000000)
 
-	return account.NewAccount(float64(balances[0]), faker.UUIDDigit(), faker.Name(), faker.Name())
+	return account.NewAccount(money.FromUnits(int64(balances[0])), faker.UUIDDigit(), faker.Name(), faker.Name())
 }
 
 func GetNewRandomTransaction() *transaction.Transaction {
@@ -34,7 +35,7 @@ func GetNewRandomTransaction() *transaction.Transaction {
 		trasanctionType[rand.Intn(2)],
 		faker.UUIDDigit(),
 		faker.UUIDDigit(),
-		float64(balances[0]))
+		money.FromUnits(int64(balances[0])))
 }
 
 func GetNewRandomCategory() *category.Category {
@@ -44,7 +45,7 @@ func GetNewRandomCategory() *category.Category {
 func GetNewRandomBudget() *budget.Budget {
 	balances, _ := faker.RandomInt(1, 10000000)
 	return budget.NewBudget(faker.UUIDDigit(), faker.UUIDDigit(), faker.UUIDDigit(),
-		float64(balances[0]))
+		money.FromUnits(int64(balances[0])))
 }
 
 func GetNewRandomInvestment() *investment.Investment {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 )
 
 func TestGetNewRandomUser(t *testing.T) {
@@ -29,7 +31,7 @@ func TestGetNewRandomAccount(t *testing.T) {
 	assert.NotEmpty(t, account.Id, "Account ID should not be empty")
 	assert.NotEmpty(t, account.Name, "Account name should not be empty")
 	assert.NotEmpty(t, account.Bank, "Account bank should not be empty")
-	assert.GreaterOrEqual(t, account.InitialBalance, 0.0, "Account balance should be non-negative")
+	assert.GreaterOrEqual(t, account.InitialBalance, money.FromUnits(0), "Account balance should be non-negative")
 
 	// Test that multiple calls generate different accounts
 	account2 := GetNewRandomAccount()
@@ -78,7 +80,7 @@ func TestGetNewRandomBudget(t *testing.T) {
 	assert.NotEmpty(t, budget.Id, "Budget ID should not be empty")
 	assert.NotEmpty(t, budget.CategoryId, "Budget category ID should not be empty")
 	assert.NotEmpty(t, budget.UserId, "Budget user ID should not be empty")
-	assert.Greater(t, budget.Amount, 0.0, "Budget amount should be positive")
+	assert.Greater(t, budget.Amount, money.FromUnits(0), "Budget amount should be positive")
 
 	// Test that multiple calls generate different budgets
 	budget2 := GetNewRandomBudget()
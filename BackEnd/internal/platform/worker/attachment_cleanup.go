@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/attachment"
+	"github.com/rs/zerolog/log"
+)
+
+// AttachmentCleanupWorker removes the files of attachments whose rows were deleted with their transaction or user.
+type AttachmentCleanupWorker struct {
+	attachmentService *attachment.AttachmentService
+	interval          time.Duration
+}
+
+func NewAttachmentCleanupWorker(attachmentService *attachment.AttachmentService, interval time.Duration) *AttachmentCleanupWorker {
+	return &AttachmentCleanupWorker{
+		attachmentService: attachmentService,
+		interval:          interval,
+	}
+}
+
+func (w *AttachmentCleanupWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		log.Info().Msg("Starting Attachment Cleanup Worker")
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Attachment Cleanup Worker")
+				return
+			case <-ticker.C:
+				purged, err := w.attachmentService.PurgeDeleted(ctx)
+				if err != nil {
+					log.Error().Err(err).Int("purged", purged).Msg("Failed to purge deleted attachments")
+				} else if purged > 0 {
+					log.Info().Int("purged", purged).Msg("Deleted attachment files purged")
+				}
+			}
+		}
+	}()
+}
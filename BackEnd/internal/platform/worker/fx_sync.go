@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+	"github.com/rs/zerolog/log"
+)
+
+// FxSyncWorker copies the rates of the configured exchange rate provider into the rate table.
+type FxSyncWorker struct {
+	fxService *fx.FxService
+	interval  time.Duration
+}
+
+func NewFxSyncWorker(fxService *fx.FxService, interval time.Duration) *FxSyncWorker {
+	return &FxSyncWorker{
+		fxService: fxService,
+		interval:  interval,
+	}
+}
+
+// Start syncs once right away, so reports have rates as soon as the server is up, and then on every tick.
+// A zero interval only runs the first sync.
+func (w *FxSyncWorker) Start(ctx context.Context) {
+	go func() {
+		log.Info().Msg("Starting FX Sync Worker")
+		w.sync(ctx)
+		if w.interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping FX Sync Worker")
+				return
+			case <-ticker.C:
+				w.sync(ctx)
+			}
+		}
+	}()
+}
+
+func (w *FxSyncWorker) sync(ctx context.Context) {
+	stored, err := w.fxService.SyncRates(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync exchange rates")
+		return
+	}
+	log.Info().Int("stored", stored).Msg("Exchange rates synced")
+}
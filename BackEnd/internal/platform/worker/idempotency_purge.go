@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/idempotency"
+	"github.com/rs/zerolog/log"
+)
+
+// IdempotencyPurgeWorker removes the idempotency keys whose TTL is over, with the responses stored for them.
+type IdempotencyPurgeWorker struct {
+	idempotencyService *idempotency.IdempotencyService
+	interval           time.Duration
+}
+
+func NewIdempotencyPurgeWorker(idempotencyService *idempotency.IdempotencyService, interval time.Duration) *IdempotencyPurgeWorker {
+	return &IdempotencyPurgeWorker{
+		idempotencyService: idempotencyService,
+		interval:           interval,
+	}
+}
+
+func (w *IdempotencyPurgeWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		log.Info().Msg("Starting Idempotency Purge Worker")
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Idempotency Purge Worker")
+				return
+			case <-ticker.C:
+				purged, err := w.idempotencyService.PurgeExpired(ctx)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to purge expired idempotency keys")
+				} else if purged > 0 {
+					log.Info().Int("purged", purged).Msg("Expired idempotency keys purged")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/networth"
+	"github.com/rs/zerolog/log"
+)
+
+// NetWorthWorker records the net worth of every user for the current month, so the monthly history keeps the
+// value of past months after balances and prices have moved on.
+type NetWorthWorker struct {
+	netWorthService *networth.NetWorthService
+	interval        time.Duration
+}
+
+func NewNetWorthWorker(netWorthService *networth.NetWorthService, interval time.Duration) *NetWorthWorker {
+	return &NetWorthWorker{
+		netWorthService: netWorthService,
+		interval:        interval,
+	}
+}
+
+// Start records once right away, so the current month is there as soon as the server is up, and then on every
+// tick. A zero interval only runs the first record.
+func (w *NetWorthWorker) Start(ctx context.Context) {
+	go func() {
+		log.Info().Msg("Starting Net Worth Worker")
+		w.record(ctx)
+		if w.interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Net Worth Worker")
+				return
+			case <-ticker.C:
+				w.record(ctx)
+			}
+		}
+	}()
+}
+
+func (w *NetWorthWorker) record(ctx context.Context) {
+	recorded, err := w.netWorthService.Record(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to record net worth")
+		return
+	}
+	log.Info().Int("recorded", recorded).Msg("Net worth recorded")
+}
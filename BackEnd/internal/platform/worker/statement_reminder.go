@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/statement"
+	"github.com/rs/zerolog/log"
+)
+
+// StatementReminderWorker reminds users of the credit card statements about to fall due that still have something
+// left to pay.
+type StatementReminderWorker struct {
+	statementService *statement.StatementService
+	interval         time.Duration
+}
+
+func NewStatementReminderWorker(statementService *statement.StatementService, interval time.Duration) *StatementReminderWorker {
+	return &StatementReminderWorker{
+		statementService: statementService,
+		interval:         interval,
+	}
+}
+
+// Start checks once right away and then on every tick. A statement is only reminded once, however often it runs.
+func (w *StatementReminderWorker) Start(ctx context.Context) {
+	go func() {
+		log.Info().Msg("Starting Statement Reminder Worker")
+		w.remind(ctx)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Statement Reminder Worker")
+				return
+			case <-ticker.C:
+				w.remind(ctx)
+			}
+		}
+	}()
+}
+
+func (w *StatementReminderWorker) remind(ctx context.Context) {
+	sent, err := w.statementService.SendReminders(ctx, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to send statement reminders")
+		return
+	}
+	log.Info().Int("sent", sent).Msg("Statement reminders sent")
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/services/trash"
+	"github.com/rs/zerolog/log"
+)
+
+// TrashPurgeWorker permanently removes the transactions, accounts and categories that have been in the trash for
+// longer than the retention period.
+type TrashPurgeWorker struct {
+	trashService *trash.TrashService
+	retention    time.Duration
+	interval     time.Duration
+}
+
+func NewTrashPurgeWorker(trashService *trash.TrashService, retention time.Duration, interval time.Duration) *TrashPurgeWorker {
+	return &TrashPurgeWorker{
+		trashService: trashService,
+		retention:    retention,
+		interval:     interval,
+	}
+}
+
+func (w *TrashPurgeWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		log.Info().Msg("Starting Trash Purge Worker")
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Trash Purge Worker")
+				return
+			case <-ticker.C:
+				purged, err := w.trashService.Purge(ctx, w.retention)
+				if err != nil {
+					log.Error().Err(err).Int("purged", purged).Msg("Failed to purge the trash")
+				} else if purged > 0 {
+					log.Info().Int("purged", purged).Msg("Trashed records purged")
+				}
+			}
+		}
+	}()
+}
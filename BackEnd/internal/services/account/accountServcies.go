@@ -4,11 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/account"
 	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
 
 	"github.com/segmentio/ksuid"
 )
@@ -16,17 +23,27 @@ import (
 // AccountService handles business logic related to account management.
 type AccountService struct {
 	accountRepository accountRepo.AccountRepositoryInterface
+	fxService         *fx.FxService
+	cache             cache.CacheRepository
+	auditService      *auditSvc.AuditService
 }
 
 // NewAccountService creates a new instance of AccountService.
-func NewAccountService(accountRepository accountRepo.AccountRepositoryInterface) *AccountService {
+// fxService may be nil, in which case balances are only reported in the currency of each account.
+// cache is the transaction cache, cleared when deleting an account trashes its transactions; it may be nil.
+// auditService may be nil, in which case changes are not recorded in the audit log.
+func NewAccountService(accountRepository accountRepo.AccountRepositoryInterface, fxService *fx.FxService, cache cache.CacheRepository, auditService *auditSvc.AuditService) *AccountService {
 	return &AccountService{
 		accountRepository: accountRepository,
+		fxService:         fxService,
+		cache:             cache,
+		auditService:      auditService,
 	}
 }
 
-// CreateAccount creates a new account for a user.
-func (s *AccountService) CreateAccount(ctx context.Context, name, bank string, balace float64, userId string) error {
+// CreateAccount creates a new account for a user. The currency is fixed for the life of the account; an empty
+// type creates a checking account.
+func (s *AccountService) CreateAccount(ctx context.Context, name, bank string, balace money.Money, currency string, userId string, accountType string, details account.Details) error {
 	uuid, err := ksuid.NewRandom()
 	if err != nil {
 		return err
@@ -35,14 +52,28 @@ func (s *AccountService) CreateAccount(ctx context.Context, name, bank string, b
 
 	account := account.NewAccount(balace, id, name, bank)
 	account.UserId = userId
-	err = s.accountRepository.Save(ctx, account)
-
-	return err
+	if currency != "" {
+		account.Currency = currency
+	}
+	if accountType != "" {
+		account.Type = accountType
+	}
+	account.Details = details
+	if err := account.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.accountRepository.Save(ctx, account); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, audit.EntityAccount, account.Id, audit.ActionCreate, userId, nil, account)
+	return nil
 }
 
-// FindAll retrieves all accounts for a specific user, including their current balances.
-func (s *AccountService) FindAll(ctx context.Context, userId string) ([]*dto.AccountResponse, error) {
-	accounts, err := s.accountRepository.FindAll(ctx, userId)
+// FindAll retrieves all accounts for a specific user, including their current balances
+// in the currency of each account and in the base currency of the user. Archived accounts are only
+// included when includeArchived is set.
+func (s *AccountService) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*dto.AccountResponse, error) {
+	accounts, err := s.accountRepository.FindAll(ctx, userId, includeArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -50,11 +81,16 @@ func (s *AccountService) FindAll(ctx context.Context, userId string) ([]*dto.Acc
 	if err != nil {
 		return nil, err
 	}
+	converter, err := s.fxService.Converter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
 	var accountResponses []*dto.AccountResponse
 
 	for _, account := range accounts {
 		balance := balances[account.Id]
 		accountResponse := dto.NewAccountResponse(account, balance+account.InitialBalance)
+		accountResponse.Convert(converter)
 		accountResponses = append(accountResponses, accountResponse)
 	}
 
@@ -63,12 +99,25 @@ func (s *AccountService) FindAll(ctx context.Context, userId string) ([]*dto.Acc
 
 // DeleteAccount removes an account by its ID and User ID.
 func (s *AccountService) DeleteAccount(ctx context.Context, id string, userId string) error {
-	err := s.accountRepository.Delete(ctx, id, userId)
-	return err
+	// The account is only loaded to snapshot it for the audit log
+	var current *account.Account
+	if s.auditService != nil {
+		current, _ = s.accountRepository.FindByIdAndUserId(ctx, id, userId)
+	}
+	if err := s.accountRepository.Delete(ctx, id, userId); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	}
+	if current != nil {
+		s.auditService.Record(ctx, audit.EntityAccount, id, audit.ActionDelete, userId, current, nil)
+	}
+	return nil
 }
 
 // Balance retrieves the current balance of an account.
-func (s *AccountService) Balance(ctx context.Context, id string) (float64, error) {
+func (s *AccountService) Balance(ctx context.Context, id string) (money.Money, error) {
 	balance, err := s.accountRepository.Balance(ctx, id)
 	if err != nil {
 		return 0, err
@@ -84,7 +133,7 @@ func (s *AccountService) UpdateAccount(ctx context.Context, id string, updateReq
 	}
 
 	// Check if account exists and belongs to the user
-	_, err := s.accountRepository.FindByIdAndUserId(ctx, id, userId)
+	current, err := s.accountRepository.FindByIdAndUserId(ctx, id, userId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errorhttp.ErrNotFound
@@ -92,8 +141,13 @@ func (s *AccountService) UpdateAccount(ctx context.Context, id string, updateReq
 		return err
 	}
 
+	updated := updateRequest.Apply(current)
+	if err := updated.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
 	// Update the account
-	err = s.accountRepository.Update(ctx, id, updateRequest.Name, updateRequest.Bank, userId)
+	err = s.accountRepository.Update(ctx, updated)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errorhttp.ErrNotFound
@@ -101,6 +155,50 @@ func (s *AccountService) UpdateAccount(ctx context.Context, id string, updateReq
 		return err
 	}
 
+	s.auditService.Record(ctx, audit.EntityAccount, id, audit.ActionUpdate, userId, current, updated)
+	return nil
+}
+
+// ArchiveAccount hides an account from listings, pickers and search and closes it to new transactions. Its
+// history stays in analytics and exports.
+func (s *AccountService) ArchiveAccount(ctx context.Context, id string, userId string) error {
+	return s.setArchived(ctx, id, userId, true)
+}
+
+// UnarchiveAccount brings an archived account back into listings and opens it to new transactions.
+func (s *AccountService) UnarchiveAccount(ctx context.Context, id string, userId string) error {
+	return s.setArchived(ctx, id, userId, false)
+}
+
+func (s *AccountService) setArchived(ctx context.Context, id string, userId string, archived bool) error {
+	current, err := s.accountRepository.FindByIdAndUserId(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if current.IsArchived() == archived {
+		return nil
+	}
+
+	updated := *current
+	if archived {
+		now := time.Now()
+		updated.ArchivedAt = &now
+		err = s.accountRepository.Archive(ctx, id, userId, now)
+	} else {
+		updated.ArchivedAt = nil
+		err = s.accountRepository.Unarchive(ctx, id, userId)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+
+	s.auditService.Record(ctx, audit.EntityAccount, id, audit.ActionUpdate, userId, current, &updated)
 	return nil
 }
 
@@ -119,5 +217,12 @@ func (s *AccountService) FindById(ctx context.Context, id, userId string) (*dto.
 		return nil, err
 	}
 
-	return dto.NewAccountResponse(acc, balance+acc.InitialBalance), nil
+	converter, err := s.fxService.Converter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	accountResponse := dto.NewAccountResponse(acc, balance+acc.InitialBalance)
+	accountResponse.Convert(converter)
+	return accountResponse, nil
 }
@@ -2,10 +2,14 @@ package account
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,8 +23,8 @@ func (m *MockAccountRepository) Save(ctx context.Context, acc *account.Account)
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) FindAll(ctx context.Context, userId string) ([]*account.Account, error) {
-	args := m.Called(ctx, userId)
+func (m *MockAccountRepository) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	args := m.Called(ctx, userId, includeArchived)
 	return args.Get(0).([]*account.Account), args.Error(1)
 }
 
@@ -29,18 +33,18 @@ func (m *MockAccountRepository) Delete(ctx context.Context, id string, userId st
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) Balance(ctx context.Context, id string) (float64, error) {
+func (m *MockAccountRepository) Balance(ctx context.Context, id string) (money.Money, error) {
 	args := m.Called(ctx, id)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Money), args.Error(1)
 }
 
-func (m *MockAccountRepository) Balances(ctx context.Context, userId string) (map[string]float64, error) {
+func (m *MockAccountRepository) Balances(ctx context.Context, userId string) (map[string]money.Money, error) {
 	args := m.Called(ctx, userId)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]money.Money), args.Error(1)
 }
 
-func (m *MockAccountRepository) Update(ctx context.Context, id string, name string, bank string, userId string) error {
-	args := m.Called(ctx, id, name, bank, userId)
+func (m *MockAccountRepository) Update(ctx context.Context, account *account.Account) error {
+	args := m.Called(ctx, account)
 	return args.Error(0)
 }
 
@@ -49,15 +53,43 @@ func (m *MockAccountRepository) FindByIdAndUserId(ctx context.Context, id string
 	return args.Get(0).(*account.Account), args.Error(1)
 }
 
-func (m *MockAccountRepository) Search(ctx context.Context, userId string, query string) ([]*account.Account, error) {
-	args := m.Called(ctx, userId, query)
+func (m *MockAccountRepository) Search(ctx context.Context, userId string, query string, includeArchived bool) ([]*account.Account, error) {
+	args := m.Called(ctx, userId, query, includeArchived)
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Archive(ctx context.Context, id string, userId string, archivedAt time.Time) error {
+	args := m.Called(ctx, id, userId, archivedAt)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) Unarchive(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindTrash(ctx context.Context, userId string) ([]*account.Account, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]*account.Account), args.Error(1)
 }
 
+func (m *MockAccountRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func TestCreateAccount(t *testing.T) {
 	mockRepo := &MockAccountRepository{}
 
-	accountSvc := NewAccountService(mockRepo)
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 
@@ -65,7 +97,7 @@ func TestCreateAccount(t *testing.T) {
 
 	account := utils.GetNewRandomAccount()
 
-	err := accountSvc.CreateAccount(ctx, account.Name, account.Bank, account.InitialBalance, account.Id)
+	err := accountSvc.CreateAccount(ctx, account.Name, account.Bank, account.InitialBalance, account.Currency, account.Id, account.Type, account.Details)
 
 	assert.NoError(t, err, "CreateAccount should not return an error")
 
@@ -77,7 +109,7 @@ func TestDeleteAccount(t *testing.T) {
 
 	mockRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	accountSvc := NewAccountService(mockRepo)
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	id := "testID"
@@ -92,21 +124,83 @@ func TestFindAll(t *testing.T) {
 	mockRepo := &MockAccountRepository{}
 
 	expectedAccounts := []*account.Account{}
-	expectedBalances := make(map[string]float64)
+	expectedBalances := make(map[string]money.Money)
 	for i := 0; i < 10; i++ {
 		acc := utils.GetNewRandomAccount()
 		expectedAccounts = append(expectedAccounts, acc)
-		expectedBalances[acc.Id] = 1000.00
+		expectedBalances[acc.Id] = money.FromUnits(1000)
 	}
 
 	mockRepo.On("Balances", context.Background(), mock.Anything).Return(expectedBalances, nil)
-	mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(expectedAccounts, nil)
+	mockRepo.On("FindAll", mock.Anything, mock.Anything, false).Return(expectedAccounts, nil)
 
-	accountSvc := NewAccountService(mockRepo)
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
-	_, err := accountSvc.FindAll(ctx, "1")
+	_, err := accountSvc.FindAll(ctx, "1", false)
 
 	mockRepo.AssertExpectations(t)
 	assert.NoError(t, err, "FindAll should not return an error")
 }
+
+func TestFindAllCreditCard(t *testing.T) {
+	mockRepo := &MockAccountRepository{}
+
+	limit := money.FromUnits(2000)
+	card := account.NewAccount(money.FromUnits(-100), "acc_card", "Visa", "Bank")
+	card.Type = account.TypeCreditCard
+	card.CreditLimit = &limit
+
+	mockRepo.On("FindAll", mock.Anything, "1", false).Return([]*account.Account{card}, nil)
+	mockRepo.On("Balances", mock.Anything, "1").Return(map[string]money.Money{card.Id: money.FromUnits(-400)}, nil)
+
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
+
+	responses, err := accountSvc.FindAll(context.Background(), "1", false)
+
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.True(t, responses[0].IsLiability)
+	assert.Equal(t, money.FromUnits(-500), responses[0].CurrentBalance)
+	assert.Equal(t, money.FromUnits(1500), *responses[0].AvailableCredit)
+	assert.Equal(t, 25.0, *responses[0].Utilization)
+}
+
+func TestCreateAccountRejectsDetailsOfOtherType(t *testing.T) {
+	mockRepo := &MockAccountRepository{}
+
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
+
+	limit := money.FromUnits(1000)
+	err := accountSvc.CreateAccount(context.Background(), "Savings", "Bank", 0, "EUR", "1", account.TypeSavings, account.Details{CreditLimit: &limit})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestArchiveAccount(t *testing.T) {
+	mockRepo := &MockAccountRepository{}
+
+	open := account.NewAccount(0, "acc_open", "Checking", "Bank")
+	archivedAt := time.Now()
+	closed := account.NewAccount(0, "acc_closed", "Old checking", "Bank")
+	closed.ArchivedAt = &archivedAt
+
+	mockRepo.On("FindByIdAndUserId", mock.Anything, open.Id, "1").Return(open, nil)
+	mockRepo.On("FindByIdAndUserId", mock.Anything, closed.Id, "1").Return(closed, nil)
+	mockRepo.On("FindByIdAndUserId", mock.Anything, "missing", "1").Return((*account.Account)(nil), sql.ErrNoRows)
+	mockRepo.On("Archive", mock.Anything, open.Id, "1", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Unarchive", mock.Anything, closed.Id, "1").Return(nil)
+
+	accountSvc := NewAccountService(mockRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	assert.NoError(t, accountSvc.ArchiveAccount(ctx, open.Id, "1"))
+	assert.NoError(t, accountSvc.UnarchiveAccount(ctx, closed.Id, "1"))
+	assert.ErrorIs(t, accountSvc.ArchiveAccount(ctx, "missing", "1"), errorhttp.ErrNotFound)
+
+	// Archiving an archived account leaves its original date alone
+	assert.NoError(t, accountSvc.ArchiveAccount(ctx, closed.Id, "1"))
+	mockRepo.AssertNumberOfCalls(t, "Archive", 1)
+	mockRepo.AssertExpectations(t)
+}
@@ -3,53 +3,178 @@ package analytics
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/analytics"
+	domainFx "github.com/osmait/gestorDePresupuesto/internal/domain/fx"
 	postgres "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/analytics"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
 )
 
-func NewAnalyticsService(repo *postgres.AnalyticsRepository) *AnalyticsService {
-	return &AnalyticsService{repo: repo}
+// NewAnalyticsService creates a new instance of AnalyticsService.
+// fxService may be nil, in which case amounts in different currencies are added up as they are.
+func NewAnalyticsService(repo *postgres.AnalyticsRepository, fxService *fx.FxService) *AnalyticsService {
+	return &AnalyticsService{repo: repo, fxService: fxService}
 }
 
 type AnalyticsService struct {
-	repo *postgres.AnalyticsRepository
+	repo      *postgres.AnalyticsRepository
+	fxService *fx.FxService
 }
 
+// GetCategoryExpenses returns the spending of every category in the base currency of the user.
+// Amounts in a currency without an exchange rate are listed per currency but left out of the value.
 func (s *AnalyticsService) GetCategoryExpenses(ctx context.Context, userID string) ([]*analytics.CategoryExpense, error) {
 	categoryExpensesRepo, err := s.repo.GetCategoryExpenses(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting category expenses: %w", err)
 	}
+	converter, err := s.fxService.Converter(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading exchange rates: %w", err)
+	}
 
 	var categoryExpenses []*analytics.CategoryExpense
+	totals := make(map[string]domainFx.Totals)
 
 	for _, categoryExpenseRepo := range categoryExpensesRepo {
-		categoryExpenses = append(categoryExpenses, &analytics.CategoryExpense{
-			ID:    categoryExpenseRepo.CategoryName,
-			Label: categoryExpenseRepo.CategoryName,
-			Value: categoryExpenseRepo.TotalAmount,
-			Color: categoryExpenseRepo.CategoryColor,
-		})
+		if _, found := totals[categoryExpenseRepo.CategoryName]; !found {
+			totals[categoryExpenseRepo.CategoryName] = domainFx.Totals{}
+			categoryExpenses = append(categoryExpenses, &analytics.CategoryExpense{
+				ID:       categoryExpenseRepo.CategoryName,
+				Label:    categoryExpenseRepo.CategoryName,
+				Color:    categoryExpenseRepo.CategoryColor,
+				Currency: converter.Base(),
+			})
+		}
+		totals[categoryExpenseRepo.CategoryName].Add(categoryExpenseRepo.Currency, categoryExpenseRepo.TotalAmount)
+	}
+
+	for _, categoryExpense := range categoryExpenses {
+		categoryExpense.ByCurrency, categoryExpense.Value = converter.Breakdown(totals[categoryExpense.ID])
 	}
 
 	return categoryExpenses, nil
 }
 
+// GetTagExpenses returns the spending of every tag of the user, next to the per-category breakdown.
+func (s *AnalyticsService) GetTagExpenses(ctx context.Context, userID string) ([]*analytics.TagExpense, error) {
+	tagExpensesRepo, err := s.repo.GetTagExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag expenses: %w", err)
+	}
+	converter, err := s.fxService.Converter(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading exchange rates: %w", err)
+	}
+
+	var tagExpenses []*analytics.TagExpense
+	byTag := make(map[string]*analytics.TagExpense)
+	totals := make(map[string]domainFx.Totals)
+
+	for _, tagExpenseRepo := range tagExpensesRepo {
+		tagExpense, found := byTag[tagExpenseRepo.TagName]
+		if !found {
+			tagExpense = &analytics.TagExpense{
+				ID:       tagExpenseRepo.TagName,
+				Label:    tagExpenseRepo.TagName,
+				Currency: converter.Base(),
+			}
+			byTag[tagExpenseRepo.TagName] = tagExpense
+			totals[tagExpenseRepo.TagName] = domainFx.Totals{}
+			tagExpenses = append(tagExpenses, tagExpense)
+		}
+		tagExpense.Count += tagExpenseRepo.TransactionCount
+		totals[tagExpenseRepo.TagName].Add(tagExpenseRepo.Currency, tagExpenseRepo.TotalAmount)
+	}
+
+	for _, tagExpense := range tagExpenses {
+		tagExpense.ByCurrency, tagExpense.Value = converter.Breakdown(totals[tagExpense.ID])
+	}
+
+	return tagExpenses, nil
+}
+
+// GetTopPayees returns the payees the user spends the most on, biggest first, at most limit of them.
+// Transactions without a payee are left out.
+func (s *AnalyticsService) GetTopPayees(ctx context.Context, userID string, limit int) ([]*analytics.PayeeExpense, error) {
+	payeeExpensesRepo, err := s.repo.GetPayeeExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payee expenses: %w", err)
+	}
+	converter, err := s.fxService.Converter(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading exchange rates: %w", err)
+	}
+
+	var payeeExpenses []*analytics.PayeeExpense
+	byPayee := make(map[string]*analytics.PayeeExpense)
+	totals := make(map[string]domainFx.Totals)
+
+	for _, payeeExpenseRepo := range payeeExpensesRepo {
+		payeeExpense, found := byPayee[payeeExpenseRepo.PayeeId]
+		if !found {
+			payeeExpense = &analytics.PayeeExpense{
+				ID:       payeeExpenseRepo.PayeeId,
+				Label:    payeeExpenseRepo.PayeeName,
+				Currency: converter.Base(),
+			}
+			byPayee[payeeExpenseRepo.PayeeId] = payeeExpense
+			totals[payeeExpenseRepo.PayeeId] = domainFx.Totals{}
+			payeeExpenses = append(payeeExpenses, payeeExpense)
+		}
+		payeeExpense.Count += payeeExpenseRepo.TransactionCount
+		totals[payeeExpenseRepo.PayeeId].Add(payeeExpenseRepo.Currency, payeeExpenseRepo.TotalAmount)
+	}
+
+	for _, payeeExpense := range payeeExpenses {
+		payeeExpense.ByCurrency, payeeExpense.Value = converter.Breakdown(totals[payeeExpense.ID])
+	}
+
+	// Expenses are negative, so the biggest spending has the lowest value. The sort is stable to keep
+	// payees with the same spending in alphabetical order.
+	sort.SliceStable(payeeExpenses, func(i, j int) bool {
+		return payeeExpenses[i].Value < payeeExpenses[j].Value
+	})
+	if limit > 0 && len(payeeExpenses) > limit {
+		payeeExpenses = payeeExpenses[:limit]
+	}
+
+	return payeeExpenses, nil
+}
+
+// GetMonthlySummary returns the income and expenses of every month in the base currency of the user.
 func (s *AnalyticsService) GetMonthlySummary(ctx context.Context, userID string) ([]*analytics.MonthlySummary, error) {
 	monthlySummariesRepo, err := s.repo.GetMonthlySummary(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting monthly summary: %w", err)
 	}
+	converter, err := s.fxService.Converter(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading exchange rates: %w", err)
+	}
 
 	var monthlySummaries []*analytics.MonthlySummary
+	incomes := make(map[string]domainFx.Totals)
+	expenses := make(map[string]domainFx.Totals)
 
 	for _, monthlySummaryRepo := range monthlySummariesRepo {
-		monthlySummaries = append(monthlySummaries, &analytics.MonthlySummary{
-			Month:    fmt.Sprintf("%d-%02d", monthlySummaryRepo.Year, monthlySummaryRepo.Month),
-			Income:   monthlySummaryRepo.TotalIncome,
-			Expenses: monthlySummaryRepo.TotalBill,
-		})
+		month := fmt.Sprintf("%d-%02d", monthlySummaryRepo.Year, monthlySummaryRepo.Month)
+		if _, found := incomes[month]; !found {
+			incomes[month] = domainFx.Totals{}
+			expenses[month] = domainFx.Totals{}
+			monthlySummaries = append(monthlySummaries, &analytics.MonthlySummary{
+				Month:    month,
+				Currency: converter.Base(),
+			})
+		}
+		incomes[month].Add(monthlySummaryRepo.Currency, monthlySummaryRepo.TotalIncome)
+		expenses[month].Add(monthlySummaryRepo.Currency, monthlySummaryRepo.TotalBill)
+	}
+
+	for _, monthlySummary := range monthlySummaries {
+		monthlySummary.IncomeByCurrency, monthlySummary.Income = converter.Breakdown(incomes[monthlySummary.Month])
+		monthlySummary.ExpensesByCurrency, monthlySummary.Expenses = converter.Breakdown(expenses[monthlySummary.Month])
 	}
 
 	return monthlySummaries, nil
@@ -0,0 +1,246 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/storage/blob"
+	attachmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/attachment"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+// purgeBatchSize bounds the number of blobs removed per PurgeDeleted round.
+const purgeBatchSize = 100
+
+// maxFileNameLength matches the file_name column.
+const maxFileNameLength = 255
+
+// AttachmentService stores invoices and receipts for transactions. Files go to the blob store and their metadata
+// to the database; the type of a file is detected from its content, never taken from the client.
+type AttachmentService struct {
+	attachmentRepository  attachmentRepo.AttachmentRepoInterface
+	transactionRepository transactionRepo.TransactionRepositoryInterface
+	store                 blob.Store
+	maxFileSize           int64
+	userQuota             int64
+}
+
+// NewAttachmentService creates a new instance of AttachmentService. maxFileSize and userQuota are in bytes.
+func NewAttachmentService(attachmentRepository attachmentRepo.AttachmentRepoInterface, transactionRepository transactionRepo.TransactionRepositoryInterface, store blob.Store, maxFileSize int64, userQuota int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepository:  attachmentRepository,
+		transactionRepository: transactionRepository,
+		store:                 store,
+		maxFileSize:           maxFileSize,
+		userQuota:             userQuota,
+	}
+}
+
+// MaxFileSize returns the largest file accepted, in bytes.
+func (s *AttachmentService) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+// Upload attaches a file to a transaction of the user. size is the length announced by the client; the stored
+// file is still cut off and rejected if it turns out to be larger than the limit.
+func (s *AttachmentService) Upload(ctx context.Context, userId, transactionId, fileName string, size int64, content io.Reader) (*dto.AttachmentResponse, error) {
+	if _, err := s.transactionRepository.FindById(ctx, transactionId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+	if size > s.maxFileSize {
+		return nil, fmt.Errorf("%w: the file must be smaller than %d bytes", errorhttp.ErrBadRequest, s.maxFileSize)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	if n == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", errorhttp.ErrBadRequest)
+	}
+	contentType := detectContentType(head)
+	if !attachment.AllowedContentTypes[contentType] {
+		return nil, fmt.Errorf("%w: files of type %s cannot be attached", errorhttp.ErrBadRequest, contentType)
+	}
+
+	used, err := s.attachmentRepository.TotalSize(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if used+size > s.userQuota {
+		return nil, fmt.Errorf("%w: the attachment quota of %d bytes is exhausted", errorhttp.ErrBadRequest, s.userQuota)
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	attachment := attachment.NewAttachment(uuid.String(), userId, transactionId, sanitizeFileName(fileName), contentType, 0)
+
+	counter := &countingReader{reader: io.LimitReader(io.MultiReader(bytes.NewReader(head), content), s.maxFileSize+1)}
+	if err := s.store.Put(ctx, attachment.StorageKey, counter); err != nil {
+		return nil, err
+	}
+	attachment.Size = counter.count
+	if attachment.Size > s.maxFileSize || used+attachment.Size > s.userQuota {
+		s.deleteBlob(ctx, attachment.StorageKey)
+		return nil, fmt.Errorf("%w: the file exceeds the size limit or the attachment quota", errorhttp.ErrBadRequest)
+	}
+
+	if err := s.attachmentRepository.Save(ctx, attachment); err != nil {
+		s.deleteBlob(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return dto.NewAttachmentResponse(attachment), nil
+}
+
+// FindByTransaction lists the attachments of a transaction of the user.
+func (s *AttachmentService) FindByTransaction(ctx context.Context, userId, transactionId string) ([]*dto.AttachmentResponse, error) {
+	attachments, err := s.attachmentRepository.FindByTransaction(ctx, transactionId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentResponses := make([]*dto.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentResponses = append(attachmentResponses, dto.NewAttachmentResponse(attachment))
+	}
+	return attachmentResponses, nil
+}
+
+// Download returns the metadata and the content of an attachment. The caller must close the reader.
+func (s *AttachmentService) Download(ctx context.Context, userId, id string) (*attachment.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errorhttp.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			log.Error().Str("attachment_id", id).Msg("attachment file missing from the blob store")
+			return nil, nil, errorhttp.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment and its file.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, userId, id string) error {
+	attachment, err := s.attachmentRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if err := s.attachmentRepository.Delete(ctx, id, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+
+	// The blob is queued for deletion already; removing it now just saves the worker a round.
+	if err := s.store.Delete(ctx, attachment.StorageKey); err == nil {
+		if err := s.attachmentRepository.ClearPendingDeletions(ctx, []string{attachment.StorageKey}); err != nil {
+			log.Error().Err(err).Str("storage_key", attachment.StorageKey).Msg("failed to clear attachment deletion")
+		}
+	}
+	return nil
+}
+
+// PurgeDeleted removes the files of attachments deleted along with their transaction or their demo user.
+// It returns the number of files removed.
+func (s *AttachmentService) PurgeDeleted(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		storageKeys, err := s.attachmentRepository.FindPendingDeletions(ctx, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(storageKeys) == 0 {
+			return purged, nil
+		}
+
+		for _, storageKey := range storageKeys {
+			if err := s.store.Delete(ctx, storageKey); err != nil {
+				return purged, fmt.Errorf("failed to delete attachment file %s: %w", storageKey, err)
+			}
+		}
+		if err := s.attachmentRepository.ClearPendingDeletions(ctx, storageKeys); err != nil {
+			return purged, err
+		}
+		purged += len(storageKeys)
+	}
+}
+
+func (s *AttachmentService) deleteBlob(ctx context.Context, storageKey string) {
+	if err := s.store.Delete(ctx, storageKey); err != nil {
+		log.Error().Err(err).Str("storage_key", storageKey).Msg("failed to delete attachment file")
+	}
+}
+
+// detectContentType sniffs the MIME type of a file, without parameters such as the charset.
+func detectContentType(head []byte) string {
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// sanitizeFileName keeps the base name of an uploaded file without control characters.
+func sanitizeFileName(fileName string) string {
+	fileName = filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, fileName)
+	if fileName == "." || fileName == "/" || strings.TrimSpace(fileName) == "" {
+		fileName = "attachment"
+	}
+	if runes := []rune(fileName); len(runes) > maxFileNameLength {
+		fileName = string(runes[len(runes)-maxFileNameLength:])
+	}
+	return fileName
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
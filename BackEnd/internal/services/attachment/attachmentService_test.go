@@ -0,0 +1,161 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/attachment"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/storage/blob"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Save(ctx context.Context, attachment *attachment.Attachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) FindById(ctx context.Context, id string, userId string) (*attachment.Attachment, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByTransaction(ctx context.Context, transactionId string, userId string) ([]*attachment.Attachment, error) {
+	args := m.Called(ctx, transactionId, userId)
+	return args.Get(0).([]*attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) TotalSize(ctx context.Context, userId string) (int64, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) FindPendingDeletions(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) ClearPendingDeletions(ctx context.Context, storageKeys []string) error {
+	args := m.Called(ctx, storageKeys)
+	return args.Error(0)
+}
+
+type MockTransactionRepository struct {
+	mock.Mock
+	transactionRepo.TransactionRepositoryInterface
+}
+
+func (m *MockTransactionRepository) FindById(ctx context.Context, id string, userId string) (*transaction.Transaction, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+// pdfContent starts with the PDF signature so content sniffing recognises it.
+var pdfContent = []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+
+func newTestService(t *testing.T, maxFileSize, userQuota int64) (*AttachmentService, *MockAttachmentRepository, *MockTransactionRepository, blob.Store) {
+	store, err := blob.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+
+	mockRepo := &MockAttachmentRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockTransactionRepo.On("FindById", mock.Anything, "txn_1", "user_1").Return(&transaction.Transaction{Id: "txn_1"}, nil)
+	mockTransactionRepo.On("FindById", mock.Anything, "txn_missing", "user_1").Return((*transaction.Transaction)(nil), sql.ErrNoRows)
+	return NewAttachmentService(mockRepo, mockTransactionRepo, store, maxFileSize, userQuota), mockRepo, mockTransactionRepo, store
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	s, mockRepo, _, store := newTestService(t, 1024, 4096)
+	ctx := context.Background()
+
+	mockRepo.On("TotalSize", mock.Anything, "user_1").Return(int64(0), nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(a *attachment.Attachment) bool {
+		return a.ContentType == "application/pdf" && a.Size == int64(len(pdfContent)) && a.FileName == "invoice.pdf"
+	})).Return(nil)
+
+	response, err := s.Upload(ctx, "user_1", "txn_1", "../../invoice.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", response.ContentType)
+
+	saved := mockRepo.Calls[1].Arguments.Get(1).(*attachment.Attachment)
+	content, err := store.Get(ctx, saved.StorageKey)
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(content)
+	_ = content.Close()
+	assert.Equal(t, pdfContent, stored)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAttachmentService_Upload_Rejected(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		s, _, _, _ := newTestService(t, 1024, 4096)
+		_, err := s.Upload(ctx, "user_1", "txn_missing", "invoice.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+		assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		s, _, _, _ := newTestService(t, 1024, 4096)
+		script := []byte("<html><script>alert(1)</script></html>")
+		_, err := s.Upload(ctx, "user_1", "txn_1", "invoice.pdf", int64(len(script)), bytes.NewReader(script))
+		assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	})
+
+	t.Run("announced size above the limit", func(t *testing.T) {
+		s, _, _, _ := newTestService(t, 16, 4096)
+		_, err := s.Upload(ctx, "user_1", "txn_1", "invoice.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+		assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	})
+
+	t.Run("content larger than announced", func(t *testing.T) {
+		s, mockRepo, _, _ := newTestService(t, 16, 4096)
+		mockRepo.On("TotalSize", mock.Anything, "user_1").Return(int64(0), nil)
+		_, err := s.Upload(ctx, "user_1", "txn_1", "invoice.pdf", 10, bytes.NewReader(pdfContent))
+		assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("quota exhausted", func(t *testing.T) {
+		s, mockRepo, _, _ := newTestService(t, 1024, 4096)
+		mockRepo.On("TotalSize", mock.Anything, "user_1").Return(int64(4090), nil)
+		_, err := s.Upload(ctx, "user_1", "txn_1", "invoice.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+		assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttachmentService_PurgeDeleted(t *testing.T) {
+	s, mockRepo, _, store := newTestService(t, 1024, 4096)
+	ctx := context.Background()
+
+	key := attachment.StorageKey("user_1", "txn_1", "att_1")
+	assert.NoError(t, store.Put(ctx, key, bytes.NewReader(pdfContent)))
+
+	mockRepo.On("FindPendingDeletions", mock.Anything, purgeBatchSize).Return([]string{key}, nil).Once()
+	mockRepo.On("FindPendingDeletions", mock.Anything, purgeBatchSize).Return([]string{}, nil).Once()
+	mockRepo.On("ClearPendingDeletions", mock.Anything, []string{key}).Return(nil)
+
+	purged, err := s.PurgeDeleted(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
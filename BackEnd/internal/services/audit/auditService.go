@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	auditRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// AuditService keeps the append-only change history of the financial records. The services of the audited records
+// call Record after every change they commit.
+type AuditService struct {
+	auditRepository auditRepo.AuditRepoInterface
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(auditRepository auditRepo.AuditRepoInterface) *AuditService {
+	return &AuditService{
+		auditRepository: auditRepository,
+	}
+}
+
+// Record appends an event for a change that was already committed, taking the request id from the context. A
+// failure to record is logged rather than returned, so it never undoes the change. Record is a no-op on a nil
+// service, which lets the audited services run without an audit log.
+func (s *AuditService) Record(ctx context.Context, entityType, entityId, action, userId string, before, after any) {
+	if s == nil {
+		return
+	}
+	requestId, _ := ctx.Value(apperrors.ContextKeyRequestID).(string)
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		log.Error().Err(err).Str("entity_type", entityType).Str("entity_id", entityId).Msg("failed to record audit event")
+		return
+	}
+	event, err := audit.NewEvent(uuid.String(), entityType, entityId, action, userId, requestId, before, after)
+	if err != nil {
+		log.Error().Err(err).Str("entity_type", entityType).Str("entity_id", entityId).Msg("failed to record audit event")
+		return
+	}
+	if err := s.auditRepository.Save(ctx, event); err != nil {
+		log.Error().Err(err).Str("entity_type", entityType).Str("entity_id", entityId).Msg("failed to record audit event")
+	}
+}
+
+// FindHistory returns the changes the user made to one record, oldest first.
+func (s *AuditService) FindHistory(ctx context.Context, entityType string, entityId string, userId string) ([]*dto.AuditEventResponse, error) {
+	if !audit.IsEntityType(entityType) {
+		return nil, fmt.Errorf("%w: unknown entity type %q", errorhttp.ErrBadRequest, entityType)
+	}
+	events, err := s.auditRepository.FindByEntity(ctx, entityType, entityId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return toResponses(events), nil
+}
+
+// FindEvents returns the events of every user matching the filter, newest first.
+func (s *AuditService) FindEvents(ctx context.Context, filter *dto.AuditFilter) ([]*dto.AuditEventResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	events, err := s.auditRepository.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return toResponses(events), nil
+}
+
+func toResponses(events []*audit.Event) []*dto.AuditEventResponse {
+	responses := make([]*dto.AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, dto.NewAuditEventResponse(event))
+	}
+	return responses
+}
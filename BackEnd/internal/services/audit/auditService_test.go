@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Save(ctx context.Context, event *audit.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) FindByEntity(ctx context.Context, entityType string, entityId string, userId string) ([]*audit.Event, error) {
+	args := m.Called(ctx, entityType, entityId, userId)
+	return args.Get(0).([]*audit.Event), args.Error(1)
+}
+
+func (m *MockAuditRepository) Find(ctx context.Context, filter *dto.AuditFilter) ([]*audit.Event, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*audit.Event), args.Error(1)
+}
+
+func TestRecord(t *testing.T) {
+	mockRepo := &MockAuditRepository{}
+	s := NewAuditService(mockRepo)
+	ctx := context.WithValue(context.Background(), apperrors.ContextKeyRequestID, "req_1")
+
+	mockRepo.On("Save", ctx, mock.MatchedBy(func(event *audit.Event) bool {
+		return event.Id != "" && event.EntityType == audit.EntityAccount && event.EntityId == "acc_1" &&
+			event.Action == audit.ActionUpdate && event.UserId == "user_1" && event.RequestId == "req_1" &&
+			string(event.Before) == `{"name":"Old"}` && string(event.After) == `{"name":"New"}`
+	})).Return(nil).Once()
+	s.Record(ctx, audit.EntityAccount, "acc_1", audit.ActionUpdate, "user_1", map[string]string{"name": "Old"}, map[string]string{"name": "New"})
+
+	// A failure to record never reaches the caller
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+	s.Record(context.Background(), audit.EntityAccount, "acc_1", audit.ActionDelete, "user_1", map[string]string{"name": "New"}, nil)
+	mockRepo.AssertExpectations(t)
+
+	// Services without an audit log keep working
+	var disabled *AuditService
+	disabled.Record(ctx, audit.EntityAccount, "acc_1", audit.ActionCreate, "user_1", nil, nil)
+}
+
+func TestFindHistory(t *testing.T) {
+	mockRepo := &MockAuditRepository{}
+	s := NewAuditService(mockRepo)
+	ctx := context.Background()
+
+	_, err := s.FindHistory(ctx, "wallet", "acc_1", "user_1")
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	event, err := audit.NewEvent("evt_1", audit.EntityAccount, "acc_1", audit.ActionCreate, "user_1", "", nil, map[string]string{"name": "New"})
+	assert.NoError(t, err)
+	mockRepo.On("FindByEntity", ctx, audit.EntityAccount, "acc_1", "user_1").Return([]*audit.Event{event}, nil)
+
+	history, err := s.FindHistory(ctx, audit.EntityAccount, "acc_1", "user_1")
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, "evt_1", history[0].Id)
+		assert.JSONEq(t, `{"name":"New"}`, string(history[0].After))
+	}
+}
+
+func TestFindEvents_InvalidFilter(t *testing.T) {
+	s := NewAuditService(&MockAuditRepository{})
+	filter := dto.NewAuditFilter()
+	filter.Action = "rename"
+
+	_, err := s.FindEvents(context.Background(), filter)
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+}
@@ -10,6 +10,7 @@ import (
 	authRequest "github.com/osmait/gestorDePresupuesto/internal/domain/auth"
 	budgetDomain "github.com/osmait/gestorDePresupuesto/internal/domain/budget"
 	categoryDomain "github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	transactionDomain "github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	userDomain "github.com/osmait/gestorDePresupuesto/internal/domain/user"
 	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
@@ -104,7 +105,7 @@ func (a *AuthService) CreateDemoUser(ctx context.Context, ip string) (*string, e
 
 	// 2. Create Account
 	accountID := uuid.New().String()
-	account := accountDomain.NewAccount(1000.0, accountID, "Cuenta Demo", "Banco Demo")
+	account := accountDomain.NewAccount(money.FromUnits(1000), accountID, "Cuenta Demo", "Banco Demo")
 	account.UserId = userID
 	if err := a.accountRepo.Save(ctx, account); err != nil {
 		log.Error().Err(err).Msg("failed to save demo account")
@@ -140,7 +141,7 @@ func (a *AuthService) CreateDemoUser(ctx context.Context, ip string) (*string, e
 
 	// 4. Create Budget (Optional)
 	budgetID := uuid.New().String()
-	budget := budgetDomain.NewBudget(budgetID, foodCatID, userID, 500.0)
+	budget := budgetDomain.NewBudget(budgetID, foodCatID, userID, money.FromUnits(500))
 	if err := a.budgetRepo.Save(ctx, budget); err != nil {
 		log.Error().Err(err).Msg("failed to save demo budget")
 	}
@@ -149,7 +150,7 @@ func (a *AuthService) CreateDemoUser(ctx context.Context, ip string) (*string, e
 	now := time.Now()
 
 	// Income: Salary (Today)
-	tx1 := transactionDomain.NewTransaction(uuid.New().String(), "Nómina Mensual", "Ingreso del mes", "income", accountID, salaryCatID, 3000.0)
+	tx1 := transactionDomain.NewTransaction(uuid.New().String(), "Nómina Mensual", "Ingreso del mes", "income", accountID, salaryCatID, money.FromUnits(3000))
 	tx1.UserId = userID
 	tx1.CreatedAt = now
 	if err := a.transactionRepo.Save(ctx, tx1); err != nil {
@@ -157,7 +158,7 @@ func (a *AuthService) CreateDemoUser(ctx context.Context, ip string) (*string, e
 	}
 
 	// Expense: Supermarket (2 days ago)
-	tx2 := transactionDomain.NewTransaction(uuid.New().String(), "Supermercado Semanal", "Compra grande", "bill", accountID, foodCatID, 150.0)
+	tx2 := transactionDomain.NewTransaction(uuid.New().String(), "Supermercado Semanal", "Compra grande", "bill", accountID, foodCatID, money.FromUnits(150))
 	tx2.UserId = userID
 	tx2.CreatedAt = now.AddDate(0, 0, -2)
 	tx2.BudgetId = budgetID
@@ -166,7 +167,7 @@ func (a *AuthService) CreateDemoUser(ctx context.Context, ip string) (*string, e
 	}
 
 	// Expense: Transport (5 days ago)
-	tx3 := transactionDomain.NewTransaction(uuid.New().String(), "Uber a casa", "Salida tarde", "bill", accountID, transportCatID, 25.50)
+	tx3 := transactionDomain.NewTransaction(uuid.New().String(), "Uber a casa", "Salida tarde", "bill", accountID, transportCatID, money.Money(2550))
 	tx3.UserId = userID
 	tx3.CreatedAt = now.AddDate(0, 0, -5)
 	_ = a.transactionRepo.Save(ctx, tx3)
@@ -0,0 +1,128 @@
+package balance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	balanceRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/balance"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// BalanceService answers balance history and balance-at-date queries from the stored daily balances. Missing
+// days are built on demand from the last stored one, so only the transactions after it are read.
+type BalanceService struct {
+	balanceRepository balanceRepo.BalanceRepositoryInterface
+	accountRepository accountRepo.AccountRepositoryInterface
+}
+
+func NewBalanceService(balanceRepository balanceRepo.BalanceRepositoryInterface, accountRepository accountRepo.AccountRepositoryInterface) *BalanceService {
+	return &BalanceService{
+		balanceRepository: balanceRepository,
+		accountRepository: accountRepository,
+	}
+}
+
+// History returns the balance of the account at the end of every day, week or month between from and to.
+func (s *BalanceService) History(ctx context.Context, accountId string, userId string, from time.Time, to time.Time, interval string) ([]account.BalancePoint, error) {
+	acc, err := s.findAccount(ctx, accountId, userId)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.dailyBalances(ctx, accountId, account.Day(from), account.Day(to))
+	if err != nil {
+		return nil, err
+	}
+	return account.Sample(days, interval, acc.InitialBalance), nil
+}
+
+// BalanceAt returns the balance of the account at the end of the day of date.
+func (s *BalanceService) BalanceAt(ctx context.Context, accountId string, userId string, date time.Time) (account.BalancePoint, error) {
+	acc, err := s.findAccount(ctx, accountId, userId)
+	if err != nil {
+		return account.BalancePoint{}, err
+	}
+	day := account.Day(date)
+	days, err := s.dailyBalances(ctx, accountId, day, day)
+	if err != nil {
+		return account.BalancePoint{}, err
+	}
+	return account.Sample(days, account.IntervalDay, acc.InitialBalance)[0], nil
+}
+
+func (s *BalanceService) findAccount(ctx context.Context, accountId string, userId string) (*account.Account, error) {
+	acc, err := s.accountRepository.FindByIdAndUserId(ctx, accountId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorhttp.ErrNotFound
+	}
+	return acc, err
+}
+
+// dailyBalances returns one balance per day between from and to. Stored days start at the first transaction of
+// the account, so the days before it have a zero balance.
+func (s *BalanceService) dailyBalances(ctx context.Context, accountId string, from time.Time, to time.Time) ([]account.DailyBalance, error) {
+	if err := s.extend(ctx, accountId, to); err != nil {
+		return nil, err
+	}
+	stored, err := s.balanceRepository.FindRange(ctx, accountId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]account.DailyBalance, 0, int(to.Sub(from).Hours()/24)+1)
+	next := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if next < len(stored) && stored[next].Day.Equal(day) {
+			days = append(days, stored[next])
+			next++
+			continue
+		}
+		days = append(days, account.DailyBalance{AccountId: accountId, Day: day})
+	}
+	return days, nil
+}
+
+// extend stores the daily balances from the day after the last stored one, or from the first transaction of the
+// account, up to through.
+func (s *BalanceService) extend(ctx context.Context, accountId string, through time.Time) error {
+	var start time.Time
+	var balance money.Money
+
+	last, err := s.balanceRepository.Last(ctx, accountId)
+	switch {
+	case err == nil:
+		if !last.Day.Before(through) {
+			return nil
+		}
+		start = last.Day.AddDate(0, 0, 1)
+		balance = last.Balance
+	case errors.Is(err, sql.ErrNoRows):
+		start, err = s.balanceRepository.FirstTransactionDay(ctx, accountId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if start.After(through) {
+			return nil
+		}
+	default:
+		return err
+	}
+
+	changes, err := s.balanceRepository.DailyChanges(ctx, accountId, start, through)
+	if err != nil {
+		return err
+	}
+	var balances []account.DailyBalance
+	for day := start; !day.After(through); day = day.AddDate(0, 0, 1) {
+		balance += changes[day]
+		balances = append(balances, account.DailyBalance{AccountId: accountId, Day: day, Balance: balance})
+	}
+	return s.balanceRepository.Save(ctx, balances)
+}
@@ -0,0 +1,161 @@
+package balance
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryAccounts struct {
+	accountRepo.AccountRepositoryInterface
+	accounts map[string]*account.Account
+}
+
+func (m *memoryAccounts) FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error) {
+	acc, ok := m.accounts[id]
+	if !ok || acc.UserId != userId {
+		return nil, sql.ErrNoRows
+	}
+	return acc, nil
+}
+
+// memoryBalances keeps the transactions as amounts per day and records from which day they were last read
+type memoryBalances struct {
+	changes  map[time.Time]money.Money
+	stored   map[time.Time]money.Money
+	readFrom []time.Time
+}
+
+func (m *memoryBalances) Last(ctx context.Context, accountId string) (*account.DailyBalance, error) {
+	var last *account.DailyBalance
+	for day, balance := range m.stored {
+		if last == nil || day.After(last.Day) {
+			last = &account.DailyBalance{AccountId: accountId, Day: day, Balance: balance}
+		}
+	}
+	if last == nil {
+		return nil, sql.ErrNoRows
+	}
+	return last, nil
+}
+
+func (m *memoryBalances) FindRange(ctx context.Context, accountId string, from time.Time, to time.Time) ([]account.DailyBalance, error) {
+	var balances []account.DailyBalance
+	for day, balance := range m.stored {
+		if !day.Before(from) && !day.After(to) {
+			balances = append(balances, account.DailyBalance{AccountId: accountId, Day: day, Balance: balance})
+		}
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Day.Before(balances[j].Day) })
+	return balances, nil
+}
+
+func (m *memoryBalances) Save(ctx context.Context, balances []account.DailyBalance) error {
+	for _, balance := range balances {
+		m.stored[balance.Day] = balance.Balance
+	}
+	return nil
+}
+
+func (m *memoryBalances) FirstTransactionDay(ctx context.Context, accountId string) (time.Time, error) {
+	var first time.Time
+	for day := range m.changes {
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+	if first.IsZero() {
+		return first, sql.ErrNoRows
+	}
+	return first, nil
+}
+
+func (m *memoryBalances) DailyChanges(ctx context.Context, accountId string, from time.Time, to time.Time) (map[time.Time]money.Money, error) {
+	m.readFrom = append(m.readFrom, from)
+	changes := make(map[time.Time]money.Money)
+	for day, amount := range m.changes {
+		if !day.Before(from) && !day.After(to) {
+			changes[day] = amount
+		}
+	}
+	return changes, nil
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestService() (*BalanceService, *memoryBalances) {
+	balances := &memoryBalances{
+		changes: map[time.Time]money.Money{
+			day(time.March, 3):  money.FromUnits(100),
+			day(time.March, 10): money.FromUnits(-40),
+			day(time.April, 2):  money.FromUnits(15),
+		},
+		stored: map[time.Time]money.Money{},
+	}
+	acc := account.NewAccount(money.FromUnits(1000), "acc_1", "Checking", "Bank")
+	acc.UserId = "user_1"
+	accounts := &memoryAccounts{accounts: map[string]*account.Account{acc.Id: acc}}
+	return NewBalanceService(balances, accounts), balances
+}
+
+func TestBalanceAt(t *testing.T) {
+	service, balances := newTestService()
+	ctx := context.Background()
+
+	point, err := service.BalanceAt(ctx, "acc_1", "user_1", day(time.March, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, account.BalancePoint{Date: "2024-03-01", Balance: money.FromUnits(1000)}, point)
+
+	point, err = service.BalanceAt(ctx, "acc_1", "user_1", day(time.March, 15).Add(20*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, account.BalancePoint{Date: "2024-03-15", Balance: money.FromUnits(1060)}, point)
+
+	// Later days only read the transactions after the last stored day
+	point, err = service.BalanceAt(ctx, "acc_1", "user_1", day(time.April, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(1075), point.Balance)
+	assert.Equal(t, []time.Time{day(time.March, 3), day(time.March, 16)}, balances.readFrom)
+
+	_, err = service.BalanceAt(ctx, "acc_1", "user_2", day(time.April, 5))
+	assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+}
+
+func TestHistory(t *testing.T) {
+	service, _ := newTestService()
+	ctx := context.Background()
+
+	points, err := service.History(ctx, "acc_1", "user_1", day(time.March, 2), day(time.March, 4), account.IntervalDay)
+	assert.NoError(t, err)
+	assert.Equal(t, []account.BalancePoint{
+		{Date: "2024-03-02", Balance: money.FromUnits(1000)},
+		{Date: "2024-03-03", Balance: money.FromUnits(1100)},
+		{Date: "2024-03-04", Balance: money.FromUnits(1100)},
+	}, points)
+
+	points, err = service.History(ctx, "acc_1", "user_1", day(time.February, 20), day(time.April, 10), account.IntervalMonth)
+	assert.NoError(t, err)
+	assert.Equal(t, []account.BalancePoint{
+		{Date: "2024-02-29", Balance: money.FromUnits(1000)},
+		{Date: "2024-03-31", Balance: money.FromUnits(1060)},
+		{Date: "2024-04-10", Balance: money.FromUnits(1075)},
+	}, points)
+
+	// Weeks start on Monday: March 4th and 11th 2024
+	points, err = service.History(ctx, "acc_1", "user_1", day(time.March, 1), day(time.March, 12), account.IntervalWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, []account.BalancePoint{
+		{Date: "2024-03-03", Balance: money.FromUnits(1100)},
+		{Date: "2024-03-10", Balance: money.FromUnits(1060)},
+		{Date: "2024-03-12", Balance: money.FromUnits(1060)},
+	}, points)
+}
@@ -3,10 +3,12 @@ package budget
 import (
 	"context"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/budget"
 	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
 	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/segmentio/ksuid"
 )
@@ -15,13 +17,16 @@ import (
 type BudgetServices struct {
 	repository      budgetRepo.BudgetRepoInterface
 	transactionRepo transactionRepo.TransactionRepositoryInterface
+	auditService    *auditSvc.AuditService
 }
 
 // NewBudgetServices creates a new instance of BudgetServices.
-func NewBudgetServices(repo budgetRepo.BudgetRepoInterface, transactionRepo transactionRepo.TransactionRepositoryInterface) *BudgetServices {
+// auditService may be nil, in which case changes are not recorded in the audit log.
+func NewBudgetServices(repo budgetRepo.BudgetRepoInterface, transactionRepo transactionRepo.TransactionRepositoryInterface, auditService *auditSvc.AuditService) *BudgetServices {
 	return &BudgetServices{
 		repository:      repo,
 		transactionRepo: transactionRepo,
+		auditService:    auditService,
 	}
 }
 
@@ -35,14 +40,29 @@ func (b *BudgetServices) CreateBudget(ctx context.Context, budgetRequest *dto.Bu
 
 	budgetToSave := budget.NewBudget(id, budgetRequest.CategoryId, userId, budgetRequest.Amount)
 
-	err = b.repository.Save(ctx, budgetToSave)
-	return err
+	if err := b.repository.Save(ctx, budgetToSave); err != nil {
+		return err
+	}
+	b.auditService.Record(ctx, audit.EntityBudget, id, audit.ActionCreate, userId, nil, budgetToSave)
+	return nil
 }
 
 // UpdateBudget modifies an existing budget details.
 func (b *BudgetServices) UpdateBudget(ctx context.Context, budgetRequest *dto.BudgetRequest, id string, userId string) error {
 	budgetToUpdate := budget.NewBudget(id, budgetRequest.CategoryId, userId, budgetRequest.Amount)
-	return b.repository.Update(ctx, budgetToUpdate)
+	// The budget is only loaded to snapshot it for the audit log
+	var current *budget.Budget
+	if b.auditService != nil {
+		current, _ = b.repository.FindOne(ctx, id)
+	}
+	if err := b.repository.Update(ctx, budgetToUpdate); err != nil {
+		return err
+	}
+	if current != nil && current.UserId == userId {
+		budgetToUpdate.CreatedAt = current.CreatedAt
+		b.auditService.Record(ctx, audit.EntityBudget, id, audit.ActionUpdate, userId, current, budgetToUpdate)
+	}
+	return nil
 }
 
 // FindAll retrieves all budgets for a user, including current spending progress.
@@ -75,6 +95,9 @@ func (b *BudgetServices) Delete(ctx context.Context, id string, userId string) e
 	if budgets.Id != id {
 		return errorhttp.ErrNotFound
 	}
-	err = b.repository.Delete(ctx, id, userId)
-	return err
+	if err := b.repository.Delete(ctx, id, userId); err != nil {
+		return err
+	}
+	b.auditService.Record(ctx, audit.EntityBudget, id, audit.ActionDelete, userId, budgets, nil)
+	return nil
 }
@@ -3,8 +3,10 @@ package budget
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/budget"
 	transactionDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
@@ -36,6 +38,12 @@ func (m *MockBudgetRepository) FindOne(ctx context.Context, id string) (*budget.
 	return args.Get(0).(*budget.Budget), args.Error(1)
 }
 
+func (m *MockBudgetRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*budget.Budget, error) {
+	args := m.Called(ctx, id, userId)
+	found, _ := args.Get(0).(*budget.Budget)
+	return found, args.Error(1)
+}
+
 func (m *MockBudgetRepository) FindByCategory(ctx context.Context, categoryId string) (*budget.Budget, error) {
 	args := m.Called(ctx, categoryId)
 	return args.Get(0).(*budget.Budget), args.Error(1)
@@ -75,14 +83,14 @@ func (m *MockTransaction) FindAllOfAllAccounts(ctx context.Context, id string) (
 	return args.Get(0).([]*transaction.Transaction), args.Error(1)
 }
 
-func (m *MockTransaction) FindCurrentBudget(ctx context.Context, budgetId string) (float64, error) {
+func (m *MockTransaction) FindCurrentBudget(ctx context.Context, budgetId string) (money.Money, error) {
 	args := m.Called(ctx, budgetId)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Money), args.Error(1)
 }
 
-func (m *MockTransaction) FindCurrentBudgets(ctx context.Context, userId string) (map[string]float64, error) {
+func (m *MockTransaction) FindCurrentBudgets(ctx context.Context, userId string) (map[string]money.Money, error) {
 	args := m.Called(ctx, userId)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]money.Money), args.Error(1)
 }
 
 func (m *MockTransaction) Delete(ctx context.Context, id string, userId string) error {
@@ -105,16 +113,129 @@ func (m *MockTransaction) CountWithFilters(ctx context.Context, userId string, f
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTransaction) SummarizeWithFilters(ctx context.Context, userId string, filter *transactionDto.TransactionFilter) (*transactionDto.SummaryTotals, error) {
+	args := m.Called(ctx, userId, filter)
+	return args.Get(0).(*transactionDto.SummaryTotals), args.Error(1)
+}
+
+func (m *MockTransaction) StreamWithFilters(ctx context.Context, userId string, filter *transactionDto.TransactionFilter, fn func(*transaction.Transaction) error) error {
+	args := m.Called(ctx, userId, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) SaveIfNew(ctx context.Context, transaction *transaction.Transaction) (bool, error) {
+	args := m.Called(ctx, transaction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransaction) FindById(ctx context.Context, id string, userId string) (*transaction.Transaction, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) SaveTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	args := m.Called(ctx, outgoing, incoming)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) UpdateTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	args := m.Called(ctx, outgoing, incoming)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindByTransferId(ctx context.Context, transferId string, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, transferId, userId)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) DeleteTransfer(ctx context.Context, transferId string, userId string) error {
+	args := m.Called(ctx, transferId, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindTrash(ctx context.Context, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransaction) UpdateStatus(ctx context.Context, id string, userId string, status string) error {
+	args := m.Called(ctx, id, userId, status)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) Unlock(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) LinkRefund(ctx context.Context, id string, userId string, original *transaction.Transaction) error {
+	args := m.Called(ctx, id, userId, original)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) UnlinkRefund(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindDuplicateCandidates(ctx context.Context, userId string, from time.Time, to time.Time) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, userId, from, to)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) FindDismissedDuplicates(ctx context.Context, userId string) ([]transaction.DuplicatePair, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]transaction.DuplicatePair), args.Error(1)
+}
+
+func (m *MockTransaction) DismissDuplicate(ctx context.Context, userId string, pair transaction.DuplicatePair) error {
+	args := m.Called(ctx, userId, pair)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) MergeDuplicate(ctx context.Context, keepId string, duplicateId string, userId string) error {
+	args := m.Called(ctx, keepId, duplicateId, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindByIds(ctx context.Context, ids []string, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, ids, userId)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) ApplyBulk(ctx context.Context, userId string, request *transactionDto.BulkRequest, ids []string) ([]string, error) {
+	args := m.Called(ctx, userId, request, ids)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTransaction) ApplyRuleChanges(ctx context.Context, userId string, transactions []*transaction.Transaction) error {
+	args := m.Called(ctx, userId, transactions)
+	return args.Error(0)
+}
+
 func TestCreateBudget(t *testing.T) {
 	mockRepoBudget := &MockBudgetRepository{}
 	mockRepoTransaction := &MockTransaction{}
 
-	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction)
+	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction, nil)
 
 	mockRepoBudget.On("Save", mock.Anything, mock.Anything).Return(nil)
 
 	ctx := context.Background()
-	budgetRequest := dto.NewBudgetRequest("123", 1000.0)
+	budgetRequest := dto.NewBudgetRequest("123", money.FromUnits(1000))
 	err := budgetService.CreateBudget(ctx, budgetRequest, "123")
 	assert.NoError(t, err)
 }
@@ -122,12 +243,12 @@ func TestCreateBudget(t *testing.T) {
 func TestGetAllBudgets(t *testing.T) {
 	mockRepoBudget := &MockBudgetRepository{}
 	mockRepoTransaction := &MockTransaction{}
-	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction)
+	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction, nil)
 	listBudget := []*budget.Budget{
-		{Id: "123", CategoryId: "123", UserId: "123", Amount: 1000.0},
-		{Id: "123", CategoryId: "123", UserId: "123", Amount: 1000.0},
+		{Id: "123", CategoryId: "123", UserId: "123", Amount: money.FromUnits(1000)},
+		{Id: "123", CategoryId: "123", UserId: "123", Amount: money.FromUnits(1000)},
 	}
-	budgetsMap := map[string]float64{"123": 1000.0}
+	budgetsMap := map[string]money.Money{"123": money.FromUnits(1000)}
 
 	mockRepoBudget.On("FindAll", mock.Anything).Return(listBudget, nil)
 	mockRepoTransaction.On("FindCurrentBudgets", mock.Anything, mock.Anything).Return(budgetsMap, nil)
@@ -139,8 +260,8 @@ func TestGetAllBudgets(t *testing.T) {
 func TestDeleteBudget(t *testing.T) {
 	mockRepoBudget := &MockBudgetRepository{}
 	mockRepoTransaction := &MockTransaction{}
-	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction)
-	budget := budget.Budget{Id: "123", CategoryId: "123", UserId: "123", Amount: 1000.0}
+	budgetService := NewBudgetServices(mockRepoBudget, mockRepoTransaction, nil)
+	budget := budget.Budget{Id: "123", CategoryId: "123", UserId: "123", Amount: money.FromUnits(1000)}
 	budgetID := budget.Id
 	userID := budget.UserId
 	mockRepoBudget.On("FindOne", mock.Anything, mock.Anything).Return(&budget, nil)
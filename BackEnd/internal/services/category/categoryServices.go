@@ -3,22 +3,27 @@ package category
 import (
 	"context"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/category"
 	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/segmentio/ksuid"
 )
 
 // CategoryServices handles business logic related to category management.
 type CategoryServices struct {
-	repository categoryRepo.CategoryRepoInterface
+	repository   categoryRepo.CategoryRepoInterface
+	auditService *auditSvc.AuditService
 }
 
 // NewCategoryServices creates a new instance of CategoryServices.
-func NewCategoryServices(repo categoryRepo.CategoryRepoInterface) *CategoryServices {
+// auditService may be nil, in which case changes are not recorded in the audit log.
+func NewCategoryServices(repo categoryRepo.CategoryRepoInterface, auditService *auditSvc.AuditService) *CategoryServices {
 	return &CategoryServices{
-		repository: repo,
+		repository:   repo,
+		auditService: auditService,
 	}
 }
 
@@ -32,8 +37,11 @@ func (c *CategoryServices) CreateCategory(ctx context.Context, categoryRequest *
 
 	categoryToSave := category.NewCategory(id, categoryRequest.Name, categoryRequest.Icon, categoryRequest.Color)
 	categoryToSave.UserId = userId
-	err = c.repository.Save(ctx, categoryToSave)
-	return err
+	if err := c.repository.Save(ctx, categoryToSave); err != nil {
+		return err
+	}
+	c.auditService.Record(ctx, audit.EntityCategory, id, audit.ActionCreate, userId, nil, categoryToSave)
+	return nil
 }
 
 // FindAll retrieves all categories for a specific user.
@@ -59,14 +67,28 @@ func (c *CategoryServices) Delete(ctx context.Context, id string, userId string)
 	if categoryToDelete.Id != id {
 		return errorhttp.ErrNotFound
 	}
-	err = c.repository.Delete(ctx, id, userId)
-	return err
+	if err := c.repository.Delete(ctx, id, userId); err != nil {
+		return err
+	}
+	c.auditService.Record(ctx, audit.EntityCategory, id, audit.ActionDelete, userId, categoryToDelete, nil)
+	return nil
 }
 
 // UpdateCategory modifies an existing category's details.
 func (c *CategoryServices) UpdateCategory(ctx context.Context, categoryRequest *dto.CategoryRequest, id string, userId string) error {
 	categoryToUpdate := category.NewCategory(id, categoryRequest.Name, categoryRequest.Icon, categoryRequest.Color)
 	categoryToUpdate.UserId = userId
-	err := c.repository.Update(ctx, categoryToUpdate)
-	return err
+	// The category is only loaded to snapshot it for the audit log
+	var current *category.Category
+	if c.auditService != nil {
+		current, _ = c.repository.FindOne(ctx, id)
+	}
+	if err := c.repository.Update(ctx, categoryToUpdate); err != nil {
+		return err
+	}
+	if current != nil && current.UserId == userId {
+		categoryToUpdate.CreatedAt = current.CreatedAt
+		c.auditService.Record(ctx, audit.EntityCategory, id, audit.ActionUpdate, userId, current, categoryToUpdate)
+	}
+	return nil
 }
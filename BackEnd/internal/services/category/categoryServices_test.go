@@ -2,11 +2,16 @@ package category
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	auditDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/audit"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/category"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -35,6 +40,12 @@ func (m *MockCategoryRepository) FindOne(ctx context.Context, id string) (*categ
 	return args.Get(0).(*category.Category), args.Error(1)
 }
 
+func (m *MockCategoryRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*category.Category, error) {
+	args := m.Called(ctx, id, userId)
+	found, _ := args.Get(0).(*category.Category)
+	return found, args.Error(1)
+}
+
 func (m *MockCategoryRepository) Update(ctx context.Context, category *category.Category) error {
 	args := m.Called(ctx, category)
 	return args.Error(0)
@@ -45,12 +56,30 @@ func (m *MockCategoryRepository) Search(ctx context.Context, userId string, quer
 	return args.Get(0).([]*category.Category), args.Error(1)
 }
 
+func (m *MockCategoryRepository) FindTrash(ctx context.Context, userId string) ([]*category.Category, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func TestCreateCategory(t *testing.T) {
 	mockRepo := &MockCategoryRepository{}
 	ctx := context.Background()
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*category.Category")).Return(nil)
 	category := utils.GetNewRandomCategory()
-	categoryServices := NewCategoryServices(mockRepo)
+	categoryServices := NewCategoryServices(mockRepo, nil)
 	categoryRequest := dto.NewCategoryRequest(category.Name, category.Icon, category.Color)
 	err := categoryServices.CreateCategory(ctx, categoryRequest, category.UserId)
 	assert.NoError(t, err, "CreateCategory should not return an error")
@@ -67,7 +96,7 @@ func TestFindAll(t *testing.T) {
 	}
 	mockRepo.On("FindAll", ctx, "1").Return(expectedCategory, nil) // Corrected: added ctx and userId to Called arguments
 
-	categoryServices := NewCategoryServices(mockRepo)
+	categoryServices := NewCategoryServices(mockRepo, nil)
 	_, err := categoryServices.FindAll(ctx, "1")
 	mockRepo.AssertExpectations(t)
 	assert.NoError(t, err, "FindAll should not return an error")
@@ -81,8 +110,49 @@ func TestDeleteCategory(t *testing.T) {
 	ctx := context.Background()
 	mockRepo.On("FindOne", ctx, mock.Anything).Return(category, nil)
 	mockRepo.On("Delete", ctx, mock.Anything, mock.Anything).Return(nil)
-	categoryServices := NewCategoryServices(mockRepo)
+	categoryServices := NewCategoryServices(mockRepo, nil)
 	err := categoryServices.Delete(ctx, category.Id, category.UserId)
 	mockRepo.AssertExpectations(t)
 	assert.NoError(t, err, "DeleteAccount should not return an error")
 }
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Save(ctx context.Context, event *audit.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) FindByEntity(ctx context.Context, entityType string, entityId string, userId string) ([]*audit.Event, error) {
+	args := m.Called(ctx, entityType, entityId, userId)
+	return args.Get(0).([]*audit.Event), args.Error(1)
+}
+
+func (m *MockAuditRepository) Find(ctx context.Context, filter *auditDto.AuditFilter) ([]*audit.Event, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*audit.Event), args.Error(1)
+}
+
+func TestUpdateCategory_RecordsAuditEvent(t *testing.T) {
+	mockRepo := &MockCategoryRepository{}
+	mockAuditRepo := &MockAuditRepository{}
+	ctx := context.Background()
+
+	current := category.NewCategory("cat_1", "Food", "🍔", "#fff")
+	current.UserId = "user_1"
+	mockRepo.On("FindOne", ctx, "cat_1").Return(current, nil)
+	mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+	mockAuditRepo.On("Save", ctx, mock.MatchedBy(func(event *audit.Event) bool {
+		return event.EntityType == audit.EntityCategory && event.EntityId == "cat_1" && event.Action == audit.ActionUpdate &&
+			event.UserId == "user_1" && strings.Contains(string(event.Before), `"name":"Food"`) &&
+			strings.Contains(string(event.After), `"name":"Groceries"`)
+	})).Return(nil)
+
+	categoryServices := NewCategoryServices(mockRepo, auditSvc.NewAuditService(mockAuditRepo))
+	err := categoryServices.UpdateCategory(ctx, &dto.CategoryRequest{Name: "Groceries", Icon: "🍔", Color: "#fff"}, "cat_1", "user_1")
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
@@ -9,6 +9,7 @@ var (
 	ErrNotFound     = errors.New("not found")
 	ErrNotDuplicate = errors.New("not duplicate email")
 	ErrBadRequest   = errors.New("bad request")
+	ErrConflict     = errors.New("conflict")
 )
 
 func IsErrNotDuplicate(err error) bool {
@@ -23,6 +24,10 @@ func IsErrNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
+func IsErrConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
 type ErrorApp struct {
 	Path   string    `json:"path"`
 	Err    string    `json:"err"`
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// Format describes an export file format.
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	newWriter   func(w io.Writer) rowWriter
+}
+
+var formats = map[string]Format{
+	"csv":    {Name: "csv", ContentType: "text/csv; charset=utf-8", Extension: "csv", newWriter: newCSVWriter},
+	"ndjson": {Name: "ndjson", ContentType: "application/x-ndjson", Extension: "ndjson", newWriter: newNDJSONWriter},
+	"xlsx":   {Name: "xlsx", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx", newWriter: newXLSXWriter},
+}
+
+// ParseFormat returns the export format with the given name. An empty name selects CSV.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("%w: unsupported export format %q, use csv, ndjson or xlsx", errorhttp.ErrBadRequest, name)
+	}
+	return format, nil
+}
+
+// rowWriter encodes export rows into a file format. Close writes any trailer the format needs.
+type rowWriter interface {
+	Write(row *dto.TransactionExportRow) error
+	Close() error
+}
+
+// ExportService writes the transactions of a user to downloadable files.
+type ExportService struct {
+	transactionRepository transactionRepo.TransactionRepositoryInterface
+	categoryRepository    categoryRepo.CategoryRepoInterface
+	accountRepository     accountRepo.AccountRepositoryInterface
+}
+
+// NewExportService creates a new instance of ExportService.
+func NewExportService(transactionRepository transactionRepo.TransactionRepositoryInterface, categoryRepository categoryRepo.CategoryRepoInterface, accountRepository accountRepo.AccountRepositoryInterface) *ExportService {
+	return &ExportService{
+		transactionRepository: transactionRepository,
+		categoryRepository:    categoryRepository,
+		accountRepository:     accountRepository,
+	}
+}
+
+// ExportTransactions streams every transaction matching the filter to w. Pagination fields of the filter are ignored.
+func (s *ExportService) ExportTransactions(ctx context.Context, userId string, filter *dto.TransactionFilter, format Format, w io.Writer) error {
+	categoryNames, accountNames, err := s.loadNames(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(w)
+	writer := format.newWriter(buffered)
+	count := 0
+	err = s.transactionRepository.StreamWithFilters(ctx, userId, filter, func(t *transaction.Transaction) error {
+		count++
+		return writer.Write(newExportRow(t, categoryNames, accountNames))
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	log.Info().Str("user_id", userId).Str("format", format.Name).Int("rows", count).Msg("transactions exported")
+	return buffered.Flush()
+}
+
+// loadNames maps the category and account ids of the user to their names.
+func (s *ExportService) loadNames(ctx context.Context, userId string) (map[string]string, map[string]string, error) {
+	categories, err := s.categoryRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.Id] = category.Name
+	}
+
+	accounts, err := s.accountRepository.FindAll(ctx, userId, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	accountNames := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		accountNames[account.Id] = account.Name
+	}
+
+	return categoryNames, accountNames, nil
+}
+
+func newExportRow(t *transaction.Transaction, categoryNames map[string]string, accountNames map[string]string) *dto.TransactionExportRow {
+	row := &dto.TransactionExportRow{
+		Id:             t.Id,
+		Date:           t.CreatedAt,
+		Name:           t.Name,
+		Description:    t.Description,
+		Amount:         t.Amount,
+		TypeTransation: t.TypeTransation,
+		AccountId:      t.AccountId,
+		AccountName:    accountNames[t.AccountId],
+		CategoryId:     t.CategoryId,
+		CategoryName:   categoryNames[t.CategoryId],
+		TransferId:     t.TransferId,
+		ExternalId:     t.ExternalId,
+	}
+	for _, split := range t.Splits {
+		row.Splits = append(row.Splits, &dto.SplitExportRow{
+			CategoryId:   split.CategoryId,
+			CategoryName: categoryNames[split.CategoryId],
+			Amount:       split.Amount,
+			Description:  split.Description,
+		})
+	}
+	return row
+}
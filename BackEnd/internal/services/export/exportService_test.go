@@ -0,0 +1,161 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// The mocks embed the repository interfaces and only implement what the export uses.
+type MockTransactionRepository struct {
+	transactionRepo.TransactionRepositoryInterface
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) StreamWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter, fn func(*transaction.Transaction) error) error {
+	args := m.Called(ctx, userId, filter)
+	for _, t := range args.Get(0).([]*transaction.Transaction) {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+type MockCategoryRepository struct {
+	categoryRepo.CategoryRepoInterface
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FindAll(ctx context.Context, userId string) ([]*category.Category, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*category.Category), args.Error(1)
+}
+
+type MockAccountRepository struct {
+	accountRepo.AccountRepositoryInterface
+	mock.Mock
+}
+
+func (m *MockAccountRepository) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	args := m.Called(ctx, userId, includeArchived)
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func newTestExportService() *ExportService {
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	coffee := transaction.NewTransaction("txn_1", "Coffee, \"large\"", "Morning", "bill", "acc_1", "cat_food", money.Money(-350))
+	coffee.CreatedAt = createdAt
+	market := transaction.NewTransaction("txn_2", "Market", "", "bill", "acc_1", "", money.FromUnits(-100))
+	market.CreatedAt = createdAt
+	market.Splits = []*transaction.Split{
+		transaction.NewSplit("split_1", "txn_2", "cat_food", money.FromUnits(-70)),
+		transaction.NewSplit("split_2", "txn_2", "cat_home", money.FromUnits(-30)),
+	}
+
+	transactionRepository := &MockTransactionRepository{}
+	transactionRepository.On("StreamWithFilters", mock.Anything, "user_1", mock.Anything).Return([]*transaction.Transaction{coffee, market}, nil)
+	categoryRepository := &MockCategoryRepository{}
+	categoryRepository.On("FindAll", mock.Anything, "user_1").Return([]*category.Category{
+		category.NewCategory("cat_food", "Food", "", ""),
+		category.NewCategory("cat_home", "Home", "", ""),
+	}, nil)
+	accountRepository := &MockAccountRepository{}
+	accountRepository.On("FindAll", mock.Anything, "user_1", true).Return([]*account.Account{
+		account.NewAccount(0, "acc_1", "Checking", "Bank"),
+	}, nil)
+
+	return NewExportService(transactionRepository, categoryRepository, accountRepository)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, "csv", format.Name)
+
+	format, err = ParseFormat("xlsx")
+	assert.NoError(t, err)
+	assert.Equal(t, "xlsx", format.Extension)
+
+	_, err = ParseFormat("pdf")
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+}
+
+func TestExportService_CSV(t *testing.T) {
+	s := newTestExportService()
+	format, _ := ParseFormat("csv")
+	var out bytes.Buffer
+
+	err := s.ExportTransactions(context.Background(), "user_1", dto.NewTransactionFilter(), format, &out)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "Date,Name,Description,Amount,Type,Account,Category,Id,Transfer Id,External Id", lines[0])
+	assert.Equal(t, `2024-01-15,"Coffee, ""large""",Morning,-3.50,bill,Checking,Food,txn_1,,`, lines[1])
+	assert.Contains(t, lines[2], "Food (-70.00); Home (-30.00)")
+}
+
+func TestExportService_NDJSON(t *testing.T) {
+	s := newTestExportService()
+	format, _ := ParseFormat("ndjson")
+	var out bytes.Buffer
+
+	err := s.ExportTransactions(context.Background(), "user_1", dto.NewTransactionFilter(), format, &out)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var row dto.TransactionExportRow
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &row))
+	assert.Equal(t, "Checking", row.AccountName)
+	assert.Len(t, row.Splits, 2)
+	assert.Equal(t, "Home", row.Splits[1].CategoryName)
+}
+
+func TestExportService_XLSX(t *testing.T) {
+	s := newTestExportService()
+	format, _ := ParseFormat("xlsx")
+	var out bytes.Buffer
+
+	err := s.ExportTransactions(context.Background(), "user_1", dto.NewTransactionFilter(), format, &out)
+
+	assert.NoError(t, err)
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	assert.NoError(t, err)
+
+	var sheet string
+	names := make([]string, 0, len(archive.File))
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			content, _ := io.ReadAll(reader)
+			sheet = string(content)
+		}
+	}
+	assert.Contains(t, names, "[Content_Types].xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+	assert.Contains(t, sheet, `<row r="3">`)
+	assert.Contains(t, sheet, "Coffee, &#34;large&#34;")
+	assert.Contains(t, sheet, "<v>-3.50</v>")
+}
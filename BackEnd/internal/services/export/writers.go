@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+)
+
+// exportColumns are the columns of the tabular formats (CSV and XLSX).
+var exportColumns = []string{"Date", "Name", "Description", "Amount", "Type", "Account", "Category", "Id", "Transfer Id", "External Id"}
+
+// exportDateLayout is the date format of the tabular formats.
+const exportDateLayout = "2006-01-02"
+
+// categoryLabel is the category cell of a row. Split transactions list every line, e.g. "Food (-70.00); Pharmacy (-30.00)".
+func categoryLabel(row *dto.TransactionExportRow) string {
+	if len(row.Splits) == 0 {
+		return row.CategoryName
+	}
+	lines := make([]string, 0, len(row.Splits))
+	for _, split := range row.Splits {
+		lines = append(lines, fmt.Sprintf("%s (%s)", split.CategoryName, split.Amount))
+	}
+	return strings.Join(lines, "; ")
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) rowWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(row *dto.TransactionExportRow) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.writer.Write([]string{
+		row.Date.Format(exportDateLayout),
+		row.Name,
+		row.Description,
+		row.Amount.String(),
+		row.TypeTransation,
+		row.AccountName,
+		categoryLabel(row),
+		row.Id,
+		row.TransferId,
+		row.ExternalId,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	// An empty export still gets its header line
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.writer.Write(exportColumns)
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) rowWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Write(row *dto.TransactionExportRow) error {
+	// Encode terminates every value with a newline, which is the NDJSON record separator
+	return n.encoder.Encode(row)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+)
+
+// xlsxWriter writes a single-sheet workbook. The sheet is streamed into the zip archive row by row with inline
+// strings, so no shared string table has to be kept in memory; the remaining parts are written on Close.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	rowNum  int
+	err     error
+}
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Transactions" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs></styleSheet>`},
+}
+
+func newXLSXWriter(w io.Writer) rowWriter {
+	return &xlsxWriter{archive: zip.NewWriter(w)}
+}
+
+func (x *xlsxWriter) Write(row *dto.TransactionExportRow) error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	x.startRow()
+	x.stringCell(row.Date.Format(exportDateLayout))
+	x.stringCell(row.Name)
+	x.stringCell(row.Description)
+	x.numberCell(row.Amount)
+	x.stringCell(row.TypeTransation)
+	x.stringCell(row.AccountName)
+	x.stringCell(categoryLabel(row))
+	x.stringCell(row.Id)
+	x.stringCell(row.TransferId)
+	x.stringCell(row.ExternalId)
+	x.write("</row>")
+	return x.err
+}
+
+func (x *xlsxWriter) Close() error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	x.write(xlsxSheetFooter)
+	if x.err != nil {
+		return x.err
+	}
+
+	for _, part := range xlsxStaticParts {
+		w, err := x.archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+	return x.archive.Close()
+}
+
+// start opens the sheet part and writes the header row on first use.
+func (x *xlsxWriter) start() error {
+	if x.sheet != nil {
+		return nil
+	}
+	sheet, err := x.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	x.write(xlsxSheetHeader)
+	x.startRow()
+	for _, column := range exportColumns {
+		x.stringCell(column)
+	}
+	x.write("</row>")
+	return x.err
+}
+
+func (x *xlsxWriter) startRow() {
+	x.rowNum++
+	x.write(`<row r="` + strconv.Itoa(x.rowNum) + `">`)
+}
+
+func (x *xlsxWriter) stringCell(value string) {
+	if value == "" {
+		x.write("<c/>")
+		return
+	}
+	x.write(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	if x.err == nil {
+		x.err = xml.EscapeText(x.sheet, []byte(value))
+	}
+	x.write("</t></is></c>")
+}
+
+func (x *xlsxWriter) numberCell(value money.Money) {
+	x.write("<c><v>" + value.String() + "</v></c>")
+}
+
+// write keeps the first error so a row can be written without checking every cell.
+func (x *xlsxWriter) write(s string) {
+	if x.err != nil {
+		return
+	}
+	_, x.err = io.WriteString(x.sheet, s)
+}
@@ -0,0 +1,99 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/fxrates"
+	fxRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/fx"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// FxService keeps the exchange rate table up to date and converts amounts into the base currency of a user.
+type FxService struct {
+	fxRepository   fxRepo.FxRepoInterface
+	userRepository userRepo.UserRepositoryInterface
+	provider       fxrates.Provider
+}
+
+// NewFxService creates a new instance of FxService.
+// provider may be nil, in which case rates only come in through SaveRate.
+func NewFxService(fxRepository fxRepo.FxRepoInterface, userRepository userRepo.UserRepositoryInterface, provider fxrates.Provider) *FxService {
+	return &FxService{
+		fxRepository:   fxRepository,
+		userRepository: userRepository,
+		provider:       provider,
+	}
+}
+
+// SyncRates copies the rates of the provider into the rate table and returns how many were stored.
+func (s *FxService) SyncRates(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, nil
+	}
+	rates, err := s.provider.FetchRates(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching rates from the %s provider: %w", s.provider.Name(), err)
+	}
+	if err := s.fxRepository.SaveRates(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// SaveRate stores a rate entered by hand. It replaces the rate of the same pair and day.
+func (s *FxService) SaveRate(ctx context.Context, request *dto.RateRequest) (*fx.Rate, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	date := request.Date
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	rate := fx.NewRate(request.Base, request.Quote, request.Rate, date, "manual")
+	if err := s.fxRepository.SaveRates(ctx, []*fx.Rate{rate}); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// FindLatest returns the most recent rate of every currency pair.
+func (s *FxService) FindLatest(ctx context.Context) ([]*fx.Rate, error) {
+	return s.fxRepository.FindLatest(ctx)
+}
+
+// BaseCurrency returns the currency the reports of the user are converted into.
+func (s *FxService) BaseCurrency(ctx context.Context, userId string) (string, error) {
+	user, err := s.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	if user.BaseCurrency == "" {
+		return fx.DefaultCurrency, nil
+	}
+	return user.BaseCurrency, nil
+}
+
+// Converter returns a converter into the base currency of the user, loaded with the latest rates.
+// A nil FxService returns a nil Converter, which leaves every amount untouched.
+func (s *FxService) Converter(ctx context.Context, userId string) (*fx.Converter, error) {
+	if s == nil {
+		return nil, nil
+	}
+	base, err := s.BaseCurrency(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := s.fxRepository.FindLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fx.NewConverter(base, rates), nil
+}
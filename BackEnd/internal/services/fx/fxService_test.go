@@ -0,0 +1,171 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/user"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+type MockFxRepository struct {
+	mock.Mock
+}
+
+func (m *MockFxRepository) SaveRates(ctx context.Context, rates []*fx.Rate) error {
+	args := m.Called(ctx, rates)
+	return args.Error(0)
+}
+
+func (m *MockFxRepository) FindLatest(ctx context.Context) ([]*fx.Rate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*fx.Rate), args.Error(1)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindUserById(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindUserByIp(ctx context.Context, ip string) (*user.User, error) {
+	args := m.Called(ctx, ip)
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Save(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteDemoUsersOlderThan(ctx context.Context, olderThan time.Time) error {
+	args := m.Called(ctx, olderThan)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+type stubProvider struct {
+	rates []*fx.Rate
+	err   error
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) FetchRates(ctx context.Context) ([]*fx.Rate, error) {
+	return p.rates, p.err
+}
+
+func TestFxService_SyncRates(t *testing.T) {
+	rates := []*fx.Rate{fx.NewRate("EUR", "USD", 1.1, time.Now(), "stub")}
+	mockRepo := &MockFxRepository{}
+	mockRepo.On("SaveRates", mock.Anything, rates).Return(nil)
+
+	s := NewFxService(mockRepo, &MockUserRepository{}, &stubProvider{rates: rates})
+	stored, err := s.SyncRates(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFxService_SyncRates_ProviderError(t *testing.T) {
+	mockRepo := &MockFxRepository{}
+	s := NewFxService(mockRepo, &MockUserRepository{}, &stubProvider{err: errors.New("file not found")})
+
+	_, err := s.SyncRates(context.Background())
+
+	assert.ErrorContains(t, err, "stub provider")
+	mockRepo.AssertNotCalled(t, "SaveRates", mock.Anything, mock.Anything)
+}
+
+func TestFxService_SaveRate(t *testing.T) {
+	mockRepo := &MockFxRepository{}
+	mockRepo.On("SaveRates", mock.Anything, mock.MatchedBy(func(rates []*fx.Rate) bool {
+		return len(rates) == 1 && rates[0].Base == "EUR" && rates[0].Quote == "USD" && rates[0].Source == "manual" &&
+			rates[0].Date.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	})).Return(nil)
+
+	s := NewFxService(mockRepo, &MockUserRepository{}, nil)
+	rate, err := s.SaveRate(context.Background(), &dto.RateRequest{Base: "eur", Quote: "usd", Rate: 1.08, Date: time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1.08, rate.Rate)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFxService_SaveRate_Invalid(t *testing.T) {
+	s := NewFxService(&MockFxRepository{}, &MockUserRepository{}, nil)
+
+	_, err := s.SaveRate(context.Background(), &dto.RateRequest{Base: "USD", Quote: "USD", Rate: 1})
+
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+}
+
+func TestFxService_Converter(t *testing.T) {
+	mockRepo := &MockFxRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockUserRepo.On("FindUserById", mock.Anything, "user_1").Return(&user.User{Id: "user_1", BaseCurrency: "EUR"}, nil)
+	mockRepo.On("FindLatest", mock.Anything).Return([]*fx.Rate{fx.NewRate("EUR", "USD", 1.25, time.Now(), "manual")}, nil)
+
+	s := NewFxService(mockRepo, mockUserRepo, nil)
+	converter, err := s.Converter(context.Background(), "user_1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", converter.Base())
+	converted, ok := converter.Convert(money.FromUnits(100), "USD")
+	assert.True(t, ok)
+	assert.Equal(t, money.FromUnits(80), converted)
+}
+
+func TestFxService_Converter_DefaultsBaseCurrency(t *testing.T) {
+	mockRepo := &MockFxRepository{}
+	mockUserRepo := &MockUserRepository{}
+	mockUserRepo.On("FindUserById", mock.Anything, "user_1").Return(&user.User{Id: "user_1"}, nil)
+	mockRepo.On("FindLatest", mock.Anything).Return([]*fx.Rate{}, nil)
+
+	s := NewFxService(mockRepo, mockUserRepo, nil)
+	converter, err := s.Converter(context.Background(), "user_1")
+
+	require.NoError(t, err)
+	assert.Equal(t, fx.DefaultCurrency, converter.Base())
+}
+
+func TestFxService_Converter_NilService(t *testing.T) {
+	var s *FxService
+
+	converter, err := s.Converter(context.Background(), "user_1")
+
+	require.NoError(t, err)
+	assert.Nil(t, converter)
+}
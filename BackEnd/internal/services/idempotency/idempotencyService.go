@@ -0,0 +1,80 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/idempotency"
+	idempotencyRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/idempotency"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// IdempotencyService keeps the responses of create requests made with an idempotency key, so retries of the same
+// request get the original response instead of creating the record again.
+type IdempotencyService struct {
+	idempotencyRepository idempotencyRepo.IdempotencyRepoInterface
+	ttl                   time.Duration
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService. Keys can be reused with a different
+// request once ttl has passed.
+func NewIdempotencyService(idempotencyRepository idempotencyRepo.IdempotencyRepoInterface, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+	}
+}
+
+// Begin reserves the key for a request. It returns nil when the request is new and must be processed, or the
+// stored record when it is a retry whose response must be replayed. A key used with a different request, or
+// whose first request is still running, is a conflict.
+func (s *IdempotencyService) Begin(ctx context.Context, userId string, key string, requestHash string) (*idempotency.Record, error) {
+	record := idempotency.NewRecord(userId, key, requestHash, s.ttl)
+	reserved, err := s.idempotencyRepository.Reserve(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	existing, err := s.idempotencyRepository.FindByKey(ctx, userId, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed and released the key between both queries
+		return nil, fmt.Errorf("%w: a request with this idempotency key is still being processed", errorhttp.ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing.RequestHash != requestHash {
+		return nil, fmt.Errorf("%w: the idempotency key was already used with a different request", errorhttp.ErrConflict)
+	}
+	if !existing.Completed() {
+		return nil, fmt.Errorf("%w: a request with this idempotency key is still being processed", errorhttp.ErrConflict)
+	}
+	return existing, nil
+}
+
+// Complete stores the response of a request reserved with Begin.
+func (s *IdempotencyService) Complete(ctx context.Context, userId string, key string, statusCode int, contentType string, response []byte) error {
+	return s.idempotencyRepository.Complete(ctx, &idempotency.Record{
+		UserId:      userId,
+		Key:         key,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Response:    response,
+	})
+}
+
+// Release frees the key of a request that failed, so a retry runs it again.
+func (s *IdempotencyService) Release(ctx context.Context, userId string, key string) error {
+	return s.idempotencyRepository.Release(ctx, userId, key)
+}
+
+// PurgeExpired removes the keys whose TTL is over.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.idempotencyRepository.PurgeExpired(ctx, time.Now().UTC())
+}
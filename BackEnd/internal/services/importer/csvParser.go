@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+const (
+	bill   = "bill"
+	income = "income"
+)
+
+// ParseCSV reads a bank statement using the given mapping. Lines that cannot be parsed are returned
+// with their error so they can be shown in the preview; only a malformed file fails as a whole.
+func ParseCSV(reader io.Reader, mapping *importer.ImportMapping) ([]*importer.ImportRow, error) {
+	layout, ok := mapping.DateLayout()
+	if !ok {
+		return nil, fmt.Errorf("unsupported date format: %s", mapping.DateFormat)
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = []rune(mapping.Delimiter)[0]
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	csvReader.TrimLeadingSpace = true
+
+	var rows []*importer.ImportRow
+	line := 0
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		line++
+
+		if line == 1 && mapping.HasHeader {
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		rows = append(rows, parseRecord(line, record, mapping, layout))
+	}
+
+	return rows, nil
+}
+
+func parseRecord(line int, record []string, mapping *importer.ImportMapping, layout string) *importer.ImportRow {
+	row := &importer.ImportRow{Line: line}
+
+	maxColumn := max(mapping.DateColumn, mapping.AmountColumn, mapping.DescriptionColumn)
+	if len(record) <= maxColumn {
+		row.Error = fmt.Sprintf("expected at least %d columns, got %d", maxColumn+1, len(record))
+		return row
+	}
+
+	date, err := time.Parse(layout, strings.TrimSpace(record[mapping.DateColumn]))
+	if err != nil {
+		row.Error = fmt.Sprintf("invalid date %q, expected %s", record[mapping.DateColumn], mapping.DateFormat)
+		return row
+	}
+	row.Date = date
+
+	amount, err := parseAmount(record[mapping.AmountColumn], mapping.DecimalSeparator)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	if amount == 0 {
+		row.Error = "amount is zero"
+		return row
+	}
+
+	isNegative := amount < 0
+	if (mapping.SignConvention == importer.SignPositiveIsBill) != isNegative {
+		row.TypeTransation = bill
+	} else {
+		row.TypeTransation = income
+	}
+	row.Amount = amount.Abs()
+
+	row.Name = strings.Join(strings.Fields(record[mapping.DescriptionColumn]), " ")
+	if row.Name == "" {
+		row.Error = "description is empty"
+	}
+
+	return row
+}
+
+// parseAmount understands thousands separators, currency symbols and accounting style negatives like (12.50).
+func parseAmount(value string, decimalSeparator string) (money.Money, error) {
+	raw := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")") {
+		negative = true
+	}
+
+	var cleaned strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			cleaned.WriteRune(r)
+		case r == '-':
+			negative = true
+		case string(r) == decimalSeparator:
+			cleaned.WriteRune('.')
+		}
+	}
+
+	if cleaned.Len() == 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	amount, err := money.Parse(cleaned.String())
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = amount * -1
+	}
+	return amount, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	mapping := importer.NewImportMapping("map_1", "user_1", "Checking", 0, 2, 1, "YYYY-MM-DD")
+	statement := `Date,Description,Amount
+2024-01-15,  SUPERMARKET   1234 ,-42.50
+2024-01-16,Salary,"2,500.00"
+
+2024-01-17,Coffee,abc
+15/01/2024,Cinema,-10
+2024-01-18,Refund,0
+`
+
+	rows, err := ParseCSV(strings.NewReader(statement), mapping)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 5)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "SUPERMARKET 1234", rows[0].Name)
+	assert.Equal(t, money.Money(4250), rows[0].Amount)
+	assert.Equal(t, "bill", rows[0].TypeTransation)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), rows[0].Date)
+	assert.True(t, rows[0].IsValid())
+
+	assert.Equal(t, money.FromUnits(2500), rows[1].Amount)
+	assert.Equal(t, "income", rows[1].TypeTransation)
+
+	assert.Contains(t, rows[2].Error, "invalid amount")
+	assert.Contains(t, rows[3].Error, "invalid date")
+	assert.Equal(t, "amount is zero", rows[4].Error)
+}
+
+func TestParseCSV_CreditCardLayout(t *testing.T) {
+	mapping := importer.NewImportMapping("map_1", "user_1", "Card", 1, 0, 2, "DD/MM/YYYY")
+	mapping.Delimiter = ";"
+	mapping.HasHeader = false
+	mapping.DecimalSeparator = ","
+	mapping.SignConvention = importer.SignPositiveIsBill
+	statement := "1.234,56 €;03/02/2024;Hotel\n(20,00);04/02/2024;Payment received\n12,00;05/02/2024\n"
+
+	rows, err := ParseCSV(strings.NewReader(statement), mapping)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, money.Money(123456), rows[0].Amount)
+	assert.Equal(t, "bill", rows[0].TypeTransation)
+	assert.Equal(t, time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), rows[0].Date)
+	assert.Equal(t, money.FromUnits(20), rows[1].Amount)
+	assert.Equal(t, "income", rows[1].TypeTransation)
+	assert.Contains(t, rows[2].Error, "expected at least 3 columns")
+}
+
+func TestParseCSV_UnsupportedDateFormat(t *testing.T) {
+	mapping := importer.NewImportMapping("map_1", "user_1", "Checking", 0, 2, 1, "YY.MM.DD")
+
+	_, err := ParseCSV(strings.NewReader("2024-01-15,Coffee,-3"), mapping)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	domainTransaction "github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/importer"
+	importerRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+)
+
+// defaultQIFDateFormat is the US layout most QIF exports use.
+const defaultQIFDateFormat = "MM/DD/YYYY"
+
+// ImportService handles bank statement imports and the column mappings saved for them.
+type ImportService struct {
+	mappingRepository  importerRepo.ImportMappingRepoInterface
+	transactionService *transaction.TransactionService
+}
+
+// NewImportService creates a new instance of ImportService.
+func NewImportService(mappingRepository importerRepo.ImportMappingRepoInterface, transactionService *transaction.TransactionService) *ImportService {
+	return &ImportService{
+		mappingRepository:  mappingRepository,
+		transactionService: transactionService,
+	}
+}
+
+// CreateMapping stores a new column mapping for the user.
+func (s *ImportService) CreateMapping(ctx context.Context, userId string, request *dto.ImportMappingRequest) (*dto.ImportMappingResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	mapping := importer.NewImportMapping(uuid.String(), userId, request.Name, request.DateColumn, request.AmountColumn, request.DescriptionColumn, request.DateFormat)
+	applyMappingRequest(mapping, request)
+
+	if err := s.mappingRepository.Save(ctx, mapping); err != nil {
+		return nil, err
+	}
+	return dto.NewImportMappingResponse(mapping), nil
+}
+
+// FindMappings retrieves all column mappings of a user.
+func (s *ImportService) FindMappings(ctx context.Context, userId string) ([]*dto.ImportMappingResponse, error) {
+	mappings, err := s.mappingRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingResponses := make([]*dto.ImportMappingResponse, 0, len(mappings))
+	for _, mapping := range mappings {
+		mappingResponses = append(mappingResponses, dto.NewImportMappingResponse(mapping))
+	}
+	return mappingResponses, nil
+}
+
+// UpdateMapping replaces an existing column mapping.
+func (s *ImportService) UpdateMapping(ctx context.Context, id string, userId string, request *dto.ImportMappingRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	mapping, err := s.findMapping(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+	mapping.Name = request.Name
+	mapping.DateColumn = request.DateColumn
+	mapping.AmountColumn = request.AmountColumn
+	mapping.DescriptionColumn = request.DescriptionColumn
+	mapping.DateFormat = request.DateFormat
+	applyMappingRequest(mapping, request)
+	mapping.UpdatedAt = time.Now().UTC()
+
+	return s.mappingRepository.Update(ctx, mapping)
+}
+
+// DeleteMapping removes a column mapping.
+func (s *ImportService) DeleteMapping(ctx context.Context, id string, userId string) error {
+	err := s.mappingRepository.Delete(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	return err
+}
+
+// PreviewCSV parses a statement with a saved mapping without storing anything.
+func (s *ImportService) PreviewCSV(ctx context.Context, userId string, mappingId string, file io.Reader) (*dto.ImportPreviewResponse, error) {
+	rows, _, err := s.parseWithMapping(ctx, userId, mappingId, file)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewImportPreviewResponse(rows), nil
+}
+
+// ImportCSV parses a statement with a saved mapping and creates a transaction in the account for every valid row.
+// categoryId overrides the default category of the mapping; invalid rows are skipped and reported.
+func (s *ImportService) ImportCSV(ctx context.Context, userId string, mappingId string, accountId string, categoryId string, file io.Reader) (*dto.ImportResultResponse, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("%w: account_id is required", errorhttp.ErrBadRequest)
+	}
+
+	rows, mapping, err := s.parseWithMapping(ctx, userId, mappingId, file)
+	if err != nil {
+		return nil, err
+	}
+	if categoryId == "" {
+		categoryId = mapping.CategoryId
+	}
+
+	return s.commitRows(ctx, userId, accountId, categoryId, rows), nil
+}
+
+// commitRows creates the transactions of the valid rows through the transaction service.
+func (s *ImportService) commitRows(ctx context.Context, userId string, accountId string, categoryId string, rows []*importer.ImportRow) *dto.ImportResultResponse {
+	result := &dto.ImportResultResponse{}
+	for _, row := range rows {
+		if !row.IsValid() {
+			result.Rejected++
+			result.Errors = append(result.Errors, dto.NewImportRowResponse(row))
+			continue
+		}
+
+		err := s.transactionService.CreateTransaction(ctx, row.Name, row.Name, row.Amount, row.TypeTransation, accountId, userId, categoryId, "", row.Date, nil)
+		if err != nil {
+			log.Error().Err(err).Int("line", row.Line).Msg("failed to import statement row")
+			row.Error = "failed to create transaction"
+			result.Rejected++
+			result.Errors = append(result.Errors, dto.NewImportRowResponse(row))
+			continue
+		}
+		result.Imported++
+	}
+	result.Skipped = result.Rejected
+
+	log.Info().Int("imported", result.Imported).Int("rejected", result.Rejected).Str("account_id", accountId).Msg("statement import finished")
+	return result
+}
+
+// ImportOFX imports an OFX or QFX statement into the account. Transactions whose FITID was already imported
+// into the account are skipped and reported as duplicates.
+func (s *ImportService) ImportOFX(ctx context.Context, userId string, accountId string, categoryId string, file io.Reader) (*dto.ImportResultResponse, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("%w: account_id is required", errorhttp.ErrBadRequest)
+	}
+
+	transactions, rejected, err := ParseOFX(file, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	return s.saveStatement(ctx, userId, categoryId, transactions, rejected), nil
+}
+
+// ImportQIF imports a QIF statement into the account. Re-importing the same file does not duplicate transactions.
+func (s *ImportService) ImportQIF(ctx context.Context, userId string, accountId string, categoryId string, dateFormat string, file io.Reader) (*dto.ImportResultResponse, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("%w: account_id is required", errorhttp.ErrBadRequest)
+	}
+	if dateFormat == "" {
+		dateFormat = defaultQIFDateFormat
+	}
+
+	transactions, rejected, err := ParseQIF(file, accountId, dateFormat)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	return s.saveStatement(ctx, userId, categoryId, transactions, rejected), nil
+}
+
+// saveStatement stores parsed statement transactions, skipping the ones already imported into the account.
+func (s *ImportService) saveStatement(ctx context.Context, userId string, categoryId string, transactions []*domainTransaction.Transaction, rejected []*importer.ImportRow) *dto.ImportResultResponse {
+	result := &dto.ImportResultResponse{Rejected: len(rejected)}
+	for _, row := range rejected {
+		result.Errors = append(result.Errors, dto.NewImportRowResponse(row))
+	}
+
+	for _, t := range transactions {
+		t.CategoryId = categoryId
+		created, err := s.transactionService.SaveImported(ctx, userId, t)
+		if err != nil {
+			log.Error().Err(err).Str("external_id", t.ExternalId).Msg("failed to import statement transaction")
+			result.Rejected++
+			result.Errors = append(result.Errors, transactionRowResponse(t, "failed to create transaction"))
+			continue
+		}
+		if !created {
+			result.Duplicates++
+			result.Errors = append(result.Errors, transactionRowResponse(t, fmt.Sprintf("already imported (external id %s)", t.ExternalId)))
+			continue
+		}
+		result.Imported++
+	}
+	result.Skipped = result.Duplicates + result.Rejected
+
+	log.Info().Int("imported", result.Imported).Int("duplicates", result.Duplicates).Int("rejected", result.Rejected).Msg("statement import finished")
+	return result
+}
+
+// transactionRowResponse reports a parsed statement transaction that was not imported.
+func transactionRowResponse(t *domainTransaction.Transaction, reason string) *dto.ImportRowResponse {
+	row := &importer.ImportRow{
+		Date:           t.CreatedAt,
+		Amount:         t.Amount,
+		TypeTransation: t.TypeTransation,
+		Name:           t.Name,
+		ExternalId:     t.ExternalId,
+		Error:          reason,
+	}
+	if row.Amount < 0 {
+		row.Amount = row.Amount * -1
+	}
+	return dto.NewImportRowResponse(row)
+}
+
+func (s *ImportService) parseWithMapping(ctx context.Context, userId string, mappingId string, file io.Reader) ([]*importer.ImportRow, *importer.ImportMapping, error) {
+	mapping, err := s.findMapping(ctx, mappingId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := ParseCSV(file, mapping)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	return rows, mapping, nil
+}
+
+func (s *ImportService) findMapping(ctx context.Context, id string, userId string) (*importer.ImportMapping, error) {
+	mapping, err := s.mappingRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// applyMappingRequest copies the optional fields of a validated request onto a mapping.
+func applyMappingRequest(mapping *importer.ImportMapping, request *dto.ImportMappingRequest) {
+	mapping.Delimiter = request.Delimiter
+	mapping.HasHeader = *request.HasHeader
+	mapping.SignConvention = request.SignConvention
+	mapping.DecimalSeparator = request.DecimalSeparator
+	mapping.CategoryId = request.CategoryId
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportMappingRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportMappingRepository) Save(ctx context.Context, mapping *importer.ImportMapping) error {
+	args := m.Called(ctx, mapping)
+	return args.Error(0)
+}
+
+func (m *MockImportMappingRepository) Update(ctx context.Context, mapping *importer.ImportMapping) error {
+	args := m.Called(ctx, mapping)
+	return args.Error(0)
+}
+
+func (m *MockImportMappingRepository) FindAll(ctx context.Context, userId string) ([]*importer.ImportMapping, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*importer.ImportMapping), args.Error(1)
+}
+
+func (m *MockImportMappingRepository) FindById(ctx context.Context, id string, userId string) (*importer.ImportMapping, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*importer.ImportMapping), args.Error(1)
+}
+
+func (m *MockImportMappingRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func TestImportService_CreateMapping(t *testing.T) {
+	mockRepo := &MockImportMappingRepository{}
+	s := NewImportService(mockRepo, nil)
+
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(mapping *importer.ImportMapping) bool {
+		return mapping.UserId == "user_1" && mapping.Delimiter == ";" && mapping.HasHeader && mapping.SignConvention == importer.SignNegativeIsBill
+	})).Return(nil)
+
+	request := dto.NewImportMappingRequest("Checking", 0, 3, 1, "DD/MM/YYYY")
+	request.Delimiter = ";"
+	response, err := s.CreateMapping(context.Background(), "user_1", request)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Id)
+	assert.Equal(t, "DD/MM/YYYY", response.DateFormat)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportService_CreateMapping_Invalid(t *testing.T) {
+	mockRepo := &MockImportMappingRepository{}
+	s := NewImportService(mockRepo, nil)
+
+	request := dto.NewImportMappingRequest("Checking", 0, 0, 1, "DD/MM/YYYY")
+	_, err := s.CreateMapping(context.Background(), "user_1", request)
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestImportService_PreviewCSV(t *testing.T) {
+	mockRepo := &MockImportMappingRepository{}
+	s := NewImportService(mockRepo, nil)
+
+	mapping := importer.NewImportMapping("map_1", "user_1", "Checking", 0, 2, 1, "YYYY-MM-DD")
+	mockRepo.On("FindById", mock.Anything, "map_1", "user_1").Return(mapping, nil)
+
+	statement := "Date,Description,Amount\n2024-01-15,Supermarket,-42.50\n2024-01-16,Salary,oops\n"
+	preview, err := s.PreviewCSV(context.Background(), "user_1", "map_1", strings.NewReader(statement))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, preview.TotalRows)
+	assert.Equal(t, 1, preview.ValidRows)
+	assert.Equal(t, 1, preview.InvalidRows)
+}
+
+func TestImportService_PreviewCSV_MappingNotFound(t *testing.T) {
+	mockRepo := &MockImportMappingRepository{}
+	s := NewImportService(mockRepo, nil)
+
+	mockRepo.On("FindById", mock.Anything, "missing", "user_1").Return((*importer.ImportMapping)(nil), sql.ErrNoRows)
+
+	_, err := s.PreviewCSV(context.Background(), "user_1", "missing", strings.NewReader(""))
+
+	assert.True(t, errorhttp.IsErrNotFound(err))
+}
+
+func TestImportService_ImportCSV_RequiresAccount(t *testing.T) {
+	s := NewImportService(&MockImportMappingRepository{}, nil)
+
+	_, err := s.ImportCSV(context.Background(), "user_1", "map_1", "", "", strings.NewReader(""))
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+}
+
+func TestImportService_ImportOFX_Validation(t *testing.T) {
+	s := NewImportService(&MockImportMappingRepository{}, nil)
+
+	_, err := s.ImportOFX(context.Background(), "user_1", "", "", strings.NewReader("<OFX></OFX>"))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	_, err = s.ImportOFX(context.Background(), "user_1", "acc_1", "", strings.NewReader("not a statement"))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	_, err = s.ImportQIF(context.Background(), "user_1", "acc_1", "", "", strings.NewReader(""))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+}
@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+)
+
+var (
+	ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxFieldPatterns      = map[string]*regexp.Regexp{}
+)
+
+func init() {
+	for _, tag := range []string{"DTPOSTED", "TRNAMT", "FITID", "NAME", "MEMO"} {
+		// OFX 1.x (SGML) leaves leaf elements unclosed, so a value ends at the next tag or line break.
+		ofxFieldPatterns[tag] = regexp.MustCompile(`(?i)<` + tag + `>([^<\r\n]*)`)
+	}
+}
+
+// ParseOFX reads an OFX or QFX statement (SGML 1.x or XML 2.x) and returns the transactions for the account.
+// Each transaction keeps the bank FITID as external id. Entries that cannot be used are returned as rejected rows.
+func ParseOFX(reader io.Reader, accountId string) ([]*transaction.Transaction, []*importer.ImportRow, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.Contains(strings.ToUpper(string(content)), "<OFX>") {
+		return nil, nil, errors.New("not an OFX file")
+	}
+
+	var transactions []*transaction.Transaction
+	var rejected []*importer.ImportRow
+	for i, match := range ofxTransactionPattern.FindAllStringSubmatch(string(content), -1) {
+		row := parseOFXTransaction(i+1, match[1])
+		if !row.IsValid() {
+			rejected = append(rejected, row)
+			continue
+		}
+		transactions = append(transactions, rowToTransaction(row, accountId))
+	}
+
+	return transactions, rejected, nil
+}
+
+func parseOFXTransaction(line int, block string) *importer.ImportRow {
+	row := &importer.ImportRow{
+		Line:       line,
+		ExternalId: ofxField(block, "FITID"),
+		Name:       ofxField(block, "NAME"),
+	}
+	if row.Name == "" {
+		row.Name = ofxField(block, "MEMO")
+	}
+
+	if row.ExternalId == "" {
+		row.Error = "missing FITID"
+		return row
+	}
+
+	date, err := parseOFXDate(ofxField(block, "DTPOSTED"))
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Date = date
+
+	rawAmount := ofxField(block, "TRNAMT")
+	amount, err := money.Parse(strings.ReplaceAll(rawAmount, ",", "."))
+	if err != nil {
+		row.Error = fmt.Sprintf("invalid amount %q", rawAmount)
+		return row
+	}
+	if amount == 0 {
+		row.Error = "amount is zero"
+		return row
+	}
+	row.Amount, row.TypeTransation = signedAmount(amount)
+
+	if row.Name == "" {
+		row.Error = "description is empty"
+	}
+	return row
+}
+
+func ofxField(block string, tag string) string {
+	match := ofxFieldPatterns[tag].FindStringSubmatch(block)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(match[1]))
+}
+
+// parseOFXDate reads the date part of an OFX datetime such as 20240115120000.000[-5:EST].
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// signedAmount splits a statement amount into its absolute value and transaction type.
+func signedAmount(amount money.Money) (money.Money, string) {
+	if amount < 0 {
+		return amount * -1, bill
+	}
+	return amount, income
+}
+
+// rowToTransaction builds the transaction for a valid row. Bills are stored with negative amounts.
+func rowToTransaction(row *importer.ImportRow, accountId string) *transaction.Transaction {
+	amount := row.Amount
+	if row.TypeTransation == bill {
+		amount = amount * -1
+	}
+	t := transaction.NewTransaction("", row.Name, row.Name, row.TypeTransation, accountId, "", amount)
+	t.ExternalId = row.ExternalId
+	t.CreatedAt = row.Date
+	return t
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+func TestParseOFX_SGML(t *testing.T) {
+	statement := `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240115120000.000[-5:EST]
+<TRNAMT>-42.50
+<FITID>20240115001
+<NAME>SUPERMARKET &amp; CO
+<MEMO>Card 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240116
+<TRNAMT>2500,00
+<FITID>20240116001
+<MEMO>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240117
+<TRNAMT>-10
+<NAME>No reference
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+	transactions, rejected, err := ParseOFX(strings.NewReader(statement), "acc_1")
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "SUPERMARKET & CO", transactions[0].Name)
+	assert.Equal(t, money.Money(-4250), transactions[0].Amount)
+	assert.Equal(t, "bill", transactions[0].TypeTransation)
+	assert.Equal(t, "20240115001", transactions[0].ExternalId)
+	assert.Equal(t, "acc_1", transactions[0].AccountId)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), transactions[0].CreatedAt)
+
+	assert.Equal(t, "Salary", transactions[1].Name)
+	assert.Equal(t, money.FromUnits(2500), transactions[1].Amount)
+	assert.Equal(t, "income", transactions[1].TypeTransation)
+
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, 3, rejected[0].Line)
+	assert.Equal(t, "missing FITID", rejected[0].Error)
+}
+
+func TestParseOFX_XML(t *testing.T) {
+	statement := `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240201</DTPOSTED><TRNAMT>-9.99</TRNAMT><FITID>A1</FITID><NAME>Streaming</NAME></STMTTRN>
+</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+	transactions, rejected, err := ParseOFX(strings.NewReader(statement), "acc_1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, rejected)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, "Streaming", transactions[0].Name)
+	assert.Equal(t, money.Money(-999), transactions[0].Amount)
+	assert.Equal(t, "A1", transactions[0].ExternalId)
+}
+
+func TestParseOFX_NotOFX(t *testing.T) {
+	_, _, err := ParseOFX(strings.NewReader("Date,Amount\n2024-01-01,10"), "acc_1")
+
+	assert.Error(t, err)
+}
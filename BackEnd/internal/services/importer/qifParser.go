@@ -0,0 +1,189 @@
+package importer
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/importer"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+)
+
+// qifAccountTypes are the QIF sections that hold plain account transactions.
+var qifAccountTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+type qifRecord struct {
+	line   int
+	date   string
+	amount string
+	payee  string
+	memo   string
+}
+
+// ParseQIF reads a QIF statement and returns the transactions for the account. QIF has no bank reference,
+// so the external id is a hash of the record contents plus its occurrence in the file, which keeps
+// re-imports of the same file idempotent. dateFormat is one of importer.DateFormats and accepts
+// unpadded days and months as well as two digit years (1/5'24).
+func ParseQIF(reader io.Reader, accountId string, dateFormat string) ([]*transaction.Transaction, []*importer.ImportRow, error) {
+	layouts, err := qifDateLayouts(dateFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, err := readQIFRecords(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transactions []*transaction.Transaction
+	var rejected []*importer.ImportRow
+	occurrences := make(map[string]int)
+	for _, record := range records {
+		row := parseQIFRecord(record, layouts)
+		if !row.IsValid() {
+			rejected = append(rejected, row)
+			continue
+		}
+
+		key := strings.Join([]string{row.Date.Format("2006-01-02"), row.Amount.String(), row.TypeTransation, row.Name, record.memo}, "|")
+		occurrences[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, occurrences[key])))
+		row.ExternalId = "qif:" + hex.EncodeToString(sum[:])
+
+		t := rowToTransaction(row, accountId)
+		if record.memo != "" {
+			t.Description = record.memo
+		}
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rejected, nil
+}
+
+func readQIFRecords(reader io.Reader) ([]*qifRecord, error) {
+	scanner := bufio.NewScanner(reader)
+	var records []*qifRecord
+	current := &qifRecord{}
+	inAccountSection := true
+	sawHeader := false
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			if strings.HasPrefix(header, "!type:") {
+				sawHeader = true
+				inAccountSection = qifAccountTypes[strings.TrimPrefix(header, "!type:")]
+			} else if strings.HasPrefix(header, "!account") {
+				inAccountSection = false
+			}
+			continue
+		}
+		if !inAccountSection {
+			continue
+		}
+
+		if current.line == 0 {
+			current.line = line
+		}
+		code, value := text[0], strings.TrimSpace(text[1:])
+		switch code {
+		case 'D':
+			current.date = value
+		case 'T':
+			current.amount = value
+		case 'U':
+			if current.amount == "" {
+				current.amount = value
+			}
+		case 'P':
+			current.payee = value
+		case 'M':
+			current.memo = value
+		case '^':
+			records = append(records, current)
+			current = &qifRecord{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current.line != 0 {
+		records = append(records, current)
+	}
+	if !sawHeader && len(records) == 0 {
+		return nil, errors.New("not a QIF file")
+	}
+
+	return records, nil
+}
+
+func parseQIFRecord(record *qifRecord, layouts []string) *importer.ImportRow {
+	row := &importer.ImportRow{Line: record.line, Name: strings.Join(strings.Fields(record.payee), " ")}
+	if row.Name == "" {
+		row.Name = strings.Join(strings.Fields(record.memo), " ")
+	}
+
+	date, err := parseQIFDate(record.date, layouts)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Date = date
+
+	amount, err := parseAmount(record.amount, ".")
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	if amount == 0 {
+		row.Error = "amount is zero"
+		return row
+	}
+	row.Amount, row.TypeTransation = signedAmount(amount)
+
+	if row.Name == "" {
+		row.Error = "description is empty"
+	}
+	return row
+}
+
+// qifDateLayouts turns a mapping date format into unpadded Go layouts for four and two digit years.
+func qifDateLayouts(dateFormat string) ([]string, error) {
+	if _, ok := importer.DateFormats[dateFormat]; !ok {
+		return nil, fmt.Errorf("unsupported date format: %s", dateFormat)
+	}
+	unpadded := strings.NewReplacer("MM", "1", "DD", "2").Replace(dateFormat)
+	return []string{
+		strings.Replace(unpadded, "YYYY", "2006", 1),
+		strings.Replace(unpadded, "YYYY", "06", 1),
+	}, nil
+}
+
+func parseQIFDate(value string, layouts []string) (time.Time, error) {
+	// Quicken writes years after 2000 as 1/5'24 and sometimes pads days with spaces.
+	normalized := strings.ReplaceAll(strings.ReplaceAll(value, "'", "/"), " ", "")
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, normalized); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+)
+
+const qifStatement = `!Type:Bank
+D1/15'24
+T-42.50
+PSUPERMARKET
+MCard 1234
+^
+D01/16/2024
+T2,500.00
+PSalary
+^
+D1/15'24
+T-42.50
+PSUPERMARKET
+MCard 1234
+^
+D13/45/2024
+T-1
+PBroken
+^
+!Type:Cat
+NGroceries
+E
+^
+`
+
+func TestParseQIF(t *testing.T) {
+	transactions, rejected, err := ParseQIF(strings.NewReader(qifStatement), "acc_1", "MM/DD/YYYY")
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 3)
+	assert.Equal(t, "SUPERMARKET", transactions[0].Name)
+	assert.Equal(t, "Card 1234", transactions[0].Description)
+	assert.Equal(t, money.Money(-4250), transactions[0].Amount)
+	assert.Equal(t, "bill", transactions[0].TypeTransation)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), transactions[0].CreatedAt)
+	assert.Equal(t, money.FromUnits(2500), transactions[1].Amount)
+	assert.Equal(t, "income", transactions[1].TypeTransation)
+
+	// Identical records get distinct but stable external ids
+	assert.NotEqual(t, transactions[0].ExternalId, transactions[2].ExternalId)
+	again, _, err := ParseQIF(strings.NewReader(qifStatement), "acc_1", "MM/DD/YYYY")
+	assert.NoError(t, err)
+	assert.Equal(t, transactions[0].ExternalId, again[0].ExternalId)
+	assert.Equal(t, transactions[2].ExternalId, again[2].ExternalId)
+
+	assert.Len(t, rejected, 1)
+	assert.Contains(t, rejected[0].Error, "invalid date")
+}
+
+func TestParseQIF_DateFormat(t *testing.T) {
+	statement := "!Type:CCard\nD15/01/2024\nT-5\nPBakery\n^\n"
+
+	transactions, rejected, err := ParseQIF(strings.NewReader(statement), "acc_1", "DD/MM/YYYY")
+
+	assert.NoError(t, err)
+	assert.Empty(t, rejected)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), transactions[0].CreatedAt)
+
+	_, _, err = ParseQIF(strings.NewReader(statement), "acc_1", "D.M.Y")
+	assert.Error(t, err)
+}
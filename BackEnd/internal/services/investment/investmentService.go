@@ -2,32 +2,52 @@ package investment
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/investment"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/osmait/gestorDePresupuesto/internal/services/quote"
 	"github.com/segmentio/ksuid"
 )
 
 // InvestmentService handles business logic related to investment management.
 type InvestmentService struct {
-	repo         investment.InvestmentRepository
-	quoteService *quote.QuoteService
+	repo              investment.InvestmentRepository
+	accountRepository accountRepo.AccountRepositoryInterface
+	quoteService      *quote.QuoteService
+	auditService      *auditSvc.AuditService
 }
 
 // NewInvestmentService creates a new instance of InvestmentService.
-func NewInvestmentService(repo investment.InvestmentRepository, quoteService *quote.QuoteService) *InvestmentService {
-	return &InvestmentService{repo: repo, quoteService: quoteService}
+// accountRepository resolves the brokerage accounts investments are linked to.
+// auditService may be nil, in which case changes are not recorded in the audit log.
+func NewInvestmentService(repo investment.InvestmentRepository, accountRepository accountRepo.AccountRepositoryInterface, quoteService *quote.QuoteService, auditService *auditSvc.AuditService) *InvestmentService {
+	return &InvestmentService{repo: repo, accountRepository: accountRepository, quoteService: quoteService, auditService: auditService}
 }
 
-// Create records a new investment for a user.
-func (s *InvestmentService) Create(ctx context.Context, id, userId string, investmentType investment.InvestmentType, name, symbol string, quantity, purchasePrice, currentPrice float64) error {
+// Create records a new investment for a user, optionally held in one of their brokerage accounts.
+func (s *InvestmentService) Create(ctx context.Context, id, userId string, investmentType investment.InvestmentType, name, symbol string, quantity, purchasePrice, currentPrice float64, accountId string) error {
 	if id == "" {
 		id = ksuid.New().String()
 	}
+	if err := s.checkBrokerage(ctx, accountId, userId); err != nil {
+		return err
+	}
 	inv := investment.NewInvestment(id, userId, investmentType, name, symbol, quantity, purchasePrice, currentPrice)
-	return s.repo.Save(ctx, inv)
+	inv.AccountId = accountId
+	if err := s.repo.Save(ctx, inv); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, audit.EntityInvestment, id, audit.ActionCreate, userId, nil, inv)
+	return nil
 }
 
 // FindAll retrieves all investments for a user, automatically updating quotes if stale.
@@ -80,13 +100,64 @@ func (s *InvestmentService) FindAll(ctx context.Context, userId string) ([]*inve
 	return investments, nil
 }
 
-// Update modifies an existing investment.
-func (s *InvestmentService) Update(ctx context.Context, inv *investment.Investment) error {
+// Update modifies an existing investment of the user.
+func (s *InvestmentService) Update(ctx context.Context, inv *investment.Investment, userId string) error {
+	current, err := s.findOwned(ctx, inv.ID, userId)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBrokerage(ctx, inv.AccountId, userId); err != nil {
+		return err
+	}
+	inv.UserID = userId
+	inv.CreatedAt = current.CreatedAt
 	inv.UpdatedAt = time.Now()
-	return s.repo.Update(ctx, inv)
+	if err := s.repo.Update(ctx, inv); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, audit.EntityInvestment, inv.ID, audit.ActionUpdate, userId, current, inv)
+	return nil
+}
+
+// Delete removes an investment of the user by its ID.
+func (s *InvestmentService) Delete(ctx context.Context, id string, userId string) error {
+	current, err := s.findOwned(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, audit.EntityInvestment, id, audit.ActionDelete, userId, current, nil)
+	return nil
 }
 
-// Delete removes an investment by its ID.
-func (s *InvestmentService) Delete(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+// findOwned loads an investment, reporting the investments of other users as not found.
+func (s *InvestmentService) findOwned(ctx context.Context, id string, userId string) (*investment.Investment, error) {
+	current, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.UserID != userId {
+		return nil, errorhttp.ErrNotFound
+	}
+	return current, nil
+}
+
+// checkBrokerage accepts an empty account id, or the id of a brokerage account of the user.
+func (s *InvestmentService) checkBrokerage(ctx context.Context, accountId string, userId string) error {
+	if accountId == "" {
+		return nil
+	}
+	acc, err := s.accountRepository.FindByIdAndUserId(ctx, accountId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: account %s not found", errorhttp.ErrBadRequest, accountId)
+	}
+	if err != nil {
+		return err
+	}
+	if acc.Type != account.TypeBrokerage {
+		return fmt.Errorf("%w: investments can only be held in brokerage accounts", errorhttp.ErrBadRequest)
+	}
+	return nil
 }
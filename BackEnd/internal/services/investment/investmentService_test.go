@@ -8,6 +8,7 @@ import (
 	domainInvestment "github.com/osmait/gestorDePresupuesto/internal/domain/investment"
 
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -47,7 +48,7 @@ func (m *MockInvestmentRepository) Delete(ctx context.Context, id string) error
 
 func TestCreateInvestment(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	investment := utils.GetNewRandomInvestment()
@@ -62,7 +63,7 @@ func TestCreateInvestment(t *testing.T) {
 			inv.CurrentPrice == investment.CurrentPrice
 	})).Return(nil)
 
-	err := investmentService.Create(ctx, investment.ID, investment.UserID, "stock", investment.Name, investment.Symbol, investment.Quantity, investment.PurchasePrice, investment.CurrentPrice)
+	err := investmentService.Create(ctx, investment.ID, investment.UserID, "stock", investment.Name, investment.Symbol, investment.Quantity, investment.PurchasePrice, investment.CurrentPrice, "")
 
 	assert.NoError(t, err, "CreateInvestment should not return an error")
 	mockRepo.AssertExpectations(t)
@@ -70,7 +71,7 @@ func TestCreateInvestment(t *testing.T) {
 
 func TestCreateInvestment_RepositoryError(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	investment := utils.GetNewRandomInvestment()
@@ -79,7 +80,7 @@ func TestCreateInvestment_RepositoryError(t *testing.T) {
 		return inv.ID == investment.ID
 	})).Return(ErrRepositoryFailure)
 
-	err := investmentService.Create(ctx, investment.ID, investment.UserID, "stock", investment.Name, investment.Symbol, investment.Quantity, investment.PurchasePrice, investment.CurrentPrice)
+	err := investmentService.Create(ctx, investment.ID, investment.UserID, "stock", investment.Name, investment.Symbol, investment.Quantity, investment.PurchasePrice, investment.CurrentPrice, "")
 
 	assert.Error(t, err, "CreateInvestment should return an error when repository fails")
 	assert.Equal(t, ErrRepositoryFailure, err)
@@ -88,7 +89,7 @@ func TestCreateInvestment_RepositoryError(t *testing.T) {
 
 func TestFindAllInvestments(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	userId := "test-user-id"
@@ -111,7 +112,7 @@ func TestFindAllInvestments(t *testing.T) {
 
 func TestFindAllInvestments_EmptyResult(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	userId := "test-user-id"
@@ -128,7 +129,7 @@ func TestFindAllInvestments_EmptyResult(t *testing.T) {
 
 func TestFindAllInvestments_RepositoryError(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	userId := "test-user-id"
@@ -145,29 +146,47 @@ func TestFindAllInvestments_RepositoryError(t *testing.T) {
 
 func TestDeleteInvestment(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
 	investment := utils.GetNewRandomInvestment()
 
+	mockRepo.On("FindByID", ctx, investment.ID).Return(investment, nil)
 	mockRepo.On("Delete", ctx, investment.ID).Return(nil)
 
-	err := investmentService.Delete(ctx, investment.ID)
+	err := investmentService.Delete(ctx, investment.ID, investment.UserID)
 
 	assert.NoError(t, err, "Delete should not return an error")
 	mockRepo.AssertExpectations(t)
 }
 
+func TestDeleteInvestment_OtherUser(t *testing.T) {
+	mockRepo := &MockInvestmentRepository{}
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
+
+	ctx := context.Background()
+	investment := utils.GetNewRandomInvestment()
+
+	mockRepo.On("FindByID", ctx, investment.ID).Return(investment, nil)
+
+	err := investmentService.Delete(ctx, investment.ID, "another-user")
+
+	assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "Delete", ctx, investment.ID)
+}
+
 func TestDeleteInvestment_RepositoryError(t *testing.T) {
 	mockRepo := &MockInvestmentRepository{}
-	investmentService := NewInvestmentService(mockRepo, nil)
+	investmentService := NewInvestmentService(mockRepo, nil, nil, nil)
 
 	ctx := context.Background()
-	investmentId := "test-id"
+	investment := utils.GetNewRandomInvestment()
+	investmentId := investment.ID
 
+	mockRepo.On("FindByID", ctx, investmentId).Return(investment, nil)
 	mockRepo.On("Delete", ctx, investmentId).Return(ErrRepositoryFailure)
 
-	err := investmentService.Delete(ctx, investmentId)
+	err := investmentService.Delete(ctx, investmentId, investment.UserID)
 
 	assert.Error(t, err, "Delete should return an error when repository fails")
 	assert.Equal(t, ErrRepositoryFailure, err)
@@ -0,0 +1,124 @@
+package networth
+
+import (
+	"context"
+	"time"
+
+	domainFx "github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	investmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/investment"
+	netWorthRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/networth"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
+	"github.com/rs/zerolog/log"
+)
+
+// NetWorthService adds up the accounts and investments of a user in their base currency. Investments are valued
+// at their quantity times their current price, which is taken to be in the base currency.
+type NetWorthService struct {
+	netWorthRepository   netWorthRepo.NetWorthRepoInterface
+	accountRepository    accountRepo.AccountRepositoryInterface
+	investmentRepository investmentRepo.InvestmentRepoInterface
+	userRepository       userRepo.UserRepositoryInterface
+	fxService            *fx.FxService
+}
+
+func NewNetWorthService(netWorthRepository netWorthRepo.NetWorthRepoInterface, accountRepository accountRepo.AccountRepositoryInterface, investmentRepository investmentRepo.InvestmentRepoInterface, userRepository userRepo.UserRepositoryInterface, fxService *fx.FxService) *NetWorthService {
+	return &NetWorthService{
+		netWorthRepository:   netWorthRepository,
+		accountRepository:    accountRepository,
+		investmentRepository: investmentRepository,
+		userRepository:       userRepository,
+		fxService:            fxService,
+	}
+}
+
+// Current returns the net worth of the user right now, with the value of every account and investment in it.
+func (s *NetWorthService) Current(ctx context.Context, userId string) (*networth.Snapshot, error) {
+	converter, err := s.fxService.Converter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.accountRepository.FindAll(ctx, userId, true)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.accountRepository.Balances(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	investments, err := s.investmentRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without exchange rates every amount is taken as it is, in the default currency
+	currency := converter.Base()
+	if currency == "" {
+		currency = domainFx.DefaultCurrency
+	}
+	snapshot := networth.NewSnapshot(currency, time.Now())
+	for _, acc := range accounts {
+		value := networth.AccountValue{
+			AccountId:   acc.Id,
+			Name:        acc.Name,
+			Type:        acc.Type,
+			IsLiability: acc.IsLiability(),
+			Currency:    acc.Currency,
+			Balance:     balances[acc.Id] + acc.InitialBalance,
+		}
+		if converted, ok := converter.Convert(value.Balance, acc.Currency); ok {
+			value.Converted = &converted
+		}
+		snapshot.AddAccount(value)
+	}
+	for _, inv := range investments {
+		snapshot.AddInvestment(networth.InvestmentValue{
+			InvestmentId: inv.ID,
+			Name:         inv.Name,
+			Symbol:       inv.Symbol,
+			Value:        money.FromFloat(inv.Quantity * inv.CurrentPrice),
+		})
+	}
+	return snapshot, nil
+}
+
+// History returns the net worth recorded for every month between from and to. Months without a record, such as
+// those before the worker first ran, are left out.
+func (s *NetWorthService) History(ctx context.Context, userId string, from time.Time, to time.Time) ([]*networth.MonthlyNetWorth, error) {
+	return s.netWorthRepository.FindRange(ctx, userId, networth.MonthStart(from), networth.MonthStart(to))
+}
+
+// RecordUser records the current net worth of the user as the one of the current month.
+func (s *NetWorthService) RecordUser(ctx context.Context, userId string) error {
+	snapshot, err := s.Current(ctx, userId)
+	if err != nil {
+		return err
+	}
+	return s.netWorthRepository.Save(ctx, &networth.MonthlyNetWorth{
+		UserId:    userId,
+		Month:     networth.MonthStart(snapshot.AsOf),
+		Totals:    snapshot.Totals,
+		UpdatedAt: snapshot.AsOf,
+	})
+}
+
+// Record records the net worth of every user for the current month and returns how many were recorded. A user
+// that fails is logged and skipped, so one bad account does not stop the rest.
+func (s *NetWorthService) Record(ctx context.Context) (int, error) {
+	users, err := s.userRepository.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	recorded := 0
+	for _, user := range users {
+		if err := s.RecordUser(ctx, user.Id); err != nil {
+			log.Error().Err(err).Str("user_id", user.Id).Msg("failed to record net worth")
+			continue
+		}
+		recorded++
+	}
+	return recorded, nil
+}
@@ -0,0 +1,148 @@
+package networth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	domainFx "github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/investment"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/networth"
+	domainUser "github.com/osmait/gestorDePresupuesto/internal/domain/user"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	investmentRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/investment"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryAccounts struct {
+	accountRepo.AccountRepositoryInterface
+	accounts []*account.Account
+	balances map[string]money.Money
+}
+
+func (m *memoryAccounts) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	var accounts []*account.Account
+	for _, acc := range m.accounts {
+		if acc.UserId == userId {
+			accounts = append(accounts, acc)
+		}
+	}
+	return accounts, nil
+}
+
+func (m *memoryAccounts) Balances(ctx context.Context, userId string) (map[string]money.Money, error) {
+	return m.balances, nil
+}
+
+type memoryInvestments struct {
+	investmentRepo.InvestmentRepoInterface
+	investments []*investment.Investment
+}
+
+func (m *memoryInvestments) FindAll(ctx context.Context, userId string) ([]*investment.Investment, error) {
+	var investments []*investment.Investment
+	for _, inv := range m.investments {
+		if inv.UserID == userId {
+			investments = append(investments, inv)
+		}
+	}
+	return investments, nil
+}
+
+type memoryUsers struct {
+	userRepo.UserRepositoryInterface
+	users []*domainUser.User
+}
+
+func (m *memoryUsers) FindAll(ctx context.Context) ([]*domainUser.User, error) {
+	return m.users, nil
+}
+
+type memoryNetWorth struct {
+	records map[string]*networth.MonthlyNetWorth
+}
+
+func (m *memoryNetWorth) Save(ctx context.Context, record *networth.MonthlyNetWorth) error {
+	m.records[record.UserId+record.Month.Format(networth.MonthLayout)] = record
+	return nil
+}
+
+func (m *memoryNetWorth) FindRange(ctx context.Context, userId string, from time.Time, to time.Time) ([]*networth.MonthlyNetWorth, error) {
+	var records []*networth.MonthlyNetWorth
+	for _, record := range m.records {
+		if record.UserId == userId && !record.Month.Before(from) && !record.Month.After(to) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func newTestService() (*NetWorthService, *memoryNetWorth) {
+	checking := account.NewAccount(money.FromUnits(1000), "acc_checking", "Checking", "Bank")
+	checking.UserId = "user_1"
+	limit := money.FromUnits(2000)
+	card := account.NewAccount(0, "acc_card", "Visa", "Bank")
+	card.UserId = "user_1"
+	card.Type = account.TypeCreditCard
+	card.CreditLimit = &limit
+	loan := account.NewAccount(money.FromUnits(-5000), "acc_loan", "Mortgage", "Bank")
+	loan.UserId = "user_1"
+	loan.Type = account.TypeLoan
+
+	accounts := &memoryAccounts{
+		accounts: []*account.Account{checking, card, loan},
+		balances: map[string]money.Money{
+			checking.Id: money.FromUnits(250),
+			card.Id:     money.FromUnits(-300),
+			loan.Id:     money.FromUnits(1000),
+		},
+	}
+	investments := &memoryInvestments{investments: []*investment.Investment{
+		investment.NewInvestment("inv_1", "user_1", investment.Stock, "Apple", "AAPL", 2.5, 100, 150.10),
+	}}
+	users := &memoryUsers{users: []*domainUser.User{{Id: "user_1"}}}
+	netWorth := &memoryNetWorth{records: map[string]*networth.MonthlyNetWorth{}}
+
+	return NewNetWorthService(netWorth, accounts, investments, users, nil), netWorth
+}
+
+func TestCurrent(t *testing.T) {
+	service, _ := newTestService()
+
+	snapshot, err := service.Current(context.Background(), "user_1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domainFx.DefaultCurrency, snapshot.Currency)
+	assert.Equal(t, money.FromUnits(1250), snapshot.Assets)
+	assert.Equal(t, money.FromUnits(4300), snapshot.Liabilities)
+	assert.Equal(t, money.FromFloat(375.25), snapshot.Investments)
+	assert.Equal(t, money.FromFloat(1250+375.25-4300), snapshot.NetWorth)
+	assert.Len(t, snapshot.Accounts, 3)
+	assert.True(t, snapshot.Accounts[1].IsLiability)
+	assert.Equal(t, money.FromUnits(-300), snapshot.Accounts[1].Balance)
+	assert.Len(t, snapshot.Holdings, 1)
+}
+
+func TestRecord(t *testing.T) {
+	service, netWorth := newTestService()
+	ctx := context.Background()
+
+	recorded, err := service.Record(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, recorded)
+
+	now := time.Now()
+	history, err := service.History(ctx, "user_1", now.AddDate(0, -1, 0), now)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, networth.MonthStart(now), history[0].Month)
+	assert.Equal(t, money.FromFloat(1250+375.25-4300), history[0].NetWorth)
+
+	// Recording again in the same month replaces the record
+	_, err = service.Record(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, netWorth.records, 1)
+}
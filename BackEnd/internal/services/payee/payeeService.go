@@ -0,0 +1,153 @@
+package payee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/payee"
+	payeeRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/segmentio/ksuid"
+)
+
+// PayeeService manages the payees of a user. Saving a payee links the transactions without a payee whose name
+// matches it, so existing history is grouped as soon as an alias is added.
+type PayeeService struct {
+	payeeRepository payeeRepo.PayeeRepoInterface
+	cache           cache.CacheRepository
+}
+
+// NewPayeeService creates a new instance of PayeeService.
+func NewPayeeService(payeeRepository payeeRepo.PayeeRepoInterface, cache cache.CacheRepository) *PayeeService {
+	return &PayeeService{
+		payeeRepository: payeeRepository,
+		cache:           cache,
+	}
+}
+
+// CreatePayee stores a new payee and links the matching transactions to it.
+func (s *PayeeService) CreatePayee(ctx context.Context, userId string, request *dto.PayeeRequest) (*dto.PayeeResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.checkNameFree(ctx, request.Name, "", userId); err != nil {
+		return nil, err
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	payee := payee.NewPayee(uuid.String(), userId, request.Name, request.Aliases)
+	if err := s.payeeRepository.Save(ctx, payee); err != nil {
+		return nil, err
+	}
+
+	response := dto.NewPayeeResponse(payee)
+	if response.Linked, err = s.linkTransactions(ctx, userId, payee.Id); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// FindPayees retrieves the payees of a user in alphabetical order.
+func (s *PayeeService) FindPayees(ctx context.Context, userId string) ([]*dto.PayeeResponse, error) {
+	payees, err := s.payeeRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	payeeResponses := make([]*dto.PayeeResponse, 0, len(payees))
+	for _, payee := range payees {
+		payeeResponses = append(payeeResponses, dto.NewPayeeResponse(payee))
+	}
+	return payeeResponses, nil
+}
+
+// UpdatePayee renames a payee and replaces its aliases. Transactions already linked to it stay linked, and those
+// without a payee that match the new aliases are linked.
+func (s *PayeeService) UpdatePayee(ctx context.Context, id string, userId string, request *dto.PayeeRequest) (*dto.PayeeResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	current, err := s.payeeRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+	if err := s.checkNameFree(ctx, request.Name, id, userId); err != nil {
+		return nil, err
+	}
+
+	updated := payee.NewPayee(current.Id, userId, request.Name, request.Aliases)
+	updated.CreatedAt = current.CreatedAt
+	if err := s.payeeRepository.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+
+	response := dto.NewPayeeResponse(updated)
+	if response.Linked, err = s.linkTransactions(ctx, userId, updated.Id); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeletePayee removes a payee; its transactions are kept without a payee.
+func (s *PayeeService) DeletePayee(ctx context.Context, id string, userId string) error {
+	err := s.payeeRepository.Delete(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	return nil
+}
+
+// checkNameFree rejects a name already used by another payee of the user.
+func (s *PayeeService) checkNameFree(ctx context.Context, name string, id string, userId string) error {
+	existing, err := s.payeeRepository.FindByName(ctx, name, userId)
+	if err == nil && existing.Id != id {
+		return fmt.Errorf("%w: a payee named %q already exists", errorhttp.ErrBadRequest, name)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// linkTransactions matches the transactions without a payee against every payee of the user and links those that
+// now belong to the saved one. The other payees take part so a more specific alias of another payee still wins.
+func (s *PayeeService) linkTransactions(ctx context.Context, userId string, payeeId string) (int, error) {
+	payees, err := s.payeeRepository.FindAll(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	candidates, err := s.payeeRepository.FindCandidates(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	var matched []string
+	for _, candidate := range candidates {
+		if found := payee.Match(payees, candidate.Name); found != nil && found.Id == payeeId {
+			matched = append(matched, candidate.TransactionId)
+		}
+	}
+	linked, err := s.payeeRepository.Assign(ctx, userId, payeeId, matched)
+	if err != nil {
+		return 0, err
+	}
+	if linked > 0 {
+		s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	}
+	return linked, nil
+}
@@ -0,0 +1,148 @@
+package payee
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPayeeRepository struct {
+	mock.Mock
+}
+
+func (m *MockPayeeRepository) Save(ctx context.Context, payee *payee.Payee) error {
+	args := m.Called(ctx, payee)
+	return args.Error(0)
+}
+
+func (m *MockPayeeRepository) Update(ctx context.Context, payee *payee.Payee) error {
+	args := m.Called(ctx, payee)
+	return args.Error(0)
+}
+
+func (m *MockPayeeRepository) FindAll(ctx context.Context, userId string) ([]*payee.Payee, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*payee.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) FindById(ctx context.Context, id string, userId string) (*payee.Payee, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*payee.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) FindByName(ctx context.Context, name string, userId string) (*payee.Payee, error) {
+	args := m.Called(ctx, name, userId)
+	return args.Get(0).(*payee.Payee), args.Error(1)
+}
+
+func (m *MockPayeeRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockPayeeRepository) FindCandidates(ctx context.Context, userId string) ([]*payee.Candidate, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*payee.Candidate), args.Error(1)
+}
+
+func (m *MockPayeeRepository) Assign(ctx context.Context, userId string, payeeId string, transactionIds []string) (int, error) {
+	args := m.Called(ctx, userId, payeeId, transactionIds)
+	return args.Int(0), args.Error(1)
+}
+
+type MockCache struct {
+	mock.Mock
+}
+
+func (m *MockCache) Get(key string) (interface{}, bool) {
+	args := m.Called(key)
+	return args.Get(0), args.Bool(1)
+}
+
+func (m *MockCache) Set(key string, value interface{}, duration time.Duration) {
+	m.Called(key, value, duration)
+}
+
+func (m *MockCache) Delete(key string) {
+	m.Called(key)
+}
+
+func (m *MockCache) DeleteByPrefix(prefix string) {
+	m.Called(prefix)
+}
+
+func (m *MockCache) Flush() {
+	m.Called()
+}
+
+func TestPayeeService_CreatePayee_LinksMatchingTransactions(t *testing.T) {
+	mockRepo := &MockPayeeRepository{}
+	mockCache := &MockCache{}
+	s := NewPayeeService(mockRepo, mockCache)
+
+	uberEats := payee.NewPayee("payee_uber_eats", "user_1", "Uber Eats", nil)
+	mockRepo.On("FindByName", mock.Anything, "Uber", "user_1").Return((*payee.Payee)(nil), sql.ErrNoRows)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(p *payee.Payee) bool {
+		return p.UserId == "user_1" && p.Name == "Uber" && len(p.Aliases) == 1 && p.Aliases[0] == "uber trip"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		saved := args.Get(1).(*payee.Payee)
+		mockRepo.On("FindAll", mock.Anything, "user_1").Return([]*payee.Payee{saved, uberEats}, nil)
+	})
+	mockRepo.On("FindCandidates", mock.Anything, "user_1").Return([]*payee.Candidate{
+		{TransactionId: "txn_ride", Name: "UBER *TRIP 8812"},
+		{TransactionId: "txn_dinner", Name: "UBER *EATS 1234"},
+		{TransactionId: "txn_bus", Name: "Bus ticket"},
+	}, nil)
+	mockRepo.On("Assign", mock.Anything, "user_1", mock.Anything, []string{"txn_ride"}).Return(1, nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+
+	response, err := s.CreatePayee(context.Background(), "user_1", &dto.PayeeRequest{Name: " Uber ", Aliases: []string{"UBER *TRIP"}})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Id)
+	assert.Equal(t, "Uber", response.Name)
+	assert.Equal(t, 1, response.Linked)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestPayeeService_CreatePayee_RejectsTakenName(t *testing.T) {
+	mockRepo := &MockPayeeRepository{}
+	s := NewPayeeService(mockRepo, &MockCache{})
+	mockRepo.On("FindByName", mock.Anything, "Uber", "user_1").Return(payee.NewPayee("payee_uber", "user_1", "Uber", nil), nil)
+
+	_, err := s.CreatePayee(context.Background(), "user_1", &dto.PayeeRequest{Name: "Uber"})
+
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestPayeeService_UpdatePayee_NotFound(t *testing.T) {
+	mockRepo := &MockPayeeRepository{}
+	s := NewPayeeService(mockRepo, &MockCache{})
+	mockRepo.On("FindById", mock.Anything, "payee_missing", "user_1").Return((*payee.Payee)(nil), sql.ErrNoRows)
+
+	_, err := s.UpdatePayee(context.Background(), "payee_missing", "user_1", &dto.PayeeRequest{Name: "Uber"})
+
+	assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+}
+
+func TestPayeeService_DeletePayee(t *testing.T) {
+	mockRepo := &MockPayeeRepository{}
+	mockCache := &MockCache{}
+	s := NewPayeeService(mockRepo, mockCache)
+	mockRepo.On("Delete", mock.Anything, "payee_uber", "user_1").Return(nil)
+	mockRepo.On("Delete", mock.Anything, "payee_missing", "user_1").Return(sql.ErrNoRows)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+
+	assert.NoError(t, s.DeletePayee(context.Background(), "payee_uber", "user_1"))
+	assert.ErrorIs(t, s.DeletePayee(context.Background(), "payee_missing", "user_1"), errorhttp.ErrNotFound)
+	mockCache.AssertNumberOfCalls(t, "DeleteByPrefix", 1)
+}
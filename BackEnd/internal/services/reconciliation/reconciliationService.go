@@ -0,0 +1,176 @@
+package reconciliation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/reconciliation"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	reconciliationRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/reconciliation"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/segmentio/ksuid"
+)
+
+// ReconciliationService checks bank statements against the cleared transactions of an account. Each account has at
+// most one open reconciliation; completing it locks the transactions it matched.
+type ReconciliationService struct {
+	reconciliationRepository reconciliationRepo.ReconciliationRepoInterface
+	accountRepository        accountRepo.AccountRepositoryInterface
+	cache                    cache.CacheRepository
+}
+
+// NewReconciliationService creates a new instance of ReconciliationService.
+func NewReconciliationService(reconciliationRepository reconciliationRepo.ReconciliationRepoInterface, accountRepository accountRepo.AccountRepositoryInterface, cache cache.CacheRepository) *ReconciliationService {
+	return &ReconciliationService{
+		reconciliationRepository: reconciliationRepository,
+		accountRepository:        accountRepository,
+		cache:                    cache,
+	}
+}
+
+// StartReconciliation opens a reconciliation of the account for a statement, or updates the statement of the one
+// already open, and returns it with the difference against the cleared transactions.
+func (s *ReconciliationService) StartReconciliation(ctx context.Context, accountId string, userId string, request *dto.ReconciliationRequest) (*dto.ReconciliationResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	reconciliations, err := s.reconciliationRepository.FindByAccount(ctx, accountId, userId)
+	if err != nil {
+		return nil, err
+	}
+	var open *reconciliation.Reconciliation
+	for _, rec := range reconciliations {
+		if !rec.IsCompleted() {
+			open = rec
+			continue
+		}
+		if !request.Date.After(rec.StatementDate) {
+			return nil, fmt.Errorf("%w: the statement of %s is already reconciled", errorhttp.ErrBadRequest, rec.StatementDate.Format(dto.StatementDateLayout))
+		}
+	}
+
+	if open == nil {
+		uuid, err := ksuid.NewRandom()
+		if err != nil {
+			return nil, err
+		}
+		open = reconciliation.NewReconciliation(uuid.String(), accountId, userId, request.Date, request.StatementBalance)
+		if err := s.refreshClearedBalance(ctx, open); err != nil {
+			return nil, err
+		}
+		if err := s.reconciliationRepository.Save(ctx, open); err != nil {
+			return nil, err
+		}
+		return dto.NewReconciliationResponse(open), nil
+	}
+
+	open.StatementDate = request.Date
+	open.StatementBalance = request.StatementBalance
+	if err := s.refreshClearedBalance(ctx, open); err != nil {
+		return nil, err
+	}
+	if err := s.reconciliationRepository.Update(ctx, open); err != nil {
+		return nil, err
+	}
+	return dto.NewReconciliationResponse(open), nil
+}
+
+// FindReconciliations retrieves the reconciliations of an account, latest statement first.
+func (s *ReconciliationService) FindReconciliations(ctx context.Context, accountId string, userId string) ([]*dto.ReconciliationResponse, error) {
+	if _, err := s.accountRepository.FindByIdAndUserId(ctx, accountId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+
+	reconciliations, err := s.reconciliationRepository.FindByAccount(ctx, accountId, userId)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*dto.ReconciliationResponse, 0, len(reconciliations))
+	for _, rec := range reconciliations {
+		responses = append(responses, dto.NewReconciliationResponse(rec))
+	}
+	return responses, nil
+}
+
+// FindReconciliation retrieves a reconciliation. The difference of an open one is computed again, since
+// transactions may have been cleared since it was started.
+func (s *ReconciliationService) FindReconciliation(ctx context.Context, id string, userId string) (*dto.ReconciliationResponse, error) {
+	rec, err := s.findReconciliation(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !rec.IsCompleted() {
+		if err := s.refreshClearedBalance(ctx, rec); err != nil {
+			return nil, err
+		}
+	}
+	return dto.NewReconciliationResponse(rec), nil
+}
+
+// CompleteReconciliation locks the cleared transactions of the statement once they match its balance.
+func (s *ReconciliationService) CompleteReconciliation(ctx context.Context, id string, userId string) (*dto.ReconciliationResponse, error) {
+	rec, err := s.findReconciliation(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if rec.IsCompleted() {
+		return nil, apperrors.NewConflictError("reconciliation", "reconciliation is already completed")
+	}
+	if err := s.refreshClearedBalance(ctx, rec); err != nil {
+		return nil, err
+	}
+	if difference := rec.Difference(); difference != 0 {
+		return nil, fmt.Errorf("%w: cleared transactions differ from the statement by %s", errorhttp.ErrBadRequest, difference)
+	}
+
+	completedAt := time.Now().UTC()
+	rec.Status = reconciliation.StatusCompleted
+	rec.CompletedAt = &completedAt
+	if err := s.reconciliationRepository.Complete(ctx, rec); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewConflictError("reconciliation", "reconciliation is already completed")
+		}
+		return nil, err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	return dto.NewReconciliationResponse(rec), nil
+}
+
+func (s *ReconciliationService) findReconciliation(ctx context.Context, id string, userId string) (*reconciliation.Reconciliation, error) {
+	rec, err := s.reconciliationRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+	return rec, nil
+}
+
+// refreshClearedBalance sets the balance of the account at the end of the statement date, counting only the
+// transactions the bank has confirmed.
+func (s *ReconciliationService) refreshClearedBalance(ctx context.Context, rec *reconciliation.Reconciliation) error {
+	acc, err := s.accountRepository.FindByIdAndUserId(ctx, rec.AccountId, rec.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	cleared, err := s.reconciliationRepository.ClearedBalance(ctx, rec.AccountId, rec.UserId, rec.Until())
+	if err != nil {
+		return err
+	}
+	rec.ClearedBalance = acc.InitialBalance + cleared
+	return nil
+}
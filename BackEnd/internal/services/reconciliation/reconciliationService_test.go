@@ -0,0 +1,256 @@
+package reconciliation
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/reconciliation"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/reconciliation"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) Save(ctx context.Context, reconciliation *reconciliation.Reconciliation) error {
+	args := m.Called(ctx, reconciliation)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) Update(ctx context.Context, reconciliation *reconciliation.Reconciliation) error {
+	args := m.Called(ctx, reconciliation)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) FindById(ctx context.Context, id string, userId string) (*reconciliation.Reconciliation, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*reconciliation.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) FindOpen(ctx context.Context, accountId string, userId string) (*reconciliation.Reconciliation, error) {
+	args := m.Called(ctx, accountId, userId)
+	return args.Get(0).(*reconciliation.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) FindByAccount(ctx context.Context, accountId string, userId string) ([]*reconciliation.Reconciliation, error) {
+	args := m.Called(ctx, accountId, userId)
+	return args.Get(0).([]*reconciliation.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) ClearedBalance(ctx context.Context, accountId string, userId string, until time.Time) (money.Money, error) {
+	args := m.Called(ctx, accountId, userId, until)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) Complete(ctx context.Context, reconciliation *reconciliation.Reconciliation) error {
+	args := m.Called(ctx, reconciliation)
+	return args.Error(0)
+}
+
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountRepository) Save(ctx context.Context, account *account.Account) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	args := m.Called(ctx, userId, includeArchived)
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) Balance(ctx context.Context, id string) (money.Money, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockAccountRepository) Balances(ctx context.Context, userId string) (map[string]money.Money, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(map[string]money.Money), args.Error(1)
+}
+
+func (m *MockAccountRepository) Update(ctx context.Context, account *account.Account) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Search(ctx context.Context, userId string, query string, includeArchived bool) ([]*account.Account, error) {
+	args := m.Called(ctx, userId, query, includeArchived)
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Archive(ctx context.Context, id string, userId string, archivedAt time.Time) error {
+	args := m.Called(ctx, id, userId, archivedAt)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) Unarchive(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindTrash(ctx context.Context, userId string) ([]*account.Account, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+type MockCache struct {
+	mock.Mock
+}
+
+func (m *MockCache) Get(key string) (interface{}, bool) {
+	args := m.Called(key)
+	return args.Get(0), args.Bool(1)
+}
+
+func (m *MockCache) Set(key string, value interface{}, duration time.Duration) {
+	m.Called(key, value, duration)
+}
+
+func (m *MockCache) Delete(key string) {
+	m.Called(key)
+}
+
+func (m *MockCache) DeleteByPrefix(prefix string) {
+	m.Called(prefix)
+}
+
+func (m *MockCache) Flush() {
+	m.Called()
+}
+
+var statementDate = time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+func newAccount() *account.Account {
+	acc := account.NewAccount(money.FromUnits(100), "acc_1", "Checking", "Bank")
+	acc.UserId = "user_1"
+	return acc
+}
+
+func TestStartReconciliation(t *testing.T) {
+	mockRepo := &MockReconciliationRepository{}
+	mockAccountRepo := &MockAccountRepository{}
+	s := NewReconciliationService(mockRepo, mockAccountRepo, &MockCache{})
+
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_1", "user_1").Return(newAccount(), nil)
+	mockRepo.On("FindByAccount", mock.Anything, "acc_1", "user_1").Return([]*reconciliation.Reconciliation{}, nil).Once()
+	mockRepo.On("ClearedBalance", mock.Anything, "acc_1", "user_1", statementDate.AddDate(0, 0, 1)).Return(money.FromUnits(-40), nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	response, err := s.StartReconciliation(context.Background(), "acc_1", "user_1", &dto.ReconciliationRequest{
+		StatementDate:    "2026-01-31",
+		StatementBalance: money.FromUnits(50),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, reconciliation.StatusOpen, response.Status)
+	assert.Equal(t, money.FromUnits(60), response.ClearedBalance)
+	assert.Equal(t, money.FromUnits(-10), response.Difference)
+
+	// Starting again updates the open reconciliation
+	open := reconciliation.NewReconciliation(response.Id, "acc_1", "user_1", statementDate, money.FromUnits(50))
+	mockRepo.On("FindByAccount", mock.Anything, "acc_1", "user_1").Return([]*reconciliation.Reconciliation{open}, nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(rec *reconciliation.Reconciliation) bool {
+		return rec.Id == open.Id && rec.StatementBalance == money.FromUnits(60)
+	})).Return(nil)
+
+	response, err = s.StartReconciliation(context.Background(), "acc_1", "user_1", &dto.ReconciliationRequest{
+		StatementDate:    "2026-01-31",
+		StatementBalance: money.FromUnits(60),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money(0), response.Difference)
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestStartReconciliation_StatementAlreadyReconciled(t *testing.T) {
+	mockRepo := &MockReconciliationRepository{}
+	s := NewReconciliationService(mockRepo, &MockAccountRepository{}, &MockCache{})
+
+	completed := reconciliation.NewReconciliation("rec_1", "acc_1", "user_1", statementDate, money.FromUnits(60))
+	completed.Status = reconciliation.StatusCompleted
+	mockRepo.On("FindByAccount", mock.Anything, "acc_1", "user_1").Return([]*reconciliation.Reconciliation{completed}, nil)
+
+	_, err := s.StartReconciliation(context.Background(), "acc_1", "user_1", &dto.ReconciliationRequest{
+		StatementDate:    "2026-01-15",
+		StatementBalance: money.FromUnits(60),
+	})
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCompleteReconciliation(t *testing.T) {
+	mockRepo := &MockReconciliationRepository{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockCache := &MockCache{}
+	s := NewReconciliationService(mockRepo, mockAccountRepo, mockCache)
+
+	open := reconciliation.NewReconciliation("rec_1", "acc_1", "user_1", statementDate, money.FromUnits(60))
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_1", "user_1").Return(newAccount(), nil)
+	mockRepo.On("FindById", mock.Anything, "rec_1", "user_1").Return(open, nil)
+	mockRepo.On("ClearedBalance", mock.Anything, "acc_1", "user_1", statementDate.AddDate(0, 0, 1)).Return(money.FromUnits(-50), nil).Once()
+
+	// A difference left blocks the completion
+	_, err := s.CompleteReconciliation(context.Background(), "rec_1", "user_1")
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+
+	mockRepo.On("ClearedBalance", mock.Anything, "acc_1", "user_1", statementDate.AddDate(0, 0, 1)).Return(money.FromUnits(-40), nil).Once()
+	mockRepo.On("Complete", mock.Anything, mock.MatchedBy(func(rec *reconciliation.Reconciliation) bool {
+		return rec.Status == reconciliation.StatusCompleted && rec.CompletedAt != nil
+	})).Return(nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+
+	response, err := s.CompleteReconciliation(context.Background(), "rec_1", "user_1")
+	assert.NoError(t, err)
+	assert.Equal(t, reconciliation.StatusCompleted, response.Status)
+	mockCache.AssertExpectations(t)
+
+	_, err = s.CompleteReconciliation(context.Background(), "rec_1", "user_1")
+	assert.True(t, apperrors.IsErrorType(err, apperrors.ErrorTypeConflict))
+}
+
+func TestFindReconciliation_NotFound(t *testing.T) {
+	mockRepo := &MockReconciliationRepository{}
+	s := NewReconciliationService(mockRepo, &MockAccountRepository{}, &MockCache{})
+
+	mockRepo.On("FindById", mock.Anything, "rec_missing", "user_1").Return((*reconciliation.Reconciliation)(nil), sql.ErrNoRows)
+
+	_, err := s.FindReconciliation(context.Background(), "rec_missing", "user_1")
+	assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+}
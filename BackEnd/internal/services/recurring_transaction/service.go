@@ -95,6 +95,7 @@ func (s *RecurringTransactionService) ProcessDueTransactions(ctx context.Context
 			rt.CategoryID,
 			budgetID,
 			time.Now(),
+			nil,
 		)
 
 		if err != nil {
@@ -110,7 +111,7 @@ func (s *RecurringTransactionService) ProcessDueTransactions(ctx context.Context
 		} else {
 			log.Info().Str("recurring_id", rt.ID).Msg("successfully executed recurring transaction")
 			// Notify the user
-			msg := fmt.Sprintf(`{"type": "recurring_executed", "message": "Transaction '%s' executed", "amount": %.2f}`, rt.Name, rt.Amount)
+			msg := fmt.Sprintf(`{"type": "recurring_executed", "message": "Transaction '%s' executed", "amount": %s}`, rt.Name, rt.Amount)
 			s.notificationService.SendToUser(rt.UserID, msg)
 		}
 	}
@@ -0,0 +1,135 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/rule"
+	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	ruleRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/segmentio/ksuid"
+)
+
+// RuleService manages the categorization rules of a user. The rules themselves are applied by the transaction service.
+type RuleService struct {
+	ruleRepository     ruleRepo.RuleRepoInterface
+	categoryRepository categoryRepo.CategoryRepoInterface
+	budgetRepository   budgetRepo.BudgetRepoInterface
+}
+
+// NewRuleService creates a new instance of RuleService.
+func NewRuleService(ruleRepository ruleRepo.RuleRepoInterface, categoryRepository categoryRepo.CategoryRepoInterface, budgetRepository budgetRepo.BudgetRepoInterface) *RuleService {
+	return &RuleService{
+		ruleRepository:     ruleRepository,
+		categoryRepository: categoryRepository,
+		budgetRepository:   budgetRepository,
+	}
+}
+
+// CreateRule stores a new categorization rule.
+func (s *RuleService) CreateRule(ctx context.Context, userId string, request *dto.RuleRequest) (*dto.RuleResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.checkActions(ctx, userId, request); err != nil {
+		return nil, err
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	rule := rule.NewRule(uuid.String(), userId, request.Name, request.Priority)
+	applyRuleRequest(rule, request)
+
+	if err := s.ruleRepository.Save(ctx, rule); err != nil {
+		return nil, err
+	}
+	return dto.NewRuleResponse(rule), nil
+}
+
+// FindRules retrieves the rules of a user in evaluation order.
+func (s *RuleService) FindRules(ctx context.Context, userId string) ([]*dto.RuleResponse, error) {
+	rules, err := s.ruleRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleResponses := make([]*dto.RuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, dto.NewRuleResponse(rule))
+	}
+	return ruleResponses, nil
+}
+
+// UpdateRule replaces an existing rule.
+func (s *RuleService) UpdateRule(ctx context.Context, id string, userId string, request *dto.RuleRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	rule, err := s.ruleRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if err := s.checkActions(ctx, userId, request); err != nil {
+		return err
+	}
+	rule.Name = request.Name
+	rule.Priority = request.Priority
+	applyRuleRequest(rule, request)
+	rule.UpdatedAt = time.Now().UTC()
+
+	return s.ruleRepository.Update(ctx, rule)
+}
+
+// DeleteRule removes a rule.
+func (s *RuleService) DeleteRule(ctx context.Context, id string, userId string) error {
+	err := s.ruleRepository.Delete(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	return err
+}
+
+// checkActions makes sure the category and budget a rule assigns belong to the user.
+func (s *RuleService) checkActions(ctx context.Context, userId string, request *dto.RuleRequest) error {
+	if request.CategoryId != "" {
+		if _, err := s.categoryRepository.FindByIdAndUserId(ctx, request.CategoryId, userId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: category not found", errorhttp.ErrBadRequest)
+			}
+			return err
+		}
+	}
+	if request.BudgetId != "" {
+		if _, err := s.budgetRepository.FindByIdAndUserId(ctx, request.BudgetId, userId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: budget not found", errorhttp.ErrBadRequest)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRuleRequest copies the conditions and actions of a validated request onto a rule.
+func applyRuleRequest(rule *rule.Rule, request *dto.RuleRequest) {
+	rule.Enabled = *request.Enabled
+	rule.NameContains = request.NameContains
+	rule.AmountMin = request.AmountMin
+	rule.AmountMax = request.AmountMax
+	rule.AccountId = request.AccountId
+	rule.CategoryId = request.CategoryId
+	rule.BudgetId = request.BudgetId
+	rule.RenameTo = request.RenameTo
+}
@@ -0,0 +1,146 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/rule"
+	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) Save(ctx context.Context, rule *rule.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) Update(ctx context.Context, rule *rule.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) FindAll(ctx context.Context, userId string) ([]*rule.Rule, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*rule.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindById(ctx context.Context, id string, userId string) (*rule.Rule, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*rule.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+// MockCategoryRepository only implements the methods the rule service uses.
+type MockCategoryRepository struct {
+	mock.Mock
+	categoryRepo.CategoryRepoInterface
+}
+
+func (m *MockCategoryRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*category.Category, error) {
+	args := m.Called(ctx, id, userId)
+	found, _ := args.Get(0).(*category.Category)
+	return found, args.Error(1)
+}
+
+// MockBudgetRepository only implements the methods the rule service uses.
+type MockBudgetRepository struct {
+	mock.Mock
+	budgetRepo.BudgetRepoInterface
+}
+
+func (m *MockBudgetRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*budget.Budget, error) {
+	args := m.Called(ctx, id, userId)
+	found, _ := args.Get(0).(*budget.Budget)
+	return found, args.Error(1)
+}
+
+func TestRuleService_CreateRule(t *testing.T) {
+	mockRepo := &MockRuleRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	s := NewRuleService(mockRepo, mockCategoryRepo, &MockBudgetRepository{})
+
+	mockCategoryRepo.On("FindByIdAndUserId", mock.Anything, "cat_streaming", "user_1").Return(&category.Category{Id: "cat_streaming", UserId: "user_1"}, nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *rule.Rule) bool {
+		return r.UserId == "user_1" && r.NameContains == "netflix" && r.CategoryId == "cat_streaming" && r.Enabled
+	})).Return(nil)
+
+	response, err := s.CreateRule(context.Background(), "user_1", dto.NewRuleRequest("Streaming", 5, " netflix ", "cat_streaming"))
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Id)
+	assert.Equal(t, 5, response.Priority)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRuleService_CreateRule_Validation(t *testing.T) {
+	s := NewRuleService(&MockRuleRepository{}, &MockCategoryRepository{}, &MockBudgetRepository{})
+
+	_, err := s.CreateRule(context.Background(), "user_1", dto.NewRuleRequest("No condition", 1, "", "cat_1"))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	_, err = s.CreateRule(context.Background(), "user_1", dto.NewRuleRequest("No action", 1, "uber", ""))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	min, max := money.FromUnits(50), money.FromUnits(10)
+	request := dto.NewRuleRequest("Bad range", 1, "", "cat_1")
+	request.AmountMin = &min
+	request.AmountMax = &max
+	_, err = s.CreateRule(context.Background(), "user_1", request)
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+}
+
+func TestRuleService_CreateRule_ForeignActions(t *testing.T) {
+	mockRepo := &MockRuleRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	s := NewRuleService(mockRepo, mockCategoryRepo, mockBudgetRepo)
+
+	mockCategoryRepo.On("FindByIdAndUserId", mock.Anything, "cat_other", "user_1").Return(nil, sql.ErrNoRows)
+	_, err := s.CreateRule(context.Background(), "user_1", dto.NewRuleRequest("Rides", 1, "uber", "cat_other"))
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	mockCategoryRepo.On("FindByIdAndUserId", mock.Anything, "cat_transport", "user_1").Return(&category.Category{Id: "cat_transport", UserId: "user_1"}, nil)
+	mockBudgetRepo.On("FindByIdAndUserId", mock.Anything, "budget_other", "user_1").Return(nil, sql.ErrNoRows)
+	request := dto.NewRuleRequest("Rides", 1, "uber", "cat_transport")
+	request.BudgetId = "budget_other"
+	_, err = s.CreateRule(context.Background(), "user_1", request)
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRuleService_UpdateRule_NotFound(t *testing.T) {
+	mockRepo := &MockRuleRepository{}
+	s := NewRuleService(mockRepo, &MockCategoryRepository{}, &MockBudgetRepository{})
+	mockRepo.On("FindById", mock.Anything, "rule_1", "user_1").Return((*rule.Rule)(nil), sql.ErrNoRows)
+
+	err := s.UpdateRule(context.Background(), "rule_1", "user_1", dto.NewRuleRequest("Rides", 1, "uber", "cat_1"))
+
+	assert.True(t, errorhttp.IsErrNotFound(err))
+}
+
+func TestRuleService_DeleteRule_NotFound(t *testing.T) {
+	mockRepo := &MockRuleRepository{}
+	s := NewRuleService(mockRepo, &MockCategoryRepository{}, &MockBudgetRepository{})
+	mockRepo.On("Delete", mock.Anything, "rule_1", "user_1").Return(sql.ErrNoRows)
+
+	err := s.DeleteRule(context.Background(), "rule_1", "user_1")
+
+	assert.True(t, errorhttp.IsErrNotFound(err))
+}
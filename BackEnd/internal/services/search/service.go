@@ -37,7 +37,9 @@ func NewSearchService(
 	}
 }
 
-func (s *SearchService) Search(ctx context.Context, userId string, query string) (*search.SearchResponse, error) {
+// Search looks the query up in transactions, categories, accounts and budgets. Archived accounts are only
+// matched when includeArchived is set.
+func (s *SearchService) Search(ctx context.Context, userId string, query string, includeArchived bool) (*search.SearchResponse, error) {
 	response := &search.SearchResponse{
 		Transactions: []*transaction.Transaction{},
 		Categories:   []*category.Category{},
@@ -89,7 +91,7 @@ func (s *SearchService) Search(ctx context.Context, userId string, query string)
 	}
 
 	// 3. Accounts
-	accs, err := s.accountRepo.Search(ctx, userId, query)
+	accs, err := s.accountRepo.Search(ctx, userId, query, includeArchived)
 	if err != nil {
 		log.Error().Err(err).Msg("error searching accounts")
 	} else {
@@ -0,0 +1,199 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/account"
+	transactionDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	statementRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/statement"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/osmait/gestorDePresupuesto/internal/services/notification"
+	transactionSvc "github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/rs/zerolog/log"
+)
+
+// StatementService works out the billing cycles of credit cards from their statement and due days, pays their
+// statements from a checking account and reminds users of upcoming due dates.
+type StatementService struct {
+	statementRepository statementRepo.StatementRepoInterface
+	accountRepository   accountRepo.AccountRepositoryInterface
+	userRepository      userRepo.UserRepositoryInterface
+	transactionService  *transactionSvc.TransactionService
+	notificationService *notification.NotificationService
+	reminderDays        int
+}
+
+// NewStatementService creates a new instance of StatementService. Reminders are sent from reminderDays before a
+// due date; notificationService may be nil, in which case no reminders are sent.
+func NewStatementService(statementRepository statementRepo.StatementRepoInterface, accountRepository accountRepo.AccountRepositoryInterface, userRepository userRepo.UserRepositoryInterface, transactionService *transactionSvc.TransactionService, notificationService *notification.NotificationService, reminderDays int) *StatementService {
+	return &StatementService{
+		statementRepository: statementRepository,
+		accountRepository:   accountRepository,
+		userRepository:      userRepository,
+		transactionService:  transactionService,
+		notificationService: notificationService,
+		reminderDays:        reminderDays,
+	}
+}
+
+// Statements returns the billing cycle of a credit card running today followed by the ones before it, count in
+// total, latest first.
+func (s *StatementService) Statements(ctx context.Context, accountId string, userId string, count int) ([]*account.Statement, error) {
+	card, err := s.findCard(ctx, accountId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now()
+	period := card.StatementPeriodOn(today)
+	statements := make([]*account.Statement, 0, count)
+	for range count {
+		statement, err := s.statement(ctx, card, period, today)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+		period = card.PreviousStatementPeriod(period)
+	}
+	return statements, nil
+}
+
+// PayStatement pays the latest closed statement of a credit card with a transfer from a checking account of the
+// user. Without an amount it pays what is left on the statement.
+func (s *StatementService) PayStatement(ctx context.Context, accountId string, userId string, request *dto.PayStatementRequest) (*transactionDto.TransferResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	card, err := s.findCard(ctx, accountId, userId)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.accountRepository.FindByIdAndUserId(ctx, request.FromAccountId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: from account not found", errorhttp.ErrBadRequest)
+		}
+		return nil, err
+	}
+	if from.Type != account.TypeChecking {
+		return nil, fmt.Errorf("%w: statements are paid from a checking account", errorhttp.ErrBadRequest)
+	}
+
+	today := time.Now()
+	statement, err := s.statement(ctx, card, card.PreviousStatementPeriod(card.StatementPeriodOn(today)), today)
+	if err != nil {
+		return nil, err
+	}
+	amount := statement.Remaining
+	if request.Amount != nil {
+		amount = *request.Amount
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: the statement closing on %s is already paid", errorhttp.ErrBadRequest, statement.ClosingDate)
+	}
+
+	transfer := transactionDto.NewTransferRequest(from.Id, card.Id, card.Name+" statement payment",
+		"Statement closing on "+statement.ClosingDate, amount)
+	return s.transactionService.CreateTransfer(ctx, userId, transfer)
+}
+
+// SendReminders notifies users of the credit cards whose latest statement falls due within the reminder days and
+// still has something left to pay. Each statement is reminded once; archived cards are left out. It returns how
+// many reminders were sent. A user that fails is logged and skipped.
+func (s *StatementService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.notificationService == nil {
+		return 0, nil
+	}
+	users, err := s.userRepository.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, user := range users {
+		reminded, err := s.remindUser(ctx, user.Id, now)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", user.Id).Msg("failed to send statement reminders")
+		}
+		sent += reminded
+	}
+	return sent, nil
+}
+
+func (s *StatementService) remindUser(ctx context.Context, userId string, now time.Time) (int, error) {
+	accounts, err := s.accountRepository.FindAll(ctx, userId, false)
+	if err != nil {
+		return 0, err
+	}
+
+	today := account.Day(now)
+	sent := 0
+	for _, card := range accounts {
+		if !card.HasStatementCycle() {
+			continue
+		}
+		period := card.PreviousStatementPeriod(card.StatementPeriodOn(today))
+		daysLeft := int(period.Due.Sub(today).Hours() / 24)
+		if daysLeft < 0 || daysLeft > s.reminderDays {
+			continue
+		}
+		statement, err := s.statement(ctx, card, period, today)
+		if err != nil {
+			return sent, err
+		}
+		if statement.Remaining <= 0 {
+			continue
+		}
+		first, err := s.statementRepository.MarkReminded(ctx, card.Id, period.Close)
+		if err != nil {
+			return sent, err
+		}
+		if !first {
+			continue
+		}
+
+		notificationPayload := map[string]interface{}{
+			"type":            "statement_due",
+			"message":         fmt.Sprintf("💳 Your %s statement is due on %s: %s left to pay, minimum payment %s.", card.Name, statement.DueDate, statement.Remaining, statement.MinimumPayment),
+			"amount":          statement.Remaining,
+			"account_id":      card.Id,
+			"due_date":        statement.DueDate,
+			"minimum_payment": statement.MinimumPayment,
+		}
+		payloadBytes, _ := json.Marshal(notificationPayload)
+		s.notificationService.SendToUser(userId, string(payloadBytes))
+		sent++
+	}
+	return sent, nil
+}
+
+// findCard loads an account of the user that has a statement cycle.
+func (s *StatementService) findCard(ctx context.Context, accountId string, userId string) (*account.Account, error) {
+	card, err := s.accountRepository.FindByIdAndUserId(ctx, accountId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorhttp.ErrNotFound
+		}
+		return nil, err
+	}
+	if !card.HasStatementCycle() {
+		return nil, fmt.Errorf("%w: statements need a credit card with a statement day and a due day", errorhttp.ErrBadRequest)
+	}
+	return card, nil
+}
+
+func (s *StatementService) statement(ctx context.Context, card *account.Account, period account.StatementPeriod, today time.Time) (*account.Statement, error) {
+	totals, err := s.statementRepository.Totals(ctx, card.Id, period)
+	if err != nil {
+		return nil, err
+	}
+	return account.NewStatement(card, period, *totals, today), nil
+}
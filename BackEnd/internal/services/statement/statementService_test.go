@@ -0,0 +1,200 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	domainNotification "github.com/osmait/gestorDePresupuesto/internal/domain/notification"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	domainUser "github.com/osmait/gestorDePresupuesto/internal/domain/user"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/account"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	userRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/user"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/osmait/gestorDePresupuesto/internal/services/notification"
+	transactionSvc "github.com/osmait/gestorDePresupuesto/internal/services/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStatements struct {
+	totals   map[string]account.StatementTotals
+	reminded map[string]bool
+}
+
+func (m *memoryStatements) Totals(ctx context.Context, accountId string, period account.StatementPeriod) (*account.StatementTotals, error) {
+	totals := m.totals[accountId]
+	return &totals, nil
+}
+
+func (m *memoryStatements) MarkReminded(ctx context.Context, accountId string, closingDate time.Time) (bool, error) {
+	key := accountId + closingDate.Format(account.DateLayout)
+	if m.reminded[key] {
+		return false, nil
+	}
+	m.reminded[key] = true
+	return true, nil
+}
+
+type memoryAccounts struct {
+	accountRepo.AccountRepositoryInterface
+	accounts []*account.Account
+}
+
+func (m *memoryAccounts) FindAll(ctx context.Context, userId string, includeArchived bool) ([]*account.Account, error) {
+	var accounts []*account.Account
+	for _, acc := range m.accounts {
+		if acc.UserId == userId {
+			accounts = append(accounts, acc)
+		}
+	}
+	return accounts, nil
+}
+
+func (m *memoryAccounts) FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error) {
+	for _, acc := range m.accounts {
+		if acc.Id == id && acc.UserId == userId {
+			return acc, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type memoryUsers struct {
+	userRepo.UserRepositoryInterface
+	users []*domainUser.User
+}
+
+func (m *memoryUsers) FindAll(ctx context.Context) ([]*domainUser.User, error) {
+	return m.users, nil
+}
+
+type memoryTransfers struct {
+	transactionRepo.TransactionRepositoryInterface
+	legs []*transaction.Transaction
+}
+
+func (m *memoryTransfers) SaveTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	m.legs = append(m.legs, outgoing, incoming)
+	return nil
+}
+
+type memoryNotifications struct {
+	domainNotification.NotificationRepository
+	sent []domainNotification.Notification
+}
+
+func (m *memoryNotifications) Save(notification domainNotification.Notification) error {
+	m.sent = append(m.sent, notification)
+	return nil
+}
+
+func newCard(id string, statementDay int, dueDay int) *account.Account {
+	card := account.NewAccount(0, id, "Visa", "Bank")
+	card.UserId = "user_1"
+	card.Type = account.TypeCreditCard
+	card.Details = account.Details{StatementDay: &statementDay, DueDay: &dueDay}
+	return card
+}
+
+func newTestStatementService() (*StatementService, *memoryTransfers, *memoryNotifications) {
+	checking := account.NewAccount(money.FromUnits(2000), "acc_checking", "Checking", "Bank")
+	checking.UserId = "user_1"
+	savings := account.NewAccount(money.FromUnits(2000), "acc_savings", "Savings", "Bank")
+	savings.UserId = "user_1"
+	savings.Type = account.TypeSavings
+
+	statements := &memoryStatements{
+		totals: map[string]account.StatementTotals{
+			"acc_card": {Before: money.FromUnits(-300), Charges: money.FromUnits(-500), Credits: money.FromUnits(300), Paid: money.FromUnits(100)},
+			"acc_paid": {Charges: money.FromUnits(-200), Paid: money.FromUnits(200)},
+			"acc_late": {Charges: money.FromUnits(-200)},
+		},
+		reminded: map[string]bool{},
+	}
+	accounts := &memoryAccounts{accounts: []*account.Account{
+		newCard("acc_card", 25, 10), newCard("acc_paid", 25, 10), newCard("acc_late", 5, 28), checking, savings,
+	}}
+	users := &memoryUsers{users: []*domainUser.User{{Id: "user_1"}}}
+	transfers := &memoryTransfers{}
+	notifications := &memoryNotifications{}
+
+	transactionService := transactionSvc.NewTransactionService(transfers, accounts, nil, nil, nil, nil, nil, cache.NewInMemoryCache(time.Minute, time.Minute), nil, nil)
+	service := NewStatementService(statements, accounts, users, transactionService, notification.NewNotificationService(notifications), 3)
+	return service, transfers, notifications
+}
+
+func TestStatements(t *testing.T) {
+	service, _, _ := newTestStatementService()
+	ctx := context.Background()
+
+	statements, err := service.Statements(ctx, "acc_card", "user_1", 3)
+	assert.NoError(t, err)
+	assert.Len(t, statements, 3)
+	assert.False(t, statements[0].Closed, "the cycle running today is still open")
+	assert.True(t, statements[1].Closed)
+	assert.Greater(t, statements[0].ClosingDate, statements[1].ClosingDate)
+	assert.Equal(t, money.FromUnits(500), statements[1].StatementBalance)
+	assert.Equal(t, money.FromUnits(25), statements[1].MinimumPayment)
+	assert.Equal(t, money.FromUnits(400), statements[1].Remaining)
+
+	_, err = service.Statements(ctx, "acc_checking", "user_1", 3)
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	_, err = service.Statements(ctx, "acc_card", "user_2", 3)
+	assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+}
+
+func TestPayStatement(t *testing.T) {
+	service, transfers, _ := newTestStatementService()
+	ctx := context.Background()
+
+	transfer, err := service.PayStatement(ctx, "acc_card", "user_1", &dto.PayStatementRequest{FromAccountId: "acc_checking"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, transfer.TransferId)
+	assert.Len(t, transfers.legs, 2)
+	assert.Equal(t, "acc_checking", transfers.legs[0].AccountId)
+	assert.Equal(t, money.FromUnits(-400), transfers.legs[0].Amount)
+	assert.Equal(t, "acc_card", transfers.legs[1].AccountId)
+	assert.Equal(t, money.FromUnits(400), transfers.legs[1].Amount)
+
+	amount := money.FromUnits(50)
+	_, err = service.PayStatement(ctx, "acc_card", "user_1", &dto.PayStatementRequest{FromAccountId: "acc_checking", Amount: &amount})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromUnits(50), transfers.legs[3].Amount)
+
+	_, err = service.PayStatement(ctx, "acc_paid", "user_1", &dto.PayStatementRequest{FromAccountId: "acc_checking"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest, "nothing is left to pay")
+	_, err = service.PayStatement(ctx, "acc_card", "user_1", &dto.PayStatementRequest{FromAccountId: "acc_savings"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest, "statements are paid from checking")
+	_, err = service.PayStatement(ctx, "acc_card", "user_1", &dto.PayStatementRequest{FromAccountId: "acc_missing"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	assert.Len(t, transfers.legs, 4)
+}
+
+func TestSendReminders(t *testing.T) {
+	service, _, notifications := newTestStatementService()
+	ctx := context.Background()
+
+	// The statement of acc_card closed on March 25 and is due on April 10; acc_paid is paid off and acc_late is
+	// only due on April 28
+	now := time.Date(2024, time.April, 8, 9, 0, 0, 0, time.UTC)
+	sent, err := service.SendReminders(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, notifications.sent, 1)
+	assert.Equal(t, "statement_due", notifications.sent[0].Type)
+	assert.Equal(t, money.FromUnits(400), *notifications.sent[0].Amount)
+
+	sent, err = service.SendReminders(ctx, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent, "each statement is reminded once")
+
+	sent, err = service.SendReminders(ctx, time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent, "no reminders after the due date")
+}
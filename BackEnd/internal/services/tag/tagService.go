@@ -0,0 +1,101 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/tag"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/segmentio/ksuid"
+)
+
+// TagService manages the tags of a user. Tags are also created implicitly when a transaction uses a new name.
+type TagService struct {
+	tagRepository tagRepo.TagRepoInterface
+}
+
+// NewTagService creates a new instance of TagService.
+func NewTagService(tagRepository tagRepo.TagRepoInterface) *TagService {
+	return &TagService{
+		tagRepository: tagRepository,
+	}
+}
+
+// CreateTag stores a new tag. Creating a tag whose name the user already has returns the existing one.
+func (s *TagService) CreateTag(ctx context.Context, userId string, request *dto.TagRequest) (*dto.TagResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	existing, err := s.tagRepository.FindByName(ctx, request.Name, userId)
+	if err == nil {
+		return dto.NewTagResponse(existing), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	tag := tag.NewTag(uuid.String(), userId, request.Name)
+	if err := s.tagRepository.Save(ctx, tag); err != nil {
+		return nil, err
+	}
+	return dto.NewTagResponse(tag), nil
+}
+
+// FindTags retrieves the tags of a user in alphabetical order.
+func (s *TagService) FindTags(ctx context.Context, userId string) ([]*dto.TagResponse, error) {
+	tags, err := s.tagRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagResponses := make([]*dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, dto.NewTagResponse(tag))
+	}
+	return tagResponses, nil
+}
+
+// RenameTag changes the name of a tag. The transactions carrying it follow the new name.
+func (s *TagService) RenameTag(ctx context.Context, id string, userId string, request *dto.TagRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	tag, err := s.tagRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if tag.Name == request.Name {
+		return nil
+	}
+
+	if _, err := s.tagRepository.FindByName(ctx, request.Name, userId); err == nil {
+		return fmt.Errorf("%w: a tag named %q already exists", errorhttp.ErrBadRequest, request.Name)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	tag.Name = request.Name
+	return s.tagRepository.Update(ctx, tag)
+}
+
+// DeleteTag removes a tag from the user and from every transaction that carried it.
+func (s *TagService) DeleteTag(ctx context.Context, id string, userId string) error {
+	err := s.tagRepository.Delete(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	return err
+}
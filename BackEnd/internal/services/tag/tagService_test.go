@@ -0,0 +1,108 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/tag"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Save(ctx context.Context, tag *tag.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) SaveIfMissing(ctx context.Context, tags []*tag.Tag) error {
+	args := m.Called(ctx, tags)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *tag.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context, userId string) ([]*tag.Tag, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindById(ctx context.Context, id string, userId string) (*tag.Tag, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByName(ctx context.Context, name string, userId string) (*tag.Tag, error) {
+	args := m.Called(ctx, name, userId)
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func TestTagService_CreateTag(t *testing.T) {
+	mockRepo := &MockTagRepository{}
+	s := NewTagService(mockRepo)
+
+	mockRepo.On("FindByName", mock.Anything, "vacation 2026", "user_1").Return((*tag.Tag)(nil), sql.ErrNoRows)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(tag *tag.Tag) bool {
+		return tag.UserId == "user_1" && tag.Name == "vacation 2026"
+	})).Return(nil)
+
+	response, err := s.CreateTag(context.Background(), "user_1", &dto.TagRequest{Name: "  Vacation 2026 "})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Id)
+	assert.Equal(t, "vacation 2026", response.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_CreateTag_ReturnsExisting(t *testing.T) {
+	mockRepo := &MockTagRepository{}
+	s := NewTagService(mockRepo)
+	existing := tag.NewTag("tag_1", "user_1", "reimbursable")
+	mockRepo.On("FindByName", mock.Anything, "reimbursable", "user_1").Return(existing, nil)
+
+	response, err := s.CreateTag(context.Background(), "user_1", &dto.TagRequest{Name: "Reimbursable"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tag_1", response.Id)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	mockRepo := &MockTagRepository{}
+	s := NewTagService(mockRepo)
+	mockRepo.On("FindById", mock.Anything, "tag_1", "user_1").Return(tag.NewTag("tag_1", "user_1", "vacation"), nil)
+	mockRepo.On("FindByName", mock.Anything, "reimbursable", "user_1").Return(tag.NewTag("tag_2", "user_1", "reimbursable"), nil)
+	mockRepo.On("FindByName", mock.Anything, "vacation 2026", "user_1").Return((*tag.Tag)(nil), sql.ErrNoRows)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(tag *tag.Tag) bool { return tag.Name == "vacation 2026" })).Return(nil)
+
+	err := s.RenameTag(context.Background(), "tag_1", "user_1", &dto.TagRequest{Name: "reimbursable"})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err), "the name is already taken")
+
+	err = s.RenameTag(context.Background(), "tag_1", "user_1", &dto.TagRequest{Name: "Vacation 2026"})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_DeleteTag_NotFound(t *testing.T) {
+	mockRepo := &MockTagRepository{}
+	s := NewTagService(mockRepo)
+	mockRepo.On("Delete", mock.Anything, "tag_1", "user_1").Return(sql.ErrNoRows)
+
+	err := s.DeleteTag(context.Background(), "tag_1", "user_1")
+
+	assert.True(t, errorhttp.IsErrNotFound(err))
+}
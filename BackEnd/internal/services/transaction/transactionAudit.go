@@ -0,0 +1,37 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+)
+
+// findForAudit loads a transaction to snapshot it around a change. The lookup is skipped when there is no audit
+// log to write, and a failed one only leaves the snapshot empty.
+func (s TransactionService) findForAudit(ctx context.Context, id string, userId string) *transaction.Transaction {
+	if s.auditService == nil {
+		return nil
+	}
+	current, err := s.transactionRepository.FindById(ctx, id, userId)
+	if err != nil {
+		return nil
+	}
+	return current
+}
+
+// recordChange appends a change of a transaction to the audit log. Before is nil for creations and after for
+// deletions.
+func (s TransactionService) recordChange(ctx context.Context, action string, userId string, before, after *transaction.Transaction) {
+	if s.auditService == nil {
+		return
+	}
+	record := after
+	if record == nil {
+		record = before
+	}
+	if record == nil {
+		return
+	}
+	s.auditService.Record(ctx, audit.EntityTransaction, record.Id, action, userId, before, after)
+}
@@ -0,0 +1,173 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// Reasons a transaction of a bulk request is left unchanged.
+const (
+	bulkErrNotFound   = "transaction not found"
+	bulkErrReconciled = "transaction is reconciled, unlock it before changing it"
+	bulkErrTransfer   = "transfers cannot be changed this way"
+	bulkErrSplit      = "split transactions take their categories from their lines"
+	bulkErrUnchanged  = "transaction could not be changed"
+)
+
+// Bulk applies one action to a selection of transactions in a single database transaction. Transactions the action
+// cannot apply to are reported as failed and left unchanged, while the rest of the selection goes through.
+func (s TransactionService) Bulk(ctx context.Context, userId string, request *dto.BulkRequest) (*dto.BulkResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	ids, selected, err := s.bulkSelection(ctx, userId, request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*dto.BulkItemResult, len(ids))
+	var eligible []string
+	for _, id := range ids {
+		current, ok := selected[id]
+		if !ok {
+			results[id] = failedItem(id, bulkErrNotFound)
+			continue
+		}
+		if reason := bulkRejection(request.Action, current); reason != "" {
+			results[id] = failedItem(id, reason)
+			continue
+		}
+		eligible = append(eligible, id)
+	}
+
+	if len(eligible) > 0 {
+		if request.Action == dto.BulkActionAddTag {
+			if err := s.ensureTags(ctx, userId, []string{request.Tag}); err != nil {
+				return nil, err
+			}
+		}
+		changed, err := s.transactionRepository.ApplyBulk(ctx, userId, request, eligible)
+		if errors.Is(err, transactionRepo.ErrCategoryNotFound) || errors.Is(err, transactionRepo.ErrAccountNotFound) {
+			return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+		}
+		if errors.Is(err, transactionRepo.ErrAccountArchived) {
+			return nil, archivedAccountError()
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+
+		for _, id := range eligible {
+			if !slices.Contains(changed, id) {
+				results[id] = failedItem(id, bulkErrUnchanged)
+				continue
+			}
+			results[id] = &dto.BulkItemResult{Id: id, Status: dto.BulkStatusOk}
+			s.recordBulkChange(ctx, userId, request, selected[id])
+		}
+	}
+
+	response := &dto.BulkResponse{Action: request.Action, Results: make([]*dto.BulkItemResult, 0, len(ids))}
+	for _, id := range ids {
+		result := results[id]
+		if result.Status == dto.BulkStatusOk {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+// bulkSelection resolves the transactions a bulk request applies to, in the order they are reported.
+func (s TransactionService) bulkSelection(ctx context.Context, userId string, request *dto.BulkRequest) ([]string, map[string]*transaction.Transaction, error) {
+	var transactions []*transaction.Transaction
+	ids := request.Ids
+	if request.Filter != nil {
+		count, err := s.transactionRepository.CountWithFilters(ctx, userId, request.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if count > dto.MaxBulkItems {
+			return nil, nil, fmt.Errorf("%w: the filter matches %d transactions, a bulk request can change at most %d", errorhttp.ErrBadRequest, count, dto.MaxBulkItems)
+		}
+		transactions, err = s.transactionRepository.FindAllOfAllAccountsWithFilters(ctx, userId, request.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = make([]string, 0, len(transactions))
+		for _, t := range transactions {
+			ids = append(ids, t.Id)
+		}
+	} else {
+		var err error
+		transactions, err = s.transactionRepository.FindByIds(ctx, ids, userId)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	selected := make(map[string]*transaction.Transaction, len(transactions))
+	for _, t := range transactions {
+		selected[t.Id] = t
+	}
+	return ids, selected, nil
+}
+
+// bulkRejection returns why the action cannot apply to a transaction, or an empty string when it can.
+func bulkRejection(action string, current *transaction.Transaction) string {
+	if current.IsReconciled() {
+		return bulkErrReconciled
+	}
+	switch action {
+	case dto.BulkActionSetCategory:
+		if current.IsTransfer() {
+			return bulkErrTransfer
+		}
+		if current.IsSplit() {
+			return bulkErrSplit
+		}
+	case dto.BulkActionSetAccount:
+		if current.IsTransfer() {
+			return bulkErrTransfer
+		}
+	}
+	return ""
+}
+
+// recordBulkChange writes the audit event of one transaction changed by a bulk request.
+func (s TransactionService) recordBulkChange(ctx context.Context, userId string, request *dto.BulkRequest, before *transaction.Transaction) {
+	if request.Action == dto.BulkActionDelete {
+		s.recordChange(ctx, audit.ActionDelete, userId, before, nil)
+		return
+	}
+	after := *before
+	switch request.Action {
+	case dto.BulkActionSetCategory:
+		after.CategoryId = request.CategoryId
+	case dto.BulkActionSetAccount:
+		after.AccountId = request.AccountId
+	case dto.BulkActionAddTag:
+		if !slices.Contains(after.Tags, request.Tag) {
+			after.Tags = append(slices.Clone(after.Tags), request.Tag)
+		}
+	case dto.BulkActionRemoveTag:
+		after.Tags = slices.DeleteFunc(slices.Clone(after.Tags), func(name string) bool { return name == request.Tag })
+	}
+	s.recordChange(ctx, audit.ActionUpdate, userId, before, &after)
+}
+
+func failedItem(id string, reason string) *dto.BulkItemResult {
+	return &dto.BulkItemResult{Id: id, Status: dto.BulkStatusFailed, Error: reason}
+}
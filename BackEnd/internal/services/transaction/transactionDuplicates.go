@@ -0,0 +1,155 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// FindDuplicates lists the pairs of transactions of the last days that look like the same charge, leaving out the
+// pairs the user dismissed.
+func (s TransactionService) FindDuplicates(ctx context.Context, userId string, days int) ([]*dto.DuplicateResponse, error) {
+	now := time.Now()
+	candidates, err := s.transactionRepository.FindDuplicateCandidates(ctx, userId, now.AddDate(0, 0, -days), now.Add(transaction.DuplicateWindow))
+	if err != nil {
+		return nil, err
+	}
+	dismissedPairs, err := s.transactionRepository.FindDismissedDuplicates(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	dismissed := make(map[transaction.DuplicatePair]bool, len(dismissedPairs))
+	for _, pair := range dismissedPairs {
+		dismissed[pair] = true
+	}
+
+	byId := make(map[string]*transaction.Transaction, len(candidates))
+	for _, candidate := range candidates {
+		byId[candidate.Id] = candidate
+	}
+	pairs := transaction.FindDuplicatePairs(candidates, dismissed)
+	duplicates := make([]*dto.DuplicateResponse, 0, len(pairs))
+	for _, pair := range pairs {
+		newer, older := byId[pair.TransactionId], byId[pair.DuplicateId]
+		if older.CreatedAt.After(newer.CreatedAt) {
+			newer, older = older, newer
+		}
+		responses := s.convertToResponseList([]*transaction.Transaction{newer, older})
+		duplicates = append(duplicates, &dto.DuplicateResponse{Transaction: responses[0], Duplicate: responses[1]})
+	}
+	return duplicates, nil
+}
+
+// DismissDuplicate marks a pair as not being the same charge, so it is never reported again.
+func (s TransactionService) DismissDuplicate(ctx context.Context, userId string, request *dto.DuplicateRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if _, _, err := s.findDuplicatePair(ctx, userId, request); err != nil {
+		return err
+	}
+	return s.transactionRepository.DismissDuplicate(ctx, userId, transaction.NewDuplicatePair(request.TransactionId, request.DuplicateId))
+}
+
+// MergeDuplicate keeps the transaction of the pair and moves the duplicate to the trash. The tags and attachments
+// of the duplicate move to the transaction that is kept. A duplicate with refunds is rejected, since removing it
+// would turn its refunds back into plain income.
+func (s TransactionService) MergeDuplicate(ctx context.Context, userId string, request *dto.DuplicateRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	keep, duplicate, err := s.findDuplicatePair(ctx, userId, request)
+	if err != nil {
+		return err
+	}
+	if keep.IsTransfer() || duplicate.IsTransfer() {
+		return fmt.Errorf("%w: transfers cannot be merged", errorhttp.ErrBadRequest)
+	}
+	if duplicate.IsReconciled() {
+		return reconciledError()
+	}
+	if len(duplicate.RefundedBy) > 0 {
+		return fmt.Errorf("%w: the duplicate has refunds, link them to the transaction you keep before merging", errorhttp.ErrBadRequest)
+	}
+
+	err = s.transactionRepository.MergeDuplicate(ctx, keep.Id, duplicate.Id, userId)
+	if errors.Is(err, transactionRepo.ErrReconciled) {
+		return reconciledError()
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	if merged := s.findForAudit(ctx, keep.Id, userId); merged != nil {
+		s.recordChange(ctx, audit.ActionUpdate, userId, keep, merged)
+	}
+	s.recordChange(ctx, audit.ActionDelete, userId, duplicate, nil)
+	return nil
+}
+
+func (s TransactionService) findDuplicatePair(ctx context.Context, userId string, request *dto.DuplicateRequest) (*transaction.Transaction, *transaction.Transaction, error) {
+	first, err := s.transactionRepository.FindById(ctx, request.TransactionId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errorhttp.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	second, err := s.transactionRepository.FindById(ctx, request.DuplicateId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errorhttp.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return first, second, nil
+}
+
+// flagDuplicates notifies the user when a new transaction looks like one already recorded. It runs in the
+// background, like the budget alerts, so a failed check never fails the creation.
+func (s TransactionService) flagDuplicates(userId string, created *transaction.Transaction) {
+	if s.notificationService == nil || created.IsTransfer() {
+		return
+	}
+	go func() {
+		candidates, err := s.transactionRepository.FindDuplicateCandidates(context.Background(), userId,
+			created.CreatedAt.Add(-transaction.DuplicateWindow), created.CreatedAt.Add(transaction.DuplicateWindow))
+		if err != nil {
+			log.Error().Err(err).Str("transaction_id", created.Id).Msg("failed to look for duplicate transactions")
+			return
+		}
+
+		var duplicateIds []string
+		for _, candidate := range candidates {
+			if transaction.IsLikelyDuplicate(created, candidate) {
+				duplicateIds = append(duplicateIds, candidate.Id)
+			}
+		}
+		if len(duplicateIds) == 0 {
+			return
+		}
+
+		log.Info().Str("transaction_id", created.Id).Int("duplicates", len(duplicateIds)).Msg("flagging possible duplicate transaction")
+		notificationPayload := map[string]interface{}{
+			"type":           "possible_duplicate",
+			"message":        fmt.Sprintf("🔁 %q looks like a transaction you already recorded.", created.Name),
+			"transaction_id": created.Id,
+			"duplicate_ids":  duplicateIds,
+		}
+		payloadBytes, _ := json.Marshal(notificationPayload)
+		s.notificationService.SendToUser(userId, string(payloadBytes))
+	}()
+}
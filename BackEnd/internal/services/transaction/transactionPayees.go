@@ -0,0 +1,34 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	"github.com/rs/zerolog/log"
+)
+
+// loadPayees returns the payees of the user. Like rules, payees are best effort: failing to load them never
+// blocks saving a transaction.
+func (s TransactionService) loadPayees(ctx context.Context, userId string) []*payee.Payee {
+	if s.payeeRepository == nil {
+		return nil
+	}
+	payees, err := s.payeeRepository.FindAll(ctx, userId)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("failed to load payees")
+		return nil
+	}
+	return payees
+}
+
+// assignPayee links the transaction to the payee its name matches, or to none. Transfers never have a payee.
+func (s TransactionService) assignPayee(ctx context.Context, userId string, t *transaction.Transaction) {
+	t.PayeeId = ""
+	if t.IsTransfer() {
+		return
+	}
+	if found := payee.Match(s.loadPayees(ctx, userId), t.Name); found != nil {
+		t.PayeeId = found.Id
+	}
+}
@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// LinkRefund links an income transaction as the refund of an earlier bill. The refund takes the category and
+// budget of the bill, so it nets against that spending instead of counting as income. All the refunds of a bill
+// together cannot give back more than the bill cost.
+func (s TransactionService) LinkRefund(ctx context.Context, id string, userId string, request *dto.RefundRequest) error {
+	if request.RefundOf == id {
+		return fmt.Errorf("%w: a transaction cannot refund itself", errorhttp.ErrBadRequest)
+	}
+
+	refund, err := s.transactionRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if refund.IsReconciled() {
+		return reconciledError()
+	}
+	if refund.TypeTransation != INCOME || refund.IsTransfer() || refund.IsSplit() {
+		return fmt.Errorf("%w: only income transactions without splits can be refunds", errorhttp.ErrBadRequest)
+	}
+
+	original, err := s.transactionRepository.FindById(ctx, request.RefundOf, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: the refunded transaction was not found", errorhttp.ErrBadRequest)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.checkRefund(ctx, refund, original, userId); err != nil {
+		return err
+	}
+
+	if err := s.transactionRepository.LinkRefund(ctx, id, userId, original); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	updated := *refund
+	updated.RefundOf = original.Id
+	updated.CategoryId = original.CategoryId
+	updated.BudgetId = original.BudgetId
+	s.recordChange(ctx, audit.ActionUpdate, userId, refund, &updated)
+	return nil
+}
+
+// UnlinkRefund turns a refund back into a plain income, keeping its category.
+func (s TransactionService) UnlinkRefund(ctx context.Context, id string, userId string) error {
+	current := s.findForAudit(ctx, id, userId)
+	if current != nil && current.IsReconciled() {
+		return reconciledError()
+	}
+	if err := s.transactionRepository.UnlinkRefund(ctx, id, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	if current != nil {
+		updated := *current
+		updated.RefundOf = ""
+		s.recordChange(ctx, audit.ActionUpdate, userId, current, &updated)
+	}
+	return nil
+}
+
+// checkRefund verifies that the original is a bill the refund can give money back for.
+func (s TransactionService) checkRefund(ctx context.Context, refund, original *transaction.Transaction, userId string) error {
+	if original.TypeTransation != BILL || original.IsTransfer() {
+		return fmt.Errorf("%w: refunds must point at a bill", errorhttp.ErrBadRequest)
+	}
+	if original.IsSplit() {
+		return fmt.Errorf("%w: split bills cannot be refunded as a whole", errorhttp.ErrBadRequest)
+	}
+	if original.Currency != refund.Currency {
+		return fmt.Errorf("%w: the refund and the bill must be in the same currency", errorhttp.ErrBadRequest)
+	}
+	if refund.CreatedAt.Before(original.CreatedAt) {
+		return fmt.Errorf("%w: a refund cannot be older than the bill", errorhttp.ErrBadRequest)
+	}
+
+	others := slices.DeleteFunc(slices.Clone(original.RefundedBy), func(refundId string) bool { return refundId == refund.Id })
+	refunded := refund.Amount
+	if len(others) > 0 {
+		previous, err := s.transactionRepository.FindByIds(ctx, others, userId)
+		if err != nil {
+			return err
+		}
+		for _, t := range previous {
+			refunded += t.Amount
+		}
+	}
+	if refunded > original.Amount.Abs() {
+		return fmt.Errorf("%w: refunds cannot add up to more than the %s of the bill", errorhttp.ErrBadRequest, original.Amount.Abs())
+	}
+	return nil
+}
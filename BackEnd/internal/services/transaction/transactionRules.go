@@ -0,0 +1,128 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	ruleDto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/rule"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	"github.com/rs/zerolog/log"
+)
+
+// ApplyRules re-runs the categorization rules of the user over the transactions selected by the filter.
+// With dryRun nothing is stored and the response only lists what would change. Reconciled transactions the rules
+// would change are left as they are and counted as skipped.
+func (s TransactionService) ApplyRules(ctx context.Context, userId string, filter *dto.TransactionFilter, dryRun bool) (*ruleDto.ApplyRulesResponse, error) {
+	rules := s.loadRules(ctx, userId)
+	response := &ruleDto.ApplyRulesResponse{DryRun: dryRun, Changes: []*ruleDto.RuleChangeResponse{}}
+	if len(rules) == 0 {
+		return response, nil
+	}
+
+	// Changes are collected first and written together once the stream is closed, so updates never run under an
+	// open cursor and either all of them are stored or none.
+	var changed []*transaction.Transaction
+	var previous []transaction.Transaction
+	err := s.transactionRepository.StreamWithFilters(ctx, userId, filter, func(t *transaction.Transaction) error {
+		response.Evaluated++
+		original := *t
+		before := ruleDto.NewRuleFieldsResponse(t.CategoryId, t.BudgetId, t.Name)
+
+		outcome := applyRules(rules, t)
+		if !outcome.Matched() {
+			return nil
+		}
+		if outcome.BudgetId != "" || outcome.CategoryId != "" {
+			t.BudgetId = ""
+			if budget := s.findBudget(ctx, userId, t.CategoryId, outcome.BudgetId); budget != nil {
+				t.BudgetId = budget.Id
+			}
+		}
+
+		after := ruleDto.NewRuleFieldsResponse(t.CategoryId, t.BudgetId, t.Name)
+		if *before == *after {
+			return nil
+		}
+		if t.IsReconciled() {
+			response.Skipped++
+			return nil
+		}
+		response.Changes = append(response.Changes, &ruleDto.RuleChangeResponse{
+			TransactionId: t.Id,
+			CreatedAt:     t.CreatedAt,
+			RuleIds:       outcome.RuleIds,
+			Before:        before,
+			After:         after,
+		})
+		changed = append(changed, t)
+		previous = append(previous, original)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Changed = len(changed)
+
+	if dryRun || len(changed) == 0 {
+		return response, nil
+	}
+	if err := s.transactionRepository.ApplyRuleChanges(ctx, userId, changed); err != nil {
+		return nil, err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	for i, t := range changed {
+		s.recordChange(ctx, audit.ActionUpdate, userId, &previous[i], t)
+	}
+
+	log.Info().Str("user_id", userId).Int("evaluated", response.Evaluated).Int("changed", response.Changed).Int("skipped", response.Skipped).Msg("categorization rules applied")
+	return response, nil
+}
+
+// loadRules returns the categorization rules of the user in evaluation order. Rules are best effort:
+// failing to load them never blocks saving a transaction.
+func (s TransactionService) loadRules(ctx context.Context, userId string) []*rule.Rule {
+	if s.ruleRepository == nil {
+		return nil
+	}
+	rules, err := s.ruleRepository.FindAll(ctx, userId)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("failed to load categorization rules")
+		return nil
+	}
+	return rules
+}
+
+// applyRules evaluates the rules against the transaction and copies the category and name they chose onto it.
+// The budget is left to the caller, since it may have to be looked up from the new category.
+func applyRules(rules []*rule.Rule, t *transaction.Transaction) *rule.Outcome {
+	outcome := rule.Evaluate(rules, t)
+	if outcome.CategoryId != "" {
+		t.CategoryId = outcome.CategoryId
+	}
+	if outcome.Name != "" {
+		t.Name = outcome.Name
+	}
+	if outcome.Matched() {
+		log.Debug().Str("transaction_id", t.Id).Strs("rule_ids", outcome.RuleIds).Msg("categorization rules matched")
+	}
+	return outcome
+}
+
+// findBudget returns the budget chosen by a rule, or else the budget of the category. A rule budget the user does
+// not own is never used.
+func (s TransactionService) findBudget(ctx context.Context, userId string, categoryId string, ruleBudgetId string) *budget.Budget {
+	var found *budget.Budget
+	if ruleBudgetId != "" {
+		found, _ = s.budgetRepository.FindByIdAndUserId(ctx, ruleBudgetId, userId)
+	} else if categoryId != "" {
+		found, _ = s.budgetRepository.FindByCategory(ctx, categoryId)
+	}
+	if found == nil || found.Id == "" {
+		return nil
+	}
+	return found
+}
@@ -2,45 +2,85 @@ package transaction
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
+	domainFx "github.com/osmait/gestorDePresupuesto/internal/domain/fx"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
 	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/osmait/gestorDePresupuesto/internal/services/fx"
 	"github.com/osmait/gestorDePresupuesto/internal/services/notification"
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/ksuid"
 
 	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
 	budgetRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/budget"
+	payeeRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/payee"
+	ruleRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/rule"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
 )
 
 const (
-	BILL = "bill"
+	BILL     = "bill"
+	INCOME   = "income"
+	TRANSFER = "transfer"
 )
 
+// archivedAccountError is returned when a transaction would be added to, or moved into, an archived account.
+func archivedAccountError() error {
+	return apperrors.NewConflictError("account", "account is archived, unarchive it before adding transactions")
+}
+
 // TransactionService handles business logic related to transaction management.
 type TransactionService struct {
 	transactionRepository transactionRepo.TransactionRepositoryInterface
+	accountRepository     accountRepo.AccountRepositoryInterface
 	budgetRepository      budgetRepo.BudgetRepoInterface
+	ruleRepository        ruleRepo.RuleRepoInterface
+	tagRepository         tagRepo.TagRepoInterface
+	payeeRepository       payeeRepo.PayeeRepoInterface
 	notificationService   *notification.NotificationService
 	cache                 cache.CacheRepository
+	fxService             *fx.FxService
+	auditService          *auditSvc.AuditService
 }
 
 // NewTransactionService creates a new instance of TransactionService.
-func NewTransactionService(transactionRepository transactionRepo.TransactionRepositoryInterface, budgetReposiotry budgetRepo.BudgetRepoInterface, notificationService *notification.NotificationService, cache cache.CacheRepository) *TransactionService {
+// ruleRepository may be nil, in which case no categorization rules are applied.
+// payeeRepository may be nil, in which case new transactions are not linked to a payee.
+// fxService may be nil, in which case summaries add up amounts in different currencies as they are.
+// auditService may be nil, in which case changes are not recorded in the audit log.
+func NewTransactionService(transactionRepository transactionRepo.TransactionRepositoryInterface, accountRepository accountRepo.AccountRepositoryInterface, budgetReposiotry budgetRepo.BudgetRepoInterface, ruleRepository ruleRepo.RuleRepoInterface, tagRepository tagRepo.TagRepoInterface, payeeRepository payeeRepo.PayeeRepoInterface, notificationService *notification.NotificationService, cache cache.CacheRepository, fxService *fx.FxService, auditService *auditSvc.AuditService) *TransactionService {
 	return &TransactionService{
 		transactionRepository: transactionRepository,
+		accountRepository:     accountRepository,
 		budgetRepository:      budgetReposiotry,
+		ruleRepository:        ruleRepository,
+		tagRepository:         tagRepository,
+		payeeRepository:       payeeRepository,
 		notificationService:   notificationService,
 		cache:                 cache,
+		fxService:             fxService,
+		auditService:          auditService,
 	}
 }
 
 // CreateTransaction records a new transaction, checks for budget thresholds, and triggers alerts if necessary.
-func (s TransactionService) CreateTransaction(ctx context.Context, name, description string, amount float64, typeTransaction string, accountId string, userId string, categoryId string, budgetId string, createdAt time.Time) error {
+// When splits are given the amount is spread across their categories and each line is checked against its own budget.
+// The categorization rules of the user run first and may replace the category, the budget and the name.
+// Tags the user does not have yet are created on the fly, and the user is notified when it looks like a duplicate.
+func (s TransactionService) CreateTransaction(ctx context.Context, name, description string, amount money.Money, typeTransaction string, accountId string, userId string, categoryId string, budgetId string, createdAt time.Time, tags []string, splits ...*transaction.Split) error {
 	uuid, err := ksuid.NewRandom()
 	if err != nil {
 		return err
@@ -58,75 +98,191 @@ func (s TransactionService) CreateTransaction(ctx context.Context, name, descrip
 		transaction.CreatedAt = time.Now()
 	}
 
-	budget, _ := s.budgetRepository.FindByCategory(ctx, categoryId)
-	if budget != nil {
-		transaction.BudgetId = budget.Id
+	transaction.Splits = splits
+	outcome := applyRules(s.loadRules(ctx, userId), transaction)
+	s.assignPayee(ctx, userId, transaction)
+
+	var budgetLines []budgetLine
+	if len(splits) > 0 {
+		budgetLines, err = s.prepareSplits(ctx, transaction)
+		if err != nil {
+			return err
+		}
+	} else if outcome.BudgetId != "" {
+		if budget := s.findBudget(ctx, userId, transaction.CategoryId, outcome.BudgetId); budget != nil {
+			transaction.BudgetId = budget.Id
+			budgetLines = append(budgetLines, budgetLine{budget: budget, amount: amount})
+		}
+	} else {
+		budget, _ := s.budgetRepository.FindByCategory(ctx, transaction.CategoryId)
+		if budget != nil {
+			transaction.BudgetId = budget.Id
+			budgetLines = append(budgetLines, budgetLine{budget: budget, amount: amount})
+		}
 	}
 
-	log.Debug().Str("category_id", transaction.CategoryId).Msg("creating transaction")
+	log.Debug().Str("category_id", transaction.CategoryId).Int("splits", len(transaction.Splits)).Msg("creating transaction")
 
+	if len(tags) > 0 {
+		transaction.Tags = tags
+		if err = s.ensureTags(ctx, userId, transaction.Tags); err != nil {
+			return err
+		}
+	}
 	err = s.transactionRepository.Save(ctx, transaction)
+	if errors.Is(err, transactionRepo.ErrAccountArchived) {
+		return archivedAccountError()
+	}
 	if err != nil {
 		return err
 	}
 	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	s.recordChange(ctx, audit.ActionCreate, userId, nil, transaction)
+	s.flagDuplicates(userId, transaction)
 
 	// Check Budget Thresholds
-	log.Debug().Msgf("Checking Alert Conditions: BudgetFound=(%v), Type=(%s), BillConst=(%s)", budget != nil, typeTransaction, BILL)
-	if budget != nil {
-		log.Debug().Msgf("Budget Details: ID=%s, Amount=%f", budget.Id, budget.Amount)
-	}
-
-	if budget != nil && typeTransaction == BILL {
-		log.Debug().Str("budget_id", budget.Id).Msg("checking budget thresholds for transaction")
-		go func() {
-			currentSpent, err := s.transactionRepository.FindCurrentBudget(context.Background(), budget.Id)
-			if err != nil {
-				log.Error().Err(err).Msg("failed to get current budget details for alert")
-				return
-			}
+	log.Debug().Msgf("Checking Alert Conditions: BudgetsFound=(%d), Type=(%s), BillConst=(%s)", len(budgetLines), typeTransaction, BILL)
+	if typeTransaction == BILL {
+		for _, line := range budgetLines {
+			s.checkBudgetThresholds(userId, line.budget, line.amount)
+		}
+	}
+
+	return nil
+}
+
+// SaveImported stores a transaction read from a bank statement. The amount must already carry its sign.
+// It returns false, without error, when the account already holds a transaction with the same external id.
+func (s TransactionService) SaveImported(ctx context.Context, userId string, transaction *transaction.Transaction) (bool, error) {
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return false, err
+	}
+	transaction.Id = uuid.String()
+	transaction.UserId = userId
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = time.Now()
+	}
+	outcome := applyRules(s.loadRules(ctx, userId), transaction)
+	s.assignPayee(ctx, userId, transaction)
+	if budget := s.findBudget(ctx, userId, transaction.CategoryId, outcome.BudgetId); budget != nil {
+		transaction.BudgetId = budget.Id
+	}
 
-			// budget.Amount is positive, currentSpent is negative (bills). Make it positive for calculation.
-			spentPositive := currentSpent * -1
-			limit := budget.Amount
+	created, err := s.transactionRepository.SaveIfNew(ctx, transaction)
+	if errors.Is(err, transactionRepo.ErrAccountArchived) {
+		return false, archivedAccountError()
+	}
+	if err != nil {
+		return false, err
+	}
+	if created {
+		s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+		s.recordChange(ctx, audit.ActionCreate, userId, nil, transaction)
+		s.flagDuplicates(userId, transaction)
+	}
+	return created, nil
+}
 
-			log.Debug().Float64("current_spent", spentPositive).Float64("limit", limit).Msg("budget status")
+// budgetLine pairs a budget with the amount a new transaction (or split line) adds to it.
+type budgetLine struct {
+	budget *budget.Budget
+	amount money.Money
+}
 
-			if limit > 0 {
-				percentage := spentPositive / limit
-				previousSpent := spentPositive - (amount * -1) // remove current transaction
-				previousPercentage := previousSpent / limit
+// checkBudgetThresholds notifies the user in the background when a new bill pushes a budget past 70% or 100%.
+func (s TransactionService) checkBudgetThresholds(userId string, budget *budget.Budget, amount money.Money) {
+	log.Debug().Str("budget_id", budget.Id).Stringer("budget_amount", budget.Amount).Msg("checking budget thresholds for transaction")
+	go func() {
+		currentSpent, err := s.transactionRepository.FindCurrentBudget(context.Background(), budget.Id)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to get current budget details for alert")
+			return
+		}
 
-				log.Debug().Float64("percentage", percentage).Float64("previous_percentage", previousPercentage).Msg("budget percentages")
+		// budget.Amount is positive, currentSpent is negative (bills). Make it positive for calculation.
+		spentPositive := currentSpent * -1
+		limit := budget.Amount
 
-				var alertType string
-				var alertMessage string
+		log.Debug().Stringer("current_spent", spentPositive).Stringer("limit", limit).Msg("budget status")
 
-				if percentage >= 1.0 && previousPercentage < 1.0 {
-					alertType = "budget_critical"
-					alertMessage = fmt.Sprintf("🚨 Critical: You have exceeded your budget for this category! (%.0f%% used)", percentage*100)
-				} else if percentage >= 0.7 && percentage < 1.0 && previousPercentage < 0.7 {
-					alertType = "budget_warning"
-					alertMessage = fmt.Sprintf("⚠️ Warning: You have used %.0f%% of your budget for this category.", percentage*100)
-				}
+		if limit > 0 {
+			// Whole percentages rounded down, so 69.99% of the budget does not trigger the 70% warning
+			percentage := spentPositive.Percent(limit)
+			previousSpent := spentPositive - (amount * -1) // remove current transaction
+			previousPercentage := previousSpent.Percent(limit)
 
-				if alertType != "" {
-					log.Info().Str("alert_type", alertType).Msg("triggering budget alert")
-					notificationPayload := map[string]interface{}{
-						"type":    alertType,
-						"message": alertMessage,
-						"amount":  spentPositive,
-					}
-					payloadBytes, _ := json.Marshal(notificationPayload)
-					s.notificationService.SendToUser(userId, string(payloadBytes))
-				} else {
-					log.Debug().Msg("no alert threshold crossed")
+			log.Debug().Int64("percentage", percentage).Int64("previous_percentage", previousPercentage).Msg("budget percentages")
+
+			var alertType string
+			var alertMessage string
+
+			if percentage >= 100 && previousPercentage < 100 {
+				alertType = "budget_critical"
+				alertMessage = fmt.Sprintf("🚨 Critical: You have exceeded your budget for this category! (%d%% used)", percentage)
+			} else if percentage >= 70 && percentage < 100 && previousPercentage < 70 {
+				alertType = "budget_warning"
+				alertMessage = fmt.Sprintf("⚠️ Warning: You have used %d%% of your budget for this category.", percentage)
+			}
+
+			if alertType != "" {
+				log.Info().Str("alert_type", alertType).Msg("triggering budget alert")
+				notificationPayload := map[string]interface{}{
+					"type":    alertType,
+					"message": alertMessage,
+					"amount":  spentPositive,
 				}
+				payloadBytes, _ := json.Marshal(notificationPayload)
+				s.notificationService.SendToUser(userId, string(payloadBytes))
+			} else {
+				log.Debug().Msg("no alert threshold crossed")
 			}
-		}()
+		}
+	}()
+}
+
+// prepareSplits validates the split lines of a transaction, gives them ids, the parent's sign and the
+// budget of their category. The parent keeps no category or budget of its own.
+func (s TransactionService) prepareSplits(ctx context.Context, parent *transaction.Transaction) ([]budgetLine, error) {
+	if parent.TypeTransation == TRANSFER {
+		return nil, fmt.Errorf("%w: transfers cannot be split", errorhttp.ErrBadRequest)
 	}
 
-	return nil
+	var total money.Money
+	for _, split := range parent.Splits {
+		if split.CategoryId == "" || split.Amount == 0 {
+			return nil, fmt.Errorf("%w: every split requires a category and an amount", errorhttp.ErrBadRequest)
+		}
+		total += split.Amount.Abs()
+	}
+	if total != parent.Amount.Abs() {
+		return nil, fmt.Errorf("%w: splits must add up to the transaction amount", errorhttp.ErrBadRequest)
+	}
+
+	var budgetLines []budgetLine
+	for _, split := range parent.Splits {
+		uuid, err := ksuid.NewRandom()
+		if err != nil {
+			return nil, err
+		}
+		split.Id = uuid.String()
+		split.TransactionId = parent.Id
+		split.Amount = split.Amount.Abs()
+		if parent.Amount < 0 {
+			split.Amount = split.Amount * -1
+		}
+
+		split.BudgetId = ""
+		budget, _ := s.budgetRepository.FindByCategory(ctx, split.CategoryId)
+		if budget != nil && budget.Id != "" {
+			split.BudgetId = budget.Id
+			budgetLines = append(budgetLines, budgetLine{budget: budget, amount: split.Amount})
+		}
+	}
+
+	parent.CategoryId = ""
+	parent.BudgetId = ""
+	return budgetLines, nil
 }
 
 // FindAll retrieves transactions based on date range and account ID.
@@ -146,7 +302,16 @@ func (s TransactionService) FindAll(ctx context.Context, date string, date2 stri
 			transaction.CategoryId,
 			transaction.Amount,
 			transaction.CreatedAt)
+		transactionResponse.Currency = transaction.Currency
+		transactionResponse.Status = transaction.Status
 		transactionResponse.BudgetId = transaction.BudgetId
+		transactionResponse.TransferId = transaction.TransferId
+		transactionResponse.ExternalId = transaction.ExternalId
+		transactionResponse.PayeeId = transaction.PayeeId
+		transactionResponse.RefundOf = transaction.RefundOf
+		transactionResponse.RefundedBy = transaction.RefundedBy
+		transactionResponse.Splits = toSplitResponses(transaction.Splits)
+		transactionResponse.Tags = transaction.Tags
 		transactionResponseList = append(transactionResponseList, transactionResponse)
 
 	}
@@ -172,7 +337,16 @@ func (s TransactionService) FindAllOfAllAccounts(ctx context.Context, id string)
 			transaction.CategoryId,
 			transaction.Amount,
 			transaction.CreatedAt)
+		transactionResponse.Currency = transaction.Currency
+		transactionResponse.Status = transaction.Status
 		transactionResponse.BudgetId = transaction.BudgetId
+		transactionResponse.TransferId = transaction.TransferId
+		transactionResponse.ExternalId = transaction.ExternalId
+		transactionResponse.PayeeId = transaction.PayeeId
+		transactionResponse.RefundOf = transaction.RefundOf
+		transactionResponse.RefundedBy = transaction.RefundedBy
+		transactionResponse.Splits = toSplitResponses(transaction.Splits)
+		transactionResponse.Tags = transaction.Tags
 		transactionResponseList = append(transactionResponseList, transactionResponse)
 
 	}
@@ -193,9 +367,17 @@ func (s TransactionService) FindAllOfAllAccountsWithFilters(
 		return nil, err
 	}
 
+	// The summary is converted into the base currency of the user, which is part of the cache key
+	var converter *domainFx.Converter
+	if includeSummary {
+		if converter, err = s.fxService.Converter(ctx, userId); err != nil {
+			return nil, err
+		}
+	}
+
 	// Cache Key Generation
 	filterBytes, _ := json.Marshal(filter)
-	cacheKey := fmt.Sprintf("transactions:user:%s:filter:%s:summary:%v", userId, string(filterBytes), includeSummary)
+	cacheKey := fmt.Sprintf("transactions:user:%s:filter:%s:summary:%v:%s", userId, string(filterBytes), includeSummary, converter.Base())
 
 	if cachedResponse, found := s.cache.Get(cacheKey); found {
 		log.Debug().Msg("Serving transactions from cache")
@@ -203,7 +385,9 @@ func (s TransactionService) FindAllOfAllAccountsWithFilters(
 	}
 
 	// Get filtered and paginated transactions
-	transactionList, err := s.transactionRepository.FindAllOfAllAccountsWithFilters(ctx, userId, filter)
+	transactionList, hasMore, err := readPage(filter, func(pageFilter *dto.TransactionFilter) ([]*transaction.Transaction, error) {
+		return s.transactionRepository.FindAllOfAllAccountsWithFilters(ctx, userId, pageFilter)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -213,18 +397,12 @@ func (s TransactionService) FindAllOfAllAccountsWithFilters(
 
 	// Create paginated response
 	if includeSummary {
-		// Get all transactions for summary calculation (without pagination)
-		allTransactionsFilter := *filter
-		allTransactionsFilter.Limit = 0 // Remove pagination for summary
-		allTransactionsFilter.Offset = 0
-
-		allTransactions, err := s.transactionRepository.FindAllOfAllAccountsWithFilters(ctx, userId, &allTransactionsFilter)
+		// The summary covers the whole filtered set and is aggregated in the database
+		totals, err := s.transactionRepository.SummarizeWithFilters(ctx, userId, filter)
 		if err != nil {
 			return nil, err
 		}
-
-		allTransactionResponses := s.convertToResponseList(allTransactions)
-		summary := dto.CalculateSummary(allTransactionResponses, totalCount)
+		summary := dto.Summarize(totals, totalCount, converter)
 
 		response := dto.NewPaginatedTransactionResponseWithSummary(
 			transactionResponseList,
@@ -232,6 +410,7 @@ func (s TransactionService) FindAllOfAllAccountsWithFilters(
 			totalCount,
 			summary,
 		)
+		setCursorPagination(&response.Pagination, transactionResponseList, filter, hasMore)
 		s.cache.Set(cacheKey, response, 5*time.Minute)
 		return response, nil
 	}
@@ -241,13 +420,16 @@ func (s TransactionService) FindAllOfAllAccountsWithFilters(
 		filter,
 		totalCount,
 	)
+	setCursorPagination(&response.Pagination, transactionResponseList, filter, hasMore)
 	s.cache.Set(cacheKey, response, 5*time.Minute)
 	return response, nil
 }
 
-// FindAllWithFilters retrieves transactions for a specific account with filtering and pagination
+// FindAllWithFilters retrieves transactions for a specific account with filtering and pagination.
+// The user id only selects the currency the summary is converted into.
 func (s TransactionService) FindAllWithFilters(
 	ctx context.Context,
+	userId string,
 	filter *dto.TransactionFilter,
 	includeSummary bool,
 ) (interface{}, error) {
@@ -258,7 +440,9 @@ func (s TransactionService) FindAllWithFilters(
 	}
 
 	// Get filtered and paginated transactions
-	transactionList, err := s.transactionRepository.FindAllWithFilters(ctx, filter)
+	transactionList, hasMore, err := readPage(filter, func(pageFilter *dto.TransactionFilter) ([]*transaction.Transaction, error) {
+		return s.transactionRepository.FindAllWithFilters(ctx, pageFilter)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -268,32 +452,70 @@ func (s TransactionService) FindAllWithFilters(
 
 	// Create paginated response
 	if includeSummary {
-		// Get all transactions for summary calculation (without pagination)
-		allTransactionsFilter := *filter
-		allTransactionsFilter.Limit = 0 // Remove pagination for summary
-		allTransactionsFilter.Offset = 0
-
-		allTransactions, err := s.transactionRepository.FindAllWithFilters(ctx, &allTransactionsFilter)
+		converter, err := s.fxService.Converter(ctx, userId)
 		if err != nil {
 			return nil, err
 		}
 
-		allTransactionResponses := s.convertToResponseList(allTransactions)
-		summary := dto.CalculateSummary(allTransactionResponses, totalCount)
+		// The summary covers the whole filtered set and is aggregated in the database
+		totals, err := s.transactionRepository.SummarizeWithFilters(ctx, "", filter)
+		if err != nil {
+			return nil, err
+		}
+		summary := dto.Summarize(totals, totalCount, converter)
 
-		return dto.NewPaginatedTransactionResponseWithSummary(
+		response := dto.NewPaginatedTransactionResponseWithSummary(
 			transactionResponseList,
 			filter,
 			totalCount,
 			summary,
-		), nil
+		)
+		setCursorPagination(&response.Pagination, transactionResponseList, filter, hasMore)
+		return response, nil
 	}
 
-	return dto.NewPaginatedTransactionResponse(
+	response := dto.NewPaginatedTransactionResponse(
 		transactionResponseList,
 		filter,
 		totalCount,
-	), nil
+	)
+	setCursorPagination(&response.Pagination, transactionResponseList, filter, hasMore)
+	return response, nil
+}
+
+// readPage reads the transactions of a page. A cursor page reads one row past the limit, which tells whether
+// another page follows in the direction of the cursor and is dropped before returning.
+func readPage(filter *dto.TransactionFilter, find func(*dto.TransactionFilter) ([]*transaction.Transaction, error)) ([]*transaction.Transaction, bool, error) {
+	if filter.DecodedCursor == nil || filter.Limit <= 0 {
+		transactions, err := find(filter)
+		return transactions, false, err
+	}
+
+	pageFilter := *filter
+	pageFilter.Limit++
+	transactions, err := find(&pageFilter)
+	if err != nil || len(transactions) <= filter.Limit {
+		return transactions, false, err
+	}
+
+	// Rows come back in sort order, so the extra row is the last one going forward and the first going back
+	if filter.DecodedCursor.Direction == dto.CursorPrev {
+		return transactions[1:], true, nil
+	}
+	return transactions[:filter.Limit], true, nil
+}
+
+// setCursorPagination sets the cursors of a cursor page. The page was reached from a neighbour in the
+// opposite direction, so that side always has more rows.
+func setCursorPagination(pagination *dto.PaginationMeta, transactions []*dto.TransactionResponse, filter *dto.TransactionFilter, hasMore bool) {
+	if filter.DecodedCursor == nil {
+		return
+	}
+	if filter.DecodedCursor.Direction == dto.CursorPrev {
+		pagination.SetCursors(transactions, filter, true, hasMore)
+		return
+	}
+	pagination.SetCursors(transactions, filter, hasMore, true)
 }
 
 // convertToResponseList converts domain transactions to response DTOs
@@ -310,34 +532,297 @@ func (s TransactionService) convertToResponseList(transactions []*transaction.Tr
 			transaction.Amount,
 			transaction.CreatedAt,
 		)
+		transactionResponse.Currency = transaction.Currency
+		transactionResponse.Status = transaction.Status
 		transactionResponse.BudgetId = transaction.BudgetId
+		transactionResponse.TransferId = transaction.TransferId
+		transactionResponse.ExternalId = transaction.ExternalId
+		transactionResponse.PayeeId = transaction.PayeeId
+		transactionResponse.RefundOf = transaction.RefundOf
+		transactionResponse.RefundedBy = transaction.RefundedBy
+		transactionResponse.Splits = toSplitResponses(transaction.Splits)
+		transactionResponse.Tags = transaction.Tags
 		transactionResponseList = append(transactionResponseList, transactionResponse)
 	}
 	return transactionResponseList
 }
 
+// toSplitResponses converts the split lines of a transaction to response DTOs
+func toSplitResponses(splits []*transaction.Split) []*dto.SplitResponse {
+	var splitResponses []*dto.SplitResponse
+	for _, split := range splits {
+		splitResponses = append(splitResponses, dto.NewSplitResponse(split.Id, split.CategoryId, split.BudgetId, split.Description, split.Amount))
+	}
+	return splitResponses
+}
+
 // UpdateTransaction modifies an existing transaction.
+// Editing one leg of a transfer applies the change to both legs.
+// Nil tags keep the current ones; an empty list removes them all.
+// Renaming a transaction links it to the payee its new name matches, if any.
+// Reconciled transactions are rejected until they are unlocked.
 func (s *TransactionService) UpdateTransaction(ctx context.Context, id string, transaction *transaction.Transaction) error {
+	current, err := s.transactionRepository.FindById(ctx, id, transaction.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if current.IsReconciled() {
+		return reconciledError()
+	}
+	if current.IsTransfer() {
+		return s.updateTransferLegs(ctx, current.TransferId, transaction.UserId, transaction.Name, transaction.Description, transaction.Amount.Abs(), transaction.CreatedAt)
+	}
+	if transaction.TypeTransation == TRANSFER {
+		return fmt.Errorf("%w: use the transfer endpoint to create transfers", errorhttp.ErrBadRequest)
+	}
+	if current.IsRefund() && transaction.TypeTransation != INCOME {
+		return fmt.Errorf("%w: unlink the refund before turning it into a bill", errorhttp.ErrBadRequest)
+	}
+	if len(current.RefundedBy) > 0 && transaction.TypeTransation != BILL {
+		return fmt.Errorf("%w: the bill has refunds, unlink them before changing its type", errorhttp.ErrBadRequest)
+	}
+
+	if transaction.CategoryId == "" && !transaction.IsSplit() {
+		return fmt.Errorf("%w: category_id is required unless splits are provided", errorhttp.ErrBadRequest)
+	}
+
 	if transaction.TypeTransation == BILL {
 		transaction.Amount = transaction.Amount * -1
 	}
 
-	budget, _ := s.budgetRepository.FindByCategory(ctx, transaction.CategoryId)
-	if budget != nil {
-		transaction.BudgetId = budget.Id
+	transaction.Id = id
+	if transaction.Name == current.Name {
+		transaction.PayeeId = current.PayeeId
+	} else {
+		s.assignPayee(ctx, transaction.UserId, transaction)
+	}
+	if transaction.Tags == nil {
+		transaction.Tags = current.Tags
+	} else if err := s.ensureTags(ctx, transaction.UserId, transaction.Tags); err != nil {
+		return err
+	}
+	if transaction.IsSplit() {
+		if _, err := s.prepareSplits(ctx, transaction); err != nil {
+			return err
+		}
+	} else {
+		budget, _ := s.budgetRepository.FindByCategory(ctx, transaction.CategoryId)
+		if budget != nil {
+			transaction.BudgetId = budget.Id
+		}
 	}
 	if err := s.transactionRepository.Update(ctx, id, transaction); err != nil {
+		if errors.Is(err, transactionRepo.ErrAccountArchived) {
+			return archivedAccountError()
+		}
 		return err
 	}
 	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", transaction.UserId))
+	s.recordChange(ctx, audit.ActionUpdate, transaction.UserId, current, transaction)
 	return nil
 }
 
 // DeleteTransaction removes a transaction by its ID and User ID.
 func (s TransactionService) DeleteTransaction(ctx context.Context, id string, userId string) error {
+	current := s.findForAudit(ctx, id, userId)
 	err := s.transactionRepository.Delete(ctx, id, userId)
+	if errors.Is(err, transactionRepo.ErrReconciled) {
+		return reconciledError()
+	}
 	if err == nil {
 		s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+		s.recordChange(ctx, audit.ActionDelete, userId, current, nil)
 	}
 	return err
 }
+
+// CreateTransfer moves money between two accounts of the same user.
+// Both legs share a transfer id, carry the 'transfer' type and no category,
+// so they stay out of income/expense analytics and budget checks.
+func (s TransactionService) CreateTransfer(ctx context.Context, userId string, request *dto.TransferRequest) (*dto.TransferResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.checkTransferAccounts(ctx, userId, request); err != nil {
+		return nil, err
+	}
+
+	transferId, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	outgoingId, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	incomingId, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := request.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	name := request.Name
+	if name == "" {
+		name = "Transfer"
+	}
+
+	outgoing := transaction.NewTransaction(outgoingId.String(), name, request.Description, TRANSFER, request.FromAccountId, "", request.Amount*-1)
+	incoming := transaction.NewTransaction(incomingId.String(), name, request.Description, TRANSFER, request.ToAccountId, "", request.Amount)
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		leg.UserId = userId
+		leg.TransferId = transferId.String()
+		leg.CreatedAt = createdAt
+	}
+
+	if err := s.transactionRepository.SaveTransfer(ctx, outgoing, incoming); err != nil {
+		if errors.Is(err, transactionRepo.ErrCurrencyMismatch) {
+			return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+		}
+		if errors.Is(err, transactionRepo.ErrAccountArchived) {
+			return nil, archivedAccountError()
+		}
+		return nil, err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	s.recordChange(ctx, audit.ActionCreate, userId, nil, outgoing)
+	s.recordChange(ctx, audit.ActionCreate, userId, nil, incoming)
+
+	legs := s.convertToResponseList([]*transaction.Transaction{outgoing, incoming})
+	return dto.NewTransferResponse(transferId.String(), legs[0], legs[1]), nil
+}
+
+// FindTransfer retrieves both legs of a transfer.
+func (s TransactionService) FindTransfer(ctx context.Context, transferId string, userId string) (*dto.TransferResponse, error) {
+	outgoing, incoming, err := s.findTransferLegs(ctx, transferId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	legs := s.convertToResponseList([]*transaction.Transaction{outgoing, incoming})
+	return dto.NewTransferResponse(transferId, legs[0], legs[1]), nil
+}
+
+// UpdateTransfer rewrites both legs of a transfer, including the accounts involved.
+func (s TransactionService) UpdateTransfer(ctx context.Context, transferId string, userId string, request *dto.TransferRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.checkTransferAccounts(ctx, userId, request); err != nil {
+		return err
+	}
+
+	outgoing, incoming, err := s.findTransferLegs(ctx, transferId, userId)
+	if err != nil {
+		return err
+	}
+	outgoing.AccountId = request.FromAccountId
+	incoming.AccountId = request.ToAccountId
+
+	return s.saveTransferLegs(ctx, userId, outgoing, incoming, request.Name, request.Description, request.Amount, request.CreatedAt)
+}
+
+// DeleteTransfer removes both legs of a transfer.
+func (s TransactionService) DeleteTransfer(ctx context.Context, transferId string, userId string) error {
+	var legs []*transaction.Transaction
+	if s.auditService != nil {
+		legs, _ = s.transactionRepository.FindByTransferId(ctx, transferId, userId)
+	}
+	if err := s.transactionRepository.DeleteTransfer(ctx, transferId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		if errors.Is(err, transactionRepo.ErrReconciled) {
+			return reconciledError()
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	for _, leg := range legs {
+		s.recordChange(ctx, audit.ActionDelete, userId, leg, nil)
+	}
+	return nil
+}
+
+// updateTransferLegs applies a single-leg edit to both legs, keeping the accounts untouched.
+func (s TransactionService) updateTransferLegs(ctx context.Context, transferId, userId, name, description string, amount money.Money, createdAt time.Time) error {
+	outgoing, incoming, err := s.findTransferLegs(ctx, transferId, userId)
+	if err != nil {
+		return err
+	}
+	return s.saveTransferLegs(ctx, userId, outgoing, incoming, name, description, amount, createdAt)
+}
+
+func (s TransactionService) saveTransferLegs(ctx context.Context, userId string, outgoing, incoming *transaction.Transaction, name, description string, amount money.Money, createdAt time.Time) error {
+	if amount <= 0 {
+		return fmt.Errorf("%w: amount must be greater than 0", errorhttp.ErrBadRequest)
+	}
+	if outgoing.IsReconciled() || incoming.IsReconciled() {
+		return reconciledError()
+	}
+	outgoingBefore, incomingBefore := *outgoing, *incoming
+	for _, leg := range []*transaction.Transaction{outgoing, incoming} {
+		if name != "" {
+			leg.Name = name
+		}
+		leg.Description = description
+		if !createdAt.IsZero() {
+			leg.CreatedAt = createdAt
+		}
+	}
+	outgoing.Amount = amount * -1
+	incoming.Amount = amount
+
+	if err := s.transactionRepository.UpdateTransfer(ctx, outgoing, incoming); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		if errors.Is(err, transactionRepo.ErrCurrencyMismatch) {
+			return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+		}
+		if errors.Is(err, transactionRepo.ErrAccountArchived) {
+			return archivedAccountError()
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	s.recordChange(ctx, audit.ActionUpdate, userId, &outgoingBefore, outgoing)
+	s.recordChange(ctx, audit.ActionUpdate, userId, &incomingBefore, incoming)
+	return nil
+}
+
+// checkTransferAccounts makes sure both accounts of a transfer belong to the user.
+func (s TransactionService) checkTransferAccounts(ctx context.Context, userId string, request *dto.TransferRequest) error {
+	for _, accountId := range []string{request.FromAccountId, request.ToAccountId} {
+		if _, err := s.accountRepository.FindByIdAndUserId(ctx, accountId, userId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: account %s not found", errorhttp.ErrBadRequest, accountId)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// findTransferLegs loads a transfer and splits it into its outgoing and incoming legs.
+func (s TransactionService) findTransferLegs(ctx context.Context, transferId string, userId string) (*transaction.Transaction, *transaction.Transaction, error) {
+	legs, err := s.transactionRepository.FindByTransferId(ctx, transferId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(legs) != 2 {
+		return nil, nil, errorhttp.ErrNotFound
+	}
+
+	outgoing, incoming := legs[0], legs[1]
+	if outgoing.Amount > incoming.Amount {
+		outgoing, incoming = incoming, outgoing
+	}
+	outgoing.UserId, incoming.UserId = userId, userId
+	return outgoing, incoming, nil
+}
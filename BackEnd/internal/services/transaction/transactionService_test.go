@@ -2,14 +2,26 @@ package transaction
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/budget"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/payee"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/rule"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	payeeRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/payee"
+	tagRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/tag"
 	"github.com/osmait/gestorDePresupuesto/internal/platform/utils"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -38,14 +50,14 @@ func (m *MockTransaction) FindAllOfAllAccounts(ctx context.Context, id string) (
 	return args.Get(0).([]*transaction.Transaction), args.Error(1)
 }
 
-func (m *MockTransaction) FindCurrentBudget(ctx context.Context, budgetId string) (float64, error) {
+func (m *MockTransaction) FindCurrentBudget(ctx context.Context, budgetId string) (money.Money, error) {
 	args := m.Called(ctx, budgetId)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Money), args.Error(1)
 }
 
-func (m *MockTransaction) FindCurrentBudgets(ctx context.Context, userId string) (map[string]float64, error) {
+func (m *MockTransaction) FindCurrentBudgets(ctx context.Context, userId string) (map[string]money.Money, error) {
 	args := m.Called(ctx, userId)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]money.Money), args.Error(1)
 }
 
 func (m *MockTransaction) Delete(ctx context.Context, id string, userId string) error {
@@ -68,6 +80,119 @@ func (m *MockTransaction) CountWithFilters(ctx context.Context, userId string, f
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTransaction) SummarizeWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter) (*dto.SummaryTotals, error) {
+	args := m.Called(ctx, userId, filter)
+	return args.Get(0).(*dto.SummaryTotals), args.Error(1)
+}
+
+func (m *MockTransaction) StreamWithFilters(ctx context.Context, userId string, filter *dto.TransactionFilter, fn func(*transaction.Transaction) error) error {
+	args := m.Called(ctx, userId, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) SaveIfNew(ctx context.Context, transaction *transaction.Transaction) (bool, error) {
+	args := m.Called(ctx, transaction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransaction) FindById(ctx context.Context, id string, userId string) (*transaction.Transaction, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) SaveTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	args := m.Called(ctx, outgoing, incoming)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) UpdateTransfer(ctx context.Context, outgoing *transaction.Transaction, incoming *transaction.Transaction) error {
+	args := m.Called(ctx, outgoing, incoming)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindByTransferId(ctx context.Context, transferId string, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, transferId, userId)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) DeleteTransfer(ctx context.Context, transferId string, userId string) error {
+	args := m.Called(ctx, transferId, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindTrash(ctx context.Context, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransaction) UpdateStatus(ctx context.Context, id string, userId string, status string) error {
+	args := m.Called(ctx, id, userId, status)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) Unlock(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) LinkRefund(ctx context.Context, id string, userId string, original *transaction.Transaction) error {
+	args := m.Called(ctx, id, userId, original)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) UnlinkRefund(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindDuplicateCandidates(ctx context.Context, userId string, from time.Time, to time.Time) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, userId, from, to)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) FindDismissedDuplicates(ctx context.Context, userId string) ([]transaction.DuplicatePair, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]transaction.DuplicatePair), args.Error(1)
+}
+
+func (m *MockTransaction) DismissDuplicate(ctx context.Context, userId string, pair transaction.DuplicatePair) error {
+	args := m.Called(ctx, userId, pair)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) MergeDuplicate(ctx context.Context, keepId string, duplicateId string, userId string) error {
+	args := m.Called(ctx, keepId, duplicateId, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransaction) FindByIds(ctx context.Context, ids []string, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, ids, userId)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransaction) ApplyBulk(ctx context.Context, userId string, request *dto.BulkRequest, ids []string) ([]string, error) {
+	args := m.Called(ctx, userId, request, ids)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTransaction) ApplyRuleChanges(ctx context.Context, userId string, transactions []*transaction.Transaction) error {
+	args := m.Called(ctx, userId, transactions)
+	return args.Error(0)
+}
+
 type MockBudgetRepository struct {
 	mock.Mock
 }
@@ -87,6 +212,12 @@ func (m *MockBudgetRepository) FindOne(ctx context.Context, id string) (*budget.
 	return args.Get(0).(*budget.Budget), args.Error(1)
 }
 
+func (m *MockBudgetRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*budget.Budget, error) {
+	args := m.Called(ctx, id, userId)
+	found, _ := args.Get(0).(*budget.Budget)
+	return found, args.Error(1)
+}
+
 func (m *MockBudgetRepository) Delete(ctx context.Context, id string, userId string) error {
 	args := m.Called(ctx, id, userId)
 	return args.Error(0)
@@ -136,18 +267,18 @@ func TestTransactionService_CreateTransaction(t *testing.T) {
 	mockRepo := &MockTransaction{}
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockCache := &MockCache{}
-	s := NewTransactionService(mockRepo, mockBudgetRepo, nil, mockCache)
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
 
 	mockCache.On("DeleteByPrefix", mock.Anything).Return()
 
 	mockBudgetRepo.On("FindByCategory", mock.Anything, mock.Anything).Return(utils.GetNewRandomBudget(), nil)
-	mockRepo.On("FindCurrentBudget", mock.Anything, mock.Anything).Return(1000.0, nil)
+	mockRepo.On("FindCurrentBudget", mock.Anything, mock.Anything).Return(money.FromUnits(1000), nil)
 	mockRepo.On("Save", context.Background(), mock.AnythingOfType("*transaction.Transaction")).Return(nil)
 
 	ctx := context.Background()
 	transaction := utils.GetNewRandomTransaction()
 	transaction.TypeTransation = "bill" // Force bill to trigger budget check
-	err := s.CreateTransaction(ctx, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, transaction.CategoryId, transaction.BudgetId, transaction.CreatedAt)
+	err := s.CreateTransaction(ctx, transaction.Name, transaction.Description, transaction.Amount, transaction.TypeTransation, transaction.AccountId, transaction.UserId, transaction.CategoryId, transaction.BudgetId, transaction.CreatedAt, nil)
 	time.Sleep(100 * time.Millisecond) // Allow goroutine to start
 	assert.NoError(t, err, "CreateAccount should not return an error")
 	mockRepo.AssertExpectations(t)
@@ -157,7 +288,7 @@ func TestFindAllTransaction(t *testing.T) {
 	mockRepo := &MockTransaction{}
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockCache := &MockCache{}
-	s := NewTransactionService(mockRepo, mockBudgetRepo, nil, mockCache)
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
 
 	expectedTransactions := []*transaction.Transaction{}
 	for i := 0; i < 10; i++ {
@@ -179,7 +310,7 @@ func TestDeleteTransaction(t *testing.T) {
 	mockRepo := &MockTransaction{}
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockCache := &MockCache{}
-	s := NewTransactionService(mockRepo, mockBudgetRepo, nil, mockCache)
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
 
 	mockCache.On("DeleteByPrefix", mock.Anything).Return()
 
@@ -196,7 +327,7 @@ func TestFindAllTransactionofAllAccount(t *testing.T) {
 	mockRepo := &MockTransaction{}
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockCache := &MockCache{}
-	s := NewTransactionService(mockRepo, mockBudgetRepo, nil, mockCache)
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
 
 	expectedTransactions := []*transaction.Transaction{}
 	for i := 0; i < 5; i++ {
@@ -208,3 +339,672 @@ func TestFindAllTransactionofAllAccount(t *testing.T) {
 	assert.NoError(t, err, "CreateAccount should not return an error")
 	mockRepo.AssertExpectations(t)
 }
+
+// MockAccountRepository only implements the methods the transaction service uses.
+type MockAccountRepository struct {
+	mock.Mock
+	accountRepo.AccountRepositoryInterface
+}
+
+func (m *MockAccountRepository) FindByIdAndUserId(ctx context.Context, id string, userId string) (*account.Account, error) {
+	args := m.Called(ctx, id, userId)
+	acc, _ := args.Get(0).(*account.Account)
+	return acc, args.Error(1)
+}
+
+func TestCreateTransfer(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, mockAccountRepo, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
+
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_from", "user_1").Return(account.NewAccount(0, "acc_from", "Checking", "Bank"), nil)
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_to", "user_1").Return(account.NewAccount(0, "acc_to", "Savings", "Bank"), nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockRepo.On("SaveTransfer", mock.Anything,
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.AccountId == "acc_from" && leg.Amount == money.FromUnits(-250) && leg.TypeTransation == TRANSFER && leg.CategoryId == ""
+		}),
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.AccountId == "acc_to" && leg.Amount == money.FromUnits(250) && leg.TypeTransation == TRANSFER && leg.CategoryId == ""
+		}),
+	).Return(nil)
+
+	request := dto.NewTransferRequest("acc_from", "acc_to", "", "", money.FromUnits(250))
+	response, err := s.CreateTransfer(context.Background(), "user_1", request)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.TransferId)
+	assert.Equal(t, response.TransferId, response.Outgoing.TransferId)
+	assert.Equal(t, response.TransferId, response.Incoming.TransferId)
+	assert.Equal(t, "Transfer", response.Outgoing.Name)
+	mockRepo.AssertExpectations(t)
+	mockBudgetRepo.AssertNotCalled(t, "FindByCategory", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransfer_SameAccount(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, &MockCache{}, nil, nil)
+
+	request := dto.NewTransferRequest("acc_1", "acc_1", "Savings", "", money.FromUnits(100))
+	_, err := s.CreateTransfer(context.Background(), "user_1", request)
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "SaveTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransfer_AccountOfAnotherUser(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockAccountRepo := &MockAccountRepository{}
+	s := NewTransactionService(mockRepo, mockAccountRepo, &MockBudgetRepository{}, nil, nil, nil, nil, &MockCache{}, nil, nil)
+
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_from", "user_1").Return(account.NewAccount(0, "acc_from", "Checking", "Bank"), nil)
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_other", "user_1").Return(nil, sql.ErrNoRows)
+
+	request := dto.NewTransferRequest("acc_from", "acc_other", "", "", money.FromUnits(100))
+	_, err := s.CreateTransfer(context.Background(), "user_1", request)
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "SaveTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// transferLegs returns the legs of trf_1 the way the repository loads them.
+func transferLegs() []*transaction.Transaction {
+	outgoing := transaction.NewTransaction("leg_out", "Savings", "", TRANSFER, "acc_from", "", money.FromUnits(-100))
+	outgoing.TransferId = "trf_1"
+	incoming := transaction.NewTransaction("leg_in", "Savings", "", TRANSFER, "acc_to", "", money.FromUnits(100))
+	incoming.TransferId = "trf_1"
+	return []*transaction.Transaction{incoming, outgoing}
+}
+
+func TestUpdateTransaction_TransferLegUpdatesBothLegs(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	current := transferLegs()[0]
+	current.UserId = "user_1"
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockRepo.On("FindById", mock.Anything, "leg_in", "user_1").Return(current, nil)
+	mockRepo.On("FindByTransferId", mock.Anything, "trf_1", "user_1").Return(transferLegs(), nil)
+	mockRepo.On("UpdateTransfer", mock.Anything,
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.Id == "leg_out" && leg.UserId == "user_1" && leg.Amount == money.FromUnits(-300)
+		}),
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.Id == "leg_in" && leg.UserId == "user_1" && leg.Amount == money.FromUnits(300)
+		}),
+	).Return(nil)
+
+	update := transaction.NewTransaction("leg_in", "Savings", "", TRANSFER, "acc_to", "", money.FromUnits(300))
+	update.UserId = "user_1"
+	err := s.UpdateTransaction(context.Background(), "leg_in", update)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransfer(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockAccountRepo := &MockAccountRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, mockAccountRepo, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_from", "user_1").Return(account.NewAccount(0, "acc_from", "Checking", "Bank"), nil)
+	mockAccountRepo.On("FindByIdAndUserId", mock.Anything, "acc_savings", "user_1").Return(account.NewAccount(0, "acc_savings", "Savings", "Bank"), nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockRepo.On("FindByTransferId", mock.Anything, "trf_1", "user_1").Return(transferLegs(), nil)
+	mockRepo.On("UpdateTransfer", mock.Anything,
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.Id == "leg_out" && leg.UserId == "user_1" && leg.AccountId == "acc_from" && leg.Amount == money.FromUnits(-250)
+		}),
+		mock.MatchedBy(func(leg *transaction.Transaction) bool {
+			return leg.Id == "leg_in" && leg.UserId == "user_1" && leg.AccountId == "acc_savings" && leg.Amount == money.FromUnits(250)
+		}),
+	).Return(nil)
+
+	request := dto.NewTransferRequest("acc_from", "acc_savings", "Savings", "", money.FromUnits(250))
+	err := s.UpdateTransfer(context.Background(), "trf_1", "user_1", request)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCreateTransaction_WithSplits(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, nil, nil, mockCache, nil, nil)
+
+	foodBudget := utils.GetNewRandomBudget()
+	foodBudget.Amount = 0 // no alert threshold to evaluate
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_food").Return(foodBudget, nil)
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_pharmacy").Return((*budget.Budget)(nil), nil)
+	mockRepo.On("FindCurrentBudget", mock.Anything, foodBudget.Id).Return(money.FromUnits(-70), nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(parent *transaction.Transaction) bool {
+		return parent.Amount == money.FromUnits(-100) && parent.CategoryId == "" && len(parent.Splits) == 2 &&
+			parent.Splits[0].Amount == money.FromUnits(-70) && parent.Splits[0].BudgetId == foodBudget.Id && parent.Splits[0].TransactionId == parent.Id &&
+			parent.Splits[1].Amount == money.FromUnits(-30) && parent.Splits[1].BudgetId == ""
+	})).Return(nil)
+
+	err := s.CreateTransaction(context.Background(), "Supermarket", "", money.FromUnits(100), BILL, "acc_1", "user_1", "", "", time.Time{}, nil,
+		transaction.NewSplit("", "", "cat_food", money.FromUnits(70)),
+		transaction.NewSplit("", "", "cat_pharmacy", money.FromUnits(30)),
+	)
+	time.Sleep(100 * time.Millisecond) // Allow goroutine to start
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBudgetRepo.AssertNotCalled(t, "FindByCategory", mock.Anything, "")
+}
+
+func TestCreateTransaction_SplitsMustMatchTotal(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, &MockCache{}, nil, nil)
+
+	err := s.CreateTransaction(context.Background(), "Supermarket", "", money.FromUnits(100), BILL, "acc_1", "user_1", "", "", time.Time{}, nil,
+		transaction.NewSplit("", "", "cat_food", money.FromUnits(70)),
+		transaction.NewSplit("", "", "cat_pharmacy", money.FromUnits(20)),
+	)
+
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) Save(ctx context.Context, rule *rule.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) Update(ctx context.Context, rule *rule.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) FindAll(ctx context.Context, userId string) ([]*rule.Rule, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*rule.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindById(ctx context.Context, id string, userId string) (*rule.Rule, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*rule.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func testRules() []*rule.Rule {
+	rename := rule.NewRule("rule_rename", "user_1", "Rename rides", 1)
+	rename.NameContains = "uber"
+	rename.RenameTo = "Uber"
+
+	rides := rule.NewRule("rule_rides", "user_1", "Rides", 2)
+	rides.NameContains = "uber"
+	rides.CategoryId = "cat_transport"
+
+	fallback := rule.NewRule("rule_fallback", "user_1", "Everything from checking", 3)
+	fallback.AccountId = "acc_1"
+	fallback.CategoryId = "cat_misc"
+	return []*rule.Rule{rename, rides, fallback}
+}
+
+func TestCreateTransaction_AppliesRules(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, mockRuleRepo, nil, nil, nil, mockCache, nil, nil)
+
+	mockCache.On("DeleteByPrefix", mock.Anything).Return()
+	mockRuleRepo.On("FindAll", mock.Anything, "user_1").Return(testRules(), nil)
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_transport").Return((*budget.Budget)(nil), nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return t.Name == "Uber" && t.CategoryId == "cat_transport" && t.Description == "UBER *TRIP 1234"
+	})).Return(nil)
+
+	err := s.CreateTransaction(context.Background(), "UBER *TRIP 1234", "UBER *TRIP 1234", money.Money(1250), "bill", "acc_1", "user_1", "cat_manual", "", time.Now(), nil)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBudgetRepo.AssertExpectations(t)
+}
+
+func TestApplyRules_DryRunAndCommit(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, mockRuleRepo, nil, nil, nil, mockCache, nil, nil)
+
+	streamed := func() []*transaction.Transaction {
+		ride := transaction.NewTransaction("txn_1", "uber eats", "", "bill", "acc_2", "cat_food", money.FromUnits(-20))
+		done := transaction.NewTransaction("txn_2", "Uber", "", "bill", "acc_2", "cat_transport", money.FromUnits(-8))
+		done.BudgetId = "budget_transport"
+		other := transaction.NewTransaction("txn_3", "Bakery", "", "bill", "acc_2", "cat_food", money.FromUnits(-3))
+		locked := transaction.NewTransaction("txn_4", "uber trip", "", "bill", "acc_2", "cat_food", money.FromUnits(-15))
+		locked.Status = transaction.StatusReconciled
+		return []*transaction.Transaction{ride, done, other, locked}
+	}
+	transportBudget := budget.NewBudget("budget_transport", "cat_transport", "user_1", money.FromUnits(100))
+
+	mockRuleRepo.On("FindAll", mock.Anything, "user_1").Return(testRules(), nil)
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_transport").Return(transportBudget, nil)
+	mockRepo.On("StreamWithFilters", mock.Anything, "user_1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(3).(func(*transaction.Transaction) error)
+		for _, t := range streamed() {
+			_ = fn(t)
+		}
+	}).Return(nil)
+
+	response, err := s.ApplyRules(context.Background(), "user_1", dto.NewTransactionFilter(), true)
+
+	assert.NoError(t, err)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 4, response.Evaluated)
+	assert.Equal(t, 1, response.Changed, "txn_2 already matches its rules")
+	assert.Equal(t, 1, response.Skipped, "txn_4 is reconciled")
+	assert.Len(t, response.Changes, 1)
+	assert.Equal(t, "txn_1", response.Changes[0].TransactionId)
+	assert.Equal(t, "cat_food", response.Changes[0].Before.CategoryId)
+	assert.Equal(t, "cat_transport", response.Changes[0].After.CategoryId)
+	assert.Equal(t, "budget_transport", response.Changes[0].After.BudgetId)
+	assert.Equal(t, "Uber", response.Changes[0].After.Name)
+	mockRepo.AssertNotCalled(t, "ApplyRuleChanges", mock.Anything, mock.Anything, mock.Anything)
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockRepo.On("ApplyRuleChanges", mock.Anything, "user_1", mock.MatchedBy(func(changed []*transaction.Transaction) bool {
+		return len(changed) == 1 && changed[0].Id == "txn_1"
+	})).Return(nil).Once()
+
+	response, err = s.ApplyRules(context.Background(), "user_1", dto.NewTransactionFilter(), false)
+
+	assert.NoError(t, err)
+	assert.False(t, response.DryRun)
+	assert.Equal(t, 1, response.Changed)
+	assert.Equal(t, 1, response.Skipped)
+	mockRepo.AssertNumberOfCalls(t, "ApplyRuleChanges", 1)
+	mockCache.AssertExpectations(t)
+
+	// A failed write changes nothing, so the cache is left alone
+	mockRepo.On("ApplyRuleChanges", mock.Anything, "user_1", mock.Anything).Return(errors.New("connection lost")).Once()
+	_, err = s.ApplyRules(context.Background(), "user_1", dto.NewTransactionFilter(), false)
+	assert.Error(t, err)
+	mockCache.AssertNumberOfCalls(t, "DeleteByPrefix", 1)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// MockTagRepository only implements the methods the transaction service uses.
+type MockTagRepository struct {
+	mock.Mock
+	tagRepo.TagRepoInterface
+}
+
+func (m *MockTagRepository) SaveIfMissing(ctx context.Context, tags []*tag.Tag) error {
+	args := m.Called(ctx, tags)
+	return args.Error(0)
+}
+
+func TestCreateTransaction_WithTags(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTagRepo := &MockTagRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, mockTagRepo, nil, nil, mockCache, nil, nil)
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_travel").Return((*budget.Budget)(nil), nil)
+	mockTagRepo.On("SaveIfMissing", mock.Anything, mock.MatchedBy(func(tags []*tag.Tag) bool {
+		return len(tags) == 2 && tags[0].Name == "vacation 2026" && tags[0].UserId == "user_1" && tags[0].Id != tags[1].Id
+	})).Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return len(t.Tags) == 2 && t.Tags[1] == "reimbursable"
+	})).Return(nil)
+
+	err := s.CreateTransaction(context.Background(), "Hotel", "", money.FromUnits(300), BILL, "acc_1", "user_1", "cat_travel", "", time.Now(), []string{"vacation 2026", "reimbursable"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestUpdateTransaction_KeepsTagsWhenOmitted(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTagRepo := &MockTagRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, mockTagRepo, nil, nil, mockCache, nil, nil)
+
+	current := transaction.NewTransaction("txn_1", "Hotel", "", BILL, "acc_1", "cat_travel", money.FromUnits(-300))
+	current.UserId = "user_1"
+	current.Tags = []string{"vacation 2026"}
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_travel").Return((*budget.Budget)(nil), nil)
+	mockRepo.On("FindById", mock.Anything, "txn_1", "user_1").Return(current, nil)
+	mockRepo.On("Update", mock.Anything, "txn_1", mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return len(t.Tags) == 1 && t.Tags[0] == "vacation 2026"
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, "txn_1", mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return t.Tags != nil && len(t.Tags) == 0
+	})).Return(nil).Once()
+
+	update := transaction.NewTransaction("txn_1", "Hotel", "", BILL, "acc_1", "cat_travel", money.FromUnits(320))
+	update.UserId = "user_1"
+	assert.NoError(t, s.UpdateTransaction(context.Background(), "txn_1", update))
+
+	// An empty list clears the tags without creating any
+	update = transaction.NewTransaction("txn_1", "Hotel", "", BILL, "acc_1", "cat_travel", money.FromUnits(320))
+	update.UserId = "user_1"
+	update.Tags = []string{}
+	assert.NoError(t, s.UpdateTransaction(context.Background(), "txn_1", update))
+
+	mockRepo.AssertExpectations(t)
+	mockTagRepo.AssertNotCalled(t, "SaveIfMissing", mock.Anything, mock.Anything)
+}
+
+// MockPayeeRepository only implements the methods the transaction service uses.
+type MockPayeeRepository struct {
+	mock.Mock
+	payeeRepo.PayeeRepoInterface
+}
+
+func (m *MockPayeeRepository) FindAll(ctx context.Context, userId string) ([]*payee.Payee, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*payee.Payee), args.Error(1)
+}
+
+func TestCreateTransaction_AssignsPayee(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockPayeeRepo := &MockPayeeRepository{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, mockBudgetRepo, nil, nil, mockPayeeRepo, nil, mockCache, nil, nil)
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockBudgetRepo.On("FindByCategory", mock.Anything, "cat_transport").Return((*budget.Budget)(nil), nil)
+	mockPayeeRepo.On("FindAll", mock.Anything, "user_1").Return([]*payee.Payee{
+		payee.NewPayee("payee_uber", "user_1", "Uber", []string{"uber trip"}),
+		payee.NewPayee("payee_netflix", "user_1", "Netflix", nil),
+	}, nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return t.Name == "UBER *TRIP 8812" && t.PayeeId == "payee_uber"
+	})).Return(nil).Once()
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *transaction.Transaction) bool {
+		return t.Name == "Bus ticket" && t.PayeeId == ""
+	})).Return(nil).Once()
+
+	assert.NoError(t, s.CreateTransaction(context.Background(), "UBER *TRIP 8812", "", money.FromUnits(12), BILL, "acc_1", "user_1", "cat_transport", "", time.Now(), nil))
+	assert.NoError(t, s.CreateTransaction(context.Background(), "Bus ticket", "", money.FromUnits(2), BILL, "acc_1", "user_1", "cat_transport", "", time.Now(), nil))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindAllOfAllAccountsWithFilters_CursorPage(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	now := time.Now()
+	rows := []*transaction.Transaction{}
+	for i := 0; i < 3; i++ {
+		txn := utils.GetNewRandomTransaction()
+		txn.Id = fmt.Sprintf("txn_%d", i)
+		txn.CreatedAt = now.Add(time.Duration(-i) * time.Hour)
+		rows = append(rows, txn)
+	}
+
+	filter := dto.NewTransactionFilter()
+	filter.Limit = 2
+	filter.DecodedCursor = dto.NewCursor(now.Add(time.Hour), "txn_newest", dto.CursorNext)
+
+	mockCache.On("Get", mock.Anything).Return(nil, false)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("CountWithFilters", mock.Anything, "user_1", filter).Return(int64(10), nil)
+	// One row past the limit is read to tell whether another page follows
+	mockRepo.On("FindAllOfAllAccountsWithFilters", mock.Anything, "user_1", mock.MatchedBy(func(f *dto.TransactionFilter) bool {
+		return f.Limit == 3 && f.DecodedCursor != nil
+	})).Return(rows, nil)
+
+	result, err := s.FindAllOfAllAccountsWithFilters(context.Background(), "user_1", filter, false)
+
+	assert.NoError(t, err)
+	response := result.(*dto.PaginatedTransactionResponse)
+	assert.Len(t, response.Data, 2)
+	assert.True(t, response.Pagination.HasNextPage)
+	assert.True(t, response.Pagination.HasPrevPage)
+	next, err := dto.DecodeCursor(response.Pagination.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_1", next.Id)
+	prev, err := dto.DecodeCursor(response.Pagination.PrevCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_0", prev.Id)
+	mockRepo.AssertExpectations(t)
+
+	// The summary of the whole filtered set is aggregated in the database, not read row by row
+	mockRepo.On("SummarizeWithFilters", mock.Anything, "user_1", filter).Return(&dto.SummaryTotals{
+		Types: []dto.SummaryTotal{{TypeTransation: "income", Total: money.FromUnits(900), Count: 10, Largest: money.FromUnits(300)}},
+	}, nil)
+
+	result, err = s.FindAllOfAllAccountsWithFilters(context.Background(), "user_1", filter, true)
+
+	assert.NoError(t, err)
+	withSummary := result.(*dto.PaginatedTransactionResponseWithSummary)
+	assert.Len(t, withSummary.Data, 2)
+	assert.Equal(t, money.FromUnits(900), withSummary.Summary.TotalIncome)
+	assert.Equal(t, 10, withSummary.Summary.IncomeCount)
+	mockRepo.AssertNumberOfCalls(t, "FindAllOfAllAccountsWithFilters", 2)
+}
+
+func TestUpdateTransaction_RejectsReconciled(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, &MockCache{}, nil, nil)
+
+	current := transaction.NewTransaction("txn_1", "Rent", "", BILL, "acc_1", "", money.FromUnits(-800))
+	current.UserId = "user_1"
+	current.Status = transaction.StatusReconciled
+	mockRepo.On("FindById", mock.Anything, "txn_1", "user_1").Return(current, nil)
+
+	update := transaction.NewTransaction("txn_1", "Rent", "", BILL, "acc_1", "", money.FromUnits(-850))
+	update.UserId = "user_1"
+	err := s.UpdateTransaction(context.Background(), "txn_1", update)
+
+	assert.True(t, apperrors.IsErrorType(err, apperrors.ErrorTypeConflict))
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	pending := transaction.NewTransaction("txn_1", "Groceries", "", BILL, "acc_1", "", money.FromUnits(-50))
+	pending.UserId = "user_1"
+	reconciled := transaction.NewTransaction("txn_2", "Rent", "", BILL, "acc_1", "", money.FromUnits(-800))
+	reconciled.UserId = "user_1"
+	reconciled.Status = transaction.StatusReconciled
+
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+	mockRepo.On("FindById", mock.Anything, "txn_1", "user_1").Return(pending, nil)
+	mockRepo.On("FindById", mock.Anything, "txn_2", "user_1").Return(reconciled, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "txn_1", "user_1", transaction.StatusCleared).Return(nil)
+
+	err := s.UpdateStatus(context.Background(), "txn_1", "user_1", &dto.StatusRequest{Status: transaction.StatusCleared})
+	assert.NoError(t, err)
+
+	// Only a completed reconciliation can mark a transaction as reconciled
+	err = s.UpdateStatus(context.Background(), "txn_1", "user_1", &dto.StatusRequest{Status: transaction.StatusReconciled})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	err = s.UpdateStatus(context.Background(), "txn_2", "user_1", &dto.StatusRequest{Status: transaction.StatusPending})
+	assert.True(t, apperrors.IsErrorType(err, apperrors.ErrorTypeConflict))
+
+	mockRepo.AssertNumberOfCalls(t, "UpdateStatus", 1)
+	mockCache.AssertExpectations(t)
+}
+
+func TestFindDuplicates(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, &MockCache{}, nil, nil)
+
+	now := time.Now()
+	newTransaction := func(id, name string, createdAt time.Time) *transaction.Transaction {
+		txn := transaction.NewTransaction(id, name, "", BILL, "acc_1", "", money.FromUnits(-15))
+		txn.UserId = "user_1"
+		txn.CreatedAt = createdAt
+		return txn
+	}
+	manual := newTransaction("txn_a", "Netflix", now)
+	imported := newTransaction("txn_b", "NETFLIX.COM", now.Add(-24*time.Hour))
+	gym := newTransaction("txn_c", "Gym", now.Add(-12*time.Hour))
+	gymAgain := newTransaction("txn_d", "Gym", now.Add(-36*time.Hour))
+
+	mockRepo.On("FindDuplicateCandidates", mock.Anything, "user_1", mock.Anything, mock.Anything).
+		Return([]*transaction.Transaction{manual, gym, imported, gymAgain}, nil)
+	mockRepo.On("FindDismissedDuplicates", mock.Anything, "user_1").
+		Return([]transaction.DuplicatePair{transaction.NewDuplicatePair("txn_d", "txn_c")}, nil)
+
+	duplicates, err := s.FindDuplicates(context.Background(), "user_1", dto.DefaultDuplicateDays)
+
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, "txn_a", duplicates[0].Transaction.Id)
+	assert.Equal(t, "txn_b", duplicates[0].Duplicate.Id)
+}
+
+func TestMergeDuplicate(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	keep := transaction.NewTransaction("txn_a", "Netflix", "", BILL, "acc_1", "", money.FromUnits(-15))
+	duplicate := transaction.NewTransaction("txn_b", "NETFLIX.COM", "", BILL, "acc_1", "", money.FromUnits(-15))
+	reconciled := transaction.NewTransaction("txn_c", "Netflix", "", BILL, "acc_1", "", money.FromUnits(-15))
+	reconciled.Status = transaction.StatusReconciled
+	transferLeg := transaction.NewTransaction("txn_d", "Netflix", "", TRANSFER, "acc_1", "", money.FromUnits(-15))
+	transferLeg.TransferId = "trf_1"
+	refunded := transaction.NewTransaction("txn_e", "Netflix", "", BILL, "acc_1", "", money.FromUnits(-15))
+	refunded.RefundedBy = []string{"txn_refund"}
+
+	for _, txn := range []*transaction.Transaction{keep, duplicate, reconciled, transferLeg, refunded} {
+		mockRepo.On("FindById", mock.Anything, txn.Id, "user_1").Return(txn, nil)
+	}
+	mockRepo.On("MergeDuplicate", mock.Anything, "txn_a", "txn_b", "user_1").Return(nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+
+	err := s.MergeDuplicate(context.Background(), "user_1", &dto.DuplicateRequest{TransactionId: "txn_a", DuplicateId: "txn_b"})
+	assert.NoError(t, err)
+
+	err = s.MergeDuplicate(context.Background(), "user_1", &dto.DuplicateRequest{TransactionId: "txn_a", DuplicateId: "txn_c"})
+	assert.True(t, apperrors.IsErrorType(err, apperrors.ErrorTypeConflict))
+
+	err = s.MergeDuplicate(context.Background(), "user_1", &dto.DuplicateRequest{TransactionId: "txn_a", DuplicateId: "txn_d"})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	err = s.MergeDuplicate(context.Background(), "user_1", &dto.DuplicateRequest{TransactionId: "txn_a", DuplicateId: "txn_a"})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	err = s.MergeDuplicate(context.Background(), "user_1", &dto.DuplicateRequest{TransactionId: "txn_a", DuplicateId: "txn_e"})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err), "merging would drop the refunds of txn_e")
+
+	mockRepo.AssertNumberOfCalls(t, "MergeDuplicate", 1)
+	mockCache.AssertExpectations(t)
+}
+
+func TestBulk(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	groceries := transaction.NewTransaction("txn_a", "Groceries", "", BILL, "acc_1", "", money.FromUnits(-40))
+	reconciled := transaction.NewTransaction("txn_b", "Rent", "", BILL, "acc_1", "", money.FromUnits(-800))
+	reconciled.Status = transaction.StatusReconciled
+	transferLeg := transaction.NewTransaction("txn_c", "Savings", "", TRANSFER, "acc_1", "", money.FromUnits(-100))
+	transferLeg.TransferId = "trf_1"
+	selected := []*transaction.Transaction{groceries, reconciled, transferLeg}
+
+	request := &dto.BulkRequest{Action: dto.BulkActionSetCategory, CategoryId: "cat_food", Ids: []string{"txn_a", "txn_b", "txn_c", "txn_missing", "txn_a"}}
+	mockRepo.On("FindByIds", mock.Anything, []string{"txn_a", "txn_b", "txn_c", "txn_missing"}, "user_1").Return(selected, nil)
+	mockRepo.On("ApplyBulk", mock.Anything, "user_1", request, []string{"txn_a"}).Return([]string{"txn_a"}, nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return().Once()
+
+	response, err := s.Bulk(context.Background(), "user_1", request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 3, response.Failed)
+	if assert.Len(t, response.Results, 4) {
+		assert.Equal(t, dto.BulkStatusOk, response.Results[0].Status)
+		assert.Equal(t, "txn_b", response.Results[1].Id)
+		assert.Equal(t, dto.BulkStatusFailed, response.Results[1].Status)
+		assert.Equal(t, dto.BulkStatusFailed, response.Results[2].Status)
+		assert.Equal(t, "transaction not found", response.Results[3].Error)
+	}
+	mockCache.AssertExpectations(t)
+
+	// A filter selecting more than the limit is rejected before anything changes
+	filtered := &dto.BulkRequest{Action: dto.BulkActionDelete, Filter: dto.NewTransactionFilter()}
+	mockRepo.On("CountWithFilters", mock.Anything, "user_1", filtered.Filter).Return(int64(dto.MaxBulkItems+1), nil)
+	_, err = s.Bulk(context.Background(), "user_1", filtered)
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+
+	_, err = s.Bulk(context.Background(), "user_1", &dto.BulkRequest{Action: dto.BulkActionDelete})
+	assert.True(t, errorhttp.IsErrNotBadRequest(err))
+	mockRepo.AssertNumberOfCalls(t, "ApplyBulk", 1)
+}
+
+func TestLinkRefund(t *testing.T) {
+	mockRepo := &MockTransaction{}
+	mockCache := &MockCache{}
+	s := NewTransactionService(mockRepo, nil, &MockBudgetRepository{}, nil, nil, nil, nil, mockCache, nil, nil)
+
+	now := time.Now()
+	bill := transaction.NewTransaction("txn_shoes", "Shoes", "", BILL, "acc_1", "cat_clothes", money.FromUnits(-80))
+	bill.Currency = "USD"
+	bill.BudgetId = "budget_clothes"
+	bill.CreatedAt = now.AddDate(0, 0, -3)
+	bill.RefundedBy = []string{"txn_first_refund"}
+	firstRefund := transaction.NewTransaction("txn_first_refund", "Refund", "", INCOME, "acc_1", "cat_clothes", money.FromUnits(50))
+	refund := transaction.NewTransaction("txn_refund", "Refund", "", INCOME, "acc_1", "cat_salary", money.FromUnits(30))
+	refund.Currency = "USD"
+	refund.CreatedAt = now
+	tooMuch := transaction.NewTransaction("txn_too_much", "Refund", "", INCOME, "acc_1", "", money.FromUnits(31))
+	tooMuch.Currency = "USD"
+	tooMuch.CreatedAt = now
+	income := transaction.NewTransaction("txn_salary", "Salary", "", INCOME, "acc_1", "cat_salary", money.FromUnits(1000))
+	income.Currency = "USD"
+
+	mockRepo.On("FindById", mock.Anything, "txn_shoes", "user_1").Return(bill, nil)
+	mockRepo.On("FindById", mock.Anything, "txn_refund", "user_1").Return(refund, nil)
+	mockRepo.On("FindById", mock.Anything, "txn_too_much", "user_1").Return(tooMuch, nil)
+	mockRepo.On("FindById", mock.Anything, "txn_salary", "user_1").Return(income, nil)
+	mockRepo.On("FindByIds", mock.Anything, []string{"txn_first_refund"}, "user_1").Return([]*transaction.Transaction{firstRefund}, nil)
+	mockRepo.On("LinkRefund", mock.Anything, "txn_refund", "user_1", bill).Return(nil)
+	mockCache.On("DeleteByPrefix", "transactions:user:user_1").Return()
+
+	assert.NoError(t, s.LinkRefund(context.Background(), "txn_refund", "user_1", &dto.RefundRequest{RefundOf: "txn_shoes"}))
+
+	// Together with the first refund it would give back more than the bill cost
+	err := s.LinkRefund(context.Background(), "txn_too_much", "user_1", &dto.RefundRequest{RefundOf: "txn_shoes"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+
+	// Only bills can be refunded, and only by income
+	err = s.LinkRefund(context.Background(), "txn_refund", "user_1", &dto.RefundRequest{RefundOf: "txn_salary"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+	err = s.LinkRefund(context.Background(), "txn_shoes", "user_1", &dto.RefundRequest{RefundOf: "txn_shoes"})
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+
+	mockRepo.AssertNumberOfCalls(t, "LinkRefund", 1)
+	mockCache.AssertExpectations(t)
+}
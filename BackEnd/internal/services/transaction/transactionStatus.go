@@ -0,0 +1,69 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/transaction"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// reconciledError is returned when a change would touch a reconciled transaction.
+func reconciledError() error {
+	return apperrors.NewConflictError("transaction", "transaction is reconciled, unlock it before changing it")
+}
+
+// UpdateStatus marks a transaction as pending or cleared. Each transfer leg clears on its own, since it shows up on
+// the statement of its own account.
+func (s TransactionService) UpdateStatus(ctx context.Context, id string, userId string, request *dto.StatusRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	current, err := s.transactionRepository.FindById(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	if current.IsReconciled() {
+		return reconciledError()
+	}
+
+	if err := s.transactionRepository.UpdateStatus(ctx, id, userId, request.Status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	updated := *current
+	updated.Status = request.Status
+	s.recordChange(ctx, audit.ActionUpdate, userId, current, &updated)
+	return nil
+}
+
+// Unlock takes a reconciled transaction out of its reconciliation so it can be edited again. It goes back to
+// cleared and has to be reconciled again with a later statement.
+func (s TransactionService) Unlock(ctx context.Context, id string, userId string) error {
+	current := s.findForAudit(ctx, id, userId)
+	if err := s.transactionRepository.Unlock(ctx, id, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhttp.ErrNotFound
+		}
+		return err
+	}
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	if current != nil {
+		updated := *current
+		updated.Status = transaction.StatusCleared
+		s.recordChange(ctx, audit.ActionUpdate, userId, current, &updated)
+	}
+	return nil
+}
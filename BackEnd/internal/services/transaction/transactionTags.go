@@ -0,0 +1,26 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/tag"
+	"github.com/segmentio/ksuid"
+)
+
+// ensureTags creates the tags of a transaction that the user does not have yet, so the repository can link
+// the transaction to them by name. Names must already be normalized.
+func (s TransactionService) ensureTags(ctx context.Context, userId string, names []string) error {
+	if s.tagRepository == nil || len(names) == 0 {
+		return nil
+	}
+
+	tags := make([]*tag.Tag, 0, len(names))
+	for _, name := range names {
+		uuid, err := ksuid.NewRandom()
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag.NewTag(uuid.String(), userId, name))
+	}
+	return s.tagRepository.SaveIfMissing(ctx, tags)
+}
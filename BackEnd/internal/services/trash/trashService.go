@@ -0,0 +1,164 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/trash"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/trash"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	auditSvc "github.com/osmait/gestorDePresupuesto/internal/services/audit"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+)
+
+// TrashService lists and restores deleted transactions, accounts and categories, and purges them for good once
+// they have been in the trash longer than the retention period.
+type TrashService struct {
+	transactionRepository transactionRepo.TransactionRepositoryInterface
+	accountRepository     accountRepo.AccountRepositoryInterface
+	categoryRepository    categoryRepo.CategoryRepoInterface
+	cache                 cache.CacheRepository
+	auditService          *auditSvc.AuditService
+}
+
+// NewTrashService creates a new instance of TrashService.
+// auditService may be nil, in which case restores are not recorded in the audit log.
+func NewTrashService(transactionRepository transactionRepo.TransactionRepositoryInterface, accountRepository accountRepo.AccountRepositoryInterface, categoryRepository categoryRepo.CategoryRepoInterface, cache cache.CacheRepository, auditService *auditSvc.AuditService) *TrashService {
+	return &TrashService{
+		transactionRepository: transactionRepository,
+		accountRepository:     accountRepository,
+		categoryRepository:    categoryRepository,
+		cache:                 cache,
+		auditService:          auditService,
+	}
+}
+
+// FindTrash returns the trashed records of one entity type, most recently deleted first.
+func (s *TrashService) FindTrash(ctx context.Context, entityType string, userId string) ([]*dto.TrashItemResponse, error) {
+	items := []*dto.TrashItemResponse{}
+	switch entityType {
+	case trash.EntityTransaction:
+		transactions, err := s.transactionRepository.FindTrash(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transactions {
+			items = append(items, newItem(entityType, t.Id, t.Name, t.DeletedAt, t))
+		}
+	case trash.EntityAccount:
+		accounts, err := s.accountRepository.FindTrash(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range accounts {
+			items = append(items, newItem(entityType, a.Id, a.Name, a.DeletedAt, a))
+		}
+	case trash.EntityCategory:
+		categories, err := s.categoryRepository.FindTrash(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range categories {
+			items = append(items, newItem(entityType, c.Id, c.Name, c.DeletedAt, c))
+		}
+	default:
+		return nil, unknownEntityType(entityType)
+	}
+	return items, nil
+}
+
+// Restore takes a record out of the trash. Restoring an account also restores the transactions deleted with it,
+// while a transaction can only be restored once its account is out of the trash.
+func (s *TrashService) Restore(ctx context.Context, entityType string, id string, userId string) error {
+	var err error
+	switch entityType {
+	case trash.EntityTransaction:
+		err = s.transactionRepository.Restore(ctx, id, userId)
+	case trash.EntityAccount:
+		err = s.accountRepository.Restore(ctx, id, userId)
+	case trash.EntityCategory:
+		err = s.categoryRepository.Restore(ctx, id, userId)
+	default:
+		return unknownEntityType(entityType)
+	}
+	if errors.Is(err, transactionRepo.ErrAccountTrashed) {
+		return apperrors.NewConflictError("transaction", "the account of the transaction is in the trash, restore it first")
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.cache.DeleteByPrefix(fmt.Sprintf("transactions:user:%s", userId))
+	s.recordRestore(ctx, entityType, id, userId)
+	return nil
+}
+
+// Purge permanently removes everything that has been in the trash for longer than retention and returns how many
+// records were removed. Transactions go first so the accounts and categories they used can be removed after them.
+func (s *TrashService) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	before := time.Now().UTC().Add(-retention)
+	purged := 0
+	for _, purge := range []func(context.Context, time.Time) (int, error){
+		s.transactionRepository.PurgeDeleted,
+		s.accountRepository.PurgeDeleted,
+		s.categoryRepository.PurgeDeleted,
+	} {
+		count, err := purge(ctx, before)
+		purged += count
+		if err != nil {
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+// recordRestore snapshots the restored record for the audit log. The record is only loaded when there is one.
+func (s *TrashService) recordRestore(ctx context.Context, entityType string, id string, userId string) {
+	if s.auditService == nil {
+		return
+	}
+	var restored any
+	switch entityType {
+	case trash.EntityTransaction:
+		if t, err := s.transactionRepository.FindById(ctx, id, userId); err == nil {
+			restored = t
+		}
+	case trash.EntityAccount:
+		if a, err := s.accountRepository.FindByIdAndUserId(ctx, id, userId); err == nil {
+			restored = a
+		}
+	case trash.EntityCategory:
+		if c, err := s.categoryRepository.FindOne(ctx, id); err == nil {
+			restored = c
+		}
+	}
+	s.auditService.Record(ctx, entityType, id, audit.ActionRestore, userId, nil, restored)
+}
+
+func newItem(entityType string, id string, name string, deletedAt *time.Time, record any) *dto.TrashItemResponse {
+	item := &dto.TrashItemResponse{
+		Id:         id,
+		EntityType: entityType,
+		Name:       name,
+		Record:     record,
+	}
+	if deletedAt != nil {
+		item.DeletedAt = *deletedAt
+	}
+	return item
+}
+
+func unknownEntityType(entityType string) error {
+	return fmt.Errorf("%w: unknown entity type %q, expected 'transaction', 'account' or 'category'", errorhttp.ErrBadRequest, entityType)
+}
@@ -0,0 +1,170 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/account"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/category"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/money"
+	"github.com/osmait/gestorDePresupuesto/internal/domain/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/platform/cache"
+	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
+	accountRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/account"
+	categoryRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/category"
+	transactionRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/transaction"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// The mocks embed the repository interfaces and only implement what the trash uses.
+type MockTransactionRepository struct {
+	transactionRepo.TransactionRepositoryInterface
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) FindTrash(ctx context.Context, userId string) ([]*transaction.Transaction, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+type MockAccountRepository struct {
+	accountRepo.AccountRepositoryInterface
+	mock.Mock
+}
+
+func (m *MockAccountRepository) FindTrash(ctx context.Context, userId string) ([]*account.Account, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+type MockCategoryRepository struct {
+	categoryRepo.CategoryRepoInterface
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FindTrash(ctx context.Context, userId string) ([]*category.Category, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Restore(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func newTestTrashService() (*TrashService, *MockTransactionRepository, *MockAccountRepository, *MockCategoryRepository, cache.CacheRepository) {
+	transactions := &MockTransactionRepository{}
+	accounts := &MockAccountRepository{}
+	categories := &MockCategoryRepository{}
+	transactionCache := cache.NewInMemoryCache(time.Minute, time.Minute)
+	return NewTrashService(transactions, accounts, categories, transactionCache, nil), transactions, accounts, categories, transactionCache
+}
+
+func TestFindTrash(t *testing.T) {
+	s, transactions, _, categories, _ := newTestTrashService()
+	ctx := context.Background()
+	deletedAt := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	coffee := transaction.NewTransaction("txn_1", "Coffee", "", "bill", "acc_1", "cat_1", money.FromUnits(-5))
+	coffee.DeletedAt = &deletedAt
+	transactions.On("FindTrash", ctx, "user_1").Return([]*transaction.Transaction{coffee}, nil)
+	items, err := s.FindTrash(ctx, "transaction", "user_1")
+	assert.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "txn_1", items[0].Id)
+		assert.Equal(t, "transaction", items[0].EntityType)
+		assert.Equal(t, "Coffee", items[0].Name)
+		assert.Equal(t, deletedAt, items[0].DeletedAt)
+		assert.Same(t, coffee, items[0].Record)
+	}
+
+	// An empty trash is an empty list, not null
+	categories.On("FindTrash", ctx, "user_1").Return([]*category.Category(nil), nil)
+	items, err = s.FindTrash(ctx, "category", "user_1")
+	assert.NoError(t, err)
+	assert.NotNil(t, items)
+	assert.Empty(t, items)
+
+	_, err = s.FindTrash(ctx, "budget", "user_1")
+	assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+}
+
+func TestRestore(t *testing.T) {
+	s, transactions, accounts, _, transactionCache := newTestTrashService()
+	ctx := context.Background()
+
+	transactionCache.Set("transactions:user:user_1:all", "cached", time.Minute)
+	accounts.On("Restore", ctx, "acc_1", "user_1").Return(nil)
+	assert.NoError(t, s.Restore(ctx, "account", "acc_1", "user_1"))
+	_, found := transactionCache.Get("transactions:user:user_1:all")
+	assert.False(t, found)
+
+	transactions.On("Restore", ctx, "txn_missing", "user_1").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, s.Restore(ctx, "transaction", "txn_missing", "user_1"), errorhttp.ErrNotFound)
+
+	// A transaction waits for its account to be restored first
+	transactions.On("Restore", ctx, "txn_1", "user_1").Return(transactionRepo.ErrAccountTrashed)
+	var appErr *apperrors.AppError
+	err := s.Restore(ctx, "transaction", "txn_1", "user_1")
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, apperrors.ErrorTypeConflict, appErr.Type)
+	}
+
+	assert.ErrorIs(t, s.Restore(ctx, "investment", "inv_1", "user_1"), errorhttp.ErrBadRequest)
+}
+
+func TestPurge(t *testing.T) {
+	s, transactions, accounts, categories, _ := newTestTrashService()
+	ctx := context.Background()
+	retention := 30 * 24 * time.Hour
+	cutoff := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= retention && time.Since(before) < retention+time.Minute
+	})
+
+	transactions.On("PurgeDeleted", ctx, cutoff).Return(4, nil).Once()
+	accounts.On("PurgeDeleted", ctx, cutoff).Return(1, nil).Once()
+	categories.On("PurgeDeleted", ctx, cutoff).Return(2, nil).Once()
+	purged, err := s.Purge(ctx, retention)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, purged)
+
+	// A failure stops the purge and reports what was removed so far
+	transactions.On("PurgeDeleted", ctx, cutoff).Return(3, nil).Once()
+	accounts.On("PurgeDeleted", ctx, cutoff).Return(0, errors.New("db down")).Once()
+	purged, err = s.Purge(ctx, retention)
+	assert.Error(t, err)
+	assert.Equal(t, 3, purged)
+	transactions.AssertExpectations(t)
+	accounts.AssertExpectations(t)
+	categories.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/osmait/gestorDePresupuesto/internal/domain/fx"
 	"github.com/osmait/gestorDePresupuesto/internal/domain/user"
 	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/user"
 	apperrors "github.com/osmait/gestorDePresupuesto/internal/platform/errors"
@@ -145,10 +146,43 @@ func (u *UserService) FindUserById(ctx context.Context, id string) (*dto.UserRes
 
 		// Create response
 		userResponse := dto.NewUserResponse(foundUser.Id, foundUser.Name, foundUser.LastName, foundUser.Email, foundUser.Role, foundUser.CreatedAt)
+		userResponse.BaseCurrency = foundUser.BaseCurrency
 		return userResponse, nil
 	})
 }
 
+// UpdateBaseCurrency changes the currency the reports of a user are converted into.
+func (u *UserService) UpdateBaseCurrency(ctx context.Context, id string, currency string) error {
+	return apperrors.SafeCall(ctx, "UpdateBaseCurrency", func() error {
+		code, ok := fx.NormalizeCurrency(currency)
+		if !ok {
+			return apperrors.NewValidationError("INVALID_CURRENCY", "currency must be a three-letter ISO 4217 code").
+				WithContext(ctx).
+				WithOperation("UpdateBaseCurrency").
+				WithDetails(map[string]interface{}{
+					"field": "base_currency",
+				})
+		}
+
+		existingUser, err := u.userRepository.FindUserById(ctx, id)
+		if err != nil {
+			if errorhttp.IsErrNotFound(err) {
+				return apperrors.NewNotFoundError("user", id).
+					WithContext(ctx).
+					WithOperation("UpdateBaseCurrency")
+			}
+			return apperrors.WrapDatabaseError(ctx, err, "FindUserById for base currency")
+		}
+
+		existingUser.BaseCurrency = code
+		if err := u.userRepository.Update(ctx, existingUser); err != nil {
+			return apperrors.WrapDatabaseError(ctx, err, "Update base currency")
+		}
+
+		return nil
+	})
+}
+
 // DeleteUser removes a user from the system by their ID.
 func (u *UserService) DeleteUser(ctx context.Context, id string) error {
 	// Use safe call to prevent panics
@@ -328,7 +362,9 @@ func (u *UserService) GetAllUsers(ctx context.Context) ([]*dto.UserResponse, err
 
 		var response []*dto.UserResponse
 		for _, user := range users {
-			response = append(response, dto.NewUserResponse(user.Id, user.Name, user.LastName, user.Email, user.Role, user.CreatedAt))
+			userResponse := dto.NewUserResponse(user.Id, user.Name, user.LastName, user.Email, user.Role, user.CreatedAt)
+			userResponse.BaseCurrency = user.BaseCurrency
+			response = append(response, userResponse)
 		}
 		return response, nil
 	})
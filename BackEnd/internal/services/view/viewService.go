@@ -0,0 +1,131 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/view"
+	viewRepo "github.com/osmait/gestorDePresupuesto/internal/platform/storage/postgress/view"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/segmentio/ksuid"
+)
+
+// ViewService manages the saved transaction filters of a user and expands them for the transaction listing.
+type ViewService struct {
+	viewRepository viewRepo.ViewRepoInterface
+}
+
+// NewViewService creates a new instance of ViewService.
+func NewViewService(viewRepository viewRepo.ViewRepoInterface) *ViewService {
+	return &ViewService{
+		viewRepository: viewRepository,
+	}
+}
+
+// CreateView saves a named filter.
+func (s *ViewService) CreateView(ctx context.Context, userId string, request *dto.ViewRequest) (*dto.ViewResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+	if err := s.checkNameFree(ctx, request.Name, "", userId); err != nil {
+		return nil, err
+	}
+
+	uuid, err := ksuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	view := view.NewView(uuid.String(), userId, request.Name, request.Query)
+	if err := s.viewRepository.Save(ctx, view); err != nil {
+		return nil, err
+	}
+	return dto.NewViewResponse(view), nil
+}
+
+// FindViews retrieves the views of a user in alphabetical order.
+func (s *ViewService) FindViews(ctx context.Context, userId string) ([]*dto.ViewResponse, error) {
+	views, err := s.viewRepository.FindAll(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	viewResponses := make([]*dto.ViewResponse, 0, len(views))
+	for _, view := range views {
+		viewResponses = append(viewResponses, dto.NewViewResponse(view))
+	}
+	return viewResponses, nil
+}
+
+// FindView retrieves one view of a user.
+func (s *ViewService) FindView(ctx context.Context, id string, userId string) (*dto.ViewResponse, error) {
+	view, err := s.find(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewViewResponse(view), nil
+}
+
+// UpdateView renames a view and replaces its query.
+func (s *ViewService) UpdateView(ctx context.Context, id string, userId string, request *dto.ViewRequest) (*dto.ViewResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhttp.ErrBadRequest, err.Error())
+	}
+
+	view, err := s.find(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(ctx, request.Name, id, userId); err != nil {
+		return nil, err
+	}
+
+	view.Name = request.Name
+	view.Query = request.Query
+	if err := s.viewRepository.Update(ctx, view); err != nil {
+		return nil, err
+	}
+	return dto.NewViewResponse(view), nil
+}
+
+// DeleteView removes a view.
+func (s *ViewService) DeleteView(ctx context.Context, id string, userId string) error {
+	err := s.viewRepository.Delete(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorhttp.ErrNotFound
+	}
+	return err
+}
+
+// Expand returns the query parameters of a transaction listing request with the saved view it names filled in.
+// Parameters given in the request win over those of the view.
+func (s *ViewService) Expand(ctx context.Context, userId string, viewId string, request url.Values) (url.Values, error) {
+	view, err := s.find(ctx, viewId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ExpandQuery(view.Query, request)
+}
+
+func (s *ViewService) find(ctx context.Context, id string, userId string) (*view.View, error) {
+	view, err := s.viewRepository.FindById(ctx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorhttp.ErrNotFound
+	}
+	return view, err
+}
+
+// checkNameFree rejects a name already used by another view of the user.
+func (s *ViewService) checkNameFree(ctx context.Context, name string, id string, userId string) error {
+	existing, err := s.viewRepository.FindByName(ctx, name, userId)
+	if err == nil && existing.Id != id {
+		return fmt.Errorf("%w: a view named %q already exists", errorhttp.ErrBadRequest, name)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
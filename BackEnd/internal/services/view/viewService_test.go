@@ -0,0 +1,118 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/osmait/gestorDePresupuesto/internal/domain/view"
+	dto "github.com/osmait/gestorDePresupuesto/internal/platform/dto/view"
+	"github.com/osmait/gestorDePresupuesto/internal/services/errorhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockViewRepository) Save(ctx context.Context, view *view.View) error {
+	args := m.Called(ctx, view)
+	return args.Error(0)
+}
+
+func (m *MockViewRepository) Update(ctx context.Context, view *view.View) error {
+	args := m.Called(ctx, view)
+	return args.Error(0)
+}
+
+func (m *MockViewRepository) FindAll(ctx context.Context, userId string) ([]*view.View, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*view.View), args.Error(1)
+}
+
+func (m *MockViewRepository) FindById(ctx context.Context, id string, userId string) (*view.View, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*view.View), args.Error(1)
+}
+
+func (m *MockViewRepository) FindByName(ctx context.Context, name string, userId string) (*view.View, error) {
+	args := m.Called(ctx, name, userId)
+	return args.Get(0).(*view.View), args.Error(1)
+}
+
+func (m *MockViewRepository) Delete(ctx context.Context, id string, userId string) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func TestCreateView(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the query in canonical form", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("FindByName", ctx, "Food", "user_1").Return((*view.View)(nil), sql.ErrNoRows)
+		repo.On("Save", ctx, mock.AnythingOfType("*view.View")).Return(nil)
+
+		response, err := NewViewService(repo).CreateView(ctx, "user_1", &dto.ViewRequest{Name: "  Food ", Query: "?period=this_month&categories=cat_food"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Food", response.Name)
+		assert.Equal(t, "categories=cat_food&period=this_month", response.Query)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects parameters that are not filters", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		for _, query := range []string{"page=2", "view_id=view_1", "period=someday", "type=income&type=bill"} {
+			_, err := NewViewService(repo).CreateView(ctx, "user_1", &dto.ViewRequest{Name: "Food", Query: query})
+			assert.ErrorIs(t, err, errorhttp.ErrBadRequest, query)
+		}
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a name already in use", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("FindByName", ctx, "Food", "user_1").Return(&view.View{Id: "view_1"}, nil)
+
+		_, err := NewViewService(repo).CreateView(ctx, "user_1", &dto.ViewRequest{Name: "Food"})
+		assert.ErrorIs(t, err, errorhttp.ErrBadRequest)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateView_KeepsOwnName(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockViewRepository)
+	current := &view.View{Id: "view_1", UserId: "user_1", Name: "Food", Query: "period=this_month"}
+	repo.On("FindById", ctx, "view_1", "user_1").Return(current, nil)
+	repo.On("FindByName", ctx, "Food", "user_1").Return(current, nil)
+	repo.On("Update", ctx, current).Return(nil)
+
+	response, err := NewViewService(repo).UpdateView(ctx, "view_1", "user_1", &dto.ViewRequest{Name: "Food", Query: "period=last_month"})
+	assert.NoError(t, err)
+	assert.Equal(t, "period=last_month", response.Query)
+	repo.AssertExpectations(t)
+}
+
+func TestExpand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request parameters override the view", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("FindById", ctx, "view_1", "user_1").Return(&view.View{Id: "view_1", Query: "categories=cat_food&period=this_month"}, nil)
+
+		request := url.Values{"view_id": {"view_1"}, "period": {"last_month"}, "page": {"2"}}
+		values, err := NewViewService(repo).Expand(ctx, "user_1", "view_1", request)
+		assert.NoError(t, err)
+		assert.Equal(t, url.Values{"categories": {"cat_food"}, "period": {"last_month"}, "page": {"2"}}, values)
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("FindById", ctx, "view_2", "user_1").Return((*view.View)(nil), sql.ErrNoRows)
+
+		_, err := NewViewService(repo).Expand(ctx, "user_1", "view_2", url.Values{})
+		assert.ErrorIs(t, err, errorhttp.ErrNotFound)
+	})
+}